@@ -0,0 +1,25 @@
+import { Ping, Pong } from './dispatch';
+import { decodeAny } from './typeRegistry';
+
+describe('any-dispatcher', () => {
+  it('decodes the message of the type URL', () => {
+    const bytes = Ping.encode({ $type: 'dispatch.Ping', id: 'a' }).finish();
+    expect(decodeAny('type.googleapis.com/dispatch.Ping', bytes)).toEqual({ $type: 'dispatch.Ping', id: 'a' });
+    expect(decodeAny('type.googleapis.com/dispatch.Pong', new Uint8Array())).toEqual({
+      $type: 'dispatch.Pong',
+      count: 0,
+    });
+  });
+
+  it('accepts other hosts and bare type names', () => {
+    const bytes = Pong.encode({ $type: 'dispatch.Pong', count: 3 }).finish();
+    expect(decodeAny('example.com/types/dispatch.Pong', bytes)).toEqual({ $type: 'dispatch.Pong', count: 3 });
+    expect(decodeAny('dispatch.Pong', bytes)).toEqual({ $type: 'dispatch.Pong', count: 3 });
+  });
+
+  it('throws for type URLs that are not registered', () => {
+    expect(() => decodeAny('type.googleapis.com/dispatch.Missing', new Uint8Array())).toThrow(
+      'Unknown type URL type.googleapis.com/dispatch.Missing'
+    );
+  });
+});
@@ -0,0 +1,85 @@
+/* eslint-disable */
+import { messageTypeRegistry } from './typeRegistry';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'dispatch';
+
+export interface Ping {
+  $type: 'dispatch.Ping';
+  id: string;
+}
+
+export interface Pong {
+  $type: 'dispatch.Pong';
+  count: number;
+}
+
+function createBasePing(): Ping {
+  return { $type: 'dispatch.Ping', id: '' };
+}
+
+export const Ping = {
+  $type: 'dispatch.Ping' as const,
+
+  encode(message: Ping, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Ping {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePing();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+messageTypeRegistry.set(Ping.$type, Ping);
+
+function createBasePong(): Pong {
+  return { $type: 'dispatch.Pong', count: 0 };
+}
+
+export const Pong = {
+  $type: 'dispatch.Pong' as const,
+
+  encode(message: Pong, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.count !== 0) {
+      writer.uint32(8).int32(message.count);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Pong {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePong();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.count = reader.int32();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+messageTypeRegistry.set(Pong.$type, Pong);
@@ -0,0 +1,32 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export interface MessageType<Message extends UnknownMessage = UnknownMessage> {
+  $type: Message['$type'];
+  encode(message: Message, writer?: _m0.Writer): _m0.Writer;
+  decode(input: _m0.Reader | Uint8Array, length?: number): Message;
+}
+
+export type UnknownMessage = { $type: string };
+
+export const messageTypeRegistry = new Map<string, MessageType>();
+
+export function decodeAny(typeUrl: string, bytes: Uint8Array): UnknownMessage {
+  // Only the part after the last slash names the type, i.e. 'type.googleapis.com/foo.Bar'
+  const type = messageTypeRegistry.get(typeUrl.slice(typeUrl.lastIndexOf('/') + 1));
+  if (type === undefined) {
+    throw new globalThis.Error(`Unknown type URL ${typeUrl}`);
+  }
+  return type.decode(bytes);
+}
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
@@ -0,0 +1,79 @@
+/**
+ * @jest-environment node
+ */
+import { ChannelCredentials, Metadata, Server, ServerCredentials, status } from '@grpc/grpc-js';
+import { firstValueFrom } from 'rxjs';
+import { toArray } from 'rxjs/operators';
+import { ChatClient, ChatServer, ChatService, observeChatTalk } from './chat';
+
+describe('bidi-observable', () => {
+  let server: Server;
+  let client: ChatClient;
+
+  beforeAll(async () => {
+    server = new Server();
+    // Shouts every message back, and fails the call on 'boom'
+    const impl: ChatServer = {
+      talk(call) {
+        call.on('data', (message) => {
+          if (message.text === 'boom') {
+            call.emit('error', { code: status.INVALID_ARGUMENT, details: 'boom' });
+          } else {
+            call.write({ text: message.text.toUpperCase() + call.metadata.get('suffix').join('') });
+          }
+        });
+        call.on('end', () => call.end());
+      },
+    };
+    server.addService(ChatService, impl);
+    const port = await new Promise<number>((resolve, reject) => {
+      server.bindAsync('localhost:0', ServerCredentials.createInsecure(), (err, port) => {
+        if (err) {
+          reject(err);
+        } else {
+          resolve(port);
+        }
+      });
+    });
+    server.start();
+    client = new ChatClient(`localhost:${port}`, ChannelCredentials.createInsecure());
+  });
+
+  afterAll(async () => {
+    client.close();
+    await new Promise<void>((resolve) => server.tryShutdown(() => resolve()));
+  });
+
+  it('streams the outgoing requests and the incoming responses', async () => {
+    const { outgoing, incoming } = observeChatTalk(client);
+    const responses = firstValueFrom(incoming.pipe(toArray()));
+    outgoing.next({ text: 'hello' });
+    outgoing.next({ text: 'world' });
+    outgoing.complete();
+    expect(await responses).toEqual([{ text: 'HELLO' }, { text: 'WORLD' }]);
+  });
+
+  it('sends the metadata with the call', async () => {
+    const metadata = new Metadata();
+    metadata.set('suffix', '!');
+    const { outgoing, incoming } = observeChatTalk(client, metadata);
+    const responses = firstValueFrom(incoming.pipe(toArray()));
+    outgoing.next({ text: 'hi' });
+    outgoing.complete();
+    expect(await responses).toEqual([{ text: 'HI!' }]);
+  });
+
+  it('errors the incoming observable with the call status', async () => {
+    const { outgoing, incoming } = observeChatTalk(client);
+    const responses = firstValueFrom(incoming.pipe(toArray()));
+    outgoing.next({ text: 'boom' });
+    await expect(responses).rejects.toMatchObject({ code: status.INVALID_ARGUMENT, details: 'boom' });
+  });
+
+  it('cancels the call when the outgoing subject errors', async () => {
+    const { outgoing, incoming } = observeChatTalk(client);
+    const responses = firstValueFrom(incoming.pipe(toArray()));
+    outgoing.error(new Error('gave up'));
+    await expect(responses).rejects.toMatchObject({ code: status.CANCELLED });
+  });
+});
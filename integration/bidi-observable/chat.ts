@@ -0,0 +1,142 @@
+/* eslint-disable */
+import {
+  makeGenericClientConstructor,
+  ChannelCredentials,
+  ChannelOptions,
+  UntypedServiceImplementation,
+  handleBidiStreamingCall,
+  Client,
+  CallOptions,
+  ClientDuplexStream,
+  Metadata,
+} from '@grpc/grpc-js';
+import { Observable, Subject } from 'rxjs';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'chat';
+
+export interface ChatMessage {
+  text: string;
+}
+
+function createBaseChatMessage(): ChatMessage {
+  return { text: '' };
+}
+
+export const ChatMessage = {
+  encode(message: ChatMessage, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.text !== '') {
+      writer.uint32(10).string(message.text);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ChatMessage {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseChatMessage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.text = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ChatMessage {
+    return {
+      text: isSet(object.text) ? String(object.text) : '',
+    };
+  },
+
+  toJSON(message: ChatMessage): unknown {
+    const obj: any = {};
+    message.text !== undefined && (obj.text = message.text);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<ChatMessage>, I>>(object: I): ChatMessage {
+    const message = createBaseChatMessage();
+    message.text = object.text ?? '';
+    return message;
+  },
+};
+
+export type ChatService = typeof ChatService;
+export const ChatService = {
+  talk: {
+    path: '/chat.Chat/Talk',
+    requestStream: true,
+    responseStream: true,
+    requestSerialize: (value: ChatMessage) => Buffer.from(ChatMessage.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => ChatMessage.decode(value),
+    responseSerialize: (value: ChatMessage) => Buffer.from(ChatMessage.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => ChatMessage.decode(value),
+  },
+} as const;
+
+export interface ChatServer extends UntypedServiceImplementation {
+  talk: handleBidiStreamingCall<ChatMessage, ChatMessage>;
+}
+
+export interface ChatClient extends Client {
+  talk(): ClientDuplexStream<ChatMessage, ChatMessage>;
+  talk(options: Partial<CallOptions>): ClientDuplexStream<ChatMessage, ChatMessage>;
+  talk(metadata: Metadata, options?: Partial<CallOptions>): ClientDuplexStream<ChatMessage, ChatMessage>;
+}
+
+export const ChatClient = makeGenericClientConstructor(ChatService, 'chat.Chat') as unknown as {
+  new (address: string, credentials: ChannelCredentials, options?: Partial<ChannelOptions>): ChatClient;
+  service: typeof ChatService;
+};
+
+export function observeChatTalk(
+  client: ChatClient,
+  metadata: Metadata = new Metadata(),
+  options: Partial<CallOptions> = {}
+): { outgoing: Subject<ChatMessage>; incoming: Observable<ChatMessage> } {
+  const call = client.talk(metadata, options);
+
+  // Listen right away, so responses are forwarded even before the first subscriber
+  const incoming = new Subject<ChatMessage>();
+  call.on('data', (response: ChatMessage) => incoming.next(response));
+  call.on('error', (error: Error) => incoming.error(error));
+  call.on('end', () => incoming.complete());
+
+  // Completing the subject half-closes the call, and erroring it cancels the call
+  const outgoing = new Subject<ChatMessage>();
+  outgoing.subscribe({
+    next: (request) => call.write(request),
+    error: () => call.cancel(),
+    complete: () => call.end(),
+  });
+
+  return { outgoing, incoming: incoming.asObservable() };
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
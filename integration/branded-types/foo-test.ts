@@ -0,0 +1,27 @@
+import { Foo, UserId } from './foo';
+
+describe('branded-types', () => {
+  const id = 'user-1' as UserId;
+
+  it('keeps the wire format of the branded field a plain string', () => {
+    const bytes = Foo.encode({ bar: id, baz: 'baz' }).finish();
+    expect(Foo.decode(bytes)).toEqual({ bar: 'user-1', baz: 'baz' });
+  });
+
+  it('reads the branded field from JSON', () => {
+    const foo: Foo = Foo.fromJSON({ bar: 'user-1' });
+    expect(foo.bar).toEqual(id);
+    expect(Foo.toJSON(foo)).toEqual({ bar: 'user-1', baz: '' });
+  });
+
+  it('accepts the branded type in fromPartial', () => {
+    expect(Foo.fromPartial({ bar: id }).bar).toEqual(id);
+    expect(Foo.fromPartial({}).bar).toEqual('');
+  });
+
+  it('rejects plain strings for the branded field', () => {
+    // @ts-expect-error a plain string is not a UserId
+    const foo: Foo = { bar: 'user-1', baz: '' };
+    expect(foo.bar).toEqual('user-1');
+  });
+});
@@ -0,0 +1,31 @@
+import { Blob } from './blob';
+import { Blob as BufferBlob } from '../bytes-as-buffer/blob';
+
+describe('bytesAs=base64-string', () => {
+  const blob: Blob = { data: '3q2+7w==', chunks: ['YQ==', ''] };
+
+  it('defaults to empty strings', () => {
+    expect(Blob.decode(new Uint8Array())).toEqual({ data: '', chunks: [] });
+    expect(Blob.fromPartial({})).toEqual({ data: '', chunks: [] });
+    expect(Blob.fromJSON({})).toEqual({ data: '', chunks: [] });
+  });
+
+  it('round-trips through encode and decode', () => {
+    expect(Blob.decode(Blob.encode(blob).finish())).toEqual(blob);
+  });
+
+  it('writes the bytes as they are in JSON', () => {
+    expect(Blob.toJSON(blob)).toEqual(blob);
+    expect(Blob.fromJSON(Blob.toJSON(blob))).toEqual(blob);
+  });
+
+  it('skips empty bytes when encoding', () => {
+    expect(Blob.encode({ data: '', chunks: [] }).finish()).toEqual(new Uint8Array());
+  });
+
+  it('uses the same wire format as bytesAs=buffer', () => {
+    const decoded = BufferBlob.decode(Blob.encode(blob).finish());
+    expect(decoded.data).toEqual(Buffer.from([0xde, 0xad, 0xbe, 0xef]));
+    expect(decoded.chunks).toEqual([Buffer.from('a'), Buffer.alloc(0)]);
+  });
+});
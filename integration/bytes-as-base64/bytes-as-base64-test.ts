@@ -31,4 +31,12 @@ describe('bytes-as-base64', () => {
     const messageB = Message.fromJSON(json);
     expect(messageA).toEqual(messageB);
   });
+
+  it('fromJSON passes already-decoded bytes through', () => {
+    for (const entry of testData) {
+      const message = { data: entry[1] };
+      expect(Message.fromJSON(message)).toEqual(Message.fromJSON(Message.toJSON(message)));
+      expect(Message.fromJSON(message)).toEqual(message);
+    }
+  });
 });
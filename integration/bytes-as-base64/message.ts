@@ -12,7 +12,11 @@ function createBaseMessage(): Message {
 export const Message = {
   fromJSON(object: any): Message {
     return {
-      data: isSet(object.data) ? bytesFromBase64(object.data) : new Uint8Array(),
+      data: isSet(object.data)
+        ? object.data instanceof Uint8Array
+          ? object.data
+          : bytesFromBase64(object.data)
+        : new Uint8Array(),
     };
   },
 
@@ -0,0 +1,107 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'bytesas';
+
+export interface Blob {
+  data: Buffer;
+  chunks: Buffer[];
+}
+
+function createBaseBlob(): Blob {
+  return { data: Buffer.alloc(0), chunks: [] };
+}
+
+export const Blob = {
+  encode(message: Blob, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.data.length !== 0) {
+      writer.uint32(10).bytes(message.data);
+    }
+    for (const v of message.chunks) {
+      writer.uint32(18).bytes(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Blob {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseBlob();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.data = Buffer.from(reader.bytes());
+          break;
+        case 2:
+          message.chunks.push(Buffer.from(reader.bytes()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Blob {
+    return {
+      data: isSet(object.data)
+        ? object.data instanceof Uint8Array
+          ? Buffer.from(object.data)
+          : bytesFromBase64(object.data)
+        : Buffer.alloc(0),
+      chunks: Array.isArray(object?.chunks)
+        ? object.chunks.map((e: any) => (e instanceof Uint8Array ? Buffer.from(e) : bytesFromBase64(e)))
+        : [],
+    };
+  },
+
+  toJSON(message: Blob): unknown {
+    const obj: any = {};
+    message.data !== undefined &&
+      (obj.data = base64FromBytes(message.data !== undefined ? message.data : Buffer.alloc(0)));
+    if (message.chunks) {
+      obj.chunks = message.chunks.map((e) => base64FromBytes(e !== undefined ? e : Buffer.alloc(0)));
+    } else {
+      obj.chunks = [];
+    }
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Blob>, I>>(object: I): Blob {
+    const message = createBaseBlob();
+    message.data = object.data ?? Buffer.alloc(0);
+    message.chunks = object.chunks?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function bytesFromBase64(b64: string): Buffer {
+  return Buffer.from(b64, 'base64');
+}
+
+function base64FromBytes(arr: Buffer): string {
+  return arr.toString('base64');
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
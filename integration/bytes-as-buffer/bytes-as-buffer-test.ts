@@ -0,0 +1,40 @@
+import { Blob } from './blob';
+import { Blob as Base64Blob } from '../bytes-as-base64-string/blob';
+
+describe('bytesAs=buffer', () => {
+  const blob: Blob = { data: Buffer.from([0xde, 0xad, 0xbe, 0xef]), chunks: [Buffer.from('a'), Buffer.alloc(0)] };
+
+  it('defaults to empty Buffers', () => {
+    const empty = Blob.decode(new Uint8Array());
+    expect(Buffer.isBuffer(empty.data)).toBe(true);
+    expect(empty.data.length).toBe(0);
+    expect(Buffer.isBuffer(Blob.fromPartial({}).data)).toBe(true);
+    expect(Buffer.isBuffer(Blob.fromJSON({}).data)).toBe(true);
+  });
+
+  it('decodes Buffers, even from Uint8Array inputs', () => {
+    const decoded = Blob.decode(new Uint8Array(Blob.encode(blob).finish()));
+    expect(decoded).toEqual(blob);
+    expect(Buffer.isBuffer(decoded.data)).toBe(true);
+    expect(decoded.chunks.every((chunk) => Buffer.isBuffer(chunk))).toBe(true);
+  });
+
+  it('writes and reads base64 in JSON', () => {
+    const json = Blob.toJSON(blob);
+    expect(json).toEqual({ data: '3q2+7w==', chunks: ['YQ==', ''] });
+    const parsed = Blob.fromJSON(json);
+    expect(parsed).toEqual(blob);
+    expect(Buffer.isBuffer(parsed.data)).toBe(true);
+  });
+
+  it('passes already-decoded bytes through fromJSON as Buffers', () => {
+    const parsed = Blob.fromJSON({ data: new Uint8Array([1, 2]), chunks: [new Uint8Array([3])] });
+    expect(parsed).toEqual({ data: Buffer.from([1, 2]), chunks: [Buffer.from([3])] });
+    expect(Buffer.isBuffer(parsed.data)).toBe(true);
+    expect(Buffer.isBuffer(parsed.chunks[0])).toBe(true);
+  });
+
+  it('uses the same wire format as bytesAs=base64-string', () => {
+    expect(Base64Blob.decode(Blob.encode(blob).finish())).toEqual({ data: '3q2+7w==', chunks: ['YQ==', ''] });
+  });
+});
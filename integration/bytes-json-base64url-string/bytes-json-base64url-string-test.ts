@@ -0,0 +1,23 @@
+import { Blob } from './blob';
+import { Blob as Base64Blob } from '../bytes-as-base64-string/blob';
+
+describe('bytesAs=base64-string with bytesJsonEncoding=base64url', () => {
+  const blob: Blob = { data: '+/8=', chunks: ['YQ==', ''] };
+
+  it('keeps the strings in standard base64 outside of JSON', () => {
+    expect(Blob.decode(Blob.encode(blob).finish())).toEqual(blob);
+    expect(Blob.decode(Base64Blob.encode(blob).finish())).toEqual(blob);
+    expect(Base64Blob.decode(Blob.encode(blob).finish())).toEqual(blob);
+  });
+
+  it('transcodes the strings to base64url in JSON', () => {
+    expect(Blob.toJSON(blob)).toEqual({ data: '-_8', chunks: ['YQ', ''] });
+    expect(Blob.fromJSON({ data: '-_8', chunks: ['YQ', ''] })).toEqual(blob);
+    expect(Blob.fromJSON({ data: '-_8=', chunks: ['YQ==', ''] })).toEqual(blob);
+  });
+
+  it('round-trips through toJSON and fromJSON', () => {
+    expect(Blob.fromJSON(Blob.toJSON(blob))).toEqual(blob);
+    expect(Blob.fromJSON(Blob.toJSON({ data: '', chunks: [] }))).toEqual({ data: '', chunks: [] });
+  });
+});
@@ -0,0 +1,140 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'bytesas';
+
+export interface Blob {
+  data: Uint8Array;
+  chunks: Uint8Array[];
+}
+
+function createBaseBlob(): Blob {
+  return { data: new Uint8Array(), chunks: [] };
+}
+
+export const Blob = {
+  encode(message: Blob, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.data.length !== 0) {
+      writer.uint32(10).bytes(message.data);
+    }
+    for (const v of message.chunks) {
+      writer.uint32(18).bytes(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Blob {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseBlob();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.data = reader.bytes();
+          break;
+        case 2:
+          message.chunks.push(reader.bytes());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Blob {
+    return {
+      data: isSet(object.data)
+        ? object.data instanceof Uint8Array
+          ? object.data
+          : bytesFromBase64Url(object.data)
+        : new Uint8Array(),
+      chunks: Array.isArray(object?.chunks)
+        ? object.chunks.map((e: any) => (e instanceof Uint8Array ? e : bytesFromBase64Url(e)))
+        : [],
+    };
+  },
+
+  toJSON(message: Blob): unknown {
+    const obj: any = {};
+    message.data !== undefined &&
+      (obj.data = base64UrlFromBytes(message.data !== undefined ? message.data : new Uint8Array()));
+    if (message.chunks) {
+      obj.chunks = message.chunks.map((e) => base64UrlFromBytes(e !== undefined ? e : new Uint8Array()));
+    } else {
+      obj.chunks = [];
+    }
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Blob>, I>>(object: I): Blob {
+    const message = createBaseBlob();
+    message.data = object.data ?? new Uint8Array();
+    message.chunks = object.chunks?.map((e) => e) || [];
+    return message;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+const atob: (b64: string) => string =
+  globalThis.atob || ((b64) => globalThis.Buffer.from(b64, 'base64').toString('binary'));
+function bytesFromBase64(b64: string): Uint8Array {
+  const bin = atob(b64);
+  const arr = new Uint8Array(bin.length);
+  for (let i = 0; i < bin.length; ++i) {
+    arr[i] = bin.charCodeAt(i);
+  }
+  return arr;
+}
+
+const btoa: (bin: string) => string =
+  globalThis.btoa || ((bin) => globalThis.Buffer.from(bin, 'binary').toString('base64'));
+function base64FromBytes(arr: Uint8Array): string {
+  const bin: string[] = [];
+  arr.forEach((byte) => {
+    bin.push(String.fromCharCode(byte));
+  });
+  return btoa(bin.join(''));
+}
+
+function bytesFromBase64Url(b64: string): Uint8Array {
+  const padding = '==='.slice((b64.length + 3) % 4);
+  return bytesFromBase64(b64.replace(/-/g, '+').replace(/_/g, '/') + padding);
+}
+
+function base64UrlFromBytes(arr: Uint8Array): string {
+  return base64FromBytes(arr).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,31 @@
+import { Blob } from './blob';
+import { Blob as BufferBlob } from '../bytes-as-buffer/blob';
+
+describe('bytesJsonEncoding=base64url', () => {
+  const blob: Blob = { data: new Uint8Array([0xfb, 0xff]), chunks: [new Uint8Array([0x61]), new Uint8Array()] };
+
+  it('writes the URL-safe alphabet without padding in JSON', () => {
+    expect(Blob.toJSON(blob)).toEqual({ data: '-_8', chunks: ['YQ', ''] });
+  });
+
+  it('reads both padded and unpadded JSON', () => {
+    expect(Blob.fromJSON({ data: '-_8', chunks: ['YQ', ''] })).toEqual(blob);
+    expect(Blob.fromJSON({ data: '-_8=', chunks: ['YQ==', ''] })).toEqual(blob);
+  });
+
+  it('round-trips every length through toJSON and fromJSON', () => {
+    for (let length = 0; length < 8; length++) {
+      const data = new Uint8Array(Array.from({ length }, (_, i) => 0xff - i * 17));
+      const json = Blob.toJSON({ data, chunks: [data] }) as { data: string };
+      expect(json.data).toMatch(/^[A-Za-z0-9_-]*$/);
+      expect(Blob.fromJSON(json)).toEqual({ data, chunks: [data] });
+    }
+  });
+
+  it('keeps the wire format unchanged', () => {
+    expect(Blob.decode(Blob.encode(blob).finish())).toEqual(blob);
+    const decoded = BufferBlob.decode(Blob.encode(blob).finish());
+    expect(decoded.data).toEqual(Buffer.from([0xfb, 0xff]));
+    expect(decoded.chunks).toEqual([Buffer.from('a'), Buffer.alloc(0)]);
+  });
+});
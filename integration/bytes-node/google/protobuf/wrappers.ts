@@ -518,7 +518,9 @@ export const BytesValue = {
 
   fromJSON(object: any): BytesValue {
     return {
-      value: isSet(object.value) ? Buffer.from(bytesFromBase64(object.value)) : Buffer.alloc(0),
+      value: isSet(object.value)
+        ? Buffer.from(object.value instanceof Uint8Array ? object.value : bytesFromBase64(object.value))
+        : Buffer.alloc(0),
     };
   },
 
@@ -13,4 +13,12 @@ describe('point', () => {
     expect(dataDecoded.toString('hex')).toMatchInlineSnapshot(`"74657374626c6f62"`);
     expect(dataDecoded2?.toString('hex')).toMatchInlineSnapshot(`"74657374626c6f62"`);
   });
+
+  it('keeps the bytes of an already-decoded message in fromJSON', () => {
+    const point: Point = { data: Buffer.from('testblob'), dataWrapped: Buffer.from('wrapped') };
+    const pointDecoded = Point.fromJSON(point);
+    expect(Buffer.isBuffer(pointDecoded.data)).toBe(true);
+    expect(pointDecoded.data.toString()).toEqual('testblob');
+    expect(pointDecoded.dataWrapped?.toString()).toEqual('wrapped');
+  });
 });
@@ -47,8 +47,12 @@ export const Point = {
 
   fromJSON(object: any): Point {
     return {
-      data: isSet(object.data) ? Buffer.from(bytesFromBase64(object.data)) : Buffer.alloc(0),
-      dataWrapped: isSet(object.dataWrapped) ? new Buffer(object.dataWrapped) : undefined,
+      data: isSet(object.data)
+        ? Buffer.from(object.data instanceof Uint8Array ? object.data : bytesFromBase64(object.data))
+        : Buffer.alloc(0),
+      dataWrapped: isSet(object.dataWrapped)
+        ? Buffer.from(object.dataWrapped instanceof Uint8Array ? object.dataWrapped : bytesFromBase64(object.dataWrapped))
+        : undefined,
     };
   },
 
@@ -56,7 +60,7 @@ export const Point = {
     const obj: any = {};
     message.data !== undefined &&
       (obj.data = base64FromBytes(message.data !== undefined ? message.data : Buffer.alloc(0)));
-    message.dataWrapped !== undefined && (obj.dataWrapped = message.dataWrapped);
+    message.dataWrapped !== undefined && (obj.dataWrapped = base64FromBytes(message.dataWrapped));
     return obj;
   },
 
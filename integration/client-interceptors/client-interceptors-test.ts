@@ -0,0 +1,110 @@
+import {
+  DeleteUserRequest,
+  DeleteUserResponse,
+  GetUserRequest,
+  MethodInterceptor,
+  UnaryInterceptor,
+  User,
+  UsersClientImpl,
+} from './users';
+
+/** An `Rpc` that records its calls and answers `GetUser` and `DeleteUser`. */
+function stubRpc() {
+  const calls: Array<{ service: string; method: string }> = [];
+  const rpc = {
+    request: async (service: string, method: string, data: Uint8Array): Promise<Uint8Array> => {
+      calls.push({ service, method });
+      if (method === 'GetUser') {
+        const { id } = GetUserRequest.decode(data);
+        return User.encode({ id, name: `user ${id}` }).finish();
+      }
+      return DeleteUserResponse.encode({ deleted: DeleteUserRequest.decode(data).id !== '' }).finish();
+    },
+  };
+  return { rpc, calls };
+}
+
+describe('client-interceptors', () => {
+  it('calls the transport without interceptors', async () => {
+    const { rpc, calls } = stubRpc();
+    const client = new UsersClientImpl(rpc);
+    expect(await client.GetUser({ id: '1' })).toEqual({ id: '1', name: 'user 1' });
+    expect(calls).toEqual([{ service: 'users.Users', method: 'GetUser' }]);
+  });
+
+  it('runs the interceptors around every unary method, with the method on the call', async () => {
+    const { rpc, calls } = stubRpc();
+    const methods: string[] = [];
+    const logging: UnaryInterceptor = (call, next) => {
+      methods.push(`${call.service}/${call.method}`);
+      return next(call.request);
+    };
+    const client = new UsersClientImpl(rpc, [logging]);
+    expect(await client.GetUser({ id: '2' })).toEqual({ id: '2', name: 'user 2' });
+    expect(await client.DeleteUser({ id: '2' })).toEqual({ deleted: true });
+    expect(methods).toEqual(['users.Users/GetUser', 'users.Users/DeleteUser']);
+    expect(calls.map((c) => c.method)).toEqual(['GetUser', 'DeleteUser']);
+  });
+
+  it('runs the interceptors in order, with the first one outermost', async () => {
+    const { rpc } = stubRpc();
+    const order: string[] = [];
+    const named =
+      (name: string): UnaryInterceptor =>
+      async (call, next) => {
+        order.push(`${name} in`);
+        const response = await next(call.request);
+        order.push(`${name} out`);
+        return response;
+      };
+    const client = new UsersClientImpl(rpc, [named('a'), named('b')]);
+    await client.GetUser({ id: '3' });
+    expect(order).toEqual(['a in', 'b in', 'b out', 'a out']);
+  });
+
+  it('short-circuits the call when an interceptor throws', async () => {
+    const { rpc, calls } = stubRpc();
+    const client = new UsersClientImpl(rpc, [
+      async () => {
+        throw new Error('not allowed');
+      },
+    ]);
+    await expect(client.DeleteUser({ id: '4' })).rejects.toThrow('not allowed');
+    expect(calls).toEqual([]);
+  });
+
+  it('runs the typed interceptors of just the called method inside the ones of every method', async () => {
+    const { rpc } = stubRpc();
+    const seen: string[] = [];
+    const logging: UnaryInterceptor = (call, next) => {
+      seen.push(`all ${call.method}`);
+      return next(call.request);
+    };
+    const getUser: MethodInterceptor<GetUserRequest, User> = async (call, next) => {
+      seen.push(`${call.method}(${call.request.id})`);
+      const user = await next({ id: `${call.request.id}-checked` });
+      return { ...user, name: user.name.toUpperCase() };
+    };
+    const deleteUser: MethodInterceptor<DeleteUserRequest, DeleteUserResponse> = async (call, next) => {
+      seen.push(`${call.method}(${call.request.id})`);
+      const response = await next(call.request);
+      return { deleted: !response.deleted };
+    };
+    const client = new UsersClientImpl(rpc, [logging], { GetUser: [getUser], DeleteUser: [deleteUser] });
+    expect(await client.GetUser({ id: '5' })).toEqual({ id: '5-checked', name: 'USER 5-CHECKED' });
+    expect(await client.DeleteUser({ id: '5' })).toEqual({ deleted: false });
+    expect(seen).toEqual(['all GetUser', 'GetUser(5)', 'all DeleteUser', 'DeleteUser(5)']);
+  });
+
+  it('only runs the per-method interceptors of the called method', async () => {
+    const { rpc } = stubRpc();
+    let intercepted = 0;
+    const getUser: MethodInterceptor<GetUserRequest, User> = (call, next) => {
+      intercepted++;
+      return next(call.request);
+    };
+    const client = new UsersClientImpl(rpc, [], { GetUser: [getUser] });
+    expect(await client.DeleteUser({ id: '6' })).toEqual({ deleted: true });
+    expect(intercepted).toEqual(0);
+  });
+});
@@ -0,0 +1,221 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'users';
+
+export interface GetUserRequest {
+  id: string;
+}
+
+export interface User {
+  id: string;
+  name: string;
+}
+
+export interface DeleteUserRequest {
+  id: string;
+}
+
+export interface DeleteUserResponse {
+  deleted: boolean;
+}
+
+function createBaseGetUserRequest(): GetUserRequest {
+  return { id: '' };
+}
+
+export const GetUserRequest = {
+  encode(message: GetUserRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetUserRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetUserRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseUser(): User {
+  return { id: '', name: '' };
+}
+
+export const User = {
+  encode(message: User, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.name !== '') {
+      writer.uint32(18).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): User {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseUser();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        case 2:
+          message.name = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseDeleteUserRequest(): DeleteUserRequest {
+  return { id: '' };
+}
+
+export const DeleteUserRequest = {
+  encode(message: DeleteUserRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteUserRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteUserRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseDeleteUserResponse(): DeleteUserResponse {
+  return { deleted: false };
+}
+
+export const DeleteUserResponse = {
+  encode(message: DeleteUserResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.deleted === true) {
+      writer.uint32(8).bool(message.deleted);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteUserResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteUserResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.deleted = reader.bool();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export interface Users {
+  GetUser(request: GetUserRequest): Promise<User>;
+  DeleteUser(request: DeleteUserRequest): Promise<DeleteUserResponse>;
+}
+
+export interface UsersInterceptors {
+  GetUser?: MethodInterceptor<GetUserRequest, User>[];
+  DeleteUser?: MethodInterceptor<DeleteUserRequest, DeleteUserResponse>[];
+}
+
+export class UsersClientImpl implements Users {
+  private readonly rpc: Rpc;
+  private readonly interceptors: UnaryInterceptor[];
+  private readonly methodInterceptors: UsersInterceptors;
+  constructor(rpc: Rpc, interceptors: UnaryInterceptor[] = [], methodInterceptors: UsersInterceptors = {}) {
+    this.interceptors = interceptors;
+    this.methodInterceptors = methodInterceptors;
+    this.rpc = rpc;
+    this.GetUser = this.GetUser.bind(this);
+    this.DeleteUser = this.DeleteUser.bind(this);
+  }
+  GetUser(request: GetUserRequest): Promise<User> {
+    const call = { service: 'users.Users', method: 'GetUser', request };
+    const methodInterceptors = this.methodInterceptors.GetUser ?? [];
+    return runInterceptors(this.interceptors, methodInterceptors, call, (request) => {
+      const data = GetUserRequest.encode(request).finish();
+      const promise = this.rpc.request(call.service, call.method, data);
+      return promise.then((data) => User.decode(new _m0.Reader(data)));
+    });
+  }
+
+  DeleteUser(request: DeleteUserRequest): Promise<DeleteUserResponse> {
+    const call = { service: 'users.Users', method: 'DeleteUser', request };
+    const methodInterceptors = this.methodInterceptors.DeleteUser ?? [];
+    return runInterceptors(this.interceptors, methodInterceptors, call, (request) => {
+      const data = DeleteUserRequest.encode(request).finish();
+      const promise = this.rpc.request(call.service, call.method, data);
+      return promise.then((data) => DeleteUserResponse.decode(new _m0.Reader(data)));
+    });
+  }
+}
+
+interface Rpc {
+  request(service: string, method: string, data: Uint8Array): Promise<Uint8Array>;
+}
+
+export interface UnaryCall<Req> {
+  service: string;
+  method: string;
+  request: Req;
+}
+
+export type UnaryInterceptor = <Req, Res>(call: UnaryCall<Req>, next: (request: Req) => Promise<Res>) => Promise<Res>;
+
+export type MethodInterceptor<Req, Res> = (call: UnaryCall<Req>, next: (request: Req) => Promise<Res>) => Promise<Res>;
+
+function runInterceptors<Req, Res>(
+  interceptors: UnaryInterceptor[],
+  methodInterceptors: MethodInterceptor<Req, Res>[],
+  call: UnaryCall<Req>,
+  transport: (request: Req) => Promise<Res>
+): Promise<Res> {
+  const all: MethodInterceptor<Req, Res>[] = [...interceptors, ...methodInterceptors];
+  const chain = all.reduceRight<(request: Req) => Promise<Res>>(
+    (next, interceptor) => (request) => interceptor({ ...call, request }, next),
+    transport
+  );
+  return chain(call.request);
+}
@@ -0,0 +1,54 @@
+import { GetUserRequest, RetryPolicy, User, Users, UsersClientImpl } from './users';
+
+/** Fakes a transport that fails with each of `errors` in turn, and then succeeds. */
+function failingWith(...errors: unknown[]) {
+  let attempts = 0;
+  const rpc = {
+    request: async (service: string, method: string, data: Uint8Array): Promise<Uint8Array> => {
+      const error = errors[attempts++];
+      if (error) {
+        throw error;
+      }
+      const { id } = GetUserRequest.decode(data);
+      return User.encode({ id, name: 'ada' }).finish();
+    },
+  };
+  return { client: new UsersClientImpl(rpc), attempts: () => attempts };
+}
+
+describe('client-retry', () => {
+  const unavailable = { code: 14, message: 'unavailable' };
+  const notFound = { code: 5, message: 'not found' };
+  const policy: RetryPolicy = { maxAttempts: 3, retryableCodes: [14], backoffMs: 0 };
+
+  it('retries errors with a retryable code until a call succeeds', async () => {
+    const { client, attempts } = failingWith(unavailable, unavailable);
+    expect(await client.GetUser({ id: '1' }, policy)).toEqual({ id: '1', name: 'ada' });
+    expect(attempts()).toEqual(3);
+  });
+
+  it('gives up after maxAttempts', async () => {
+    const { client, attempts } = failingWith(unavailable, unavailable, unavailable);
+    await expect(client.GetUser({ id: '1' }, policy)).rejects.toEqual(unavailable);
+    expect(attempts()).toEqual(3);
+  });
+
+  it('does not retry errors with other codes', async () => {
+    const { client, attempts } = failingWith(notFound);
+    await expect(client.GetUser({ id: '1' }, policy)).rejects.toEqual(notFound);
+    expect(attempts()).toEqual(1);
+  });
+
+  it('does not retry without a policy', async () => {
+    const { client, attempts } = failingWith(unavailable);
+    await expect(client.GetUser({ id: '1' })).rejects.toEqual(unavailable);
+    expect(attempts()).toEqual(1);
+  });
+
+  it('accepts the policy through the service interface', async () => {
+    const { client, attempts } = failingWith(unavailable);
+    const users: Users = client;
+    expect(await users.GetUser({ id: '2' }, policy)).toEqual({ id: '2', name: 'ada' });
+    expect(attempts()).toEqual(2);
+  });
+});
@@ -0,0 +1,206 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'users';
+
+export interface GetUserRequest {
+  id: string;
+}
+
+export interface User {
+  id: string;
+  name: string;
+}
+
+export interface DeleteUserRequest {
+  id: string;
+}
+
+export interface DeleteUserResponse {
+  deleted: boolean;
+}
+
+function createBaseGetUserRequest(): GetUserRequest {
+  return { id: '' };
+}
+
+export const GetUserRequest = {
+  encode(message: GetUserRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetUserRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetUserRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseUser(): User {
+  return { id: '', name: '' };
+}
+
+export const User = {
+  encode(message: User, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.name !== '') {
+      writer.uint32(18).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): User {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseUser();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        case 2:
+          message.name = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseDeleteUserRequest(): DeleteUserRequest {
+  return { id: '' };
+}
+
+export const DeleteUserRequest = {
+  encode(message: DeleteUserRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteUserRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteUserRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseDeleteUserResponse(): DeleteUserResponse {
+  return { deleted: false };
+}
+
+export const DeleteUserResponse = {
+  encode(message: DeleteUserResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.deleted === true) {
+      writer.uint32(8).bool(message.deleted);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteUserResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteUserResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.deleted = reader.bool();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export interface Users {
+  GetUser(request: GetUserRequest, retry?: RetryPolicy): Promise<User>;
+  DeleteUser(request: DeleteUserRequest, retry?: RetryPolicy): Promise<DeleteUserResponse>;
+}
+
+export class UsersClientImpl implements Users {
+  private readonly rpc: Rpc;
+  constructor(rpc: Rpc) {
+    this.rpc = rpc;
+    this.GetUser = this.GetUser.bind(this);
+    this.DeleteUser = this.DeleteUser.bind(this);
+  }
+  GetUser(request: GetUserRequest, retry?: RetryPolicy): Promise<User> {
+    return withRetry(retry, () => {
+      const data = GetUserRequest.encode(request).finish();
+      const promise = this.rpc.request('users.Users', 'GetUser', data);
+      return promise.then((data) => User.decode(new _m0.Reader(data)));
+    });
+  }
+
+  DeleteUser(request: DeleteUserRequest, retry?: RetryPolicy): Promise<DeleteUserResponse> {
+    return withRetry(retry, () => {
+      const data = DeleteUserRequest.encode(request).finish();
+      const promise = this.rpc.request('users.Users', 'DeleteUser', data);
+      return promise.then((data) => DeleteUserResponse.decode(new _m0.Reader(data)));
+    });
+  }
+}
+
+interface Rpc {
+  request(service: string, method: string, data: Uint8Array): Promise<Uint8Array>;
+}
+
+export interface RetryPolicy {
+  /** The maximum number of attempts, including the first one. */
+  maxAttempts: number;
+  /** The status codes of the errors to retry. */
+  retryableCodes: Array<number | string>;
+  /** The delay before the first retry, which doubles with each further retry. */
+  backoffMs: number;
+}
+
+async function withRetry<T>(retry: RetryPolicy | undefined, attempt: () => Promise<T>): Promise<T> {
+  for (let i = 1; ; i++) {
+    try {
+      return await attempt();
+    } catch (e) {
+      if (!retry || i >= retry.maxAttempts || retry.retryableCodes.indexOf((e as any)?.code) === -1) {
+        throw e;
+      }
+      await new Promise((resolve) => setTimeout(resolve, retry.backoffMs * Math.pow(2, i - 1)));
+    }
+  }
+}
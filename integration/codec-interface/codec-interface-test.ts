@@ -0,0 +1,21 @@
+import { Codec, Group, User } from './codec';
+
+/** A helper that works with any message, which needs the message objects to share a type. */
+function roundTrip<T>(codec: Codec<T>, message: T): T {
+  return codec.fromJSON(codec.toJSON(codec.decode(codec.encode(message).finish())));
+}
+
+describe('codec-interface', () => {
+  it('annotates each message object with the Codec interface', () => {
+    const codecs: Codec<any>[] = [User, Group];
+    expect(codecs.map((codec) => codec.fromPartial({}))).toEqual([
+      { id: '', name: '' },
+      { name: '', size: 0 },
+    ]);
+  });
+
+  it('allows writing generic helpers over the messages', () => {
+    expect(roundTrip(User, { id: '1', name: 'ada' })).toEqual({ id: '1', name: 'ada' });
+    expect(roundTrip(Group, { name: 'admins', size: 3 })).toEqual({ name: 'admins', size: 3 });
+  });
+});
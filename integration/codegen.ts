@@ -2,12 +2,13 @@ import { CodeGeneratorRequest } from 'ts-proto-descriptors';
 import { mkdir, readFile, writeFile } from 'fs';
 import { parse } from 'path';
 import { promisify } from 'util';
-import { generateFile, makeUtils } from '../src/main';
+import { generateFiles, makeUtils } from '../src/main';
 import { createTypeMap } from '../src/types';
 import { prefixDisableLinter } from '../src/utils';
 import { getTsPoetOpts, optionsFromParameter } from '../src/options';
 import { Context } from '../src/context';
 import { generateTypeRegistry } from '../src/generate-type-registry';
+import { generateTypeUrlEnum } from '../src/generate-type-url-enum';
 
 /**
  * Generates output for our integration tests from their example proto files.
@@ -28,29 +29,49 @@ async function generate(binFile: string, baseDir: string, parameter: string) {
   const request = CodeGeneratorRequest.decode(stdin);
   request.parameter = parameter;
 
-  const options = optionsFromParameter(parameter || '');
+  // The fixtures follow this repo's own .prettierrc, so default them to single quotes
+  const fixtureParameter = (parameter || '').includes('quoteStyle=')
+    ? parameter
+    : ['quoteStyle=single', parameter].filter((p) => !!p).join(',');
+  const options = optionsFromParameter(fixtureParameter);
   const typeMap = createTypeMap(request, options);
 
   for (let file of request.protoFile) {
     // Make a different utils per file to track per-file usage
     const utils = makeUtils(options);
     const ctx: Context = { options, typeMap, utils };
-    const [path, code] = generateFile(ctx, file);
+    for (const [path, code] of generateFiles(ctx, file)) {
+      const filePath = `${baseDir}/${path}`;
+      const dirPath = parse(filePath).dir;
+      await promisify(mkdir)(dirPath, { recursive: true }).catch(() => {});
+      await promisify(writeFile)(
+        filePath,
+        prefixDisableLinter(await code.toStringWithImports({ ...getTsPoetOpts(options), path }))
+      );
+    }
+  }
+
+  if (options.outputTypeRegistry) {
+    const utils = makeUtils(options);
+    const ctx: Context = { options, typeMap, utils };
+
+    const path = 'typeRegistry.ts';
+    const code = generateTypeRegistry(ctx);
+
     const filePath = `${baseDir}/${path}`;
-    const dirPath = parse(filePath).dir;
-    await promisify(mkdir)(dirPath, { recursive: true }).catch(() => {});
+
     await promisify(writeFile)(
       filePath,
       prefixDisableLinter(await code.toStringWithImports({ ...getTsPoetOpts(options), path }))
     );
   }
 
-  if (options.outputTypeRegistry) {
+  if (options.outputTypeUrlEnum) {
     const utils = makeUtils(options);
     const ctx: Context = { options, typeMap, utils };
 
-    const path = 'typeRegistry.ts';
-    const code = generateTypeRegistry(ctx);
+    const path = 'knownTypeUrls.ts';
+    const code = generateTypeUrlEnum(ctx);
 
     const filePath = `${baseDir}/${path}`;
 
@@ -0,0 +1,26 @@
+import { compareTask, Task } from './tasks';
+import * as tasks from './tasks';
+
+function task(priority: number, title: string, owner?: string): Task {
+  return { priority, title, owner, created: 0 };
+}
+
+describe('comparators', () => {
+  it('compares by the first sort key', () => {
+    expect(compareTask(task(1, 'b'), task(2, 'a'))).toBeLessThan(0);
+    expect(compareTask(task(2, 'a'), task(1, 'b'))).toBeGreaterThan(0);
+  });
+
+  it('breaks ties with the later sort keys', () => {
+    const sorted = [task(1, 'b', 'ann'), task(1, 'a'), task(0, 'z'), task(1, 'b')].sort(compareTask);
+    expect(sorted).toEqual([task(0, 'z'), task(1, 'a'), task(1, 'b'), task(1, 'b', 'ann')]);
+  });
+
+  it('ignores fields that are not sort keys', () => {
+    expect(compareTask({ ...task(1, 'a'), created: 1 }, { ...task(1, 'a'), created: 2 })).toEqual(0);
+  });
+
+  it('only outputs comparators for messages with sort keys', () => {
+    expect('compareNote' in tasks).toBe(false);
+  });
+});
@@ -0,0 +1,30 @@
+/* eslint-disable */
+
+export const protobufPackage = 'tasks';
+
+export interface Task {
+  priority: number;
+  title: string;
+  owner?: string | undefined;
+  created: number;
+}
+
+export function compareTask(a: Task, b: Task): number {
+  return (
+    compareSortKey(a.priority, b.priority, (x, y) => (x < y ? -1 : x > y ? 1 : 0)) ||
+    compareSortKey(a.title, b.title, (x, y) => (x < y ? -1 : x > y ? 1 : 0)) ||
+    compareSortKey(a.owner, b.owner, (x, y) => (x < y ? -1 : x > y ? 1 : 0))
+  );
+}
+
+export interface Note {
+  text: string;
+}
+
+function compareSortKey<T>(a: T | null | undefined, b: T | null | undefined, compare: (a: T, b: T) => number): number {
+  // Unset keys sort before set ones
+  if (a === undefined || a === null || b === undefined || b === null) {
+    return Number(a !== undefined && a !== null) - Number(b !== undefined && b !== null);
+  }
+  return compare(a, b);
+}
@@ -0,0 +1,38 @@
+import { MethodIdempotency, MethodKind } from '@bufbuild/protobuf';
+import { GreeterConnect, HelloReply, HelloRequest } from './greeter';
+
+describe('connect-services', () => {
+  it('names the service by its full proto name', () => {
+    expect(GreeterConnect.typeName).toEqual('greeter.Greeter');
+    expect(Object.keys(GreeterConnect.methods)).toEqual([
+      'sayHello',
+      'setGreeting',
+      'streamHellos',
+      'collectHellos',
+      'chat',
+    ]);
+  });
+
+  it('describes the kind of each method', () => {
+    const { methods } = GreeterConnect;
+    expect(methods.sayHello.kind).toEqual(MethodKind.Unary);
+    expect(methods.streamHellos.kind).toEqual(MethodKind.ServerStreaming);
+    expect(methods.collectHellos.kind).toEqual(MethodKind.ClientStreaming);
+    expect(methods.chat.kind).toEqual(MethodKind.BiDiStreaming);
+  });
+
+  it('takes the idempotency from the idempotency_level option', () => {
+    const { methods } = GreeterConnect;
+    expect(methods.sayHello.idempotency).toEqual(MethodIdempotency.NoSideEffects);
+    expect(methods.setGreeting.idempotency).toEqual(MethodIdempotency.Idempotent);
+    expect('idempotency' in methods.streamHellos).toBe(false);
+  });
+
+  it('references the generated messages as I and O', () => {
+    const { name, I, O } = GreeterConnect.methods.sayHello;
+    expect(name).toEqual('SayHello');
+    expect(I).toBe(HelloRequest);
+    expect(O).toBe(HelloReply);
+    expect(O.decode(O.encode({ message: 'hi' }).finish())).toEqual({ message: 'hi' });
+  });
+});
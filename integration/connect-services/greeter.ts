@@ -0,0 +1,113 @@
+/* eslint-disable */
+import { MethodKind, MethodIdempotency } from '@bufbuild/protobuf';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'greeter';
+
+export interface HelloRequest {
+  name: string;
+}
+
+export interface HelloReply {
+  message: string;
+}
+
+function createBaseHelloRequest(): HelloRequest {
+  return { name: '' };
+}
+
+export const HelloRequest = {
+  encode(message: HelloRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): HelloRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseHelloRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseHelloReply(): HelloReply {
+  return { message: '' };
+}
+
+export const HelloReply = {
+  encode(message: HelloReply, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.message !== '') {
+      writer.uint32(10).string(message.message);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): HelloReply {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseHelloReply();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.message = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export const GreeterConnect = {
+  typeName: 'greeter.Greeter',
+  methods: {
+    sayHello: {
+      name: 'SayHello',
+      I: HelloRequest,
+      O: HelloReply,
+      kind: MethodKind.Unary,
+      idempotency: MethodIdempotency.NoSideEffects,
+    },
+    setGreeting: {
+      name: 'SetGreeting',
+      I: HelloRequest,
+      O: HelloReply,
+      kind: MethodKind.Unary,
+      idempotency: MethodIdempotency.Idempotent,
+    },
+    streamHellos: {
+      name: 'StreamHellos',
+      I: HelloRequest,
+      O: HelloReply,
+      kind: MethodKind.ServerStreaming,
+    },
+    collectHellos: {
+      name: 'CollectHellos',
+      I: HelloRequest,
+      O: HelloReply,
+      kind: MethodKind.ClientStreaming,
+    },
+    chat: {
+      name: 'Chat',
+      I: HelloRequest,
+      O: HelloReply,
+      kind: MethodKind.BiDiStreaming,
+    },
+  },
+} as const;
@@ -0,0 +1,19 @@
+import { DividerData, DividerData_DividerType } from './const-enum';
+
+describe('const-enum', () => {
+  it('decodes map enum values given by name or by number', () => {
+    const data = DividerData.fromJSON({ typeMap: { a: 'SINGLE', b: 2 } });
+    expect(data.typeMap).toEqual({ a: DividerData_DividerType.SINGLE, b: DividerData_DividerType.DASHED });
+  });
+
+  it('defaults missing map enum values to the zero value', () => {
+    const data = DividerData.fromJSON({ typeMap: { a: null } });
+    expect(data.typeMap).toEqual({ a: DividerData_DividerType.DOUBLE });
+  });
+
+  it('round trips map enum values through JSON', () => {
+    const data = DividerData.fromPartial({ typeMap: { a: DividerData_DividerType.DOTTED } });
+    expect(DividerData.toJSON(data)).toEqual({ type: 'DOUBLE', typeMap: { a: 'DOTTED' } });
+    expect(DividerData.fromJSON(DividerData.toJSON(data))).toEqual(data);
+  });
+});
@@ -118,7 +118,7 @@ export const DividerData = {
       type: isSet(object.type) ? dividerData_DividerTypeFromJSON(object.type) : DividerData_DividerType.DOUBLE,
       typeMap: isObject(object.typeMap)
         ? Object.entries(object.typeMap).reduce<{ [key: string]: DividerData_DividerType }>((acc, [key, value]) => {
-            acc[key] = dividerData_DividerTypeFromJSON(value);
+            acc[key] = isSet(value) ? dividerData_DividerTypeFromJSON(value) : DividerData_DividerType.DOUBLE;
             return acc;
           }, {})
         : {},
@@ -0,0 +1,39 @@
+import { Post, postDebugString, Status } from './debug-string';
+
+describe('debug-string', () => {
+  const post: Post = {
+    id: 1,
+    title: 'hello',
+    data: new Uint8Array([1, 2, 3]),
+    tags: ['a', 'b'],
+    author: { name: 'bob' },
+    status: Status.STATUS_ACTIVE,
+    target: { $case: 'userId', userId: 'u1' },
+  };
+
+  it('prints the set fields on a single line', () => {
+    expect(postDebugString(post)).toEqual(
+      'id=1 title="hello" data=bytes[3] tags=["a", "b"] author={name="bob"} status=STATUS_ACTIVE userId="u1"'
+    );
+  });
+
+  it('leaves out fields at their default value', () => {
+    expect(postDebugString(Post.fromJSON({}))).toEqual('');
+    expect(postDebugString({ ...post, id: 0, title: '', tags: [], author: { name: '' }, target: undefined })).toEqual(
+      'data=bytes[3] author={} status=STATUS_ACTIVE'
+    );
+  });
+
+  it('shows oneof members that are set to their default value', () => {
+    expect(postDebugString(Post.fromJSON({ groupId: '' }))).toEqual('groupId=""');
+  });
+
+  it('truncates long strings and repeated fields', () => {
+    const title = 'x'.repeat(100);
+    const tags = Array.from({ length: 12 }, (_, i) => String(i));
+    const debug = postDebugString(Post.fromJSON({ title, tags }));
+    expect(debug).toEqual(
+      `title="${'x'.repeat(64)}"... tags=["0", "1", "2", "3", "4", "5", "6", "7", "8", "9", ... 2 more]`
+    );
+  });
+});
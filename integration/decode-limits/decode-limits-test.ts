@@ -0,0 +1,70 @@
+import { Reader } from 'protobufjs';
+import { Node } from './node';
+
+/** Returns a chain of `depth` nested children below the top-level message. */
+function nested(depth: number): Node {
+  let node = Node.fromPartial({ values: [depth] });
+  for (let i = depth - 1; i >= 0; i--) {
+    node = Node.fromPartial({ child: node, values: [i] });
+  }
+  return node;
+}
+
+describe('decode-limits', () => {
+  it('decodes messages nested exactly at the maximum depth', () => {
+    const bytes = Node.encode(nested(3)).finish();
+    expect(Node.decode(bytes, { maxDepth: 3 })).toEqual(nested(3));
+  });
+
+  it('throws on messages nested deeper than the maximum depth', () => {
+    const bytes = Node.encode(nested(4)).finish();
+    expect(() => Node.decode(bytes, { maxDepth: 3 })).toThrow('Message exceeds the maximum nesting depth of 3');
+    expect(() => Node.decode(bytes, { maxDepth: 0 })).toThrow('Message exceeds the maximum nesting depth of 0');
+  });
+
+  it('decodes messages of exactly the maximum size', () => {
+    const message = Node.fromPartial({ values: [1, 2, 3], data: new Uint8Array(100) });
+    const bytes = Node.encode(message).finish();
+    expect(Node.decode(bytes, { maxBytes: bytes.length })).toEqual(message);
+  });
+
+  it('throws on bytes fields that take the message over the maximum size', () => {
+    const bytes = Node.encode(Node.fromPartial({ data: new Uint8Array(100) })).finish();
+    expect(() => Node.decode(bytes, { maxBytes: bytes.length - 1 })).toThrow(
+      `Message exceeds the maximum size of ${bytes.length - 1} bytes`
+    );
+  });
+
+  it('throws on repeated fields that take the message over the maximum size', () => {
+    const bytes = Node.encode(Node.fromPartial({ values: new Array(1000).fill(-1) })).finish();
+    expect(() => Node.decode(bytes, { maxBytes: 1000 })).toThrow('Message exceeds the maximum size of 1000 bytes');
+  });
+
+  it('counts the bytes of nested messages towards the maximum size', () => {
+    const message = Node.fromPartial({ child: { child: { data: new Uint8Array(100) } } });
+    const bytes = Node.encode(message).finish();
+    expect(Node.decode(bytes, { maxBytes: bytes.length })).toEqual(message);
+    expect(() => Node.decode(bytes, { maxBytes: 100 })).toThrow('Message exceeds the maximum size of 100 bytes');
+  });
+
+  it('checks both limits together', () => {
+    const bytes = Node.encode(nested(2)).finish();
+    expect(Node.decode(bytes, { maxDepth: 2, maxBytes: bytes.length })).toEqual(nested(2));
+    expect(() => Node.decode(bytes, { maxDepth: 1, maxBytes: bytes.length })).toThrow(/nesting depth/);
+    expect(() => Node.decode(bytes, { maxDepth: 2, maxBytes: bytes.length - 1 })).toThrow(/maximum size/);
+  });
+
+  it('accepts the limits after the length', () => {
+    const bytes = Node.encode(Node.fromPartial({ data: new Uint8Array(10) })).finish();
+    const prefixed = new Uint8Array([...bytes, 0xff, 0xff]);
+    expect(Node.decode(new Reader(prefixed), bytes.length, { maxBytes: bytes.length })).toEqual(Node.decode(bytes));
+    expect(() => Node.decode(new Reader(prefixed), bytes.length, { maxBytes: bytes.length - 1 })).toThrow(
+      /maximum size/
+    );
+  });
+
+  it('decodes without limits', () => {
+    const bytes = Node.encode(nested(50)).finish();
+    expect(Node.decode(bytes)).toEqual(nested(50));
+  });
+});
@@ -0,0 +1,175 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'decodelimits';
+
+export interface Node {
+  child: Node | undefined;
+  values: number[];
+  data: Uint8Array;
+}
+
+function createBaseNode(): Node {
+  return { child: undefined, values: [], data: new Uint8Array() };
+}
+
+export const Node = {
+  encode(message: Node, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.child !== undefined) {
+      Node.encode(message.child, writer.uint32(10).fork()).ldelim();
+    }
+    writer.uint32(18).fork();
+    for (const v of message.values) {
+      writer.int32(v);
+    }
+    writer.ldelim();
+    if (message.data.length !== 0) {
+      writer.uint32(26).bytes(message.data);
+    }
+    return writer;
+  },
+
+  decode(
+    input: _m0.Reader | Uint8Array,
+    length?: number | DecodeLimits,
+    limits?: DecodeLimits,
+    depth: number = 0
+  ): Node {
+    if (typeof length === 'object') {
+      limits = length;
+      length = undefined;
+    }
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    checkDecodeLimits(end - reader.pos, limits, depth);
+    const message = createBaseNode();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.child = Node.decode(reader, reader.uint32(), limits, depth + 1);
+          break;
+        case 2:
+          if ((tag & 7) === 2) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.values.push(reader.int32());
+            }
+          } else {
+            message.values.push(reader.int32());
+          }
+          break;
+        case 3:
+          message.data = reader.bytes();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Node {
+    return {
+      child: isSet(object.child) ? Node.fromJSON(object.child) : undefined,
+      values: Array.isArray(object?.values) ? object.values.map((e: any) => Number(e)) : [],
+      data: isSet(object.data)
+        ? object.data instanceof Uint8Array
+          ? object.data
+          : bytesFromBase64(object.data)
+        : new Uint8Array(),
+    };
+  },
+
+  toJSON(message: Node): unknown {
+    const obj: any = {};
+    message.child !== undefined && (obj.child = message.child ? Node.toJSON(message.child) : undefined);
+    if (message.values) {
+      obj.values = message.values.map((e) => Math.round(e));
+    } else {
+      obj.values = [];
+    }
+    message.data !== undefined &&
+      (obj.data = base64FromBytes(message.data !== undefined ? message.data : new Uint8Array()));
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Node>, I>>(object: I): Node {
+    const message = createBaseNode();
+    message.child = object.child !== undefined && object.child !== null ? Node.fromPartial(object.child) : undefined;
+    message.values = object.values?.map((e) => e) || [];
+    message.data = object.data ?? new Uint8Array();
+    return message;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+const atob: (b64: string) => string =
+  globalThis.atob || ((b64) => globalThis.Buffer.from(b64, 'base64').toString('binary'));
+function bytesFromBase64(b64: string): Uint8Array {
+  const bin = atob(b64);
+  const arr = new Uint8Array(bin.length);
+  for (let i = 0; i < bin.length; ++i) {
+    arr[i] = bin.charCodeAt(i);
+  }
+  return arr;
+}
+
+const btoa: (bin: string) => string =
+  globalThis.btoa || ((bin) => globalThis.Buffer.from(bin, 'binary').toString('base64'));
+function base64FromBytes(arr: Uint8Array): string {
+  const bin: string[] = [];
+  arr.forEach((byte) => {
+    bin.push(String.fromCharCode(byte));
+  });
+  return btoa(bin.join(''));
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
+
+export interface DecodeLimits {
+  /** The maximum number of nested messages, where the top-level message is at depth 0. */
+  maxDepth?: number;
+  /** The maximum number of bytes that the top-level message may span. */
+  maxBytes?: number;
+}
+
+function checkDecodeLimits(length: number, limits: DecodeLimits | undefined, depth: number): void {
+  if (limits?.maxDepth !== undefined && depth > limits.maxDepth) {
+    throw new globalThis.Error('Message exceeds the maximum nesting depth of ' + limits.maxDepth);
+  }
+  // Nested messages are within the top-level message's bytes, so it's enough to check it once
+  if (depth === 0 && limits?.maxBytes !== undefined && length > limits.maxBytes) {
+    throw new globalThis.Error('Message exceeds the maximum size of ' + limits.maxBytes + ' bytes');
+  }
+}
@@ -0,0 +1,30 @@
+import { addDuration, durationFromMillis, durationToMillis } from './duration-helpers';
+
+describe('duration-helpers', () => {
+  it('carries nanos into seconds when adding', () => {
+    const sum = addDuration({ seconds: 1, nanos: 600_000_000 }, { seconds: 0, nanos: 700_000_000 });
+    expect(sum).toEqual({ seconds: 2, nanos: 300_000_000 });
+  });
+
+  it('borrows so that seconds and nanos have the same sign', () => {
+    expect(addDuration({ seconds: 2, nanos: 0 }, { seconds: 0, nanos: -1 })).toEqual({
+      seconds: 1,
+      nanos: 999_999_999,
+    });
+    expect(addDuration({ seconds: 1, nanos: 0 }, { seconds: -2, nanos: -500_000_000 })).toEqual({
+      seconds: -1,
+      nanos: -500_000_000,
+    });
+    expect(addDuration({ seconds: -1, nanos: -500_000_000 }, { seconds: 1, nanos: 500_000_000 })).toEqual({
+      seconds: 0,
+      nanos: 0,
+    });
+  });
+
+  it('converts to and from milliseconds', () => {
+    expect(durationToMillis({ seconds: -1, nanos: -500_000_000 })).toEqual(-1500);
+    expect(durationToMillis({ seconds: 0, nanos: 1 })).toEqual(0.000001);
+    expect(durationFromMillis(-1500)).toEqual({ seconds: -1, nanos: -500_000_000 });
+    expect(durationFromMillis(0.1 + 0.2)).toEqual({ seconds: 0, nanos: 300_000 });
+  });
+});
@@ -0,0 +1,44 @@
+/* eslint-disable */
+import type { Duration } from './google/protobuf/duration';
+
+export const protobufPackage = 'durationhelpers';
+
+export interface Job {
+  timeout: Duration | undefined;
+}
+
+function durationParts(d: Duration): [number, number] {
+  return [d.seconds, d.nanos];
+}
+
+function durationFromParts(seconds: number, nanos: number): Duration {
+  // Carry the whole seconds out of the nanos, and then borrow so that both have the same sign
+  seconds += Math.trunc(nanos / 1_000_000_000);
+  nanos %= 1_000_000_000;
+  if (seconds > 0 && nanos < 0) {
+    seconds -= 1;
+    nanos += 1_000_000_000;
+  } else if (seconds < 0 && nanos > 0) {
+    seconds += 1;
+    nanos -= 1_000_000_000;
+  }
+  seconds = seconds || 0;
+  nanos = nanos || 0;
+  return { seconds: seconds, nanos };
+}
+
+export function addDuration(a: Duration, b: Duration): Duration {
+  const [aSeconds, aNanos] = durationParts(a);
+  const [bSeconds, bNanos] = durationParts(b);
+  return durationFromParts(aSeconds + bSeconds, aNanos + bNanos);
+}
+
+export function durationToMillis(d: Duration): number {
+  const [seconds, nanos] = durationParts(d);
+  return seconds * 1_000 + nanos / 1_000_000;
+}
+
+export function durationFromMillis(ms: number): Duration {
+  const seconds = Math.trunc(ms / 1_000);
+  return durationFromParts(seconds, Math.round((ms - seconds * 1_000) * 1_000_000));
+}
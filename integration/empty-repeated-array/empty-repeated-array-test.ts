@@ -0,0 +1,25 @@
+import { Bag } from './bag';
+
+describe('emptyRepeated=array', () => {
+  it('reads unset repeated fields as empty arrays after decode', () => {
+    const bag = Bag.decode(new Uint8Array());
+    expect(bag.tags).toEqual([]);
+    expect(bag.numbers).toEqual([]);
+    expect(bag.items).toEqual([]);
+  });
+
+  it('reads unset repeated fields as empty arrays after fromJSON', () => {
+    expect(Bag.fromJSON({})).toEqual({ tags: [], numbers: [], items: [] });
+  });
+
+  it('reads unset repeated fields as empty arrays after fromPartial', () => {
+    expect(Bag.fromPartial({})).toEqual({ tags: [], numbers: [], items: [] });
+  });
+
+  it('round-trips set repeated fields', () => {
+    const bag: Bag = { tags: ['a', 'b'], numbers: [1, -2], items: [{ name: 'x' }] };
+    expect(Bag.decode(Bag.encode(bag).finish())).toEqual(bag);
+    expect(Bag.fromJSON(Bag.toJSON(bag))).toEqual(bag);
+    expect(Bag.fromPartial(bag)).toEqual(bag);
+  });
+});
@@ -0,0 +1,187 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'emptyrepeated';
+
+export interface Bag {
+  tags?: string[];
+  numbers?: number[];
+  items?: Item[];
+}
+
+export interface Item {
+  name: string;
+}
+
+function createBaseBag(): Bag {
+  return { tags: undefined, numbers: undefined, items: undefined };
+}
+
+export const Bag = {
+  encode(message: Bag, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.tags !== undefined && message.tags.length !== 0) {
+      for (const v of message.tags) {
+        writer.uint32(10).string(v!);
+      }
+    }
+    if (message.numbers !== undefined && message.numbers.length !== 0) {
+      writer.uint32(18).fork();
+      for (const v of message.numbers) {
+        writer.int32(v);
+      }
+      writer.ldelim();
+    }
+    if (message.items !== undefined && message.items.length !== 0) {
+      for (const v of message.items) {
+        Item.encode(v!, writer.uint32(26).fork()).ldelim();
+      }
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Bag {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseBag();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (message.tags === undefined) {
+            message.tags = [];
+          }
+          message.tags!.push(reader.string());
+          break;
+        case 2:
+          if (message.numbers === undefined) {
+            message.numbers = [];
+          }
+          if ((tag & 7) === 2) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.numbers!.push(reader.int32());
+            }
+          } else {
+            message.numbers!.push(reader.int32());
+          }
+          break;
+        case 3:
+          if (message.items === undefined) {
+            message.items = [];
+          }
+          message.items!.push(Item.decode(reader, reader.uint32()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Bag {
+    return {
+      tags: Array.isArray(object?.tags) ? object.tags.map((e: any) => String(e)) : undefined,
+      numbers: Array.isArray(object?.numbers) ? object.numbers.map((e: any) => Number(e)) : undefined,
+      items: Array.isArray(object?.items) ? object.items.map((e: any) => Item.fromJSON(e)) : undefined,
+    };
+  },
+
+  toJSON(message: Bag): unknown {
+    const obj: any = {};
+    if (message.tags) {
+      obj.tags = message.tags.map((e) => e);
+    } else {
+      obj.tags = [];
+    }
+    if (message.numbers) {
+      obj.numbers = message.numbers.map((e) => Math.round(e));
+    } else {
+      obj.numbers = [];
+    }
+    if (message.items) {
+      obj.items = message.items.map((e) => (e ? Item.toJSON(e) : undefined));
+    } else {
+      obj.items = [];
+    }
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Bag>, I>>(object: I): Bag {
+    const message = createBaseBag();
+    message.tags = object.tags?.map((e) => e);
+    message.numbers = object.numbers?.map((e) => e);
+    message.items = object.items?.map((e) => Item.fromPartial(e));
+    return message;
+  },
+};
+
+function createBaseItem(): Item {
+  return { name: '' };
+}
+
+export const Item = {
+  encode(message: Item, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Item {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseItem();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Item {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+    };
+  },
+
+  toJSON(message: Item): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Item>, I>>(object: I): Item {
+    const message = createBaseItem();
+    message.name = object.name ?? '';
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
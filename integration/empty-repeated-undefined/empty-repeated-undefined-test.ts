@@ -0,0 +1,44 @@
+import { Bag } from './bag';
+
+describe('emptyRepeated=undefined', () => {
+  it('reads unset repeated fields as undefined after decode', () => {
+    const bag = Bag.decode(new Uint8Array());
+    expect(bag.tags).toBeUndefined();
+    expect(bag.numbers).toBeUndefined();
+    expect(bag.items).toBeUndefined();
+  });
+
+  it('reads unset repeated fields as undefined after fromJSON', () => {
+    const bag = Bag.fromJSON({});
+    expect(bag.tags).toBeUndefined();
+    expect(bag.numbers).toBeUndefined();
+    expect(bag.items).toBeUndefined();
+  });
+
+  it('reads unset repeated fields as undefined after fromPartial', () => {
+    const bag = Bag.fromPartial({});
+    expect(bag.tags).toBeUndefined();
+    expect(bag.numbers).toBeUndefined();
+    expect(bag.items).toBeUndefined();
+  });
+
+  it('only sets the repeated fields that were present', () => {
+    const bag = Bag.decode(Bag.encode({ tags: ['a'] }).finish());
+    expect(bag.tags).toEqual(['a']);
+    expect(bag.numbers).toBeUndefined();
+    expect(bag.items).toBeUndefined();
+  });
+
+  it('encodes and writes unset repeated fields as empty', () => {
+    expect(Bag.encode({}).finish()).toEqual(new Uint8Array());
+    expect(Bag.encode({ tags: [], numbers: [], items: [] }).finish()).toEqual(new Uint8Array());
+    expect(Bag.toJSON({})).toEqual({ tags: [], numbers: [], items: [] });
+  });
+
+  it('round-trips set repeated fields', () => {
+    const bag: Bag = { tags: ['a', 'b'], numbers: [1, -2], items: [{ name: 'x' }] };
+    expect(Bag.decode(Bag.encode(bag).finish())).toEqual(bag);
+    expect(Bag.fromJSON(Bag.toJSON(bag))).toEqual(bag);
+    expect(Bag.fromPartial(bag)).toEqual(bag);
+  });
+});
@@ -0,0 +1,36 @@
+import { Customer, Order } from './order';
+
+describe('encodeAcceptsPartial', () => {
+  it('encodes a partial and decodes it to the default-filled message', () => {
+    const bytes = Order.encode({ id: 'o-1', customer: { name: 'Ann' } }).finish();
+    expect(Order.decode(bytes)).toEqual({
+      id: 'o-1',
+      quantity: 0,
+      items: [],
+      customer: { name: 'Ann', email: '' },
+      gift: false,
+    });
+  });
+
+  it('encodes an empty partial like the default message', () => {
+    expect(Order.encode({}).finish()).toEqual(new Uint8Array());
+    expect(Order.decode(Order.encode({}).finish())).toEqual(Order.fromPartial({}));
+  });
+
+  it('writes the same bytes as the equivalent full message', () => {
+    const partial = { quantity: 2, items: ['apple'], gift: true };
+    expect(Order.encode(partial).finish()).toEqual(Order.encode(Order.fromPartial(partial)).finish());
+  });
+
+  it('still encodes full messages', () => {
+    const order: Order = { id: 'o-2', quantity: 3, items: ['a', 'b'], customer: undefined, gift: false };
+    expect(Order.decode(Order.encode(order).finish())).toEqual(order);
+  });
+
+  it('accepts partial sub-messages when encoding them on their own', () => {
+    expect(Customer.decode(Customer.encode({ email: 'ann@example.com' }).finish())).toEqual({
+      name: '',
+      email: 'ann@example.com',
+    });
+  });
+});
@@ -0,0 +1,146 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'partial';
+
+export interface Order {
+  id: string;
+  quantity: number;
+  items: string[];
+  customer: Customer | undefined;
+  gift: boolean;
+}
+
+export interface Customer {
+  name: string;
+  email: string;
+}
+
+function createBaseOrder(): Order {
+  return { id: '', quantity: 0, items: [], customer: undefined, gift: false };
+}
+
+export const Order = {
+  encode(input: Order | DeepPartial<Order>, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    const message = Order.fromPartial(input as DeepPartial<Order>);
+    if (message.id !== '') {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.quantity !== 0) {
+      writer.uint32(16).int32(message.quantity);
+    }
+    for (const v of message.items) {
+      writer.uint32(26).string(v!);
+    }
+    if (message.customer !== undefined) {
+      Customer.encode(message.customer, writer.uint32(34).fork()).ldelim();
+    }
+    if (message.gift === true) {
+      writer.uint32(40).bool(message.gift);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Order {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOrder();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.id = reader.string();
+          break;
+        case 2:
+          message.quantity = reader.int32();
+          break;
+        case 3:
+          message.items.push(reader.string());
+          break;
+        case 4:
+          message.customer = Customer.decode(reader, reader.uint32());
+          break;
+        case 5:
+          message.gift = reader.bool();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Order>, I>>(object: I): Order {
+    const message = createBaseOrder();
+    message.id = object.id ?? '';
+    message.quantity = object.quantity ?? 0;
+    message.items = object.items?.map((e) => e) || [];
+    message.customer =
+      object.customer !== undefined && object.customer !== null ? Customer.fromPartial(object.customer) : undefined;
+    message.gift = object.gift ?? false;
+    return message;
+  },
+};
+
+function createBaseCustomer(): Customer {
+  return { name: '', email: '' };
+}
+
+export const Customer = {
+  encode(input: Customer | DeepPartial<Customer>, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    const message = Customer.fromPartial(input as DeepPartial<Customer>);
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.email !== '') {
+      writer.uint32(18).string(message.email);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Customer {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCustomer();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        case 2:
+          message.email = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Customer>, I>>(object: I): Customer {
+    const message = createBaseCustomer();
+    message.name = object.name ?? '';
+    message.email = object.email ?? '';
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
@@ -0,0 +1,16 @@
+import { Writer } from 'protobufjs';
+import { Reading } from './encode-field-order';
+
+describe('encode-field-order', () => {
+  const reading: Reading = { label: 'a', value: 5, ok: true };
+
+  it('writes the fields in field number order', () => {
+    const expected = Writer.create().uint32(8).int32(5).uint32(16).bool(true).uint32(26).string('a').finish();
+    expect(Reading.encode(reading).finish()).toEqual(expected);
+  });
+
+  it('decodes fields in any order', () => {
+    const bytes = Writer.create().uint32(26).string('a').uint32(16).bool(true).uint32(8).int32(5).finish();
+    expect(Reading.decode(bytes)).toEqual(reading);
+  });
+});
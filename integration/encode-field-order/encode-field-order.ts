@@ -0,0 +1,53 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'fieldorder';
+
+export interface Reading {
+  label: string;
+  value: number;
+  ok: boolean;
+}
+
+function createBaseReading(): Reading {
+  return { label: '', value: 0, ok: false };
+}
+
+export const Reading = {
+  encode(message: Reading, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.value !== 0) {
+      writer.uint32(8).int32(message.value);
+    }
+    if (message.ok === true) {
+      writer.uint32(16).bool(message.ok);
+    }
+    if (message.label !== '') {
+      writer.uint32(26).string(message.label);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Reading {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseReading();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 3:
+          message.label = reader.string();
+          break;
+        case 1:
+          message.value = reader.int32();
+          break;
+        case 2:
+          message.ok = reader.bool();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
@@ -0,0 +1,33 @@
+import { Ack, Frame } from './frame';
+
+const frame: Frame = { seq: 7, payload: 'hello' };
+
+describe('encode-into', () => {
+  it('writes the same bytes as encode into the target', () => {
+    const target = new Uint8Array(32);
+    const end = Frame.encodeInto(frame, target);
+    expect(target.subarray(0, end)).toEqual(Uint8Array.from(Frame.encode(frame).finish()));
+    expect(Frame.decode(target.subarray(0, end))).toEqual(frame);
+  });
+
+  it('starts at the offset and returns the offset past the written bytes', () => {
+    const target = new Uint8Array(32);
+    const afterFrame = Frame.encodeInto(frame, target, 4);
+    const afterAck = Ack.encodeInto({ seq: 8 }, target, afterFrame);
+    expect(target.subarray(0, 4)).toEqual(new Uint8Array(4));
+    expect(Frame.decode(target.subarray(4, afterFrame))).toEqual(frame);
+    expect(Ack.decode(target.subarray(afterFrame, afterAck))).toEqual({ seq: 8 });
+  });
+
+  it('throws without writing when the message does not fit', () => {
+    const size = Frame.encode(frame).finish().length;
+    const target = new Uint8Array(size + 2);
+    expect(() => Frame.encodeInto(frame, target, 3)).toThrow(RangeError);
+    expect(target).toEqual(new Uint8Array(size + 2));
+    expect(Frame.encodeInto(frame, target, 2)).toEqual(size + 2);
+  });
+
+  it('rejects negative offsets', () => {
+    expect(() => Frame.encodeInto(frame, new Uint8Array(32), -1)).toThrow(RangeError);
+  });
+});
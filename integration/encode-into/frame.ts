@@ -0,0 +1,131 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+import { Writer } from 'protobufjs/minimal';
+
+export const protobufPackage = 'frames';
+
+export interface Frame {
+  seq: number;
+  payload: string;
+}
+
+export interface Ack {
+  seq: number;
+}
+
+function createBaseFrame(): Frame {
+  return { seq: 0, payload: '' };
+}
+
+export const Frame = {
+  encode(message: Frame, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.seq !== 0) {
+      writer.uint32(8).uint32(message.seq);
+    }
+    if (message.payload !== '') {
+      writer.uint32(18).string(message.payload);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Frame {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseFrame();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.seq = reader.uint32();
+          break;
+        case 2:
+          message.payload = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  encodeInto(message: Frame, target: Uint8Array, offset: number = 0): number {
+    // Writer.create() picks a BufferWriter on Node, whose ops only work on Buffers
+    const writer = Frame.encode(message, new Writer());
+    const end = offset + writer.len;
+    if (offset < 0 || end > target.length) {
+      throw new globalThis.RangeError(
+        'Encoding Frame needs ' + writer.len + ' bytes at offset ' + offset + ', but the target has ' + target.length
+      );
+    }
+    let op = (writer as any).head.next;
+    let pos = offset;
+    while (op) {
+      op.fn(op.val, target, pos);
+      pos += op.len;
+      op = op.next;
+    }
+    return end;
+  },
+};
+
+function createBaseAck(): Ack {
+  return { seq: 0 };
+}
+
+export const Ack = {
+  encode(message: Ack, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.seq !== 0) {
+      writer.uint32(8).uint32(message.seq);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Ack {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAck();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.seq = reader.uint32();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  encodeInto(message: Ack, target: Uint8Array, offset: number = 0): number {
+    // Writer.create() picks a BufferWriter on Node, whose ops only work on Buffers
+    const writer = Ack.encode(message, new Writer());
+    const end = offset + writer.len;
+    if (offset < 0 || end > target.length) {
+      throw new globalThis.RangeError(
+        'Encoding Ack needs ' + writer.len + ' bytes at offset ' + offset + ', but the target has ' + target.length
+      );
+    }
+    let op = (writer as any).head.next;
+    let pos = offset;
+    while (op) {
+      op.fn(op.val, target, pos);
+      pos += op.len;
+      op = op.next;
+    }
+    return end;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
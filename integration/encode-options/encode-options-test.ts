@@ -0,0 +1,28 @@
+import { Writer } from 'protobufjs';
+import { Area, Point } from './point';
+
+describe('encode-options', () => {
+  it('leaves out default values by default', () => {
+    expect(Point.encode({ lat: 0, lng: 2 }).finish()).toEqual(Writer.create().uint32(17).double(2).finish());
+  });
+
+  it('writes default values with skipDefaults=false', () => {
+    const bytes = Point.encode({ lat: 0, lng: 2 }, { skipDefaults: false }).finish();
+    expect(bytes).toEqual(Writer.create().uint32(9).double(0).uint32(17).double(2).finish());
+    expect(Point.decode(bytes)).toEqual({ lat: 0, lng: 2 });
+  });
+
+  it('passes skipDefaults=false to nested messages', () => {
+    const area: Area = { nw: { lat: 0, lng: 0 }, se: undefined };
+    expect(Area.encode(area).finish()).toEqual(Writer.create().uint32(10).fork().ldelim().finish());
+    expect(Area.encode(area, { skipDefaults: false }).finish()).toEqual(
+      Writer.create().uint32(10).fork().uint32(9).double(0).uint32(17).double(0).ldelim().finish()
+    );
+  });
+
+  it('still accepts a writer', () => {
+    const writer = Writer.create();
+    expect(Point.encode({ lat: 0, lng: 0 }, writer, { skipDefaults: false })).toBe(writer);
+    expect(writer.finish()).toEqual(Writer.create().uint32(9).double(0).uint32(17).double(0).finish());
+  });
+});
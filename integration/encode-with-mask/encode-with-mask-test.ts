@@ -0,0 +1,31 @@
+import { Area, Point } from './point';
+
+describe('encode-with-mask', () => {
+  const area: Area = { nw: { lat: 1, lng: 2 }, se: { lat: 3, lng: 4 } };
+
+  it('encodes only the named fields', () => {
+    const point = Point.decode(Point.encodeWithMask({ lat: 1, lng: 2 }, ['lng']).finish());
+    expect(point).toEqual({ lat: 0, lng: 2 });
+    expect(Point.encodeWithMask({ lat: 1, lng: 2 }, []).finish()).toHaveLength(0);
+  });
+
+  it('encodes sub-messages in full when they are named', () => {
+    const decoded = Area.decode(Area.encodeWithMask(area, ['nw']).finish());
+    expect(decoded).toEqual({ nw: { lat: 1, lng: 2 }, se: undefined });
+  });
+
+  it('encodes the named fields of sub-messages', () => {
+    const decoded = Area.decode(Area.encodeWithMask(area, ['nw.lat', 'se.lng']).finish());
+    expect(decoded).toEqual({ nw: { lat: 1, lng: 0 }, se: { lat: 0, lng: 4 } });
+  });
+
+  it('prefers the whole sub-message over its nested paths', () => {
+    const decoded = Area.decode(Area.encodeWithMask(area, ['nw.lat', 'nw']).finish());
+    expect(decoded).toEqual({ nw: { lat: 1, lng: 2 }, se: undefined });
+  });
+
+  it('ignores paths that only share a prefix with a field', () => {
+    const decoded = Area.decode(Area.encodeWithMask(area, ['nwx', 'se.lngx']).finish());
+    expect(decoded).toEqual({ nw: undefined, se: { lat: 0, lng: 0 } });
+  });
+});
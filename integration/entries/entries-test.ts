@@ -0,0 +1,38 @@
+import { addressFromEntries, Profile, profileEntries, profileFromEntries } from './entries';
+
+describe('entries', () => {
+  const profile: Profile = { name: 'a', age: 3, tags: ['x'], address: { city: 'b' } };
+
+  it('returns the properties as key/value pairs in declaration order', () => {
+    expect(profileEntries(profile)).toEqual([
+      ['name', 'a'],
+      ['age', 3],
+      ['tags', ['x']],
+      ['address', { city: 'b' }],
+    ]);
+  });
+
+  it('round-trips a message through its entries', () => {
+    expect(profileFromEntries(profileEntries(profile))).toEqual(profile);
+  });
+
+  it('keeps the default values of missing properties', () => {
+    expect(profileFromEntries([['age', 4]])).toEqual({ name: '', age: 4, tags: [], address: undefined });
+    expect(addressFromEntries([])).toEqual({ city: '' });
+  });
+
+  it('ignores unknown keys', () => {
+    const entries = [['city', 'c'], ['country', 'd']] as Array<[any, unknown]>;
+    expect(addressFromEntries(entries)).toEqual({ city: 'c' });
+  });
+
+  it('passes sub-messages through as they are', () => {
+    const address = { city: 'b' };
+    expect(profileFromEntries([['address', address]]).address).toBe(address);
+  });
+
+  it('keeps the literal types of the keys', () => {
+    const keys: Array<keyof Profile> = profileEntries(profile).map(([key]) => key);
+    expect(keys).toEqual(['name', 'age', 'tags', 'address']);
+  });
+});
@@ -0,0 +1,174 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'entries';
+
+export interface Profile {
+  name: string;
+  age: number;
+  tags: string[];
+  address: Address | undefined;
+}
+
+export function profileEntries(message: Profile): Array<{ [K in keyof Profile]: [K, Profile[K]] }[keyof Profile]> {
+  return [
+    ['name', message.name],
+    ['age', message.age],
+    ['tags', message.tags],
+    ['address', message.address],
+  ];
+}
+
+export function profileFromEntries(entries: Iterable<[keyof Profile, unknown]>): Profile {
+  const message = createBaseProfile();
+  for (const [key, value] of entries) {
+    switch (key) {
+      case 'name':
+        message.name = value as Profile['name'];
+        break;
+      case 'age':
+        message.age = value as Profile['age'];
+        break;
+      case 'tags':
+        message.tags = value as Profile['tags'];
+        break;
+      case 'address':
+        message.address = value as Profile['address'];
+        break;
+    }
+  }
+  return message;
+}
+
+export interface Address {
+  city: string;
+}
+
+export function addressEntries(message: Address): Array<{ [K in keyof Address]: [K, Address[K]] }[keyof Address]> {
+  return [['city', message.city]];
+}
+
+export function addressFromEntries(entries: Iterable<[keyof Address, unknown]>): Address {
+  const message = createBaseAddress();
+  for (const [key, value] of entries) {
+    switch (key) {
+      case 'city':
+        message.city = value as Address['city'];
+        break;
+    }
+  }
+  return message;
+}
+
+function createBaseProfile(): Profile {
+  return { name: '', age: 0, tags: [], address: undefined };
+}
+
+export const Profile = {
+  encode(message: Profile, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.age !== 0) {
+      writer.uint32(16).int32(message.age);
+    }
+    for (const v of message.tags) {
+      writer.uint32(26).string(v!);
+    }
+    if (message.address !== undefined) {
+      Address.encode(message.address, writer.uint32(34).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Profile {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseProfile();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        case 2:
+          message.age = reader.int32();
+          break;
+        case 3:
+          message.tags.push(reader.string());
+          break;
+        case 4:
+          message.address = Address.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Profile>, I>>(object: I): Profile {
+    const message = createBaseProfile();
+    message.name = object.name ?? '';
+    message.age = object.age ?? 0;
+    message.tags = object.tags?.map((e) => e) || [];
+    message.address =
+      object.address !== undefined && object.address !== null ? Address.fromPartial(object.address) : undefined;
+    return message;
+  },
+};
+
+function createBaseAddress(): Address {
+  return { city: '' };
+}
+
+export const Address = {
+  encode(message: Address, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.city !== '') {
+      writer.uint32(10).string(message.city);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Address {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAddress();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.city = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Address>, I>>(object: I): Address {
+    const message = createBaseAddress();
+    message.city = object.city ?? '';
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
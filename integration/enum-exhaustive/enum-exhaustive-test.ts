@@ -0,0 +1,50 @@
+import { Writer } from 'protobufjs/minimal';
+import {
+  assertUnreachableDividerData_DividerType,
+  DividerData,
+  DividerData_DividerType,
+  dividerData_DividerTypeFromJSON,
+  dividerData_DividerTypeToJSON,
+} from './enums-as-literals';
+
+function describeType(type: DividerData_DividerType): string {
+  switch (type) {
+    case DividerData_DividerType.DOUBLE:
+    case DividerData_DividerType.SINGLE:
+      return 'solid';
+    case DividerData_DividerType.DASHED:
+    case DividerData_DividerType.DOTTED:
+      return 'broken';
+    case DividerData_DividerType.UNRECOGNIZED:
+      return 'unknown';
+    default:
+      // Fails to compile if a case above is missing
+      return assertUnreachableDividerData_DividerType(type);
+  }
+}
+
+describe('enum-exhaustive', () => {
+  it('decodes values that are not in the enum as UNRECOGNIZED', () => {
+    const bytes = Writer.create().uint32(8).int32(42).finish();
+    const message = DividerData.decode(bytes);
+    expect(message.type).toEqual(DividerData_DividerType.UNRECOGNIZED);
+    expect(describeType(message.type)).toEqual('unknown');
+  });
+
+  it('decodes known values as is', () => {
+    const bytes = DividerData.encode({ type: DividerData_DividerType.DOTTED }).finish();
+    expect(DividerData.decode(bytes).type).toEqual(DividerData_DividerType.DOTTED);
+  });
+
+  it('reads and writes unknown JSON values as UNRECOGNIZED', () => {
+    expect(dividerData_DividerTypeFromJSON(42)).toEqual(DividerData_DividerType.UNRECOGNIZED);
+    expect(dividerData_DividerTypeFromJSON('WAVY')).toEqual(DividerData_DividerType.UNRECOGNIZED);
+    expect(dividerData_DividerTypeToJSON(42 as DividerData_DividerType)).toEqual('UNRECOGNIZED');
+  });
+
+  it('throws when the unreachable branch is reached anyway', () => {
+    expect(() => assertUnreachableDividerData_DividerType(42 as never)).toThrow(
+      'Unhandled value 42 of enum DividerData_DividerType'
+    );
+  });
+});
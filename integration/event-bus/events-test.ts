@@ -0,0 +1,62 @@
+import {
+  OrderPlaced,
+  publishOrderPlaced,
+  publishShipment_Item,
+  subscribeOrderPlaced,
+  subscribeShipment_Item,
+} from './events';
+
+/** An in-memory queue that delivers each published message to the current subscribers of its topic. */
+function memoryBus() {
+  const handlers = new Map<string, Set<(data: Uint8Array) => void>>();
+  const published: Array<{ topic: string; data: Uint8Array }> = [];
+  return {
+    published,
+    publish(topic: string, data: Uint8Array): void {
+      published.push({ topic, data });
+      handlers.get(topic)?.forEach((handler) => handler(data));
+    },
+    subscribe(topic: string, handler: (data: Uint8Array) => void): () => void {
+      const topicHandlers = handlers.get(topic) ?? new Set();
+      handlers.set(topic, topicHandlers.add(handler));
+      return () => topicHandlers.delete(handler);
+    },
+  };
+}
+
+describe('event-bus', () => {
+  const order = { orderId: 'o-1', quantity: 3 };
+
+  it('publishes the encoded message on the topic of its fully-qualified name', () => {
+    const bus = memoryBus();
+    publishOrderPlaced(bus, order);
+    expect(bus.published).toEqual([{ topic: 'events.OrderPlaced', data: OrderPlaced.encode(order).finish() }]);
+  });
+
+  it('decodes the messages of the topic for its subscribers', () => {
+    const bus = memoryBus();
+    const received: OrderPlaced[] = [];
+    subscribeOrderPlaced(bus, (message) => received.push(message));
+    publishOrderPlaced(bus, order);
+    publishShipment_Item(bus, { sku: 'sku-1' });
+    expect(received).toEqual([order]);
+  });
+
+  it('uses the proto names of nested messages as topics', () => {
+    const bus = memoryBus();
+    const received: string[] = [];
+    subscribeShipment_Item(bus, (item) => received.push(item.sku));
+    publishShipment_Item(bus, { sku: 'sku-1' });
+    expect(bus.published[0].topic).toEqual('events.Shipment.Item');
+    expect(received).toEqual(['sku-1']);
+  });
+
+  it('returns what the bus returns, i.e. a function that unsubscribes', () => {
+    const bus = memoryBus();
+    const received: OrderPlaced[] = [];
+    const unsubscribe = subscribeOrderPlaced(bus, (message) => received.push(message));
+    unsubscribe();
+    publishOrderPlaced(bus, order);
+    expect(received).toEqual([]);
+  });
+});
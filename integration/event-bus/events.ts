@@ -0,0 +1,157 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'events';
+
+export interface OrderPlaced {
+  orderId: string;
+  quantity: number;
+}
+
+export interface Shipment {
+  orderId: string;
+  items: Shipment_Item[];
+}
+
+export interface Shipment_Item {
+  sku: string;
+}
+
+function createBaseOrderPlaced(): OrderPlaced {
+  return { orderId: '', quantity: 0 };
+}
+
+export const OrderPlaced = {
+  encode(message: OrderPlaced, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.orderId !== '') {
+      writer.uint32(10).string(message.orderId);
+    }
+    if (message.quantity !== 0) {
+      writer.uint32(16).int32(message.quantity);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): OrderPlaced {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOrderPlaced();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.orderId = reader.string();
+          break;
+        case 2:
+          message.quantity = reader.int32();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export function publishOrderPlaced(bus: Bus, message: OrderPlaced): ReturnType<Bus['publish']> {
+  return bus.publish('events.OrderPlaced', OrderPlaced.encode(message).finish());
+}
+
+export function subscribeOrderPlaced(bus: Bus, handler: (message: OrderPlaced) => void): ReturnType<Bus['subscribe']> {
+  return bus.subscribe('events.OrderPlaced', (data) => handler(OrderPlaced.decode(data)));
+}
+
+function createBaseShipment(): Shipment {
+  return { orderId: '', items: [] };
+}
+
+export const Shipment = {
+  encode(message: Shipment, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.orderId !== '') {
+      writer.uint32(10).string(message.orderId);
+    }
+    for (const v of message.items) {
+      Shipment_Item.encode(v!, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Shipment {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseShipment();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.orderId = reader.string();
+          break;
+        case 2:
+          message.items.push(Shipment_Item.decode(reader, reader.uint32()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export function publishShipment(bus: Bus, message: Shipment): ReturnType<Bus['publish']> {
+  return bus.publish('events.Shipment', Shipment.encode(message).finish());
+}
+
+export function subscribeShipment(bus: Bus, handler: (message: Shipment) => void): ReturnType<Bus['subscribe']> {
+  return bus.subscribe('events.Shipment', (data) => handler(Shipment.decode(data)));
+}
+
+function createBaseShipment_Item(): Shipment_Item {
+  return { sku: '' };
+}
+
+export const Shipment_Item = {
+  encode(message: Shipment_Item, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.sku !== '') {
+      writer.uint32(10).string(message.sku);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Shipment_Item {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseShipment_Item();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.sku = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export function publishShipment_Item(bus: Bus, message: Shipment_Item): ReturnType<Bus['publish']> {
+  return bus.publish('events.Shipment.Item', Shipment_Item.encode(message).finish());
+}
+
+export function subscribeShipment_Item(
+  bus: Bus,
+  handler: (message: Shipment_Item) => void
+): ReturnType<Bus['subscribe']> {
+  return bus.subscribe('events.Shipment.Item', (data) => handler(Shipment_Item.decode(data)));
+}
+
+interface Bus {
+  /** Sends the bytes of a message to the subscribers of the topic. */
+  publish(topic: string, data: Uint8Array): void | Promise<void>;
+  /** Calls the handler with the bytes of each message on the topic, until the returned function is called. */
+  subscribe(topic: string, handler: (data: Uint8Array) => void): () => void;
+}
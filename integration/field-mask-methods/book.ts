@@ -0,0 +1,239 @@
+/* eslint-disable */
+export const protobufPackage = 'fieldmask';
+
+export interface Book {
+  title: string;
+  displayName: string;
+  tags: string[];
+  author: Author | undefined;
+  isbn: string | undefined;
+  pages: number | undefined;
+}
+
+export interface Author {
+  name: string;
+  age: number;
+}
+
+function createBaseBook(): Book {
+  return { title: '', displayName: '', tags: [], author: undefined, isbn: undefined, pages: undefined };
+}
+
+export const Book = {
+  fromJSON(object: any): Book {
+    return {
+      title: isSet(object.title) ? String(object.title) : '',
+      displayName: isSet(object.displayName) ? String(object.displayName) : '',
+      tags: Array.isArray(object?.tags) ? object.tags.map((e: any) => String(e)) : [],
+      author: isSet(object.author) ? Author.fromJSON(object.author) : undefined,
+      isbn: isSet(object.isbn) ? String(object.isbn) : undefined,
+      pages: isSet(object.pages) ? Number(object.pages) : undefined,
+    };
+  },
+
+  toJSON(message: Book): unknown {
+    const obj: any = {};
+    message.title !== undefined && (obj.title = message.title);
+    message.displayName !== undefined && (obj.displayName = message.displayName);
+    if (message.tags) {
+      obj.tags = message.tags.map((e) => e);
+    } else {
+      obj.tags = [];
+    }
+    message.author !== undefined && (obj.author = message.author ? Author.toJSON(message.author) : undefined);
+    message.isbn !== undefined && (obj.isbn = message.isbn);
+    message.pages !== undefined && (obj.pages = Math.round(message.pages));
+    return obj;
+  },
+
+  applyUpdate(existing: Book | undefined, update: Book | undefined, mask: string[]): Book {
+    const source = update ?? createBaseBook();
+    const message = { ...existing ?? createBaseBook() };
+    for (const path of mask) {
+      if (path === '*') {
+        return { ...source };
+      }
+      const dot = path.indexOf('.');
+      const head = dot === -1 ? path : path.slice(0, dot);
+      const rest = dot === -1 ? [] : [path.slice(dot + 1)];
+      switch (head) {
+        case 'title':
+          if (rest.length > 0) {
+            throw new Error(`Cannot apply field mask path ${path} to the non-message field title`);
+          }
+          message.title = source.title;
+          break;
+
+        case 'display_name':
+          if (rest.length > 0) {
+            throw new Error(`Cannot apply field mask path ${path} to the non-message field displayName`);
+          }
+          message.displayName = source.displayName;
+          break;
+
+        case 'tags':
+          if (rest.length > 0) {
+            throw new Error(`Cannot apply field mask path ${path} to the non-message field tags`);
+          }
+          message.tags = source.tags;
+          break;
+
+        case 'author':
+          message.author = rest.length > 0 ? Author.applyUpdate(message.author, source.author, rest) : source.author;
+          break;
+
+        case 'isbn':
+          if (rest.length > 0) {
+            throw new Error(`Cannot apply field mask path ${path} to the non-message field isbn`);
+          }
+          message.isbn = source.isbn;
+          if (source.isbn !== undefined) {
+            message.pages = undefined;
+          }
+          break;
+
+        case 'pages':
+          if (rest.length > 0) {
+            throw new Error(`Cannot apply field mask path ${path} to the non-message field pages`);
+          }
+          message.pages = source.pages;
+          if (source.pages !== undefined) {
+            message.isbn = undefined;
+          }
+          break;
+
+        default:
+          throw new Error(`Unknown field mask path ${path} for Book`);
+      }
+    }
+    return message;
+  },
+
+  assertMasked<P extends string>(
+    message: Book,
+    mask: readonly P[]
+  ): Pick<
+    Book,
+    string extends P
+      ? keyof Book
+      : P extends 'title' | `title.${string}`
+      ? 'title'
+      : P extends 'display_name' | `display_name.${string}`
+      ? 'displayName'
+      : P extends 'tags' | `tags.${string}`
+      ? 'tags'
+      : P extends 'author' | `author.${string}`
+      ? 'author'
+      : P extends 'isbn' | `isbn.${string}`
+      ? 'isbn'
+      : P extends 'pages' | `pages.${string}`
+      ? 'pages'
+      : never
+  > {
+    if (mask.includes('*' as P)) {
+      return message;
+    }
+    const paths = new Set(mask.map((path) => path.split('.')[0]));
+    if (!paths.has('title') && message.title !== '') {
+      throw new Error('Field title of Book is set, but not in the mask');
+    }
+    if (!paths.has('display_name') && message.displayName !== '') {
+      throw new Error('Field display_name of Book is set, but not in the mask');
+    }
+    if (!paths.has('tags') && (message.tags?.length ?? 0) > 0) {
+      throw new Error('Field tags of Book is set, but not in the mask');
+    }
+    if (!paths.has('author') && message.author !== undefined) {
+      throw new Error('Field author of Book is set, but not in the mask');
+    }
+    if (!paths.has('isbn') && message.isbn !== undefined) {
+      throw new Error('Field isbn of Book is set, but not in the mask');
+    }
+    if (!paths.has('pages') && message.pages !== undefined) {
+      throw new Error('Field pages of Book is set, but not in the mask');
+    }
+    return message;
+  },
+};
+
+function createBaseAuthor(): Author {
+  return { name: '', age: 0 };
+}
+
+export const Author = {
+  fromJSON(object: any): Author {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+      age: isSet(object.age) ? Number(object.age) : 0,
+    };
+  },
+
+  toJSON(message: Author): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.age !== undefined && (obj.age = Math.round(message.age));
+    return obj;
+  },
+
+  applyUpdate(existing: Author | undefined, update: Author | undefined, mask: string[]): Author {
+    const source = update ?? createBaseAuthor();
+    const message = { ...existing ?? createBaseAuthor() };
+    for (const path of mask) {
+      if (path === '*') {
+        return { ...source };
+      }
+      const dot = path.indexOf('.');
+      const head = dot === -1 ? path : path.slice(0, dot);
+      const rest = dot === -1 ? [] : [path.slice(dot + 1)];
+      switch (head) {
+        case 'name':
+          if (rest.length > 0) {
+            throw new Error(`Cannot apply field mask path ${path} to the non-message field name`);
+          }
+          message.name = source.name;
+          break;
+
+        case 'age':
+          if (rest.length > 0) {
+            throw new Error(`Cannot apply field mask path ${path} to the non-message field age`);
+          }
+          message.age = source.age;
+          break;
+
+        default:
+          throw new Error(`Unknown field mask path ${path} for Author`);
+      }
+    }
+    return message;
+  },
+
+  assertMasked<P extends string>(
+    message: Author,
+    mask: readonly P[]
+  ): Pick<
+    Author,
+    string extends P
+      ? keyof Author
+      : P extends 'name' | `name.${string}`
+      ? 'name'
+      : P extends 'age' | `age.${string}`
+      ? 'age'
+      : never
+  > {
+    if (mask.includes('*' as P)) {
+      return message;
+    }
+    const paths = new Set(mask.map((path) => path.split('.')[0]));
+    if (!paths.has('name') && message.name !== '') {
+      throw new Error('Field name of Author is set, but not in the mask');
+    }
+    if (!paths.has('age') && message.age !== 0) {
+      throw new Error('Field age of Author is set, but not in the mask');
+    }
+    return message;
+  },
+};
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,122 @@
+import { Author, Book } from './book';
+
+describe('field-mask-methods', () => {
+  const existing: Book = {
+    title: 'Old title',
+    displayName: 'Old',
+    tags: ['a', 'b'],
+    author: { name: 'Ann', age: 40 },
+    isbn: '978-0',
+    pages: undefined,
+  };
+
+  const update: Book = {
+    title: 'New title',
+    displayName: 'New',
+    tags: ['c'],
+    author: { name: 'Bob', age: 0 },
+    isbn: undefined,
+    pages: 320,
+  };
+
+  describe('applyUpdate', () => {
+    it('copies only the masked fields', () => {
+      expect(Book.applyUpdate(existing, update, ['display_name'])).toEqual({ ...existing, displayName: 'New' });
+      expect(Book.applyUpdate(existing, update, ['title', 'display_name'])).toEqual({
+        ...existing,
+        title: 'New title',
+        displayName: 'New',
+      });
+    });
+
+    it('does not modify its arguments', () => {
+      const before = JSON.parse(JSON.stringify(existing));
+      Book.applyUpdate(existing, update, ['title', 'author.name']);
+      expect(JSON.parse(JSON.stringify(existing))).toEqual(before);
+    });
+
+    it('replaces repeated and message fields wholesale', () => {
+      const message = Book.applyUpdate(existing, update, ['tags', 'author']);
+      expect(message.tags).toEqual(['c']);
+      expect(message.author).toEqual({ name: 'Bob', age: 0 });
+      expect(Book.applyUpdate(existing, { ...update, author: undefined }, ['author']).author).toBeUndefined();
+    });
+
+    it('updates a single field of a sub-message with a dotted path', () => {
+      expect(Book.applyUpdate(existing, update, ['author.name']).author).toEqual({ name: 'Bob', age: 40 });
+      expect(Book.applyUpdate({ ...existing, author: undefined }, update, ['author.name']).author).toEqual({
+        name: 'Bob',
+        age: 0,
+      });
+    });
+
+    it('sets or clears the masked member of a oneof', () => {
+      const message = Book.applyUpdate(existing, update, ['pages']);
+      expect(message.pages).toEqual(320);
+      expect(message.isbn).toBeUndefined();
+      expect(Book.applyUpdate(existing, update, ['isbn']).isbn).toBeUndefined();
+      expect(Book.applyUpdate(existing, { ...update, pages: undefined }, ['pages']).isbn).toEqual('978-0');
+    });
+
+    it('replaces the whole message with the * path', () => {
+      expect(Book.applyUpdate(existing, update, ['*'])).toEqual(update);
+    });
+
+    it('fills in missing messages with the defaults', () => {
+      expect(Book.applyUpdate(undefined, update, ['title'])).toEqual({ ...Book.fromJSON({}), title: 'New title' });
+      expect(Book.applyUpdate(existing, undefined, ['title']).title).toEqual('');
+    });
+
+    it('throws on unknown and invalid paths', () => {
+      expect(() => Book.applyUpdate(existing, update, ['displayName'])).toThrow(
+        'Unknown field mask path displayName for Book'
+      );
+      expect(() => Book.applyUpdate(existing, update, ['author.nickname'])).toThrow(
+        'Unknown field mask path nickname for Author'
+      );
+      expect(() => Book.applyUpdate(existing, update, ['title.length'])).toThrow(
+        'Cannot apply field mask path title.length to the non-message field title'
+      );
+    });
+  });
+
+  describe('assertMasked', () => {
+    it('accepts messages that only set masked fields', () => {
+      const book = Book.fromJSON({ displayName: 'Shown', author: { name: 'Ann' } });
+      expect(Book.assertMasked(book, ['display_name', 'author.name'])).toBe(book);
+      expect(Book.assertMasked(Book.fromJSON({}), [])).toEqual(Book.fromJSON({}));
+    });
+
+    it('throws on set fields that are not in the mask', () => {
+      expect(() => Book.assertMasked(existing, ['title', 'display_name', 'author', 'isbn'])).toThrow(
+        'Field tags of Book is set, but not in the mask'
+      );
+      expect(() => Book.assertMasked(update, ['title', 'display_name', 'tags', 'author'])).toThrow(
+        'Field pages of Book is set, but not in the mask'
+      );
+      expect(() => Author.assertMasked({ name: '', age: 1 }, ['name'])).toThrow(
+        'Field age of Author is set, but not in the mask'
+      );
+    });
+
+    it('allows everything with the * path', () => {
+      expect(Book.assertMasked(existing, ['*'])).toBe(existing);
+    });
+
+    it('narrows the result to the fields of a literal mask', () => {
+      const book = Book.fromJSON({ displayName: 'Shown' });
+      const masked = Book.assertMasked(book, ['display_name'] as const);
+      const displayName: string = masked.displayName;
+      // @ts-expect-error title isn't in the mask
+      masked.title;
+      expect(displayName).toEqual('Shown');
+    });
+
+    it('keeps the full message type for a wide string[] mask', () => {
+      const mask: string[] = ['title', 'display_name', 'tags', 'author', 'isbn'];
+      const masked: Book = Book.assertMasked(existing, mask);
+      expect(masked.title).toEqual('Old title');
+      expect(masked.author?.name).toEqual('Ann');
+    });
+  });
+});
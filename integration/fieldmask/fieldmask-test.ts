@@ -51,4 +51,15 @@ describe('fieldmask', () => {
       }
     `);
   });
+
+  it('encodes an empty mask as an empty string', () => {
+    expect(FieldMaskMessage.toJSON({ fieldMask: [] })).toEqual({ fieldMask: '' });
+    expect(FieldMaskMessage.fromJSON({ fieldMask: '' })).toEqual({ fieldMask: [] });
+  });
+
+  it('keeps the field names of paths as they are', () => {
+    const f = FieldMaskMessage.fromJSON({ fieldMask: 'display_name,author.first_name' });
+    expect(f).toEqual({ fieldMask: ['display_name', 'author.first_name'] });
+    expect(FieldMaskMessage.toJSON(f)).toEqual({ fieldMask: 'display_name,author.first_name' });
+  });
 });
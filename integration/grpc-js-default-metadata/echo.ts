@@ -0,0 +1,225 @@
+/* eslint-disable */
+import {
+  makeGenericClientConstructor,
+  ChannelCredentials,
+  ChannelOptions,
+  UntypedServiceImplementation,
+  handleUnaryCall,
+  Client,
+  ClientUnaryCall,
+  Metadata,
+  CallOptions,
+  ServiceError,
+  Interceptor,
+  InterceptingCall,
+} from '@grpc/grpc-js';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'metadata';
+
+export interface EchoRequest {
+  key: string;
+}
+
+export interface EchoResponse {
+  values: string[];
+}
+
+function createBaseEchoRequest(): EchoRequest {
+  return { key: '' };
+}
+
+export const EchoRequest = {
+  encode(message: EchoRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.key !== '') {
+      writer.uint32(10).string(message.key);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): EchoRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEchoRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.key = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EchoRequest {
+    return {
+      key: isSet(object.key) ? String(object.key) : '',
+    };
+  },
+
+  toJSON(message: EchoRequest): unknown {
+    const obj: any = {};
+    message.key !== undefined && (obj.key = message.key);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<EchoRequest>, I>>(object: I): EchoRequest {
+    const message = createBaseEchoRequest();
+    message.key = object.key ?? '';
+    return message;
+  },
+};
+
+function createBaseEchoResponse(): EchoResponse {
+  return { values: [] };
+}
+
+export const EchoResponse = {
+  encode(message: EchoResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.values) {
+      writer.uint32(10).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): EchoResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEchoResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.values.push(reader.string());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EchoResponse {
+    return {
+      values: Array.isArray(object?.values) ? object.values.map((e: any) => String(e)) : [],
+    };
+  },
+
+  toJSON(message: EchoResponse): unknown {
+    const obj: any = {};
+    if (message.values) {
+      obj.values = message.values.map((e) => e);
+    } else {
+      obj.values = [];
+    }
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<EchoResponse>, I>>(object: I): EchoResponse {
+    const message = createBaseEchoResponse();
+    message.values = object.values?.map((e) => e) || [];
+    return message;
+  },
+};
+
+export type EchoService = typeof EchoService;
+export const EchoService = {
+  say: {
+    path: '/metadata.Echo/Say',
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: EchoRequest) => Buffer.from(EchoRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => EchoRequest.decode(value),
+    responseSerialize: (value: EchoResponse) => Buffer.from(EchoResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => EchoResponse.decode(value),
+  },
+} as const;
+
+export interface EchoServer extends UntypedServiceImplementation {
+  say: handleUnaryCall<EchoRequest, EchoResponse>;
+}
+
+export interface EchoClient extends Client {
+  say(request: EchoRequest, callback: (error: ServiceError | null, response: EchoResponse) => void): ClientUnaryCall;
+  say(
+    request: EchoRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: EchoResponse) => void
+  ): ClientUnaryCall;
+  say(
+    request: EchoRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: EchoResponse) => void
+  ): ClientUnaryCall;
+}
+
+export const EchoClient = class extends makeGenericClientConstructor(EchoService, 'metadata.Echo') {
+  constructor(
+    address: string,
+    credentials: ChannelCredentials,
+    options?: Partial<ChannelOptions>,
+    defaultMetadata?: Metadata
+  ) {
+    super(
+      address,
+      credentials,
+      defaultMetadata === undefined
+        ? options
+        : {
+            ...options,
+            interceptors: [defaultMetadataInterceptor(defaultMetadata), ...(options?.interceptors ?? [])],
+          }
+    );
+  }
+} as unknown as {
+  new (
+    address: string,
+    credentials: ChannelCredentials,
+    options?: Partial<ChannelOptions>,
+    defaultMetadata?: Metadata
+  ): EchoClient;
+  service: typeof EchoService;
+};
+
+function defaultMetadataInterceptor(defaultMetadata: Metadata): Interceptor {
+  return (options, nextCall) =>
+    new InterceptingCall(nextCall(options), {
+      start(metadata, listener, next) {
+        // Per-call metadata wins over the defaults, and keys can have multiple values, which getMap() drops
+        const merged = metadata.clone();
+        Object.keys(defaultMetadata.getMap()).forEach((key) => {
+          if (merged.get(key).length === 0) {
+            defaultMetadata.get(key).forEach((value) => merged.add(key, value));
+          }
+        });
+        next(merged, listener);
+      },
+    });
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
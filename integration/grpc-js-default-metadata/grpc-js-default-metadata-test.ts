@@ -0,0 +1,94 @@
+/**
+ * @jest-environment node
+ */
+import { ChannelCredentials, InterceptingCall, Interceptor, Metadata, Server, ServerCredentials } from '@grpc/grpc-js';
+import { EchoClient, EchoServer, EchoService } from './echo';
+
+describe('grpc-js-default-metadata', () => {
+  let server: Server;
+  let address: string;
+
+  beforeAll(async () => {
+    server = new Server();
+    // Echoes the values of the requested metadata key
+    const impl: EchoServer = {
+      say(call, callback) {
+        callback(null, { values: call.metadata.get(call.request.key).map(String) });
+      },
+    };
+    server.addService(EchoService, impl);
+    const port = await new Promise<number>((resolve, reject) => {
+      server.bindAsync('localhost:0', ServerCredentials.createInsecure(), (err, port) => {
+        if (err) {
+          reject(err);
+        } else {
+          resolve(port);
+        }
+      });
+    });
+    server.start();
+    address = `localhost:${port}`;
+  });
+
+  afterAll(async () => {
+    await new Promise<void>((resolve) => server.tryShutdown(() => resolve()));
+  });
+
+  function say(client: EchoClient, key: string, metadata = new Metadata()): Promise<string[]> {
+    return new Promise((resolve, reject) => {
+      client.say({ key }, metadata, (error, response) => (error ? reject(error) : resolve(response.values)));
+    });
+  }
+
+  function defaults(): Metadata {
+    const metadata = new Metadata();
+    metadata.add('x-tag', 'a');
+    metadata.add('x-tag', 'b');
+    metadata.set('authorization', 'default');
+    return metadata;
+  }
+
+  it('sends the default metadata with each call', async () => {
+    const client = new EchoClient(address, ChannelCredentials.createInsecure(), {}, defaults());
+    expect(await say(client, 'authorization')).toEqual(['default']);
+    expect(await say(client, 'authorization')).toEqual(['default']);
+    client.close();
+  });
+
+  it('keeps every value of multi-valued default keys', async () => {
+    const client = new EchoClient(address, ChannelCredentials.createInsecure(), {}, defaults());
+    expect(await say(client, 'x-tag')).toEqual(['a', 'b']);
+    client.close();
+  });
+
+  it('lets per-call metadata win over the defaults', async () => {
+    const client = new EchoClient(address, ChannelCredentials.createInsecure(), {}, defaults());
+    const metadata = new Metadata();
+    metadata.set('authorization', 'per-call');
+    expect(await say(client, 'authorization', metadata)).toEqual(['per-call']);
+    expect(await say(client, 'x-tag', metadata)).toEqual(['a', 'b']);
+    client.close();
+  });
+
+  it('runs the caller interceptors with the merged metadata', async () => {
+    const seen: string[][] = [];
+    const interceptor: Interceptor = (options, nextCall) =>
+      new InterceptingCall(nextCall(options), {
+        start(metadata, listener, next) {
+          seen.push(metadata.get('x-tag').map(String));
+          next(metadata, listener);
+        },
+      });
+    const options = { interceptors: [interceptor] };
+    const client = new EchoClient(address, ChannelCredentials.createInsecure(), options, defaults());
+    expect(await say(client, 'x-tag')).toEqual(['a', 'b']);
+    expect(seen).toEqual([['a', 'b']]);
+    client.close();
+  });
+
+  it('works without default metadata', async () => {
+    const client = new EchoClient(address, ChannelCredentials.createInsecure());
+    expect(await say(client, 'authorization')).toEqual([]);
+    client.close();
+  });
+});
@@ -518,7 +518,11 @@ export const BytesValue = {
 
   fromJSON(object: any): BytesValue {
     return {
-      value: isSet(object.value) ? bytesFromBase64(object.value) : new Uint8Array(),
+      value: isSet(object.value)
+        ? object.value instanceof Uint8Array
+          ? object.value
+          : bytesFromBase64(object.value)
+        : new Uint8Array(),
     };
   },
 
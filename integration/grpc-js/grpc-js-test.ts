@@ -16,6 +16,16 @@ describe('grpc-js-test', () => {
     expect(TestService).not.toBeUndefined();
   });
 
+  it('types the server call context', () => {
+    // Handlers get grpc-js' own call types, so the peer, metadata and deadline are typed without casts
+    const seen: Array<[string, string[], Date | number]> = [];
+    const unary: TestServer['unary'] = (call, callback) => {
+      seen.push([call.getPeer(), call.metadata.get('authorization').map(String), call.getDeadline()]);
+      callback(null, call.request);
+    };
+    expect(unary).not.toBeUndefined();
+  });
+
   it('can create a server and a client', async () => {
     const server = new Server();
 
@@ -0,0 +1,57 @@
+import { grpc } from '@improbable-eng/grpc-web';
+import { DashStateClientImpl, DashUserSettingsState, GrpcWebImpl } from './example';
+
+describe('grpc-web-abort-signal', () => {
+  let onEnd: ((response: any) => void) | undefined;
+  let close: jest.Mock;
+
+  beforeEach(() => {
+    onEnd = undefined;
+    close = jest.fn();
+    jest.spyOn(grpc, 'unary').mockImplementation((_methodDesc, props) => {
+      onEnd = props.onEnd;
+      return { close } as any;
+    });
+  });
+
+  afterEach(() => {
+    jest.restoreAllMocks();
+  });
+
+  function newClient() {
+    return new DashStateClientImpl(new GrpcWebImpl('http://localhost', {}));
+  }
+
+  it('rejects with CANCELLED when aborted before the response arrives', async () => {
+    const controller = new AbortController();
+    const response = newClient().UserSettings({}, undefined, controller.signal);
+    controller.abort();
+    await expect(response).rejects.toMatchObject({ code: grpc.Code.Canceled });
+    expect(close).toHaveBeenCalled();
+  });
+
+  it('rejects with CANCELLED without calling when already aborted', async () => {
+    const controller = new AbortController();
+    controller.abort();
+    await expect(newClient().UserSettings({}, undefined, controller.signal)).rejects.toMatchObject({
+      code: grpc.Code.Canceled,
+    });
+    expect(grpc.unary).not.toHaveBeenCalled();
+  });
+
+  it('ignores an abort after the call resolved', async () => {
+    const controller = new AbortController();
+    const response = newClient().UserSettings({}, undefined, controller.signal);
+    const message = DashUserSettingsState.fromPartial({ email: 'a@b.com' });
+    onEnd!({ status: grpc.Code.OK, message });
+    controller.abort();
+    await expect(response).resolves.toEqual(message);
+    expect(close).not.toHaveBeenCalled();
+  });
+
+  it('still works without a signal', async () => {
+    const response = newClient().UserSettings({});
+    onEnd!({ status: grpc.Code.OK, message: DashUserSettingsState.fromPartial({}) });
+    await expect(response).resolves.toEqual(DashUserSettingsState.fromPartial({}));
+  });
+});
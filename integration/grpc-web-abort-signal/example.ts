@@ -0,0 +1,469 @@
+/* eslint-disable */
+import { grpc } from '@improbable-eng/grpc-web';
+import { BrowserHeaders } from 'browser-headers';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'rpx';
+
+export interface DashFlash {
+  msg: string;
+  type: DashFlash_Type;
+}
+
+export enum DashFlash_Type {
+  Undefined = 0,
+  Success = 1,
+  Warn = 2,
+  Error = 3,
+  UNRECOGNIZED = -1,
+}
+
+export function dashFlash_TypeFromJSON(object: any): DashFlash_Type {
+  switch (object) {
+    case 0:
+    case 'Undefined':
+      return DashFlash_Type.Undefined;
+    case 1:
+    case 'Success':
+      return DashFlash_Type.Success;
+    case 2:
+    case 'Warn':
+      return DashFlash_Type.Warn;
+    case 3:
+    case 'Error':
+      return DashFlash_Type.Error;
+    case -1:
+    case 'UNRECOGNIZED':
+    default:
+      return DashFlash_Type.UNRECOGNIZED;
+  }
+}
+
+export function dashFlash_TypeToJSON(object: DashFlash_Type): string {
+  switch (object) {
+    case DashFlash_Type.Undefined:
+      return 'Undefined';
+    case DashFlash_Type.Success:
+      return 'Success';
+    case DashFlash_Type.Warn:
+      return 'Warn';
+    case DashFlash_Type.Error:
+      return 'Error';
+    case DashFlash_Type.UNRECOGNIZED:
+    default:
+      return 'UNRECOGNIZED';
+  }
+}
+
+export interface DashUserSettingsState {
+  email: string;
+  urls: DashUserSettingsState_URLs | undefined;
+  flashes: DashFlash[];
+}
+
+export interface DashUserSettingsState_URLs {
+  connectGoogle: string;
+  connectGithub: string;
+}
+
+export interface Empty {}
+
+function createBaseDashFlash(): DashFlash {
+  return { msg: '', type: 0 };
+}
+
+export const DashFlash = {
+  encode(message: DashFlash, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.msg !== '') {
+      writer.uint32(10).string(message.msg);
+    }
+    if (message.type !== 0) {
+      writer.uint32(16).int32(message.type);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DashFlash {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDashFlash();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.msg = reader.string();
+          break;
+        case 2:
+          message.type = reader.int32() as any;
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DashFlash {
+    return {
+      msg: isSet(object.msg) ? String(object.msg) : '',
+      type: isSet(object.type) ? dashFlash_TypeFromJSON(object.type) : 0,
+    };
+  },
+
+  toJSON(message: DashFlash): unknown {
+    const obj: any = {};
+    message.msg !== undefined && (obj.msg = message.msg);
+    message.type !== undefined && (obj.type = dashFlash_TypeToJSON(message.type));
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<DashFlash>, I>>(object: I): DashFlash {
+    const message = createBaseDashFlash();
+    message.msg = object.msg ?? '';
+    message.type = object.type ?? 0;
+    return message;
+  },
+};
+
+function createBaseDashUserSettingsState(): DashUserSettingsState {
+  return { email: '', urls: undefined, flashes: [] };
+}
+
+export const DashUserSettingsState = {
+  encode(message: DashUserSettingsState, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.email !== '') {
+      writer.uint32(10).string(message.email);
+    }
+    if (message.urls !== undefined) {
+      DashUserSettingsState_URLs.encode(message.urls, writer.uint32(50).fork()).ldelim();
+    }
+    for (const v of message.flashes) {
+      DashFlash.encode(v!, writer.uint32(58).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DashUserSettingsState {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDashUserSettingsState();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.email = reader.string();
+          break;
+        case 6:
+          message.urls = DashUserSettingsState_URLs.decode(reader, reader.uint32());
+          break;
+        case 7:
+          message.flashes.push(DashFlash.decode(reader, reader.uint32()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DashUserSettingsState {
+    return {
+      email: isSet(object.email) ? String(object.email) : '',
+      urls: isSet(object.urls) ? DashUserSettingsState_URLs.fromJSON(object.urls) : undefined,
+      flashes: Array.isArray(object?.flashes) ? object.flashes.map((e: any) => DashFlash.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: DashUserSettingsState): unknown {
+    const obj: any = {};
+    message.email !== undefined && (obj.email = message.email);
+    message.urls !== undefined &&
+      (obj.urls = message.urls ? DashUserSettingsState_URLs.toJSON(message.urls) : undefined);
+    if (message.flashes) {
+      obj.flashes = message.flashes.map((e) => (e ? DashFlash.toJSON(e) : undefined));
+    } else {
+      obj.flashes = [];
+    }
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<DashUserSettingsState>, I>>(object: I): DashUserSettingsState {
+    const message = createBaseDashUserSettingsState();
+    message.email = object.email ?? '';
+    message.urls =
+      object.urls !== undefined && object.urls !== null
+        ? DashUserSettingsState_URLs.fromPartial(object.urls)
+        : undefined;
+    message.flashes = object.flashes?.map((e) => DashFlash.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseDashUserSettingsState_URLs(): DashUserSettingsState_URLs {
+  return { connectGoogle: '', connectGithub: '' };
+}
+
+export const DashUserSettingsState_URLs = {
+  encode(message: DashUserSettingsState_URLs, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.connectGoogle !== '') {
+      writer.uint32(10).string(message.connectGoogle);
+    }
+    if (message.connectGithub !== '') {
+      writer.uint32(18).string(message.connectGithub);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DashUserSettingsState_URLs {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDashUserSettingsState_URLs();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.connectGoogle = reader.string();
+          break;
+        case 2:
+          message.connectGithub = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DashUserSettingsState_URLs {
+    return {
+      connectGoogle: isSet(object.connectGoogle) ? String(object.connectGoogle) : '',
+      connectGithub: isSet(object.connectGithub) ? String(object.connectGithub) : '',
+    };
+  },
+
+  toJSON(message: DashUserSettingsState_URLs): unknown {
+    const obj: any = {};
+    message.connectGoogle !== undefined && (obj.connectGoogle = message.connectGoogle);
+    message.connectGithub !== undefined && (obj.connectGithub = message.connectGithub);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<DashUserSettingsState_URLs>, I>>(object: I): DashUserSettingsState_URLs {
+    const message = createBaseDashUserSettingsState_URLs();
+    message.connectGoogle = object.connectGoogle ?? '';
+    message.connectGithub = object.connectGithub ?? '';
+    return message;
+  },
+};
+
+function createBaseEmpty(): Empty {
+  return {};
+}
+
+export const Empty = {
+  encode(_: Empty, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Empty {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEmpty();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): Empty {
+    return {};
+  },
+
+  toJSON(_: Empty): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Empty>, I>>(_: I): Empty {
+    const message = createBaseEmpty();
+    return message;
+  },
+};
+
+/**
+ * This is the same example.proto used by the other grpc-web examples,
+ * but with the streaming method removed.
+ */
+export interface DashState {
+  UserSettings(
+    request: DeepPartial<Empty>,
+    metadata?: grpc.Metadata,
+    abortSignal?: AbortSignal
+  ): Promise<DashUserSettingsState>;
+}
+
+export class DashStateClientImpl implements DashState {
+  private readonly rpc: Rpc;
+
+  constructor(rpc: Rpc) {
+    this.rpc = rpc;
+    this.UserSettings = this.UserSettings.bind(this);
+  }
+
+  UserSettings(
+    request: DeepPartial<Empty>,
+    metadata?: grpc.Metadata,
+    abortSignal?: AbortSignal
+  ): Promise<DashUserSettingsState> {
+    return this.rpc.unary(DashStateUserSettingsDesc, Empty.fromPartial(request), metadata, abortSignal);
+  }
+}
+
+export const DashStateDesc = {
+  serviceName: 'rpx.DashState',
+};
+
+export const DashStateUserSettingsDesc: UnaryMethodDefinitionish = {
+  methodName: 'UserSettings',
+  service: DashStateDesc,
+  requestStream: false,
+  responseStream: false,
+  requestType: {
+    serializeBinary() {
+      return Empty.encode(this).finish();
+    },
+  } as any,
+  responseType: {
+    deserializeBinary(data: Uint8Array) {
+      return {
+        ...DashUserSettingsState.decode(data),
+        toObject() {
+          return this;
+        },
+      };
+    },
+  } as any,
+};
+
+interface UnaryMethodDefinitionishR extends grpc.UnaryMethodDefinition<any, any> {
+  requestStream: any;
+  responseStream: any;
+}
+
+type UnaryMethodDefinitionish = UnaryMethodDefinitionishR;
+
+interface MethodDefinitionishR extends grpc.MethodDefinition<any, any> {
+  requestStream: any;
+  responseStream: any;
+}
+
+type MethodDefinitionish = MethodDefinitionishR;
+
+interface Rpc {
+  unary<T extends UnaryMethodDefinitionish>(
+    methodDesc: T,
+    request: any,
+    metadata: grpc.Metadata | undefined,
+    abortSignal?: AbortSignal
+  ): Promise<any>;
+}
+
+export class GrpcWebImpl {
+  private host: string;
+  private options: {
+    transport?: grpc.TransportFactory;
+
+    debug?: boolean;
+    metadata?: grpc.Metadata;
+    upStreamRetryCodes?: number[];
+  };
+
+  constructor(
+    host: string,
+    options: {
+      transport?: grpc.TransportFactory;
+
+      debug?: boolean;
+      metadata?: grpc.Metadata;
+      upStreamRetryCodes?: number[];
+    }
+  ) {
+    this.host = host;
+    this.options = options;
+  }
+
+  unary<T extends UnaryMethodDefinitionish>(
+    methodDesc: T,
+    _request: any,
+    metadata: grpc.Metadata | undefined,
+    abortSignal?: AbortSignal
+  ): Promise<any> {
+    const request = { ..._request, ...methodDesc.requestType };
+    const maybeCombinedMetadata =
+      metadata && this.options.metadata
+        ? new BrowserHeaders({ ...this.options?.metadata.headersMap, ...metadata?.headersMap })
+        : metadata || this.options.metadata;
+    return new Promise((resolve, reject) => {
+      if (abortSignal?.aborted) {
+        const err = new Error('Request was aborted') as any;
+        err.code = grpc.Code.Canceled;
+        reject(err);
+        return;
+      }
+      const onAbort = () => {
+        client.close();
+        const err = new Error('Request was aborted') as any;
+        err.code = grpc.Code.Canceled;
+        reject(err);
+      };
+      const client = grpc.unary(methodDesc, {
+        request,
+        host: this.host,
+        metadata: maybeCombinedMetadata,
+        transport: this.options.transport,
+        debug: this.options.debug,
+        onEnd: function (response) {
+          abortSignal?.removeEventListener('abort', onAbort);
+          if (response.status === grpc.Code.OK) {
+            resolve(response.message);
+          } else {
+            const err = new Error(response.statusMessage) as any;
+            err.code = response.status;
+            err.metadata = response.trailers;
+            reject(err);
+          }
+        },
+      });
+      abortSignal?.addEventListener('abort', onAbort);
+    });
+  }
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
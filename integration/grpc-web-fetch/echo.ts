@@ -0,0 +1,334 @@
+/* eslint-disable */
+import { map } from 'rxjs/operators';
+import { from, Observable } from 'rxjs';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'echo';
+
+export interface EchoRequest {
+  text: string;
+  times: number;
+}
+
+export interface EchoResponse {
+  text: string;
+}
+
+function createBaseEchoRequest(): EchoRequest {
+  return { text: '', times: 0 };
+}
+
+export const EchoRequest = {
+  encode(message: EchoRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.text !== '') {
+      writer.uint32(10).string(message.text);
+    }
+    if (message.times !== 0) {
+      writer.uint32(16).int32(message.times);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): EchoRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEchoRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.text = reader.string();
+          break;
+        case 2:
+          message.times = reader.int32();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EchoRequest {
+    return {
+      text: isSet(object.text) ? String(object.text) : '',
+      times: isSet(object.times) ? Number(object.times) : 0,
+    };
+  },
+
+  toJSON(message: EchoRequest): unknown {
+    const obj: any = {};
+    message.text !== undefined && (obj.text = message.text);
+    message.times !== undefined && (obj.times = Math.round(message.times));
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<EchoRequest>, I>>(object: I): EchoRequest {
+    const message = createBaseEchoRequest();
+    message.text = object.text ?? '';
+    message.times = object.times ?? 0;
+    return message;
+  },
+};
+
+function createBaseEchoResponse(): EchoResponse {
+  return { text: '' };
+}
+
+export const EchoResponse = {
+  encode(message: EchoResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.text !== '') {
+      writer.uint32(10).string(message.text);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): EchoResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEchoResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.text = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EchoResponse {
+    return {
+      text: isSet(object.text) ? String(object.text) : '',
+    };
+  },
+
+  toJSON(message: EchoResponse): unknown {
+    const obj: any = {};
+    message.text !== undefined && (obj.text = message.text);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<EchoResponse>, I>>(object: I): EchoResponse {
+    const message = createBaseEchoResponse();
+    message.text = object.text ?? '';
+    return message;
+  },
+};
+
+export interface Echo {
+  Say(request: DeepPartial<EchoRequest>, metadata?: { [key: string]: string }): Promise<EchoResponse>;
+  Repeat(request: DeepPartial<EchoRequest>, metadata?: { [key: string]: string }): Observable<EchoResponse>;
+}
+
+export class EchoClientImpl implements Echo {
+  private readonly rpc: GrpcWebFetchRpc;
+
+  constructor(rpc: GrpcWebFetchRpc) {
+    this.rpc = rpc;
+    this.Say = this.Say.bind(this);
+    this.Repeat = this.Repeat.bind(this);
+  }
+
+  Say(request: DeepPartial<EchoRequest>, metadata?: { [key: string]: string }): Promise<EchoResponse> {
+    return this.rpc
+      .unary('echo.Echo', 'Say', EchoRequest.encode(EchoRequest.fromPartial(request)).finish(), metadata)
+      .then((data) => EchoResponse.decode(data));
+  }
+
+  Repeat(request: DeepPartial<EchoRequest>, metadata?: { [key: string]: string }): Observable<EchoResponse> {
+    return from(
+      this.rpc.serverStream(
+        'echo.Echo',
+        'Repeat',
+        EchoRequest.encode(EchoRequest.fromPartial(request)).finish(),
+        metadata
+      )
+    ).pipe(map((data) => EchoResponse.decode(data)));
+  }
+}
+
+interface GrpcWebFetchRpc {
+  unary(service: string, method: string, data: Uint8Array, metadata?: { [key: string]: string }): Promise<Uint8Array>;
+  serverStream(
+    service: string,
+    method: string,
+    data: Uint8Array,
+    metadata?: { [key: string]: string }
+  ): AsyncIterable<Uint8Array>;
+}
+
+type GrpcWebFetchOptions = {
+  metadata?: { [key: string]: string };
+  fetch?: (
+    input: string,
+    init: { method: string; headers: { [key: string]: string }; body: Uint8Array }
+  ) => Promise<any>;
+};
+
+export class GrpcWebFetchImpl implements GrpcWebFetchRpc {
+  private host: string;
+  private options: GrpcWebFetchOptions;
+
+  constructor(host: string, options: GrpcWebFetchOptions = {}) {
+    this.host = host;
+    this.options = options;
+  }
+
+  async unary(
+    service: string,
+    method: string,
+    data: Uint8Array,
+    metadata?: { [key: string]: string }
+  ): Promise<Uint8Array> {
+    const messages: Uint8Array[] = [];
+    for await (const message of this.serverStream(service, method, data, metadata)) {
+      messages.push(message);
+    }
+    if (messages.length !== 1) {
+      throw new GrpcWebError(`Expected a single response message but received ${messages.length}`, 13, {});
+    }
+    return messages[0];
+  }
+
+  async *serverStream(
+    service: string,
+    method: string,
+    data: Uint8Array,
+    metadata?: { [key: string]: string }
+  ): AsyncIterable<Uint8Array> {
+    // Every grpc-web message is framed with a flag byte and a big-endian uint32 length
+    const body = new Uint8Array(5 + data.length);
+    new DataView(body.buffer).setUint32(1, data.length);
+    body.set(data, 5);
+
+    const fetch = this.options.fetch ?? globalThis.fetch;
+    const response = await fetch(`${this.host}/${service}/${method}`, {
+      method: 'POST',
+      headers: {
+        'content-type': 'application/grpc-web+proto',
+        'x-grpc-web': '1',
+        ...this.options.metadata,
+        ...metadata,
+      },
+      body,
+    });
+
+    const headers: { [key: string]: string } = {};
+    response.headers.forEach((value: string, key: string) => (headers[key] = value));
+    if (!response.ok) {
+      throw new GrpcWebError(response.statusText, httpStatusToGrpcCode(response.status), headers);
+    }
+
+    // Trailers-only responses put the status in the headers
+    let trailers: { [key: string]: string } = headers;
+    const reader = response.body.getReader();
+    let buffer = new Uint8Array(0);
+    while (true) {
+      const { done, value } = await reader.read();
+      if (value) {
+        const next = new Uint8Array(buffer.length + value.length);
+        next.set(buffer);
+        next.set(value, buffer.length);
+        buffer = next;
+      }
+      while (buffer.length >= 5) {
+        const length = new DataView(buffer.buffer, buffer.byteOffset).getUint32(1);
+        if (buffer.length < 5 + length) {
+          break;
+        }
+        const flag = buffer[0];
+        const frame = buffer.slice(5, 5 + length);
+        buffer = buffer.slice(5 + length);
+        if (flag & 0x80) {
+          trailers = parseGrpcWebTrailers(frame);
+        } else {
+          yield frame;
+        }
+      }
+      if (done) {
+        break;
+      }
+    }
+
+    const status = Number(trailers['grpc-status'] ?? 0);
+    if (status !== 0) {
+      throw new GrpcWebError(decodeURIComponent(trailers['grpc-message'] ?? ''), status, trailers);
+    }
+  }
+}
+
+function parseGrpcWebTrailers(frame: Uint8Array): { [key: string]: string } {
+  const trailers: { [key: string]: string } = {};
+  for (const line of _m0.util.utf8.read(frame, 0, frame.length).split('\r\n')) {
+    const index = line.indexOf(':');
+    if (index > 0) {
+      trailers[line.slice(0, index).trim().toLowerCase()] = line.slice(index + 1).trim();
+    }
+  }
+  return trailers;
+}
+
+function httpStatusToGrpcCode(status: number): number {
+  switch (status) {
+    case 400:
+      return 13; // INTERNAL
+    case 401:
+      return 16; // UNAUTHENTICATED
+    case 403:
+      return 7; // PERMISSION_DENIED
+    case 404:
+      return 12; // UNIMPLEMENTED
+    case 429:
+    case 502:
+    case 503:
+    case 504:
+      return 14; // UNAVAILABLE
+    default:
+      return 2; // UNKNOWN
+  }
+}
+
+// This runs when the module loads, i.e. before the `globalThis` helper below has been initialized
+export class GrpcWebError extends Error {
+  constructor(message: string, public code: number, public metadata: { [key: string]: string }) {
+    super(message);
+  }
+}
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
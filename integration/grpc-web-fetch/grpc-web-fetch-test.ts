@@ -0,0 +1,112 @@
+import { firstValueFrom } from 'rxjs';
+import { toArray } from 'rxjs/operators';
+import { EchoClientImpl, EchoRequest, EchoResponse, GrpcWebError, GrpcWebFetchImpl } from './echo';
+
+/** Frames `data` like grpc-web does, i.e. with a flag byte and a big-endian uint32 length. */
+function frame(data: Uint8Array, flag = 0): Uint8Array {
+  const framed = new Uint8Array(5 + data.length);
+  new DataView(framed.buffer).setUint32(1, data.length);
+  framed[0] = flag;
+  framed.set(data, 5);
+  return framed;
+}
+
+function trailers(text: string): Uint8Array {
+  return frame(new Uint8Array(Buffer.from(text)), 0x80);
+}
+
+function concat(...chunks: Uint8Array[]): Uint8Array {
+  return new Uint8Array(chunks.reduce<number[]>((acc, chunk) => [...acc, ...chunk], []));
+}
+
+/** A `fetch` that records its calls and responds with the given status, headers and body chunks. */
+function stubFetch(chunks: Uint8Array[], headers: { [key: string]: string } = {}, status = 200) {
+  const calls: Array<{ input: string; init: any }> = [];
+  const fetch = async (input: string, init: any) => {
+    calls.push({ input, init });
+    let i = 0;
+    return {
+      ok: status >= 200 && status < 300,
+      status,
+      statusText: `HTTP ${status}`,
+      headers: new Map(Object.entries(headers)),
+      body: {
+        getReader: () => ({
+          read: async () => (i < chunks.length ? { done: false, value: chunks[i++] } : { done: true }),
+        }),
+      },
+    };
+  };
+  return { fetch, calls };
+}
+
+function response(text: string): Uint8Array {
+  return frame(EchoResponse.encode({ text }).finish());
+}
+
+describe('grpc-web-fetch', () => {
+  it('sends unary requests as a single frame and decodes the response', async () => {
+    const { fetch, calls } = stubFetch([response('hi'), trailers('grpc-status:0\r\ngrpc-message:')]);
+    const client = new EchoClientImpl(new GrpcWebFetchImpl('https://example.com', { fetch, metadata: { a: '1' } }));
+    expect(await client.Say({ text: 'hi' }, { b: '2' })).toEqual({ text: 'hi' });
+    expect(calls).toEqual([
+      {
+        input: 'https://example.com/echo.Echo/Say',
+        init: {
+          method: 'POST',
+          headers: { 'content-type': 'application/grpc-web+proto', 'x-grpc-web': '1', a: '1', b: '2' },
+          body: frame(EchoRequest.encode({ text: 'hi', times: 0 }).finish()),
+        },
+      },
+    ]);
+  });
+
+  it('yields each message of server-streaming responses', async () => {
+    const chunks = [concat(response('a'), response('b')), response('c'), trailers('grpc-status: 0')];
+    const { fetch, calls } = stubFetch(chunks);
+    const client = new EchoClientImpl(new GrpcWebFetchImpl('https://example.com', { fetch }));
+    const responses = await firstValueFrom(client.Repeat({ text: 'x', times: 3 }).pipe(toArray()));
+    expect(responses).toEqual([{ text: 'a' }, { text: 'b' }, { text: 'c' }]);
+    expect(calls[0].input).toEqual('https://example.com/echo.Echo/Repeat');
+  });
+
+  it('reassembles frames that are split across chunks', async () => {
+    const body = concat(response('first'), response(''), response('second'), trailers('grpc-status:0'));
+    const chunks = Array.from(body).map((byte) => new Uint8Array([byte]));
+    const { fetch } = stubFetch(chunks);
+    const client = new EchoClientImpl(new GrpcWebFetchImpl('https://example.com', { fetch }));
+    const responses = await firstValueFrom(client.Repeat({}).pipe(toArray()));
+    expect(responses).toEqual([{ text: 'first' }, { text: '' }, { text: 'second' }]);
+  });
+
+  it('throws the status of trailers-only responses', async () => {
+    const { fetch } = stubFetch([], { 'grpc-status': '5', 'grpc-message': 'no%20such%20thing' });
+    const client = new EchoClientImpl(new GrpcWebFetchImpl('https://example.com', { fetch }));
+    const error = await client.Say({}).catch((e) => e);
+    expect(error).toBeInstanceOf(GrpcWebError);
+    expect(error).toMatchObject({ message: 'no such thing', code: 5 });
+  });
+
+  it('throws the status of the trailers after the streamed messages', async () => {
+    const { fetch } = stubFetch([response('a'), trailers('Grpc-Status: 8\r\nGrpc-Message: too%20many')]);
+    const client = new EchoClientImpl(new GrpcWebFetchImpl('https://example.com', { fetch }));
+    const received: EchoResponse[] = [];
+    const error = await new Promise((resolve) => {
+      client.Repeat({}).subscribe({ next: (r) => received.push(r), error: resolve });
+    });
+    expect(received).toEqual([{ text: 'a' }]);
+    expect(error).toMatchObject({ message: 'too many', code: 8, metadata: { 'grpc-status': '8' } });
+  });
+
+  it('maps HTTP errors to grpc status codes', async () => {
+    const { fetch } = stubFetch([], {}, 503);
+    const client = new EchoClientImpl(new GrpcWebFetchImpl('https://example.com', { fetch }));
+    await expect(client.Say({})).rejects.toMatchObject({ message: 'HTTP 503', code: 14 });
+  });
+
+  it('throws on unary responses without exactly one message', async () => {
+    const { fetch } = stubFetch([response('a'), response('b'), trailers('grpc-status:0')]);
+    const client = new EchoClientImpl(new GrpcWebFetchImpl('https://example.com', { fetch }));
+    await expect(client.Say({})).rejects.toMatchObject({ code: 13 });
+  });
+});
@@ -0,0 +1,21 @@
+import { Amounts } from './int64-json';
+
+describe('int64-json', () => {
+  it('writes 64-bit values and wrappers as numbers in toJSON', () => {
+    const amounts = Amounts.fromPartial({ total: -3, count: Number.MAX_SAFE_INTEGER, limit: 2, quota: 0 });
+    const json = Amounts.toJSON(amounts) as any;
+    expect(json).toEqual({ total: -3, count: Number.MAX_SAFE_INTEGER, limit: 2, quota: 0 });
+    expect(Amounts.toJSON(Amounts.fromPartial({}))).toEqual({ total: 0, count: 0 });
+  });
+
+  it('reads both numbers and strings in fromJSON', () => {
+    const amounts = Amounts.fromJSON({ total: '-3', count: 4, limit: '2', quota: 1 });
+    expect(amounts).toEqual({ total: -3, count: 4, limit: 2, quota: 1 });
+    expect(Amounts.fromJSON(Amounts.toJSON(amounts))).toEqual(amounts);
+  });
+
+  it('round-trips the wrappers through the wire format', () => {
+    const amounts = Amounts.fromPartial({ total: -3, limit: 0 });
+    expect(Amounts.decode(Amounts.encode(amounts).finish())).toEqual(amounts);
+  });
+});
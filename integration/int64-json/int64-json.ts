@@ -0,0 +1,135 @@
+/* eslint-disable */
+import * as Long from 'long';
+import * as _m0 from 'protobufjs/minimal';
+import { Int64Value, UInt64Value } from './google/protobuf/wrappers';
+
+export const protobufPackage = 'int64json';
+
+export interface Amounts {
+  total: number;
+  count: number;
+  limit: number | undefined;
+  quota: number | undefined;
+}
+
+function createBaseAmounts(): Amounts {
+  return { total: 0, count: 0, limit: undefined, quota: undefined };
+}
+
+export const Amounts = {
+  encode(message: Amounts, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.total !== 0) {
+      writer.uint32(8).int64(message.total);
+    }
+    if (message.count !== 0) {
+      writer.uint32(16).uint64(message.count);
+    }
+    if (message.limit !== undefined) {
+      Int64Value.encode({ value: message.limit! }, writer.uint32(26).fork()).ldelim();
+    }
+    if (message.quota !== undefined) {
+      UInt64Value.encode({ value: message.quota! }, writer.uint32(34).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Amounts {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAmounts();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.total = longToNumber(reader.int64() as Long);
+          break;
+        case 2:
+          message.count = longToNumber(reader.uint64() as Long);
+          break;
+        case 3:
+          message.limit = Int64Value.decode(reader, reader.uint32()).value;
+          break;
+        case 4:
+          message.quota = UInt64Value.decode(reader, reader.uint32()).value;
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Amounts {
+    return {
+      total: isSet(object.total) ? Number(object.total) : 0,
+      count: isSet(object.count) ? Number(object.count) : 0,
+      limit: isSet(object.limit) ? Number(object.limit) : undefined,
+      quota: isSet(object.quota) ? Number(object.quota) : undefined,
+    };
+  },
+
+  toJSON(message: Amounts): unknown {
+    const obj: any = {};
+    message.total !== undefined && (obj.total = Math.round(message.total));
+    message.count !== undefined && (obj.count = Math.round(message.count));
+    message.limit !== undefined && (obj.limit = message.limit);
+    message.quota !== undefined && (obj.quota = message.quota);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Amounts>, I>>(object: I): Amounts {
+    const message = createBaseAmounts();
+    message.total = object.total ?? 0;
+    message.count = object.count ?? 0;
+    message.limit = object.limit ?? undefined;
+    message.quota = object.quota ?? undefined;
+    return message;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function longToNumber(long: Long): number {
+  if (long.gt(Number.MAX_SAFE_INTEGER)) {
+    throw new globalThis.Error('Value is larger than Number.MAX_SAFE_INTEGER');
+  }
+  return long.toNumber();
+}
+
+// If you get a compile-error about 'Constructor<Long> and ... have no overlap',
+// add '--ts_proto_opt=esModuleInterop=true' as a flag when calling 'protoc'.
+if (_m0.util.Long !== Long) {
+  _m0.util.Long = Long as any;
+  _m0.configure();
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,40 @@
+import * as Long from 'long';
+import * as Module from './long-conversions';
+import { Counter, counterLongAsString, counterLongFromString } from './long-conversions';
+
+describe('long-conversions', () => {
+  const counter: Counter = {
+    id: Long.fromString('-9007199254740993'),
+    samples: [Long.fromString('18446744073709551615', true), Long.UZERO],
+    owner: { account: Long.fromNumber(42) },
+    totals: { a: Long.fromNumber(1), b: Long.fromString('9223372036854775807') },
+    name: 'clicks',
+  };
+
+  it('converts the 64-bit fields to strings', () => {
+    expect(counterLongAsString(counter)).toEqual({
+      id: '-9007199254740993',
+      samples: ['18446744073709551615', '0'],
+      owner: { account: '42' },
+      totals: { a: '1', b: '9223372036854775807' },
+      name: 'clicks',
+    });
+  });
+
+  it('round-trips without losing precision or signedness', () => {
+    const back = counterLongFromString(counterLongAsString(counter));
+    expect(back).toEqual(counter);
+    expect(back.samples[0].unsigned).toBe(true);
+    expect(back.id.unsigned).toBe(false);
+  });
+
+  it('passes unset sub-messages through', () => {
+    const strings = counterLongAsString({ ...counter, owner: undefined });
+    expect(strings.owner).toBeUndefined();
+    expect(counterLongFromString(strings).owner).toBeUndefined();
+  });
+
+  it('skips messages without 64-bit fields', () => {
+    expect(Object.keys(Module)).not.toContain('labelLongAsString');
+  });
+});
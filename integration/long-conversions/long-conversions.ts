@@ -0,0 +1,78 @@
+/* eslint-disable */
+import * as Long from 'long';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'longconv';
+
+export interface Counter {
+  id: Long;
+  samples: Long[];
+  owner: Owner | undefined;
+  totals: { [key: string]: Long };
+  name: string;
+}
+
+export type CounterLongAsString = Omit<Counter, 'id' | 'samples' | 'owner' | 'totals'> & {
+  id: string;
+  samples: string[];
+  owner: OwnerLongAsString | undefined;
+  totals: { [key: string]: string };
+};
+
+export function counterLongAsString(message: Counter): CounterLongAsString {
+  return {
+    ...message,
+    id: message.id.toString(),
+    samples: message.samples.map((e) => e.toString()),
+    owner: message.owner !== undefined ? ownerLongAsString(message.owner) : undefined,
+    totals: Object.entries(message.totals).reduce<{ [key: string]: string }>((acc, [key, value]) => {
+      acc[key] = value.toString();
+      return acc;
+    }, {}),
+  };
+}
+
+export function counterLongFromString(message: CounterLongAsString): Counter {
+  return {
+    ...message,
+    id: Long.fromString(message.id, false),
+    samples: message.samples.map((e) => Long.fromString(e, true)),
+    owner: message.owner !== undefined ? ownerLongFromString(message.owner) : undefined,
+    totals: Object.entries(message.totals).reduce<{ [key: string]: Long }>((acc, [key, value]) => {
+      acc[key] = Long.fromString(value, false);
+      return acc;
+    }, {}),
+  };
+}
+
+export interface Counter_TotalsEntry {
+  key: string;
+  value: Long;
+}
+
+export interface Owner {
+  account: Long;
+}
+
+export type OwnerLongAsString = Omit<Owner, 'account'> & {
+  account: string;
+};
+
+export function ownerLongAsString(message: Owner): OwnerLongAsString {
+  return { ...message, account: message.account.toString() };
+}
+
+export function ownerLongFromString(message: OwnerLongAsString): Owner {
+  return { ...message, account: Long.fromString(message.account, false) };
+}
+
+export interface Label {
+  text: string;
+}
+
+// If you get a compile-error about 'Constructor<Long> and ... have no overlap',
+// add '--ts_proto_opt=esModuleInterop=true' as a flag when calling 'protoc'.
+if (_m0.util.Long !== Long) {
+  _m0.util.Long = Long as any;
+  _m0.configure();
+}
@@ -0,0 +1,177 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'lowlevel';
+
+export interface Log {
+  source: string;
+  codes: number[];
+  last: Entry | undefined;
+  counts: { [key: string]: number };
+}
+
+export interface Log_CountsEntry {
+  key: string;
+  value: number;
+}
+
+export interface Entry {
+  text: string;
+}
+
+function createBaseLog(): Log {
+  return { source: '', codes: [], last: undefined, counts: {} };
+}
+
+export const Log = {
+  encode(message: Log, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.source !== '') {
+      writer.uint32(10).string(message.source);
+    }
+    writer.uint32(18).fork();
+    for (const v of message.codes) {
+      writer.int32(v);
+    }
+    writer.ldelim();
+    if (message.last !== undefined) {
+      Entry.encode(message.last, writer.uint32(26).fork()).ldelim();
+    }
+    Object.entries(message.counts).forEach(([key, value]) => {
+      Log_CountsEntry.encode({ key: key as any, value }, writer.uint32(34).fork()).ldelim();
+    });
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Log {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLog();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.source = reader.string();
+          break;
+        case 2:
+          if ((tag & 7) === 2) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.codes.push(reader.int32());
+            }
+          } else {
+            message.codes.push(reader.int32());
+          }
+          break;
+        case 3:
+          message.last = Entry.decode(reader, reader.uint32());
+          break;
+        case 4:
+          const entry4 = Log_CountsEntry.decode(reader, reader.uint32());
+          if (entry4.value !== undefined) {
+            message.counts[entry4.key] = entry4.value;
+          }
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  writeFieldSource(writer: _m0.Writer, value: string): _m0.Writer {
+    return writer.uint32(10).string(value);
+  },
+
+  writeFieldCodes(writer: _m0.Writer, value: number): _m0.Writer {
+    return writer.uint32(16).int32(value);
+  },
+
+  writeFieldLast(writer: _m0.Writer, value: Entry): _m0.Writer {
+    return Entry.encode(value, writer.uint32(26).fork()).ldelim();
+  },
+
+  writeFieldCounts(writer: _m0.Writer, key: string, value: number): _m0.Writer {
+    return Log_CountsEntry.encode({ key: key as any, value }, writer.uint32(34).fork()).ldelim();
+  },
+};
+
+function createBaseLog_CountsEntry(): Log_CountsEntry {
+  return { key: '', value: 0 };
+}
+
+export const Log_CountsEntry = {
+  encode(message: Log_CountsEntry, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.key !== '') {
+      writer.uint32(10).string(message.key);
+    }
+    if (message.value !== 0) {
+      writer.uint32(16).int32(message.value);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Log_CountsEntry {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLog_CountsEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.key = reader.string();
+          break;
+        case 2:
+          message.value = reader.int32();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  writeFieldKey(writer: _m0.Writer, value: string): _m0.Writer {
+    return writer.uint32(10).string(value);
+  },
+
+  writeFieldValue(writer: _m0.Writer, value: number): _m0.Writer {
+    return writer.uint32(16).int32(value);
+  },
+};
+
+function createBaseEntry(): Entry {
+  return { text: '' };
+}
+
+export const Entry = {
+  encode(message: Entry, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.text !== '') {
+      writer.uint32(10).string(message.text);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Entry {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.text = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  writeFieldText(writer: _m0.Writer, value: string): _m0.Writer {
+    return writer.uint32(10).string(value);
+  },
+};
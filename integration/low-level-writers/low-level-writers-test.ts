@@ -0,0 +1,33 @@
+import { Writer } from 'protobufjs/minimal';
+import { Log } from './log';
+
+describe('low-level-writers', () => {
+  it('writes fields one value at a time', () => {
+    const writer = Writer.create();
+    Log.writeFieldSource(writer, 'api');
+    Log.writeFieldCodes(writer, 1);
+    Log.writeFieldCodes(writer, 2);
+    Log.writeFieldLast(writer, { text: 'done' });
+    Log.writeFieldCounts(writer, 'ok', 3);
+    expect(Log.decode(writer.finish())).toEqual({
+      source: 'api',
+      codes: [1, 2],
+      last: { text: 'done' },
+      counts: { ok: 3 },
+    });
+  });
+
+  it('appends fields to an already-encoded message', () => {
+    const encoded = Log.encode({ source: 'api', codes: [1], last: undefined, counts: { ok: 1 } }).finish();
+    const writer = Writer.create();
+    Log.writeFieldCodes(writer, 2);
+    Log.writeFieldCounts(writer, 'error', 1);
+    const appended = new Uint8Array([...encoded, ...writer.finish()]);
+    expect(Log.decode(appended)).toEqual({
+      source: 'api',
+      codes: [1, 2],
+      last: undefined,
+      counts: { ok: 1, error: 1 },
+    });
+  });
+});
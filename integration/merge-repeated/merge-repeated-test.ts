@@ -0,0 +1,54 @@
+import { accumulatePage, Page } from './merge-repeated';
+
+async function collect<T>(source: AsyncIterable<T>): Promise<T[]> {
+  const result: T[] = [];
+  for await (const value of source) {
+    result.push(value);
+  }
+  return result;
+}
+
+async function* pages(...chunks: Page[]): AsyncIterable<Page> {
+  yield* chunks;
+}
+
+describe('merge-repeated', () => {
+  const target = Page.fromPartial({ title: 'a', lines: ['1'], summary: { tags: ['x'] } });
+
+  it('replaces repeated fields by default', () => {
+    const merged = Page.merge(target, { lines: ['2'], summary: { tags: ['y'] } });
+    expect(merged).toEqual({ title: 'a', lines: ['2'], summary: { tags: ['y'] } });
+  });
+
+  it("replaces repeated fields with repeated: 'replace'", () => {
+    const merged = Page.merge(target, { lines: ['2'] }, { repeated: 'replace' });
+    expect(merged.lines).toEqual(['2']);
+  });
+
+  it("appends repeated fields, also of sub-messages, with repeated: 'append'", () => {
+    const merged = Page.merge(target, { lines: ['2', '3'], summary: { tags: ['y'] } }, { repeated: 'append' });
+    expect(merged).toEqual({ title: 'a', lines: ['1', '2', '3'], summary: { tags: ['x', 'y'] } });
+    expect(target.lines).toEqual(['1']);
+  });
+
+  it('keeps the target values of empty repeated fields in either mode', () => {
+    expect(Page.merge(target, { title: 'b' }).lines).toEqual(['1']);
+    expect(Page.merge(target, { title: 'b' }, { repeated: 'append' }).lines).toEqual(['1']);
+  });
+
+  it('appends the repeated fields of stream chunks by default', async () => {
+    const states = await collect(
+      accumulatePage(pages(Page.fromPartial({ title: 'a', lines: ['1'] }), Page.fromPartial({ lines: ['2'] })))
+    );
+    expect(states.map((state) => state.lines)).toEqual([['1'], ['1', '2']]);
+  });
+
+  it('can replace the repeated fields of stream chunks', async () => {
+    const states = await collect(
+      accumulatePage(pages(Page.fromPartial({ lines: ['1'] }), Page.fromPartial({ lines: ['2'] })), {
+        repeated: 'replace',
+      })
+    );
+    expect(states.map((state) => state.lines)).toEqual([['1'], ['2']]);
+  });
+});
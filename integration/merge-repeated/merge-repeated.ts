@@ -0,0 +1,203 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'merge';
+
+export interface WatchRequest {}
+
+export interface Page {
+  title: string;
+  lines: string[];
+  summary: Summary | undefined;
+}
+
+export interface Summary {
+  tags: string[];
+}
+
+function createBaseWatchRequest(): WatchRequest {
+  return {};
+}
+
+export const WatchRequest = {
+  encode(_: WatchRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WatchRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWatchRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<WatchRequest>, I>>(_: I): WatchRequest {
+    const message = createBaseWatchRequest();
+    return message;
+  },
+
+  merge<I extends Exact<DeepPartial<WatchRequest>, I>>(
+    target: WatchRequest,
+    _: I,
+    _mergeOptions?: MergeOptions
+  ): WatchRequest {
+    const message = { ...target };
+    return message;
+  },
+};
+
+function createBasePage(): Page {
+  return { title: '', lines: [], summary: undefined };
+}
+
+export const Page = {
+  encode(message: Page, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.title !== '') {
+      writer.uint32(10).string(message.title);
+    }
+    for (const v of message.lines) {
+      writer.uint32(18).string(v!);
+    }
+    if (message.summary !== undefined) {
+      Summary.encode(message.summary, writer.uint32(26).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Page {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.title = reader.string();
+          break;
+        case 2:
+          message.lines.push(reader.string());
+          break;
+        case 3:
+          message.summary = Summary.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Page>, I>>(object: I): Page {
+    const message = createBasePage();
+    message.title = object.title ?? '';
+    message.lines = object.lines?.map((e) => e) || [];
+    message.summary =
+      object.summary !== undefined && object.summary !== null ? Summary.fromPartial(object.summary) : undefined;
+    return message;
+  },
+
+  merge<I extends Exact<DeepPartial<Page>, I>>(target: Page, partial: I, mergeOptions?: MergeOptions): Page {
+    const source = Page.fromPartial(partial);
+    const message = { ...target };
+    if (source.title !== '') {
+      message.title = source.title;
+    }
+    if (source.lines.length !== 0) {
+      message.lines = mergeOptions?.repeated === 'append' ? [...target.lines, ...source.lines] : [...source.lines];
+    }
+    if (source.summary !== undefined) {
+      message.summary =
+        message.summary !== undefined ? Summary.merge(message.summary, source.summary, mergeOptions) : source.summary;
+    }
+    return message;
+  },
+};
+
+function createBaseSummary(): Summary {
+  return { tags: [] };
+}
+
+export const Summary = {
+  encode(message: Summary, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.tags) {
+      writer.uint32(10).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Summary {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSummary();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.tags.push(reader.string());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Summary>, I>>(object: I): Summary {
+    const message = createBaseSummary();
+    message.tags = object.tags?.map((e) => e) || [];
+    return message;
+  },
+
+  merge<I extends Exact<DeepPartial<Summary>, I>>(target: Summary, partial: I, mergeOptions?: MergeOptions): Summary {
+    const source = Summary.fromPartial(partial);
+    const message = { ...target };
+    if (source.tags.length !== 0) {
+      message.tags = mergeOptions?.repeated === 'append' ? [...target.tags, ...source.tags] : [...source.tags];
+    }
+    return message;
+  },
+};
+
+/** Merges each message of a Page stream into the previous ones, yielding the accumulated state. */
+export async function* accumulatePage(
+  stream: AsyncIterable<Page>,
+  mergeOptions: MergeOptions = { repeated: 'append' }
+): AsyncIterable<Page> {
+  let state: Page | undefined;
+  for await (const chunk of stream) {
+    state = state === undefined ? chunk : Page.merge(state, chunk, mergeOptions);
+    yield state;
+  }
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+export interface MergeOptions {
+  /** Whether repeated fields of the source replace those of the target, which is the default, or are appended. */
+  repeated?: 'append' | 'replace';
+}
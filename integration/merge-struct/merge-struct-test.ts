@@ -0,0 +1,21 @@
+import { StructMessage } from './merge-struct';
+
+describe('merge-struct', () => {
+  const target: StructMessage = { value: { a: { b: 1, c: { d: 2 } }, e: 'x', list: [1, 2] } };
+
+  it('merges overlapping Struct keys recursively', () => {
+    const merged = StructMessage.merge(target, { value: { a: { c: { f: 3 } }, g: true } });
+    expect(merged.value).toEqual({ a: { b: 1, c: { d: 2, f: 3 } }, e: 'x', list: [1, 2], g: true });
+    expect(target.value).toEqual({ a: { b: 1, c: { d: 2 } }, e: 'x', list: [1, 2] });
+  });
+
+  it('replaces arrays, nulls and values of different types', () => {
+    const merged = StructMessage.merge(target, { value: { a: 'y', e: null, list: [3] } });
+    expect(merged.value).toEqual({ a: 'y', e: null, list: [3] });
+  });
+
+  it('keeps the target Struct when the source has none', () => {
+    expect(StructMessage.merge(target, {}).value).toEqual(target.value);
+    expect(StructMessage.merge({ value: undefined }, target).value).toEqual(target.value);
+  });
+});
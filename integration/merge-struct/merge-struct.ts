@@ -0,0 +1,106 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+import { Struct } from './google/protobuf/struct';
+
+export const protobufPackage = '';
+
+export interface StructMessage {
+  value: { [key: string]: any } | undefined;
+}
+
+function createBaseStructMessage(): StructMessage {
+  return { value: undefined };
+}
+
+export const StructMessage = {
+  encode(message: StructMessage, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.value !== undefined) {
+      Struct.encode(Struct.wrap(message.value), writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): StructMessage {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseStructMessage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.value = Struct.unwrap(Struct.decode(reader, reader.uint32()));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): StructMessage {
+    return {
+      value: isObject(object.value) ? object.value : undefined,
+    };
+  },
+
+  toJSON(message: StructMessage): unknown {
+    const obj: any = {};
+    message.value !== undefined && (obj.value = message.value);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<StructMessage>, I>>(object: I): StructMessage {
+    const message = createBaseStructMessage();
+    message.value = object.value ?? undefined;
+    return message;
+  },
+
+  merge<I extends Exact<DeepPartial<StructMessage>, I>>(
+    target: StructMessage,
+    partial: I,
+    mergeOptions?: MergeOptions
+  ): StructMessage {
+    const source = StructMessage.fromPartial(partial);
+    const message = { ...target };
+    if (source.value !== undefined) {
+      message.value = message.value !== undefined ? mergeStruct(message.value, source.value) : source.value;
+    }
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isObject(value: any): boolean {
+  return typeof value === 'object' && value !== null;
+}
+
+export interface MergeOptions {
+  /** Whether repeated fields of the source replace those of the target, which is the default, or are appended. */
+  repeated?: 'append' | 'replace';
+}
+
+function mergeStruct(target: { [key: string]: any }, source: { [key: string]: any }): { [key: string]: any } {
+  const isJsonObject = (value: any) => typeof value === 'object' && value !== null && !Array.isArray(value);
+  const result = Object.assign({}, target);
+  for (const [key, value] of Object.entries(source)) {
+    result[key] = isJsonObject(result[key]) && isJsonObject(value) ? mergeStruct(result[key], value) : value;
+  }
+  return result;
+}
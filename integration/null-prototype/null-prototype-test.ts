@@ -0,0 +1,32 @@
+import { DividerData, DividerData_DividerType } from './null-prototype';
+
+describe('null-prototype', () => {
+  it('creates messages and maps without a prototype', () => {
+    const data = DividerData.fromJSON({ typeMap: { a: 'SINGLE' } });
+    expect(Object.getPrototypeOf(data)).toBeNull();
+    expect(Object.getPrototypeOf(data.typeMap)).toBeNull();
+    expect(Object.getPrototypeOf(DividerData.decode(DividerData.encode(data).finish()))).toBeNull();
+    expect(Object.getPrototypeOf(DividerData.fromPartial({}))).toBeNull();
+  });
+
+  it('keeps a __proto__ key of a map from JSON as an entry', () => {
+    const data = DividerData.fromJSON(JSON.parse('{ "typeMap": { "__proto__": "SINGLE", "a": "DASHED" } }'));
+    expect(Object.keys(data.typeMap)).toEqual(['__proto__', 'a']);
+    expect(data.typeMap['__proto__']).toEqual(DividerData_DividerType.SINGLE);
+    expect(Object.getPrototypeOf(data.typeMap)).toBeNull();
+  });
+
+  it('keeps a __proto__ key of a decoded map as an entry', () => {
+    const data = DividerData.fromJSON(JSON.parse('{ "typeMap": { "__proto__": "DOTTED" } }'));
+    const decoded = DividerData.decode(DividerData.encode(data).finish());
+    expect(Object.keys(decoded.typeMap)).toEqual(['__proto__']);
+    expect(decoded.typeMap['__proto__']).toEqual(DividerData_DividerType.DOTTED);
+  });
+
+  it('ignores a top-level __proto__ key', () => {
+    const data = DividerData.fromJSON(JSON.parse('{ "__proto__": { "polluted": true } }'));
+    expect(Object.getPrototypeOf(data)).toBeNull();
+    expect((data as any).polluted).toBeUndefined();
+    expect(({} as any).polluted).toBeUndefined();
+  });
+});
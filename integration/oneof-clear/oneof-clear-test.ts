@@ -0,0 +1,28 @@
+import * as generated from './oneof-clear';
+import { clearEventPayload, clearEventTarget, Event } from './oneof-clear';
+
+describe('oneof-clear', () => {
+  const event: Event = { id: 'e1', text: 'hi', code: undefined, user: 'u1', group: undefined, note: 'n' };
+
+  it('unsets every member of the oneof', () => {
+    expect(clearEventPayload(event)).toEqual({ ...event, text: undefined, code: undefined });
+    expect(clearEventPayload({ ...event, text: undefined, code: 7 }).code).toBeUndefined();
+  });
+
+  it('leaves the other fields and oneofs alone', () => {
+    expect(clearEventTarget(event)).toEqual({ ...event, user: undefined, group: undefined });
+    expect(clearEventTarget(event).text).toEqual('hi');
+  });
+
+  it('returns a copy', () => {
+    clearEventPayload(event);
+    expect(event.text).toEqual('hi');
+  });
+
+  it('skips the synthetic oneofs of optional fields', () => {
+    expect(Object.keys(generated).filter((name) => name.startsWith('clear'))).toEqual([
+      'clearEventPayload',
+      'clearEventTarget',
+    ]);
+  });
+});
@@ -0,0 +1,20 @@
+/* eslint-disable */
+
+export const protobufPackage = 'oneofclear';
+
+export interface Event {
+  id: string;
+  text: string | undefined;
+  code: number | undefined;
+  user: string | undefined;
+  group: string | undefined;
+  note?: string | undefined;
+}
+
+export function clearEventPayload(message: Event): Event {
+  return { ...message, text: undefined, code: undefined };
+}
+
+export function clearEventTarget(message: Event): Event {
+  return { ...message, user: undefined, group: undefined };
+}
@@ -0,0 +1,137 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = '';
+
+export interface Baz {
+  type?: { $case: 'foo'; foo: FooBar };
+}
+
+export interface FooBar {}
+
+function createBaseBaz(): Baz {
+  return { type: undefined };
+}
+
+export const Baz = {
+  encode(message: Baz, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.type?.$case === 'foo') {
+      FooBar.encode(message.type.foo, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Baz {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseBaz();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.type = { $case: 'foo', foo: FooBar.decode(reader, reader.uint32()) };
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Baz {
+    return {
+      type: isSet(object.foo) ? { $case: 'foo', foo: FooBar.fromJSON(object.foo) } : undefined,
+    };
+  },
+
+  toJSON(message: Baz): unknown {
+    const obj: any = {};
+    message.type?.$case === 'foo' && (obj.foo = message.type?.foo ? FooBar.toJSON(message.type?.foo) : undefined);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Baz>, I>>(object: I): Baz {
+    const message = createBaseBaz();
+    if (object.type?.$case !== undefined && !['foo'].includes(object.type.$case)) {
+      throw new globalThis.Error('Unknown $case ' + object.type.$case + ' for oneof type of Baz');
+    }
+    if (object.type?.$case === 'foo' && object.type?.foo !== undefined && object.type?.foo !== null) {
+      message.type = { $case: 'foo', foo: FooBar.fromPartial(object.type.foo) };
+    }
+    return message;
+  },
+};
+
+function createBaseFooBar(): FooBar {
+  return {};
+}
+
+export const FooBar = {
+  encode(_: FooBar, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): FooBar {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseFooBar();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(_: any): FooBar {
+    return {};
+  },
+
+  toJSON(_: FooBar): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<FooBar>, I>>(_: I): FooBar {
+    const message = createBaseFooBar();
+    return message;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends { $case: string }
+  ? { [K in keyof Omit<T, '$case'>]?: DeepPartial<T[K]> } & { $case: T['$case'] }
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
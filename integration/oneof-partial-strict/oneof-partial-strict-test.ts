@@ -0,0 +1,16 @@
+import { Baz } from './file';
+
+describe('oneofPartialStrict', () => {
+  it('reads a known $case', () => {
+    expect(Baz.fromPartial({ type: { $case: 'foo', foo: {} } })).toEqual({ type: { $case: 'foo', foo: {} } });
+  });
+
+  it('leaves an unset oneof unset', () => {
+    expect(Baz.fromPartial({})).toEqual({ type: undefined });
+  });
+
+  it('throws on an unknown $case', () => {
+    const partial = { type: { $case: 'fooo', fooo: {} } } as any;
+    expect(() => Baz.fromPartial(partial)).toThrow('Unknown $case fooo for oneof type of Baz');
+  });
+});
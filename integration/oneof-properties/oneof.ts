@@ -181,7 +181,11 @@ export const PleaseChoose = {
       aString: isSet(object.aString) ? String(object.aString) : undefined,
       aMessage: isSet(object.aMessage) ? PleaseChoose_Submessage.fromJSON(object.aMessage) : undefined,
       aBool: isSet(object.aBool) ? Boolean(object.aBool) : undefined,
-      bunchaBytes: isSet(object.bunchaBytes) ? bytesFromBase64(object.bunchaBytes) : undefined,
+      bunchaBytes: isSet(object.bunchaBytes)
+        ? object.bunchaBytes instanceof Uint8Array
+          ? object.bunchaBytes
+          : bytesFromBase64(object.bunchaBytes)
+        : undefined,
       anEnum: isSet(object.anEnum) ? pleaseChoose_StateEnumFromJSON(object.anEnum) : undefined,
       age: isSet(object.age) ? Number(object.age) : 0,
       either: isSet(object.either) ? String(object.either) : undefined,
@@ -297,33 +297,11 @@ export const Value = {
   },
 
   fromJSON(object: any): Value {
-    return {
-      kind: isSet(object.null_value)
-        ? { $case: 'null_value', null_value: nullValueFromJSON(object.null_value) }
-        : isSet(object.number_value)
-        ? { $case: 'number_value', number_value: Number(object.number_value) }
-        : isSet(object.string_value)
-        ? { $case: 'string_value', string_value: String(object.string_value) }
-        : isSet(object.bool_value)
-        ? { $case: 'bool_value', bool_value: Boolean(object.bool_value) }
-        : isSet(object.struct_value)
-        ? { $case: 'struct_value', struct_value: object.struct_value }
-        : isSet(object.list_value)
-        ? { $case: 'list_value', list_value: [...object.list_value] }
-        : undefined,
-    };
+    return Value.wrap(object);
   },
 
   toJSON(message: Value): unknown {
-    const obj: any = {};
-    message.kind?.$case === 'null_value' &&
-      (obj.null_value = message.kind?.null_value !== undefined ? nullValueToJSON(message.kind?.null_value) : undefined);
-    message.kind?.$case === 'number_value' && (obj.number_value = message.kind?.number_value);
-    message.kind?.$case === 'string_value' && (obj.string_value = message.kind?.string_value);
-    message.kind?.$case === 'bool_value' && (obj.bool_value = message.kind?.bool_value);
-    message.kind?.$case === 'struct_value' && (obj.struct_value = message.kind?.struct_value);
-    message.kind?.$case === 'list_value' && (obj.list_value = message.kind?.list_value);
-    return obj;
+    return Value.unwrap(message);
   },
 
   fromPartial<I extends Exact<DeepPartial<Value>, I>>(object: I): Value {
@@ -0,0 +1,80 @@
+import { PleaseChoose, PleaseChoose_StateEnum } from './oneof';
+import { Value } from './google/protobuf/struct';
+import { PleaseChoose as PleaseChooseUnions } from '../oneof-unions/oneof';
+
+describe('oneof=unions-value', () => {
+  const debbie: PleaseChoose = {
+    name: 'Debbie',
+    age: 37,
+    choice: { $case: 'aMessage', value: { name: 'sub' } },
+    eitherOr: { $case: 'or', value: 'perhaps not' },
+    signature: new Uint8Array([0xab, 0xcd]),
+    value: { list: [1, 'two', null] },
+  };
+
+  const choices: Array<PleaseChoose['choice']> = [
+    { $case: 'aNumber', value: 1.5 },
+    { $case: 'aString', value: 'a string' },
+    { $case: 'aMessage', value: { name: 'sub' } },
+    { $case: 'aBool', value: true },
+    { $case: 'bunchaBytes', value: new Uint8Array([1, 2, 3]) },
+    { $case: 'anEnum', value: PleaseChoose_StateEnum.ON },
+    undefined,
+  ];
+
+  it('round-trips every case through encode and decode', () => {
+    for (const choice of choices) {
+      const message = { ...debbie, choice };
+      expect(PleaseChoose.decode(PleaseChoose.encode(message).finish())).toEqual(message);
+    }
+  });
+
+  it('round-trips every case through toJSON and fromJSON', () => {
+    for (const choice of choices) {
+      const message = { ...debbie, choice };
+      expect(PleaseChoose.fromJSON(PleaseChoose.toJSON(message))).toEqual(message);
+    }
+  });
+
+  it('writes the same JSON as oneof=unions', () => {
+    expect(PleaseChoose.toJSON(debbie)).toEqual({
+      name: 'Debbie',
+      age: 37,
+      aMessage: { name: 'sub' },
+      or: 'perhaps not',
+      signature: 'q80=',
+      value: { list: [1, 'two', null] },
+    });
+  });
+
+  it('uses the same wire format as oneof=unions', () => {
+    const decoded = PleaseChooseUnions.decode(PleaseChoose.encode(debbie).finish());
+    expect(decoded.choice).toEqual({ $case: 'aMessage', aMessage: { name: 'sub' } });
+    expect(decoded.eitherOr).toEqual({ $case: 'or', or: 'perhaps not' });
+    const encoded = PleaseChooseUnions.encode({ ...decoded, choice: { $case: 'aNumber', aNumber: 2 } }).finish();
+    expect(PleaseChoose.decode(encoded).choice).toEqual({ $case: 'aNumber', value: 2 });
+  });
+
+  it('fills in partial cases with fromPartial', () => {
+    const message = PleaseChoose.fromPartial({
+      choice: { $case: 'aMessage', value: {} },
+      eitherOr: { $case: 'thirdOption', value: 'third' },
+    });
+    expect(message.choice).toEqual({ $case: 'aMessage', value: { name: '' } });
+    expect(message.eitherOr).toEqual({ $case: 'thirdOption', value: 'third' });
+    expect(PleaseChoose.fromPartial({ choice: { $case: 'aString' } }).choice).toBeUndefined();
+  });
+
+  it('reads the value of any case under the same key', () => {
+    const values = choices.map((choice) => choice?.value);
+    expect(values).toEqual([1.5, 'a string', { name: 'sub' }, true, new Uint8Array([1, 2, 3]), 2, undefined]);
+  });
+
+  it('wraps and unwraps google.protobuf.Value', () => {
+    expect(Value.wrap('hi').kind).toEqual({ $case: 'stringValue', value: 'hi' });
+    expect(Value.wrap(null).kind).toEqual({ $case: 'nullValue', value: 0 });
+    for (const value of [null, true, 2, 'three', [1, 'a'], { a: { b: [false] } }]) {
+      expect(Value.unwrap(Value.decode(Value.encode(Value.wrap(value)).finish()))).toEqual(value);
+    }
+  });
+});
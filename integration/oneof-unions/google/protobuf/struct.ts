@@ -294,33 +294,11 @@ export const Value = {
   },
 
   fromJSON(object: any): Value {
-    return {
-      kind: isSet(object.nullValue)
-        ? { $case: 'nullValue', nullValue: nullValueFromJSON(object.nullValue) }
-        : isSet(object.numberValue)
-        ? { $case: 'numberValue', numberValue: Number(object.numberValue) }
-        : isSet(object.stringValue)
-        ? { $case: 'stringValue', stringValue: String(object.stringValue) }
-        : isSet(object.boolValue)
-        ? { $case: 'boolValue', boolValue: Boolean(object.boolValue) }
-        : isSet(object.structValue)
-        ? { $case: 'structValue', structValue: object.structValue }
-        : isSet(object.listValue)
-        ? { $case: 'listValue', listValue: [...object.listValue] }
-        : undefined,
-    };
+    return Value.wrap(object);
   },
 
   toJSON(message: Value): unknown {
-    const obj: any = {};
-    message.kind?.$case === 'nullValue' &&
-      (obj.nullValue = message.kind?.nullValue !== undefined ? nullValueToJSON(message.kind?.nullValue) : undefined);
-    message.kind?.$case === 'numberValue' && (obj.numberValue = message.kind?.numberValue);
-    message.kind?.$case === 'stringValue' && (obj.stringValue = message.kind?.stringValue);
-    message.kind?.$case === 'boolValue' && (obj.boolValue = message.kind?.boolValue);
-    message.kind?.$case === 'structValue' && (obj.structValue = message.kind?.structValue);
-    message.kind?.$case === 'listValue' && (obj.listValue = message.kind?.listValue);
-    return obj;
+    return Value.unwrap(message);
   },
 
   fromPartial<I extends Exact<DeepPartial<Value>, I>>(object: I): Value {
@@ -185,7 +185,11 @@ export const PleaseChoose = {
         : isSet(object.aBool)
         ? { $case: 'aBool', aBool: Boolean(object.aBool) }
         : isSet(object.bunchaBytes)
-        ? { $case: 'bunchaBytes', bunchaBytes: bytesFromBase64(object.bunchaBytes) }
+        ? {
+            $case: 'bunchaBytes',
+            bunchaBytes:
+              object.bunchaBytes instanceof Uint8Array ? object.bunchaBytes : bytesFromBase64(object.bunchaBytes),
+          }
         : isSet(object.anEnum)
         ? { $case: 'anEnum', anEnum: pleaseChoose_StateEnumFromJSON(object.anEnum) }
         : undefined,
@@ -197,7 +201,11 @@ export const PleaseChoose = {
         : isSet(object.thirdOption)
         ? { $case: 'thirdOption', thirdOption: String(object.thirdOption) }
         : undefined,
-      signature: isSet(object.signature) ? bytesFromBase64(object.signature) : new Uint8Array(),
+      signature: isSet(object.signature)
+        ? object.signature instanceof Uint8Array
+          ? object.signature
+          : bytesFromBase64(object.signature)
+        : new Uint8Array(),
       value: isSet(object?.value) ? object.value : undefined,
     };
   },
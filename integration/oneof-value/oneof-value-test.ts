@@ -0,0 +1,18 @@
+import * as generated from './oneof-value';
+import { Notice, noticeRecipientValue } from './oneof-value';
+
+describe('oneof-value', () => {
+  it('returns the value of whichever member is set', () => {
+    expect(noticeRecipientValue({ recipient: { $case: 'userId', userId: 'u1' } })).toEqual('u1');
+    expect(noticeRecipientValue({ recipient: { $case: 'groupId', groupId: 'g1' } })).toEqual('g1');
+  });
+
+  it('returns undefined when no member is set', () => {
+    const notice: Notice = { detail: { $case: 'code', code: 1 } };
+    expect(noticeRecipientValue(notice)).toBeUndefined();
+  });
+
+  it('skips oneofs with members of different types', () => {
+    expect('noticeDetailValue' in generated).toBe(false);
+  });
+});
@@ -0,0 +1,19 @@
+/* eslint-disable */
+
+export const protobufPackage = 'oneofvalue';
+
+export interface Notice {
+  recipient?: { $case: 'userId'; userId: string } | { $case: 'groupId'; groupId: string };
+  detail?: { $case: 'text'; text: string } | { $case: 'code'; code: number };
+}
+
+export function noticeRecipientValue(message: Notice): string | undefined {
+  switch (message.recipient?.$case) {
+    case 'userId':
+      return message.recipient.userId;
+    case 'groupId':
+      return message.recipient.groupId;
+    default:
+      return undefined;
+  }
+}
@@ -0,0 +1,590 @@
+/* eslint-disable */
+import * as Long from 'long';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'google.protobuf';
+
+/**
+ * Wrapper message for `double`.
+ *
+ * The JSON representation for `DoubleValue` is JSON number.
+ */
+export interface DoubleValue {
+  /** The double value. */
+  value: number;
+}
+
+/**
+ * Wrapper message for `float`.
+ *
+ * The JSON representation for `FloatValue` is JSON number.
+ */
+export interface FloatValue {
+  /** The float value. */
+  value: number;
+}
+
+/**
+ * Wrapper message for `int64`.
+ *
+ * The JSON representation for `Int64Value` is JSON string.
+ */
+export interface Int64Value {
+  /** The int64 value. */
+  value: number;
+}
+
+/**
+ * Wrapper message for `uint64`.
+ *
+ * The JSON representation for `UInt64Value` is JSON string.
+ */
+export interface UInt64Value {
+  /** The uint64 value. */
+  value: number;
+}
+
+/**
+ * Wrapper message for `int32`.
+ *
+ * The JSON representation for `Int32Value` is JSON number.
+ */
+export interface Int32Value {
+  /** The int32 value. */
+  value: number;
+}
+
+/**
+ * Wrapper message for `uint32`.
+ *
+ * The JSON representation for `UInt32Value` is JSON number.
+ */
+export interface UInt32Value {
+  /** The uint32 value. */
+  value: number;
+}
+
+/**
+ * Wrapper message for `bool`.
+ *
+ * The JSON representation for `BoolValue` is JSON `true` and `false`.
+ */
+export interface BoolValue {
+  /** The bool value. */
+  value: boolean;
+}
+
+/**
+ * Wrapper message for `string`.
+ *
+ * The JSON representation for `StringValue` is JSON string.
+ */
+export interface StringValue {
+  /** The string value. */
+  value: string;
+}
+
+/**
+ * Wrapper message for `bytes`.
+ *
+ * The JSON representation for `BytesValue` is JSON string.
+ */
+export interface BytesValue {
+  /** The bytes value. */
+  value: Uint8Array;
+}
+
+function createBaseDoubleValue(): DoubleValue {
+  return { value: 0 };
+}
+
+export function encodeDoubleValue(message: DoubleValue, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value !== 0) {
+    writer.uint32(9).double(message.value);
+  }
+  return writer;
+}
+
+export function decodeDoubleValue(input: _m0.Reader | Uint8Array, length?: number): DoubleValue {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseDoubleValue();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = reader.double();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONDoubleValue(object: any): DoubleValue {
+  return {
+    value: isSet(object.value) ? Number(object.value) : 0,
+  };
+}
+
+export function toJSONDoubleValue(message: DoubleValue): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = message.value);
+  return obj;
+}
+
+export function fromPartialDoubleValue<I extends Exact<DeepPartial<DoubleValue>, I>>(object: I): DoubleValue {
+  const message = createBaseDoubleValue();
+  message.value = object.value ?? 0;
+  return message;
+}
+
+function createBaseFloatValue(): FloatValue {
+  return { value: 0 };
+}
+
+export function encodeFloatValue(message: FloatValue, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value !== 0) {
+    writer.uint32(13).float(message.value);
+  }
+  return writer;
+}
+
+export function decodeFloatValue(input: _m0.Reader | Uint8Array, length?: number): FloatValue {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseFloatValue();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = reader.float();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONFloatValue(object: any): FloatValue {
+  return {
+    value: isSet(object.value) ? Number(object.value) : 0,
+  };
+}
+
+export function toJSONFloatValue(message: FloatValue): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = message.value);
+  return obj;
+}
+
+export function fromPartialFloatValue<I extends Exact<DeepPartial<FloatValue>, I>>(object: I): FloatValue {
+  const message = createBaseFloatValue();
+  message.value = object.value ?? 0;
+  return message;
+}
+
+function createBaseInt64Value(): Int64Value {
+  return { value: 0 };
+}
+
+export function encodeInt64Value(message: Int64Value, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value !== 0) {
+    writer.uint32(8).int64(message.value);
+  }
+  return writer;
+}
+
+export function decodeInt64Value(input: _m0.Reader | Uint8Array, length?: number): Int64Value {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseInt64Value();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = longToNumber(reader.int64() as Long);
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONInt64Value(object: any): Int64Value {
+  return {
+    value: isSet(object.value) ? Number(object.value) : 0,
+  };
+}
+
+export function toJSONInt64Value(message: Int64Value): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = Math.round(message.value));
+  return obj;
+}
+
+export function fromPartialInt64Value<I extends Exact<DeepPartial<Int64Value>, I>>(object: I): Int64Value {
+  const message = createBaseInt64Value();
+  message.value = object.value ?? 0;
+  return message;
+}
+
+function createBaseUInt64Value(): UInt64Value {
+  return { value: 0 };
+}
+
+export function encodeUInt64Value(message: UInt64Value, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value !== 0) {
+    writer.uint32(8).uint64(message.value);
+  }
+  return writer;
+}
+
+export function decodeUInt64Value(input: _m0.Reader | Uint8Array, length?: number): UInt64Value {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseUInt64Value();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = longToNumber(reader.uint64() as Long);
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONUInt64Value(object: any): UInt64Value {
+  return {
+    value: isSet(object.value) ? Number(object.value) : 0,
+  };
+}
+
+export function toJSONUInt64Value(message: UInt64Value): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = Math.round(message.value));
+  return obj;
+}
+
+export function fromPartialUInt64Value<I extends Exact<DeepPartial<UInt64Value>, I>>(object: I): UInt64Value {
+  const message = createBaseUInt64Value();
+  message.value = object.value ?? 0;
+  return message;
+}
+
+function createBaseInt32Value(): Int32Value {
+  return { value: 0 };
+}
+
+export function encodeInt32Value(message: Int32Value, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value !== 0) {
+    writer.uint32(8).int32(message.value);
+  }
+  return writer;
+}
+
+export function decodeInt32Value(input: _m0.Reader | Uint8Array, length?: number): Int32Value {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseInt32Value();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = reader.int32();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONInt32Value(object: any): Int32Value {
+  return {
+    value: isSet(object.value) ? Number(object.value) : 0,
+  };
+}
+
+export function toJSONInt32Value(message: Int32Value): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = Math.round(message.value));
+  return obj;
+}
+
+export function fromPartialInt32Value<I extends Exact<DeepPartial<Int32Value>, I>>(object: I): Int32Value {
+  const message = createBaseInt32Value();
+  message.value = object.value ?? 0;
+  return message;
+}
+
+function createBaseUInt32Value(): UInt32Value {
+  return { value: 0 };
+}
+
+export function encodeUInt32Value(message: UInt32Value, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value !== 0) {
+    writer.uint32(8).uint32(message.value);
+  }
+  return writer;
+}
+
+export function decodeUInt32Value(input: _m0.Reader | Uint8Array, length?: number): UInt32Value {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseUInt32Value();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = reader.uint32();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONUInt32Value(object: any): UInt32Value {
+  return {
+    value: isSet(object.value) ? Number(object.value) : 0,
+  };
+}
+
+export function toJSONUInt32Value(message: UInt32Value): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = Math.round(message.value));
+  return obj;
+}
+
+export function fromPartialUInt32Value<I extends Exact<DeepPartial<UInt32Value>, I>>(object: I): UInt32Value {
+  const message = createBaseUInt32Value();
+  message.value = object.value ?? 0;
+  return message;
+}
+
+function createBaseBoolValue(): BoolValue {
+  return { value: false };
+}
+
+export function encodeBoolValue(message: BoolValue, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value === true) {
+    writer.uint32(8).bool(message.value);
+  }
+  return writer;
+}
+
+export function decodeBoolValue(input: _m0.Reader | Uint8Array, length?: number): BoolValue {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseBoolValue();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = reader.bool();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONBoolValue(object: any): BoolValue {
+  return {
+    value: isSet(object.value) ? Boolean(object.value) : false,
+  };
+}
+
+export function toJSONBoolValue(message: BoolValue): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = message.value);
+  return obj;
+}
+
+export function fromPartialBoolValue<I extends Exact<DeepPartial<BoolValue>, I>>(object: I): BoolValue {
+  const message = createBaseBoolValue();
+  message.value = object.value ?? false;
+  return message;
+}
+
+function createBaseStringValue(): StringValue {
+  return { value: '' };
+}
+
+export function encodeStringValue(message: StringValue, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value !== '') {
+    writer.uint32(10).string(message.value);
+  }
+  return writer;
+}
+
+export function decodeStringValue(input: _m0.Reader | Uint8Array, length?: number): StringValue {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseStringValue();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = reader.string();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONStringValue(object: any): StringValue {
+  return {
+    value: isSet(object.value) ? String(object.value) : '',
+  };
+}
+
+export function toJSONStringValue(message: StringValue): unknown {
+  const obj: any = {};
+  message.value !== undefined && (obj.value = message.value);
+  return obj;
+}
+
+export function fromPartialStringValue<I extends Exact<DeepPartial<StringValue>, I>>(object: I): StringValue {
+  const message = createBaseStringValue();
+  message.value = object.value ?? '';
+  return message;
+}
+
+function createBaseBytesValue(): BytesValue {
+  return { value: new Uint8Array() };
+}
+
+export function encodeBytesValue(message: BytesValue, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.value.length !== 0) {
+    writer.uint32(10).bytes(message.value);
+  }
+  return writer;
+}
+
+export function decodeBytesValue(input: _m0.Reader | Uint8Array, length?: number): BytesValue {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseBytesValue();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.value = reader.bytes();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONBytesValue(object: any): BytesValue {
+  return {
+    value: isSet(object.value)
+      ? object.value instanceof Uint8Array
+        ? object.value
+        : bytesFromBase64(object.value)
+      : new Uint8Array(),
+  };
+}
+
+export function toJSONBytesValue(message: BytesValue): unknown {
+  const obj: any = {};
+  message.value !== undefined &&
+    (obj.value = base64FromBytes(message.value !== undefined ? message.value : new Uint8Array()));
+  return obj;
+}
+
+export function fromPartialBytesValue<I extends Exact<DeepPartial<BytesValue>, I>>(object: I): BytesValue {
+  const message = createBaseBytesValue();
+  message.value = object.value ?? new Uint8Array();
+  return message;
+}
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+const atob: (b64: string) => string =
+  globalThis.atob || ((b64) => globalThis.Buffer.from(b64, 'base64').toString('binary'));
+function bytesFromBase64(b64: string): Uint8Array {
+  const bin = atob(b64);
+  const arr = new Uint8Array(bin.length);
+  for (let i = 0; i < bin.length; ++i) {
+    arr[i] = bin.charCodeAt(i);
+  }
+  return arr;
+}
+
+const btoa: (bin: string) => string =
+  globalThis.btoa || ((bin) => globalThis.Buffer.from(bin, 'binary').toString('base64'));
+function base64FromBytes(arr: Uint8Array): string {
+  const bin: string[] = [];
+  arr.forEach((byte) => {
+    bin.push(String.fromCharCode(byte));
+  });
+  return btoa(bin.join(''));
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function longToNumber(long: Long): number {
+  if (long.gt(Number.MAX_SAFE_INTEGER)) {
+    throw new globalThis.Error('Value is larger than Number.MAX_SAFE_INTEGER');
+  }
+  return long.toNumber();
+}
+
+// If you get a compile-error about 'Constructor<Long> and ... have no overlap',
+// add '--ts_proto_opt=esModuleInterop=true' as a flag when calling 'protoc'.
+if (_m0.util.Long !== Long) {
+  _m0.util.Long = Long as any;
+  _m0.configure();
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
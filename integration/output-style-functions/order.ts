@@ -0,0 +1,448 @@
+/* eslint-disable */
+import {
+  makeGenericClientConstructor,
+  ChannelCredentials,
+  ChannelOptions,
+  UntypedServiceImplementation,
+  handleUnaryCall,
+  Client,
+  ClientUnaryCall,
+  Metadata,
+  CallOptions,
+  ServiceError,
+} from '@grpc/grpc-js';
+import * as _m0 from 'protobufjs/minimal';
+import { encodeStringValue, decodeStringValue } from './google/protobuf/wrappers';
+
+export const protobufPackage = 'functions';
+
+export interface GetOrderRequest {
+  id: string;
+}
+
+export interface Order {
+  id: string;
+  customer: Customer | undefined;
+  items: Order_Item[];
+  itemsBySku: { [key: string]: Order_Item };
+  note: string | undefined;
+}
+
+export interface Order_Item {
+  sku: string;
+  quantity: number;
+}
+
+export interface Order_ItemsBySkuEntry {
+  key: string;
+  value: Order_Item | undefined;
+}
+
+export interface Customer {
+  name: string;
+}
+
+function createBaseGetOrderRequest(): GetOrderRequest {
+  return { id: '' };
+}
+
+export function encodeGetOrderRequest(message: GetOrderRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.id !== '') {
+    writer.uint32(10).string(message.id);
+  }
+  return writer;
+}
+
+export function decodeGetOrderRequest(input: _m0.Reader | Uint8Array, length?: number): GetOrderRequest {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseGetOrderRequest();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.id = reader.string();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONGetOrderRequest(object: any): GetOrderRequest {
+  return {
+    id: isSet(object.id) ? String(object.id) : '',
+  };
+}
+
+export function toJSONGetOrderRequest(message: GetOrderRequest): unknown {
+  const obj: any = {};
+  message.id !== undefined && (obj.id = message.id);
+  return obj;
+}
+
+export function fromPartialGetOrderRequest<I extends Exact<DeepPartial<GetOrderRequest>, I>>(
+  object: I
+): GetOrderRequest {
+  const message = createBaseGetOrderRequest();
+  message.id = object.id ?? '';
+  return message;
+}
+
+function createBaseOrder(): Order {
+  return { id: '', customer: undefined, items: [], itemsBySku: {}, note: undefined };
+}
+
+export function encodeOrder(message: Order, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.id !== '') {
+    writer.uint32(10).string(message.id);
+  }
+  if (message.customer !== undefined) {
+    encodeCustomer(message.customer, writer.uint32(18).fork()).ldelim();
+  }
+  for (const v of message.items) {
+    encodeOrder_Item(v!, writer.uint32(26).fork()).ldelim();
+  }
+  Object.entries(message.itemsBySku).forEach(([key, value]) => {
+    encodeOrder_ItemsBySkuEntry({ key: key as any, value }, writer.uint32(34).fork()).ldelim();
+  });
+  if (message.note !== undefined) {
+    encodeStringValue({ value: message.note! }, writer.uint32(42).fork()).ldelim();
+  }
+  return writer;
+}
+
+export function decodeOrder(input: _m0.Reader | Uint8Array, length?: number): Order {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseOrder();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.id = reader.string();
+        break;
+      case 2:
+        message.customer = decodeCustomer(reader, reader.uint32());
+        break;
+      case 3:
+        message.items.push(decodeOrder_Item(reader, reader.uint32()));
+        break;
+      case 4:
+        const entry4 = decodeOrder_ItemsBySkuEntry(reader, reader.uint32());
+        if (entry4.value !== undefined) {
+          message.itemsBySku[entry4.key] = entry4.value;
+        }
+        break;
+      case 5:
+        message.note = decodeStringValue(reader, reader.uint32()).value;
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONOrder(object: any): Order {
+  return {
+    id: isSet(object.id) ? String(object.id) : '',
+    customer: isSet(object.customer) ? fromJSONCustomer(object.customer) : undefined,
+    items: Array.isArray(object?.items) ? object.items.map((e: any) => fromJSONOrder_Item(e)) : [],
+    itemsBySku: isObject(object.itemsBySku)
+      ? Object.entries(object.itemsBySku).reduce<{ [key: string]: Order_Item }>((acc, [key, value]) => {
+          acc[key] = fromJSONOrder_Item(value);
+          return acc;
+        }, {})
+      : {},
+    note: isSet(object.note) ? String(object.note) : undefined,
+  };
+}
+
+export function toJSONOrder(message: Order): unknown {
+  const obj: any = {};
+  message.id !== undefined && (obj.id = message.id);
+  message.customer !== undefined && (obj.customer = message.customer ? toJSONCustomer(message.customer) : undefined);
+  if (message.items) {
+    obj.items = message.items.map((e) => (e ? toJSONOrder_Item(e) : undefined));
+  } else {
+    obj.items = [];
+  }
+  obj.itemsBySku = {};
+  if (message.itemsBySku) {
+    Object.entries(message.itemsBySku).forEach(([k, v]) => {
+      obj.itemsBySku[k] = toJSONOrder_Item(v);
+    });
+  }
+  message.note !== undefined && (obj.note = message.note);
+  return obj;
+}
+
+export function fromPartialOrder<I extends Exact<DeepPartial<Order>, I>>(object: I): Order {
+  const message = createBaseOrder();
+  message.id = object.id ?? '';
+  message.customer =
+    object.customer !== undefined && object.customer !== null ? fromPartialCustomer(object.customer) : undefined;
+  message.items = object.items?.map((e) => fromPartialOrder_Item(e)) || [];
+  message.itemsBySku = Object.entries(object.itemsBySku ?? {}).reduce<{ [key: string]: Order_Item }>(
+    (acc, [key, value]) => {
+      if (value !== undefined) {
+        acc[key] = fromPartialOrder_Item(value);
+      }
+      return acc;
+    },
+    {}
+  );
+  message.note = object.note ?? undefined;
+  return message;
+}
+
+function createBaseOrder_Item(): Order_Item {
+  return { sku: '', quantity: 0 };
+}
+
+export function encodeOrder_Item(message: Order_Item, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.sku !== '') {
+    writer.uint32(10).string(message.sku);
+  }
+  if (message.quantity !== 0) {
+    writer.uint32(16).int32(message.quantity);
+  }
+  return writer;
+}
+
+export function decodeOrder_Item(input: _m0.Reader | Uint8Array, length?: number): Order_Item {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseOrder_Item();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.sku = reader.string();
+        break;
+      case 2:
+        message.quantity = reader.int32();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONOrder_Item(object: any): Order_Item {
+  return {
+    sku: isSet(object.sku) ? String(object.sku) : '',
+    quantity: isSet(object.quantity) ? Number(object.quantity) : 0,
+  };
+}
+
+export function toJSONOrder_Item(message: Order_Item): unknown {
+  const obj: any = {};
+  message.sku !== undefined && (obj.sku = message.sku);
+  message.quantity !== undefined && (obj.quantity = Math.round(message.quantity));
+  return obj;
+}
+
+export function fromPartialOrder_Item<I extends Exact<DeepPartial<Order_Item>, I>>(object: I): Order_Item {
+  const message = createBaseOrder_Item();
+  message.sku = object.sku ?? '';
+  message.quantity = object.quantity ?? 0;
+  return message;
+}
+
+function createBaseOrder_ItemsBySkuEntry(): Order_ItemsBySkuEntry {
+  return { key: '', value: undefined };
+}
+
+export function encodeOrder_ItemsBySkuEntry(
+  message: Order_ItemsBySkuEntry,
+  writer: _m0.Writer = _m0.Writer.create()
+): _m0.Writer {
+  if (message.key !== '') {
+    writer.uint32(10).string(message.key);
+  }
+  if (message.value !== undefined) {
+    encodeOrder_Item(message.value, writer.uint32(18).fork()).ldelim();
+  }
+  return writer;
+}
+
+export function decodeOrder_ItemsBySkuEntry(input: _m0.Reader | Uint8Array, length?: number): Order_ItemsBySkuEntry {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseOrder_ItemsBySkuEntry();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.key = reader.string();
+        break;
+      case 2:
+        message.value = decodeOrder_Item(reader, reader.uint32());
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONOrder_ItemsBySkuEntry(object: any): Order_ItemsBySkuEntry {
+  return {
+    key: isSet(object.key) ? String(object.key) : '',
+    value: isSet(object.value) ? fromJSONOrder_Item(object.value) : undefined,
+  };
+}
+
+export function toJSONOrder_ItemsBySkuEntry(message: Order_ItemsBySkuEntry): unknown {
+  const obj: any = {};
+  message.key !== undefined && (obj.key = message.key);
+  message.value !== undefined && (obj.value = message.value ? toJSONOrder_Item(message.value) : undefined);
+  return obj;
+}
+
+export function fromPartialOrder_ItemsBySkuEntry<I extends Exact<DeepPartial<Order_ItemsBySkuEntry>, I>>(
+  object: I
+): Order_ItemsBySkuEntry {
+  const message = createBaseOrder_ItemsBySkuEntry();
+  message.key = object.key ?? '';
+  message.value = object.value !== undefined && object.value !== null ? fromPartialOrder_Item(object.value) : undefined;
+  return message;
+}
+
+function createBaseCustomer(): Customer {
+  return { name: '' };
+}
+
+export function encodeCustomer(message: Customer, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+  if (message.name !== '') {
+    writer.uint32(10).string(message.name);
+  }
+  return writer;
+}
+
+export function decodeCustomer(input: _m0.Reader | Uint8Array, length?: number): Customer {
+  const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+  let end = length === undefined ? reader.len : reader.pos + length;
+  const message = createBaseCustomer();
+  while (reader.pos < end) {
+    const tag = reader.uint32();
+    switch (tag >>> 3) {
+      case 1:
+        message.name = reader.string();
+        break;
+      default:
+        reader.skipType(tag & 7);
+        break;
+    }
+  }
+  return message;
+}
+
+export function fromJSONCustomer(object: any): Customer {
+  return {
+    name: isSet(object.name) ? String(object.name) : '',
+  };
+}
+
+export function toJSONCustomer(message: Customer): unknown {
+  const obj: any = {};
+  message.name !== undefined && (obj.name = message.name);
+  return obj;
+}
+
+export function fromPartialCustomer<I extends Exact<DeepPartial<Customer>, I>>(object: I): Customer {
+  const message = createBaseCustomer();
+  message.name = object.name ?? '';
+  return message;
+}
+
+export interface Orders {
+  GetOrder(request: GetOrderRequest): Promise<Order>;
+}
+
+export class OrdersClientImpl implements Orders {
+  private readonly rpc: Rpc;
+  constructor(rpc: Rpc) {
+    this.rpc = rpc;
+    this.GetOrder = this.GetOrder.bind(this);
+  }
+  GetOrder(request: GetOrderRequest): Promise<Order> {
+    const data = encodeGetOrderRequest(request).finish();
+    const promise = this.rpc.request('functions.Orders', 'GetOrder', data);
+    return promise.then((data) => decodeOrder(new _m0.Reader(data)));
+  }
+}
+
+export type OrdersService = typeof OrdersService;
+export const OrdersService = {
+  getOrder: {
+    path: '/functions.Orders/GetOrder',
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetOrderRequest) => Buffer.from(encodeGetOrderRequest(value).finish()),
+    requestDeserialize: (value: Buffer) => decodeGetOrderRequest(value),
+    responseSerialize: (value: Order) => Buffer.from(encodeOrder(value).finish()),
+    responseDeserialize: (value: Buffer) => decodeOrder(value),
+  },
+} as const;
+
+export interface OrdersServer extends UntypedServiceImplementation {
+  getOrder: handleUnaryCall<GetOrderRequest, Order>;
+}
+
+export interface OrdersClient extends Client {
+  getOrder(request: GetOrderRequest, callback: (error: ServiceError | null, response: Order) => void): ClientUnaryCall;
+  getOrder(
+    request: GetOrderRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: Order) => void
+  ): ClientUnaryCall;
+  getOrder(
+    request: GetOrderRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: Order) => void
+  ): ClientUnaryCall;
+}
+
+export const OrdersClient = makeGenericClientConstructor(OrdersService, 'functions.Orders') as unknown as {
+  new (address: string, credentials: ChannelCredentials, options?: Partial<ChannelOptions>): OrdersClient;
+  service: typeof OrdersService;
+};
+
+interface Rpc {
+  request(service: string, method: string, data: Uint8Array): Promise<Uint8Array>;
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isObject(value: any): boolean {
+  return typeof value === 'object' && value !== null;
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
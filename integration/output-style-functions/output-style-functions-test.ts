@@ -0,0 +1,83 @@
+import {
+  decodeGetOrderRequest,
+  decodeOrder,
+  encodeGetOrderRequest,
+  encodeOrder,
+  fromJSONOrder,
+  fromPartialOrder,
+  Order,
+  OrdersClientImpl,
+  OrdersService,
+  toJSONOrder,
+} from './order';
+import * as exports from './order';
+
+const order: Order = {
+  id: 'o-1',
+  customer: { name: 'Ada' },
+  items: [
+    { sku: 'apple', quantity: 2 },
+    { sku: 'pear', quantity: 1 },
+  ],
+  itemsBySku: { apple: { sku: 'apple', quantity: 2 } },
+  note: 'leave at the door',
+};
+
+describe('output-style-functions', () => {
+  it('exports standalone functions instead of message objects', () => {
+    expect(typeof encodeOrder).toEqual('function');
+    expect(Object.keys(exports)).not.toContain('Order');
+    expect(Object.keys(exports)).not.toContain('Order_Item');
+  });
+
+  it('round-trips through encodeOrder and decodeOrder', () => {
+    const bytes = encodeOrder(order).finish();
+    expect(decodeOrder(bytes)).toEqual(order);
+  });
+
+  it('round-trips through toJSONOrder and fromJSONOrder', () => {
+    const json = toJSONOrder(order);
+    expect(json).toEqual({
+      id: 'o-1',
+      customer: { name: 'Ada' },
+      items: [
+        { sku: 'apple', quantity: 2 },
+        { sku: 'pear', quantity: 1 },
+      ],
+      itemsBySku: { apple: { sku: 'apple', quantity: 2 } },
+      note: 'leave at the door',
+    });
+    expect(fromJSONOrder(json)).toEqual(order);
+  });
+
+  it('fills in the defaults of nested messages, maps and wrappers in fromPartialOrder', () => {
+    expect(fromPartialOrder({ items: [{ sku: 'fig' }], itemsBySku: { fig: {} } })).toEqual({
+      id: '',
+      customer: undefined,
+      items: [{ sku: 'fig', quantity: 0 }],
+      itemsBySku: { fig: { sku: '', quantity: 0 } },
+      note: undefined,
+    });
+  });
+
+  it('calls the functions from the default client', async () => {
+    const requests: string[] = [];
+    const rpc = {
+      request: async (service: string, method: string, data: Uint8Array): Promise<Uint8Array> => {
+        requests.push(`${service}/${method}:${decodeGetOrderRequest(data).id}`);
+        return encodeOrder(order).finish();
+      },
+    };
+    const client = new OrdersClientImpl(rpc);
+    expect(await client.GetOrder({ id: 'o-1' })).toEqual(order);
+    expect(requests).toEqual(['functions.Orders/GetOrder:o-1']);
+  });
+
+  it('calls the functions from the grpc-js service definition', () => {
+    const { getOrder } = OrdersService;
+    const request = getOrder.requestSerialize({ id: 'o-2' });
+    expect(getOrder.requestDeserialize(request)).toEqual({ id: 'o-2' });
+    expect(Buffer.from(encodeGetOrderRequest({ id: 'o-2' }).finish())).toEqual(request);
+    expect(getOrder.responseDeserialize(getOrder.responseSerialize(order))).toEqual(order);
+  });
+});
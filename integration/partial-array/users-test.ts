@@ -0,0 +1,20 @@
+import { User } from './users';
+
+describe('partial-array', () => {
+  it('maps each partial through fromPartial', () => {
+    expect(User.fromPartialArray([{ name: 'ann' }, { name: 'bob', age: 30, roles: ['admin'] }, {}])).toEqual([
+      { name: 'ann', age: 0, roles: [] },
+      { name: 'bob', age: 30, roles: ['admin'] },
+      { name: '', age: 0, roles: [] },
+    ]);
+  });
+
+  it('returns an empty array for no items', () => {
+    expect(User.fromPartialArray([])).toEqual([]);
+  });
+
+  it('rejects properties that the message does not have', () => {
+    // @ts-expect-error
+    User.fromPartialArray([{ name: 'ann', email: 'ann@example.com' }]);
+  });
+});
@@ -0,0 +1,49 @@
+import { Profile } from './patch';
+
+describe('patch', () => {
+  const profile = Profile.fromPartial({
+    name: 'ann',
+    address: { city: 'Oslo', street: 'Main' },
+    labels: { team: 'core' },
+    tags: ['a'],
+    email: 'ann@example.com',
+  });
+
+  it('applies changes and returns the prior values as the inverse', () => {
+    const { next, inverse } = Profile.patch(profile, { name: 'bob', age: 30, tags: ['b', 'c'] });
+    expect(next).toEqual({ ...profile, name: 'bob', age: 30, tags: ['b', 'c'] });
+    expect(inverse).toEqual({ name: 'ann', age: 0, tags: ['a'] });
+    expect(Profile.patch(next, inverse).next).toEqual(profile);
+    expect(profile.name).toEqual('ann');
+  });
+
+  it('patches sub-messages recursively', () => {
+    const { next, inverse } = Profile.patch(profile, { address: { city: 'Bergen' } });
+    expect(next.address).toEqual({ city: 'Bergen', street: 'Main' });
+    expect(inverse).toEqual({ address: { city: 'Oslo' } });
+    expect(Profile.patch(next, inverse).next).toEqual(profile);
+  });
+
+  it('clears sub-messages that were unset', () => {
+    const empty = Profile.fromPartial({});
+    const { next, inverse } = Profile.patch(empty, { address: { city: 'Bergen' } });
+    expect(next.address).toEqual({ city: 'Bergen', street: '' });
+    expect('address' in inverse).toBe(true);
+    expect(Profile.patch(next, inverse).next.address).toBeUndefined();
+  });
+
+  it('patches maps key by key, deleting undefined values', () => {
+    const { next, inverse } = Profile.patch(profile, { labels: { team: undefined, role: 'lead' } });
+    expect(next.labels).toEqual({ role: 'lead' });
+    expect(Profile.patch(next, inverse).next.labels).toEqual({ team: 'core' });
+  });
+
+  it('restores the member of a oneof that a change cleared', () => {
+    const { next, inverse } = Profile.patch(profile, { phone: '555' });
+    expect(next.email).toBeUndefined();
+    expect(next.phone).toEqual('555');
+    const undone = Profile.patch(next, inverse);
+    expect(undone.next).toEqual(profile);
+    expect(Profile.patch(undone.next, undone.inverse).next).toEqual(next);
+  });
+});
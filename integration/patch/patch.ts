@@ -0,0 +1,309 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'patch';
+
+export interface Profile {
+  name: string;
+  age: number;
+  address: Address | undefined;
+  labels: { [key: string]: string };
+  tags: string[];
+  email: string | undefined;
+  phone: string | undefined;
+}
+
+export interface Profile_LabelsEntry {
+  key: string;
+  value: string;
+}
+
+export interface Address {
+  city: string;
+  street: string;
+}
+
+function createBaseProfile(): Profile {
+  return { name: '', age: 0, address: undefined, labels: {}, tags: [], email: undefined, phone: undefined };
+}
+
+export const Profile = {
+  encode(message: Profile, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.age !== 0) {
+      writer.uint32(16).int32(message.age);
+    }
+    if (message.address !== undefined) {
+      Address.encode(message.address, writer.uint32(26).fork()).ldelim();
+    }
+    Object.entries(message.labels).forEach(([key, value]) => {
+      Profile_LabelsEntry.encode({ key: key as any, value }, writer.uint32(34).fork()).ldelim();
+    });
+    for (const v of message.tags) {
+      writer.uint32(42).string(v!);
+    }
+    if (message.email !== undefined) {
+      writer.uint32(50).string(message.email);
+    }
+    if (message.phone !== undefined) {
+      writer.uint32(58).string(message.phone);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Profile {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseProfile();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        case 2:
+          message.age = reader.int32();
+          break;
+        case 3:
+          message.address = Address.decode(reader, reader.uint32());
+          break;
+        case 4:
+          const entry4 = Profile_LabelsEntry.decode(reader, reader.uint32());
+          if (entry4.value !== undefined) {
+            message.labels[entry4.key] = entry4.value;
+          }
+          break;
+        case 5:
+          message.tags.push(reader.string());
+          break;
+        case 6:
+          message.email = reader.string();
+          break;
+        case 7:
+          message.phone = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Profile>, I>>(object: I): Profile {
+    const message = createBaseProfile();
+    message.name = object.name ?? '';
+    message.age = object.age ?? 0;
+    message.address =
+      object.address !== undefined && object.address !== null ? Address.fromPartial(object.address) : undefined;
+    message.labels = Object.entries(object.labels ?? {}).reduce<{ [key: string]: string }>((acc, [key, value]) => {
+      if (value !== undefined) {
+        acc[key] = String(value);
+      }
+      return acc;
+    }, {});
+    message.tags = object.tags?.map((e) => e) || [];
+    message.email = object.email ?? undefined;
+    message.phone = object.phone ?? undefined;
+    return message;
+  },
+
+  patch(message: Profile, changes: DeepPartial<Profile>): { next: Profile; inverse: DeepPartial<Profile> } {
+    const source = Profile.fromPartial(changes);
+    const next = { ...message };
+    const inverse: DeepPartial<Profile> = {};
+    if ('name' in changes) {
+      next.name = source.name;
+      inverse.name = message.name;
+    }
+    if ('age' in changes) {
+      next.age = source.age;
+      inverse.age = message.age;
+    }
+    if ('address' in changes) {
+      if (message.address !== undefined && changes.address !== undefined) {
+        const patched = Address.patch(message.address, changes.address);
+        next.address = patched.next;
+        inverse.address = patched.inverse;
+      } else {
+        next.address = source.address;
+        inverse.address = message.address;
+      }
+    }
+    if (changes.labels !== undefined) {
+      const prior: NonNullable<typeof inverse.labels> = {};
+      next.labels = { ...message.labels };
+      for (const key of Object.keys(changes.labels)) {
+        prior[key] = message.labels?.[key];
+        const value = source.labels?.[key];
+        if (value !== undefined) {
+          next.labels[key] = value;
+        } else {
+          delete next.labels[key];
+        }
+      }
+      inverse.labels = prior;
+    }
+    if ('tags' in changes) {
+      next.tags = source.tags;
+      inverse.tags = message.tags;
+    }
+    if ('email' in changes) {
+      next.email = source.email;
+      inverse.email = message.email;
+      if (source.email !== undefined) {
+        next.phone = undefined;
+        inverse.phone = message.phone;
+      }
+    }
+    if ('phone' in changes) {
+      next.phone = source.phone;
+      inverse.phone = message.phone;
+      if (source.phone !== undefined) {
+        next.email = undefined;
+        inverse.email = message.email;
+      }
+    }
+    return { next, inverse };
+  },
+};
+
+function createBaseProfile_LabelsEntry(): Profile_LabelsEntry {
+  return { key: '', value: '' };
+}
+
+export const Profile_LabelsEntry = {
+  encode(message: Profile_LabelsEntry, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.key !== '') {
+      writer.uint32(10).string(message.key);
+    }
+    if (message.value !== '') {
+      writer.uint32(18).string(message.value);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Profile_LabelsEntry {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseProfile_LabelsEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.key = reader.string();
+          break;
+        case 2:
+          message.value = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Profile_LabelsEntry>, I>>(object: I): Profile_LabelsEntry {
+    const message = createBaseProfile_LabelsEntry();
+    message.key = object.key ?? '';
+    message.value = object.value ?? '';
+    return message;
+  },
+
+  patch(
+    message: Profile_LabelsEntry,
+    changes: DeepPartial<Profile_LabelsEntry>
+  ): { next: Profile_LabelsEntry; inverse: DeepPartial<Profile_LabelsEntry> } {
+    const source = Profile_LabelsEntry.fromPartial(changes);
+    const next = { ...message };
+    const inverse: DeepPartial<Profile_LabelsEntry> = {};
+    if ('key' in changes) {
+      next.key = source.key;
+      inverse.key = message.key;
+    }
+    if ('value' in changes) {
+      next.value = source.value;
+      inverse.value = message.value;
+    }
+    return { next, inverse };
+  },
+};
+
+function createBaseAddress(): Address {
+  return { city: '', street: '' };
+}
+
+export const Address = {
+  encode(message: Address, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.city !== '') {
+      writer.uint32(10).string(message.city);
+    }
+    if (message.street !== '') {
+      writer.uint32(18).string(message.street);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Address {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAddress();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.city = reader.string();
+          break;
+        case 2:
+          message.street = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Address>, I>>(object: I): Address {
+    const message = createBaseAddress();
+    message.city = object.city ?? '';
+    message.street = object.street ?? '';
+    return message;
+  },
+
+  patch(message: Address, changes: DeepPartial<Address>): { next: Address; inverse: DeepPartial<Address> } {
+    const source = Address.fromPartial(changes);
+    const next = { ...message };
+    const inverse: DeepPartial<Address> = {};
+    if ('city' in changes) {
+      next.city = source.city;
+      inverse.city = message.city;
+    }
+    if ('street' in changes) {
+      next.street = source.street;
+      inverse.street = message.street;
+    }
+    return { next, inverse };
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
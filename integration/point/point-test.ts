@@ -0,0 +1,26 @@
+import { Writer } from 'protobufjs';
+import { Area, Point } from './point';
+
+describe('point', () => {
+  it('decodes fields with the expected wire types', () => {
+    const area: Area = { nw: { lat: 1.5, lng: -2 }, se: { lat: 0.5, lng: 3 } };
+    expect(Area.decode(Area.encode(area).finish())).toEqual(area);
+  });
+
+  it('throws on a scalar field with the wrong wire type', () => {
+    // lat is a double (wire type 1), but is written as a varint
+    const bytes = Writer.create().uint32((1 << 3) | 0).int32(12).finish();
+    expect(() => Point.decode(bytes)).toThrow('Invalid wire type 0 for field lat of Point');
+  });
+
+  it('throws on a message field with the wrong wire type', () => {
+    // nw is a message (wire type 2), but is written as a fixed64
+    const bytes = Writer.create().uint32((1 << 3) | 1).double(1.5).finish();
+    expect(() => Area.decode(bytes)).toThrow('Invalid wire type 1 for field nw of Area');
+  });
+
+  it('still skips unknown fields', () => {
+    const bytes = Writer.create().uint32((3 << 3) | 0).int32(12).uint32((1 << 3) | 1).double(1.5).finish();
+    expect(Point.decode(bytes)).toEqual({ lat: 1.5, lng: 0 });
+  });
+});
@@ -36,9 +36,15 @@ export const Point = {
       const tag = reader.uint32();
       switch (tag >>> 3) {
         case 1:
+          if ((tag & 7) !== 1) {
+            throw new Error(`Invalid wire type ${tag & 7} for field lat of Point`);
+          }
           message.lat = reader.double();
           break;
         case 2:
+          if ((tag & 7) !== 1) {
+            throw new Error(`Invalid wire type ${tag & 7} for field lng of Point`);
+          }
           message.lng = reader.double();
           break;
         default:
@@ -94,9 +100,15 @@ export const Area = {
       const tag = reader.uint32();
       switch (tag >>> 3) {
         case 1:
+          if ((tag & 7) !== 2) {
+            throw new Error(`Invalid wire type ${tag & 7} for field nw of Area`);
+          }
           message.nw = Point.decode(reader, reader.uint32());
           break;
         case 2:
+          if ((tag & 7) !== 2) {
+            throw new Error(`Invalid wire type ${tag & 7} for field se of Area`);
+          }
           message.se = Point.decode(reader, reader.uint32());
           break;
         default:
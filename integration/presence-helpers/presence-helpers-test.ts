@@ -0,0 +1,34 @@
+import * as generated from './presence-helpers';
+import { hasProfileAddress, hasProfileAge, hasProfileNickname, Profile } from './presence-helpers';
+
+const empty: Profile = { name: '', address: undefined, previous: [] };
+
+describe('presence-helpers', () => {
+  it('is false for unset fields', () => {
+    expect(hasProfileAge(empty)).toBe(false);
+    expect(hasProfileNickname(empty)).toBe(false);
+    expect(hasProfileAddress(empty)).toBe(false);
+  });
+
+  it('is true for set fields, including falsy values', () => {
+    const profile: Profile = { ...empty, age: 0, nickname: '', address: { city: '' } };
+    expect(hasProfileAge(profile)).toBe(true);
+    expect(hasProfileNickname(profile)).toBe(true);
+    expect(hasProfileAddress(profile)).toBe(true);
+  });
+
+  it('narrows the field to be set', () => {
+    const profile: Profile = { ...empty, address: { city: 'Oslo' } };
+    // Compiles without a `?.`, since the guard narrows away `undefined`
+    const city = hasProfileAddress(profile) ? profile.address.city : undefined;
+    expect(city).toEqual('Oslo');
+  });
+
+  it('skips fields without explicit presence', () => {
+    expect(Object.keys(generated).filter((name) => name.startsWith('has'))).toEqual([
+      'hasProfileAge',
+      'hasProfileNickname',
+      'hasProfileAddress',
+    ]);
+  });
+});
@@ -0,0 +1,29 @@
+/* eslint-disable */
+
+export const protobufPackage = 'presence';
+
+export interface Address {
+  city: string;
+}
+
+export interface Profile {
+  name: string;
+  age?: number | undefined;
+  nickname?: string | undefined;
+  address: Address | undefined;
+  previous: Address[];
+}
+
+export function hasProfileAge(message: Profile): message is Profile & { age: NonNullable<Profile['age']> } {
+  return message.age !== undefined;
+}
+
+export function hasProfileNickname(
+  message: Profile
+): message is Profile & { nickname: NonNullable<Profile['nickname']> } {
+  return message.nickname !== undefined;
+}
+
+export function hasProfileAddress(message: Profile): message is Profile & { address: NonNullable<Profile['address']> } {
+  return message.address !== undefined;
+}
@@ -0,0 +1,51 @@
+import {
+  filterToQueryString,
+  SearchRequest,
+  searchRequestToQueryParams,
+  searchRequestToQueryString,
+  Status,
+} from './search';
+
+const request: SearchRequest = {
+  query: 'red shoes',
+  tags: ['sale', 'new'],
+  filter: { status: Status.STATUS_ACTIVE, minScore: 3 },
+  pageSize: 20,
+  includeDrafts: true,
+  orFilters: [{ status: Status.STATUS_ARCHIVED, minScore: 0 }],
+};
+
+describe('query-string', () => {
+  it('writes the set fields under their JSON names', () => {
+    expect(searchRequestToQueryString(request)).toEqual(
+      'query=red%20shoes&tags=sale&tags=new&filter.status=STATUS_ACTIVE&filter.minScore=3&pageSize=20&includeDrafts=true'
+    );
+  });
+
+  it('leaves out fields at their default value', () => {
+    expect(searchRequestToQueryString(SearchRequest.fromJSON({ query: 'x' }))).toEqual('query=x');
+    expect(filterToQueryString({ status: Status.STATUS_UNKNOWN, minScore: 0 })).toEqual('');
+  });
+
+  it('flattens sub-messages into dotted names under the given prefix', () => {
+    expect(searchRequestToQueryParams({ ...request, tags: [], orFilters: [] }, 'q.')).toEqual([
+      ['q.query', 'red shoes'],
+      ['q.filter.status', 'STATUS_ACTIVE'],
+      ['q.filter.minScore', '3'],
+      ['q.pageSize', '20'],
+      ['q.includeDrafts', 'true'],
+    ]);
+  });
+
+  it('skips repeated messages', () => {
+    const params = searchRequestToQueryParams(request).map(([key]) => key);
+    expect(params.some((key) => key.startsWith('orFilters'))).toBe(false);
+  });
+
+  it('can be parsed back with URLSearchParams', () => {
+    const parsed = new URLSearchParams(searchRequestToQueryString(request));
+    expect(parsed.get('query')).toEqual('red shoes');
+    expect(parsed.getAll('tags')).toEqual(['sale', 'new']);
+    expect(parsed.get('filter.status')).toEqual('STATUS_ACTIVE');
+  });
+});
@@ -0,0 +1,159 @@
+/* eslint-disable */
+
+export const protobufPackage = 'search';
+
+export enum Status {
+  STATUS_UNKNOWN = 0,
+  STATUS_ACTIVE = 1,
+  STATUS_ARCHIVED = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function statusFromJSON(object: any): Status {
+  switch (object) {
+    case 0:
+    case 'STATUS_UNKNOWN':
+      return Status.STATUS_UNKNOWN;
+    case 1:
+    case 'STATUS_ACTIVE':
+      return Status.STATUS_ACTIVE;
+    case 2:
+    case 'STATUS_ARCHIVED':
+      return Status.STATUS_ARCHIVED;
+    case -1:
+    case 'UNRECOGNIZED':
+    default:
+      return Status.UNRECOGNIZED;
+  }
+}
+
+export function statusToJSON(object: Status): string {
+  switch (object) {
+    case Status.STATUS_UNKNOWN:
+      return 'STATUS_UNKNOWN';
+    case Status.STATUS_ACTIVE:
+      return 'STATUS_ACTIVE';
+    case Status.STATUS_ARCHIVED:
+      return 'STATUS_ARCHIVED';
+    case Status.UNRECOGNIZED:
+    default:
+      return 'UNRECOGNIZED';
+  }
+}
+
+export interface Filter {
+  status: Status;
+  minScore: number;
+}
+
+export function filterToQueryParams(message: Filter, prefix: string = ''): [string, string][] {
+  const params: [string, string][] = [];
+  if (message.status !== 0) {
+    params.push([prefix + 'status', statusToJSON(message.status)]);
+  }
+  if (message.minScore !== 0) {
+    params.push([prefix + 'minScore', String(message.minScore)]);
+  }
+  return params;
+}
+
+export function filterToQueryString(message: Filter): string {
+  return filterToQueryParams(message)
+    .map(([key, value]) => encodeURIComponent(key) + '=' + encodeURIComponent(value))
+    .join('&');
+}
+
+export interface SearchRequest {
+  query: string;
+  tags: string[];
+  filter: Filter | undefined;
+  pageSize: number;
+  includeDrafts: boolean;
+  orFilters: Filter[];
+}
+
+export function searchRequestToQueryParams(message: SearchRequest, prefix: string = ''): [string, string][] {
+  const params: [string, string][] = [];
+  if (message.query !== '') {
+    params.push([prefix + 'query', message.query]);
+  }
+  for (const v of message.tags ?? []) {
+    params.push([prefix + 'tags', v]);
+  }
+  if (message.filter !== undefined) {
+    params.push(...filterToQueryParams(message.filter, prefix + 'filter' + '.'));
+  }
+  if (message.pageSize !== 0) {
+    params.push([prefix + 'pageSize', String(message.pageSize)]);
+  }
+  if (message.includeDrafts === true) {
+    params.push([prefix + 'includeDrafts', String(message.includeDrafts)]);
+  }
+  return params;
+}
+
+export function searchRequestToQueryString(message: SearchRequest): string {
+  return searchRequestToQueryParams(message)
+    .map(([key, value]) => encodeURIComponent(key) + '=' + encodeURIComponent(value))
+    .join('&');
+}
+
+function createBaseFilter(): Filter {
+  return { status: 0, minScore: 0 };
+}
+
+export const Filter = {
+  fromJSON(object: any): Filter {
+    return {
+      status: isSet(object.status) ? statusFromJSON(object.status) : 0,
+      minScore: isSet(object.minScore) ? Number(object.minScore) : 0,
+    };
+  },
+
+  toJSON(message: Filter): unknown {
+    const obj: any = {};
+    message.status !== undefined && (obj.status = statusToJSON(message.status));
+    message.minScore !== undefined && (obj.minScore = Math.round(message.minScore));
+    return obj;
+  },
+};
+
+function createBaseSearchRequest(): SearchRequest {
+  return { query: '', tags: [], filter: undefined, pageSize: 0, includeDrafts: false, orFilters: [] };
+}
+
+export const SearchRequest = {
+  fromJSON(object: any): SearchRequest {
+    return {
+      query: isSet(object.query) ? String(object.query) : '',
+      tags: Array.isArray(object?.tags) ? object.tags.map((e: any) => String(e)) : [],
+      filter: isSet(object.filter) ? Filter.fromJSON(object.filter) : undefined,
+      pageSize: isSet(object.pageSize) ? Number(object.pageSize) : 0,
+      includeDrafts: isSet(object.includeDrafts) ? Boolean(object.includeDrafts) : false,
+      orFilters: Array.isArray(object?.orFilters) ? object.orFilters.map((e: any) => Filter.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: SearchRequest): unknown {
+    const obj: any = {};
+    message.query !== undefined && (obj.query = message.query);
+    if (message.tags) {
+      obj.tags = message.tags.map((e) => e);
+    } else {
+      obj.tags = [];
+    }
+    message.filter !== undefined && (obj.filter = message.filter ? Filter.toJSON(message.filter) : undefined);
+    message.pageSize !== undefined && (obj.pageSize = Math.round(message.pageSize));
+    message.includeDrafts !== undefined && (obj.includeDrafts = message.includeDrafts);
+    if (message.orFilters) {
+      obj.orFilters = message.orFilters.map((e) => (e ? Filter.toJSON(e) : undefined));
+    } else {
+      obj.orFilters = [];
+    }
+    return obj;
+  },
+};
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,39 @@
+import { groupFieldDescriptors, userFieldDescriptors } from './reflection';
+
+describe('reflection', () => {
+  it('describes the fields in declaration order', () => {
+    expect(userFieldDescriptors.map(({ name, number, type }) => [name, number, type])).toEqual([
+      ['userName', 1, 'string'],
+      ['scores', 2, 'int32'],
+      ['role', 3, 'enum'],
+      ['manager', 4, 'message'],
+      ['groups', 5, 'message'],
+      ['email', 6, 'string'],
+      ['phone', 7, 'uint64'],
+    ]);
+    expect(userFieldDescriptors.filter((field) => field.repeated).map((field) => field.name)).toEqual(['scores']);
+  });
+
+  it('lists the values of enum fields', () => {
+    expect(userFieldDescriptors[2].enumValues).toEqual([
+      { name: 'ROLE_UNKNOWN', number: 0 },
+      { name: 'ROLE_ADMIN', number: 1 },
+    ]);
+  });
+
+  it('refers to the descriptors of message types, including recursively', () => {
+    expect(userFieldDescriptors[3].messageType!()).toBe(userFieldDescriptors);
+    expect(userFieldDescriptors[4].messageType!()).toBe(groupFieldDescriptors);
+  });
+
+  it('describes maps by their values and key type', () => {
+    expect(userFieldDescriptors[4]).toMatchObject({ type: 'message', repeated: false, mapKey: 'string' });
+  });
+
+  it('names the oneof of union members', () => {
+    expect(userFieldDescriptors.filter((field) => field.oneof === 'contact').map((field) => field.name)).toEqual([
+      'email',
+      'phone',
+    ]);
+  });
+});
@@ -0,0 +1,87 @@
+/* eslint-disable */
+
+export const protobufPackage = 'reflection';
+
+export enum Role {
+  ROLE_UNKNOWN = 0,
+  ROLE_ADMIN = 1,
+  UNRECOGNIZED = -1,
+}
+
+export interface User {
+  userName: string;
+  scores: number[];
+  role: Role;
+  manager: User | undefined;
+  groups: { [key: string]: Group };
+  contact?: { $case: 'email'; email: string } | { $case: 'phone'; phone: number };
+}
+
+export const userFieldDescriptors: ReflectionField[] = [
+  { name: 'userName', number: 1, type: 'string', repeated: false },
+  { name: 'scores', number: 2, type: 'int32', repeated: true },
+  {
+    name: 'role',
+    number: 3,
+    type: 'enum',
+    repeated: false,
+    enumValues: [
+      { name: 'ROLE_UNKNOWN', number: 0 },
+      { name: 'ROLE_ADMIN', number: 1 },
+    ],
+  },
+  { name: 'manager', number: 4, type: 'message', repeated: false, messageType: () => userFieldDescriptors },
+  {
+    name: 'groups',
+    number: 5,
+    type: 'message',
+    repeated: false,
+    mapKey: 'string',
+    messageType: () => groupFieldDescriptors,
+  },
+  { name: 'email', number: 6, type: 'string', repeated: false, oneof: 'contact' },
+  { name: 'phone', number: 7, type: 'uint64', repeated: false, oneof: 'contact' },
+];
+
+export interface User_GroupsEntry {
+  key: string;
+  value: Group | undefined;
+}
+
+export interface Group {
+  title: string;
+}
+
+export const groupFieldDescriptors: ReflectionField[] = [{ name: 'title', number: 1, type: 'string', repeated: false }];
+
+interface ReflectionField {
+  /** The property of the field in the message's interface. */
+  name: string;
+  number: number;
+  type:
+    | 'double'
+    | 'float'
+    | 'int64'
+    | 'uint64'
+    | 'int32'
+    | 'fixed64'
+    | 'fixed32'
+    | 'bool'
+    | 'string'
+    | 'message'
+    | 'bytes'
+    | 'uint32'
+    | 'enum'
+    | 'sfixed32'
+    | 'sfixed64'
+    | 'sint32'
+    | 'sint64';
+  repeated: boolean;
+  /** The key type of a map field, whose other properties describe its values. */
+  mapKey?: ReflectionField['type'];
+  /** The property of the oneof=unions union that the field is a member of. */
+  oneof?: string;
+  /** The fields of the message type, which are looked up lazily as messages may refer to each other. */
+  messageType?: () => ReflectionField[];
+  enumValues?: Array<{ name: string; number: number }>;
+}
@@ -0,0 +1,40 @@
+import * as generated from './repeated-helpers';
+import {
+  addPlaylistRatings,
+  addPlaylistTracks,
+  Playlist,
+  removePlaylistTracksAt,
+  setPlaylistRatingsAt,
+  setPlaylistTracksAt,
+} from './repeated-helpers';
+
+describe('repeated-helpers', () => {
+  const playlist: Playlist = { name: 'mix', tracks: ['a', 'b', 'c'], ratings: [{ stars: 3 }], tags: {} };
+
+  it('appends to a repeated field', () => {
+    expect(addPlaylistTracks(playlist, 'd').tracks).toEqual(['a', 'b', 'c', 'd']);
+    expect(addPlaylistRatings(playlist, { stars: 5 }).ratings).toEqual([{ stars: 3 }, { stars: 5 }]);
+  });
+
+  it('removes the item at an index', () => {
+    expect(removePlaylistTracksAt(playlist, 1).tracks).toEqual(['a', 'c']);
+    expect(removePlaylistTracksAt(playlist, 5).tracks).toEqual(['a', 'b', 'c']);
+  });
+
+  it('replaces the item at an index', () => {
+    expect(setPlaylistTracksAt(playlist, 0, 'z').tracks).toEqual(['z', 'b', 'c']);
+    expect(setPlaylistRatingsAt(playlist, 0, { stars: 1 }).ratings).toEqual([{ stars: 1 }]);
+  });
+
+  it('returns copies and leaves the other fields alone', () => {
+    const updated = addPlaylistTracks(playlist, 'd');
+    expect(updated).not.toBe(playlist);
+    expect(playlist.tracks).toEqual(['a', 'b', 'c']);
+    expect(updated.name).toEqual('mix');
+    expect(updated.ratings).toBe(playlist.ratings);
+  });
+
+  it('skips map fields', () => {
+    expect('addPlaylistTags' in generated).toBe(false);
+  });
+});
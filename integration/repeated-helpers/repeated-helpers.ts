@@ -0,0 +1,43 @@
+/* eslint-disable */
+
+export const protobufPackage = 'repeatedhelpers';
+
+export interface Playlist {
+  name: string;
+  tracks: string[];
+  ratings: Rating[];
+  tags: { [key: string]: string };
+}
+
+export function addPlaylistTracks(message: Playlist, value: Playlist['tracks'][number]): Playlist {
+  return { ...message, tracks: [...message.tracks, value] };
+}
+
+export function removePlaylistTracksAt(message: Playlist, index: number): Playlist {
+  return { ...message, tracks: message.tracks.filter((_, i) => i !== index) };
+}
+
+export function setPlaylistTracksAt(message: Playlist, index: number, value: Playlist['tracks'][number]): Playlist {
+  return { ...message, tracks: message.tracks.map((v, i) => (i === index ? value : v)) };
+}
+
+export function addPlaylistRatings(message: Playlist, value: Playlist['ratings'][number]): Playlist {
+  return { ...message, ratings: [...message.ratings, value] };
+}
+
+export function removePlaylistRatingsAt(message: Playlist, index: number): Playlist {
+  return { ...message, ratings: message.ratings.filter((_, i) => i !== index) };
+}
+
+export function setPlaylistRatingsAt(message: Playlist, index: number, value: Playlist['ratings'][number]): Playlist {
+  return { ...message, ratings: message.ratings.map((v, i) => (i === index ? value : v)) };
+}
+
+export interface Playlist_TagsEntry {
+  key: string;
+  value: string;
+}
+
+export interface Rating {
+  stars: number;
+}
@@ -0,0 +1,38 @@
+import * as generated from './repeated-to-map';
+import { Config, configItemsFromMap, configItemsToMap } from './repeated-to-map';
+
+describe('repeated-to-map', () => {
+  it('converts key/value items to a map', () => {
+    const config: Config = {
+      items: [
+        { key: 'a', value: '1' },
+        { key: 'b', value: '2' },
+      ],
+      entities: [],
+    };
+    expect(configItemsToMap(config)).toEqual({ a: '1', b: '2' });
+  });
+
+  it('keeps the last item of a repeated key', () => {
+    const config: Config = {
+      items: [
+        { key: 'a', value: '1' },
+        { key: 'a', value: '2' },
+      ],
+      entities: [],
+    };
+    expect(configItemsToMap(config)).toEqual({ a: '2' });
+  });
+
+  it('converts a map to key/value items', () => {
+    expect(configItemsFromMap({ a: '1', b: '2' })).toEqual([
+      { key: 'a', value: '1' },
+      { key: 'b', value: '2' },
+    ]);
+    expect(configItemsFromMap({})).toEqual([]);
+  });
+
+  it('skips items with other fields than the key and value', () => {
+    expect(Object.keys(generated)).not.toContain('configEntitiesToMap');
+  });
+});
@@ -0,0 +1,31 @@
+/* eslint-disable */
+export const protobufPackage = 'kv';
+
+export interface KeyValue {
+  key: string;
+  value: string;
+}
+
+export interface Entity {
+  key: string;
+  value: number;
+  comment: string;
+}
+
+export interface Config {
+  items: KeyValue[];
+  entities: Entity[];
+}
+
+export function configItemsToMap(message: Config): { [key: string]: KeyValue['value'] } {
+  const map: { [key: string]: KeyValue['value'] } = {};
+  // Like for map fields, the last item of a key wins
+  for (const item of message.items) {
+    map[item.key] = item.value;
+  }
+  return map;
+}
+
+export function configItemsFromMap(map: { [key: string]: KeyValue['value'] }): KeyValue[] {
+  return Object.entries(map).map(([key, value]) => ({ key: key, value: value }));
+}
@@ -0,0 +1,29 @@
+import * as Long from 'long';
+import { __roundTripTestSample, __roundTripTestTag, Sample } from './round-trip-tests';
+
+describe('round-trip-tests', () => {
+  const sample: Sample = { data: new Uint8Array([1, 2]), id: Long.fromString('9007199254740993'), ratio: 0.5 };
+
+  it('round-trips bytes, Longs and oneofs', () => {
+    expect(__roundTripTestSample(sample)).toBe(true);
+    expect(__roundTripTestSample({ ...sample, choice: { $case: 'name', name: 'a' } })).toBe(true);
+    expect(__roundTripTestSample({ ...sample, choice: { $case: 'tag', tag: { label: 'b' } } })).toBe(true);
+  });
+
+  it('round-trips default values and NaN', () => {
+    expect(__roundTripTestSample({ data: new Uint8Array(), id: Long.ZERO, choice: undefined, ratio: 0 })).toBe(true);
+    expect(__roundTripTestSample({ ...sample, ratio: NaN })).toBe(true);
+    expect(__roundTripTestTag({ label: '' })).toBe(true);
+  });
+
+  it('detects values that are lost on the wire', () => {
+    // 0.1 isn't representable as a 32-bit float, so it doesn't come back the same
+    expect(__roundTripTestSample({ ...sample, ratio: 0.1 })).toBe(false);
+  });
+
+  it('keeps the case of oneof members with default values', () => {
+    // Oneof members are written even when they hold their default value
+    expect(__roundTripTestSample({ ...sample, choice: { $case: 'name', name: '' } })).toBe(true);
+    expect(__roundTripTestSample({ ...sample, choice: { $case: 'tag', tag: { label: '' } } })).toBe(true);
+  });
+});
@@ -0,0 +1,143 @@
+/* eslint-disable */
+import * as Long from 'long';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'roundtrip';
+
+export interface Sample {
+  data: Uint8Array;
+  id: Long;
+  choice?: { $case: 'name'; name: string } | { $case: 'tag'; tag: Tag };
+  ratio: number;
+}
+
+export interface Tag {
+  label: string;
+}
+
+function createBaseSample(): Sample {
+  return { data: new Uint8Array(), id: Long.ZERO, choice: undefined, ratio: 0 };
+}
+
+export const Sample = {
+  encode(message: Sample, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.data.length !== 0) {
+      writer.uint32(10).bytes(message.data);
+    }
+    if (!message.id.isZero()) {
+      writer.uint32(16).int64(message.id);
+    }
+    if (message.choice?.$case === 'name') {
+      writer.uint32(26).string(message.choice.name);
+    }
+    if (message.choice?.$case === 'tag') {
+      Tag.encode(message.choice.tag, writer.uint32(34).fork()).ldelim();
+    }
+    if (message.ratio !== 0) {
+      writer.uint32(45).float(message.ratio);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Sample {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSample();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.data = reader.bytes();
+          break;
+        case 2:
+          message.id = reader.int64() as Long;
+          break;
+        case 3:
+          message.choice = { $case: 'name', name: reader.string() };
+          break;
+        case 4:
+          message.choice = { $case: 'tag', tag: Tag.decode(reader, reader.uint32()) };
+          break;
+        case 5:
+          message.ratio = reader.float();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export function __roundTripTestSample(message: Sample): boolean {
+  return roundTripEquals(message, Sample.decode(Sample.encode(message).finish()));
+}
+
+function createBaseTag(): Tag {
+  return { label: '' };
+}
+
+export const Tag = {
+  encode(message: Tag, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.label !== '') {
+      writer.uint32(10).string(message.label);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Tag {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTag();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.label = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export function __roundTripTestTag(message: Tag): boolean {
+  return roundTripEquals(message, Tag.decode(Tag.encode(message).finish()));
+}
+
+// If you get a compile-error about 'Constructor<Long> and ... have no overlap',
+// add '--ts_proto_opt=esModuleInterop=true' as a flag when calling 'protoc'.
+if (_m0.util.Long !== Long) {
+  _m0.util.Long = Long as any;
+  _m0.configure();
+}
+
+function roundTripEquals(a: any, b: any): boolean {
+  if (a === b || (a !== a && b !== b)) {
+    return true;
+  } else if (typeof a !== 'object' || typeof b !== 'object' || a === null || b === null) {
+    return false;
+  } else if (ArrayBuffer.isView(a) && ArrayBuffer.isView(b)) {
+    const x = new Uint8Array(a.buffer, a.byteOffset, a.byteLength);
+    const y = new Uint8Array(b.buffer, b.byteOffset, b.byteLength);
+    return x.length === y.length && x.every((v, i) => v === y[i]);
+  } else if (a instanceof Date && b instanceof Date) {
+    return a.getTime() === b.getTime();
+  } else if (typeof a.low === 'number' && typeof a.high === 'number') {
+    return a.low === b.low && a.high === b.high;
+  } else if (a instanceof Map && b instanceof Map) {
+    // Keys that are Longs aren't found by `has`, so the entries are matched by their values
+    const entries = [...b];
+    const has = ([k, v]: [any, any]) =>
+      entries.some(([k2, v2]) => roundTripEquals(k, k2) && roundTripEquals(v, v2));
+    return a.size === b.size && [...a].every(has);
+  } else if (Array.isArray(a) !== Array.isArray(b)) {
+    return false;
+  }
+  const keys = new Set([...Object.keys(a), ...Object.keys(b)]);
+  return [...keys].every((key) => key === '_unknownFields' || roundTripEquals(a[key], b[key]));
+}
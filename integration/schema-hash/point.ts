@@ -0,0 +1,92 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = '';
+
+export interface Point {
+  lat: number;
+  lng: number;
+}
+
+export const POINT_SCHEMA_HASH = '5b9969a80a49822c';
+
+export interface Area {
+  nw: Point | undefined;
+  se: Point | undefined;
+}
+
+export const AREA_SCHEMA_HASH = 'f534d9b7401569ae';
+
+function createBasePoint(): Point {
+  return { lat: 0, lng: 0 };
+}
+
+export const Point = {
+  encode(message: Point, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.lat !== 0) {
+      writer.uint32(9).double(message.lat);
+    }
+    if (message.lng !== 0) {
+      writer.uint32(17).double(message.lng);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Point {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePoint();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.lat = reader.double();
+          break;
+        case 2:
+          message.lng = reader.double();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseArea(): Area {
+  return { nw: undefined, se: undefined };
+}
+
+export const Area = {
+  encode(message: Area, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.nw !== undefined) {
+      Point.encode(message.nw, writer.uint32(10).fork()).ldelim();
+    }
+    if (message.se !== undefined) {
+      Point.encode(message.se, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Area {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseArea();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.nw = Point.decode(reader, reader.uint32());
+          break;
+        case 2:
+          message.se = Point.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
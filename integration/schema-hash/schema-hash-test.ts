@@ -0,0 +1,14 @@
+import { AREA_SCHEMA_HASH, POINT_SCHEMA_HASH } from './point';
+
+describe('schema-hash', () => {
+  it('outputs a hash per message', () => {
+    expect(POINT_SCHEMA_HASH).toMatch(/^[0-9a-f]{16}$/);
+    expect(AREA_SCHEMA_HASH).toMatch(/^[0-9a-f]{16}$/);
+    expect(AREA_SCHEMA_HASH).not.toEqual(POINT_SCHEMA_HASH);
+  });
+
+  it('keeps the hash stable across generations of the same schema', () => {
+    expect(POINT_SCHEMA_HASH).toEqual('5b9969a80a49822c');
+    expect(AREA_SCHEMA_HASH).toEqual('f534d9b7401569ae');
+  });
+});
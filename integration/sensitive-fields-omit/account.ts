@@ -0,0 +1,203 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'accounts';
+
+export interface Account {
+  name: string;
+  email: string;
+  pin: number;
+  recoveryCodes: string[];
+  credentials: Credentials | undefined;
+}
+
+export interface Credentials {
+  token: string;
+  scope: string;
+}
+
+function createBaseAccount(): Account {
+  return { name: '', email: '', pin: 0, recoveryCodes: [], credentials: undefined };
+}
+
+export const Account = {
+  encode(message: Account, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.email !== '') {
+      writer.uint32(18).string(message.email);
+    }
+    if (message.pin !== 0) {
+      writer.uint32(24).int32(message.pin);
+    }
+    for (const v of message.recoveryCodes) {
+      writer.uint32(34).string(v!);
+    }
+    if (message.credentials !== undefined) {
+      Credentials.encode(message.credentials, writer.uint32(42).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Account {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAccount();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        case 2:
+          message.email = reader.string();
+          break;
+        case 3:
+          message.pin = reader.int32();
+          break;
+        case 4:
+          message.recoveryCodes.push(reader.string());
+          break;
+        case 5:
+          message.credentials = Credentials.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Account {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+      email: isSet(object.email) ? String(object.email) : '',
+      pin: isSet(object.pin) ? Number(object.pin) : 0,
+      recoveryCodes: Array.isArray(object?.recoveryCodes) ? object.recoveryCodes.map((e: any) => String(e)) : [],
+      credentials: isSet(object.credentials) ? Credentials.fromJSON(object.credentials) : undefined,
+    };
+  },
+
+  toJSON(message: Account): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.credentials !== undefined &&
+      (obj.credentials = message.credentials ? Credentials.toJSON(message.credentials) : undefined);
+    return obj;
+  },
+
+  toJSONFull(message: Account): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.email !== undefined && (obj.email = message.email);
+    message.pin !== undefined && (obj.pin = Math.round(message.pin));
+    if (message.recoveryCodes) {
+      obj.recoveryCodes = message.recoveryCodes.map((e) => e);
+    } else {
+      obj.recoveryCodes = [];
+    }
+    message.credentials !== undefined &&
+      (obj.credentials = message.credentials ? Credentials.toJSONFull(message.credentials) : undefined);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Account>, I>>(object: I): Account {
+    const message = createBaseAccount();
+    message.name = object.name ?? '';
+    message.email = object.email ?? '';
+    message.pin = object.pin ?? 0;
+    message.recoveryCodes = object.recoveryCodes?.map((e) => e) || [];
+    message.credentials =
+      object.credentials !== undefined && object.credentials !== null
+        ? Credentials.fromPartial(object.credentials)
+        : undefined;
+    return message;
+  },
+};
+
+function createBaseCredentials(): Credentials {
+  return { token: '', scope: '' };
+}
+
+export const Credentials = {
+  encode(message: Credentials, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.token !== '') {
+      writer.uint32(10).string(message.token);
+    }
+    if (message.scope !== '') {
+      writer.uint32(18).string(message.scope);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Credentials {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCredentials();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.token = reader.string();
+          break;
+        case 2:
+          message.scope = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Credentials {
+    return {
+      token: isSet(object.token) ? String(object.token) : '',
+      scope: isSet(object.scope) ? String(object.scope) : '',
+    };
+  },
+
+  toJSON(message: Credentials): unknown {
+    const obj: any = {};
+    message.scope !== undefined && (obj.scope = message.scope);
+    return obj;
+  },
+
+  toJSONFull(message: Credentials): unknown {
+    const obj: any = {};
+    message.token !== undefined && (obj.token = message.token);
+    message.scope !== undefined && (obj.scope = message.scope);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Credentials>, I>>(object: I): Credentials {
+    const message = createBaseCredentials();
+    message.token = object.token ?? '';
+    message.scope = object.scope ?? '';
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,28 @@
+import { Account } from './account';
+
+const account: Account = {
+  name: 'ada',
+  email: 'ada@example.com',
+  pin: 1234,
+  recoveryCodes: ['a1', 'b2'],
+  credentials: { token: 'secret', scope: 'read' },
+};
+
+describe('sensitive-fields-omit', () => {
+  it('leaves the sensitive fields out of toJSON, including nested ones', () => {
+    const json = Account.toJSON(account);
+    expect(json).toEqual({ name: 'ada', credentials: { scope: 'read' } });
+    expect(JSON.stringify(json)).not.toContain('secret');
+  });
+
+  it('includes every field in toJSONFull, recursively', () => {
+    expect(Account.toJSONFull(account)).toEqual({
+      name: 'ada',
+      email: 'ada@example.com',
+      pin: 1234,
+      recoveryCodes: ['a1', 'b2'],
+      credentials: { token: 'secret', scope: 'read' },
+    });
+    expect(Account.fromJSON(Account.toJSONFull(account))).toEqual(account);
+  });
+});
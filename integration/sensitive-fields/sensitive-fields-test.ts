@@ -0,0 +1,41 @@
+import { Account } from './account';
+
+const account: Account = {
+  name: 'ada',
+  email: 'ada@example.com',
+  pin: 1234,
+  recoveryCodes: ['a1', 'b2'],
+  credentials: { token: 'secret', scope: 'read' },
+};
+
+describe('sensitive-fields', () => {
+  it('redacts the set sensitive fields in toJSON, including nested ones', () => {
+    expect(Account.toJSON(account)).toEqual({
+      name: 'ada',
+      email: '[redacted]',
+      pin: '[redacted]',
+      recoveryCodes: '[redacted]',
+      credentials: { token: '[redacted]', scope: 'read' },
+    });
+  });
+
+  it('leaves out sensitive fields at their default value', () => {
+    const json = Account.toJSON(Account.fromPartial({ name: 'ada', credentials: { scope: 'read' } }));
+    expect(json).toEqual({ name: 'ada', credentials: { scope: 'read' } });
+  });
+
+  it('includes every field in toJSONFull, recursively', () => {
+    expect(Account.toJSONFull(account)).toEqual({
+      name: 'ada',
+      email: 'ada@example.com',
+      pin: 1234,
+      recoveryCodes: ['a1', 'b2'],
+      credentials: { token: 'secret', scope: 'read' },
+    });
+    expect(Account.fromJSON(Account.toJSONFull(account))).toEqual(account);
+  });
+
+  it('still encodes the sensitive fields', () => {
+    expect(Account.decode(Account.encode(account).finish())).toEqual(account);
+  });
+});
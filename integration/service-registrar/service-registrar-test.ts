@@ -0,0 +1,27 @@
+import { Server } from '@grpc/grpc-js';
+import { GreeterServer, GreeterService, HealthServer, HealthService, registerAllServices } from './services';
+
+describe('service-registrar', () => {
+  const greeter: GreeterServer = {
+    sayHello: (call, callback) => callback(null, { message: `hello ${call.request.name}` }),
+  };
+  const health: HealthServer = {
+    check: (call, callback) => callback(null, { serving: true }),
+  };
+
+  it('adds every service of the file to the server', () => {
+    const server = { addService: jest.fn() };
+    registerAllServices(server as any as Server, { greeter, health });
+    expect(server.addService.mock.calls).toEqual([
+      [GreeterService, greeter],
+      [HealthService, health],
+    ]);
+  });
+
+  it('registers the services with a real grpc-js server', () => {
+    const server = new Server();
+    expect(() => registerAllServices(server, { greeter, health })).not.toThrow();
+    // grpc-js rejects adding a service twice
+    expect(() => server.addService(GreeterService, greeter)).toThrow();
+  });
+});
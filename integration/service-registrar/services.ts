@@ -0,0 +1,242 @@
+/* eslint-disable */
+import {
+  makeGenericClientConstructor,
+  ChannelCredentials,
+  ChannelOptions,
+  UntypedServiceImplementation,
+  handleUnaryCall,
+  Client,
+  ClientUnaryCall,
+  Metadata,
+  CallOptions,
+  ServiceError,
+  Server,
+} from '@grpc/grpc-js';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'registrar';
+
+export interface HelloRequest {
+  name: string;
+}
+
+export interface HelloReply {
+  message: string;
+}
+
+export interface CheckRequest {
+  service: string;
+}
+
+export interface CheckResponse {
+  serving: boolean;
+}
+
+function createBaseHelloRequest(): HelloRequest {
+  return { name: '' };
+}
+
+export const HelloRequest = {
+  encode(message: HelloRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): HelloRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseHelloRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseHelloReply(): HelloReply {
+  return { message: '' };
+}
+
+export const HelloReply = {
+  encode(message: HelloReply, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.message !== '') {
+      writer.uint32(10).string(message.message);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): HelloReply {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseHelloReply();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.message = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseCheckRequest(): CheckRequest {
+  return { service: '' };
+}
+
+export const CheckRequest = {
+  encode(message: CheckRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.service !== '') {
+      writer.uint32(10).string(message.service);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CheckRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCheckRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.service = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+function createBaseCheckResponse(): CheckResponse {
+  return { serving: false };
+}
+
+export const CheckResponse = {
+  encode(message: CheckResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.serving === true) {
+      writer.uint32(8).bool(message.serving);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CheckResponse {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCheckResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.serving = reader.bool();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+};
+
+export type GreeterService = typeof GreeterService;
+export const GreeterService = {
+  sayHello: {
+    path: '/registrar.Greeter/SayHello',
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: HelloRequest) => Buffer.from(HelloRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => HelloRequest.decode(value),
+    responseSerialize: (value: HelloReply) => Buffer.from(HelloReply.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => HelloReply.decode(value),
+  },
+} as const;
+
+export interface GreeterServer extends UntypedServiceImplementation {
+  sayHello: handleUnaryCall<HelloRequest, HelloReply>;
+}
+
+export interface GreeterClient extends Client {
+  sayHello(
+    request: HelloRequest,
+    callback: (error: ServiceError | null, response: HelloReply) => void
+  ): ClientUnaryCall;
+  sayHello(
+    request: HelloRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: HelloReply) => void
+  ): ClientUnaryCall;
+  sayHello(
+    request: HelloRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: HelloReply) => void
+  ): ClientUnaryCall;
+}
+
+export const GreeterClient = makeGenericClientConstructor(GreeterService, 'registrar.Greeter') as unknown as {
+  new (address: string, credentials: ChannelCredentials, options?: Partial<ChannelOptions>): GreeterClient;
+  service: typeof GreeterService;
+};
+
+export type HealthService = typeof HealthService;
+export const HealthService = {
+  check: {
+    path: '/registrar.Health/Check',
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CheckRequest) => Buffer.from(CheckRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => CheckRequest.decode(value),
+    responseSerialize: (value: CheckResponse) => Buffer.from(CheckResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => CheckResponse.decode(value),
+  },
+} as const;
+
+export interface HealthServer extends UntypedServiceImplementation {
+  check: handleUnaryCall<CheckRequest, CheckResponse>;
+}
+
+export interface HealthClient extends Client {
+  check(
+    request: CheckRequest,
+    callback: (error: ServiceError | null, response: CheckResponse) => void
+  ): ClientUnaryCall;
+  check(
+    request: CheckRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: CheckResponse) => void
+  ): ClientUnaryCall;
+  check(
+    request: CheckRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: CheckResponse) => void
+  ): ClientUnaryCall;
+}
+
+export const HealthClient = makeGenericClientConstructor(HealthService, 'registrar.Health') as unknown as {
+  new (address: string, credentials: ChannelCredentials, options?: Partial<ChannelOptions>): HealthClient;
+  service: typeof HealthService;
+};
+
+export function registerAllServices(server: Server, impls: { greeter: GreeterServer; health: HealthServer }): void {
+  server.addService(GreeterService, impls.greeter);
+  server.addService(HealthService, impls.health);
+}
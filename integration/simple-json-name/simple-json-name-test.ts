@@ -22,4 +22,21 @@ describe('simple', () => {
     expect(typeof simple2.age).toBe('number');
     expect(typeof simple2.createdAt).toBe(typeof new Date());
   });
+
+  it('accepts the original proto field names in fromJSON', () => {
+    const simple = Simple.fromJSON({
+      name: 'test',
+      age: 10,
+      created_at: '2020-01-01T00:00:00.000Z',
+      hyphenList: ['a'],
+    });
+    expect(simple.name).toBe('test');
+    expect(simple.age).toBe(10);
+    expect(simple.createdAt).toEqual(new Date('2020-01-01T00:00:00.000Z'));
+    expect(simple.hyphenList).toEqual(['a']);
+  });
+
+  it('prefers the json names over the original names', () => {
+    expect(Simple.fromJSON({ other_name: 'json', name: 'original' }).name).toBe('json');
+  });
 });
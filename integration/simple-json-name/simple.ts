@@ -98,14 +98,20 @@ export const Simple = {
 
   fromJSON(object: any): Simple {
     return {
-      name: isSet(object.other_name) ? String(object.other_name) : '',
-      age: isSet(object.other_age) ? Number(object.other_age) : undefined,
-      createdAt: isSet(object.createdAt) ? fromJsonTimestamp(object.createdAt) : undefined,
-      hyphen: isSet(object['hyphened-name']) ? String(object['hyphened-name']) : '',
-      spaces: isSet(object['name with spaces']) ? String(object['name with spaces']) : '',
-      dollarStart: isSet(object.$dollar) ? String(object.$dollar) : '',
-      dollarEnd: isSet(object.dollar$) ? String(object.dollar$) : '',
-      hyphenList: Array.isArray(object?.['hyphen-list']) ? object['hyphen-list'].map((e: any) => String(e)) : [],
+      name: isSet(object.other_name ?? object.name) ? String(object.other_name ?? object.name) : '',
+      age: isSet(object.other_age ?? object.age) ? Number(object.other_age ?? object.age) : undefined,
+      createdAt: isSet(object.createdAt ?? object.created_at)
+        ? fromJsonTimestamp(object.createdAt ?? object.created_at)
+        : undefined,
+      hyphen: isSet(object['hyphened-name'] ?? object.hyphen) ? String(object['hyphened-name'] ?? object.hyphen) : '',
+      spaces: isSet(object['name with spaces'] ?? object.spaces)
+        ? String(object['name with spaces'] ?? object.spaces)
+        : '',
+      dollarStart: isSet(object.$dollar ?? object.dollarStart) ? String(object.$dollar ?? object.dollarStart) : '',
+      dollarEnd: isSet(object.dollar$ ?? object.dollarEnd) ? String(object.dollar$ ?? object.dollarEnd) : '',
+      hyphenList: Array.isArray(object?.['hyphen-list'] ?? object?.hyphenList)
+        ? (object['hyphen-list'] ?? object.hyphenList).map((e: any) => String(e))
+        : [],
     };
   },
 
@@ -158,8 +164,8 @@ export type Exact<P, I extends P> = P extends Builtin
   : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
 
 function toTimestamp(date: Date): Timestamp {
-  const seconds = date.getTime() / 1_000;
-  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  const seconds = Math.floor(date.getTime() / 1_000);
+  const nanos = (((date.getTime() % 1_000) + 1_000) % 1_000) * 1_000_000;
   return { seconds, nanos };
 }
 
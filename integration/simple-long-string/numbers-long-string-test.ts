@@ -1,5 +1,6 @@
 import { Reader } from 'protobufjs';
 import * as Long from 'long';
+import { deserialize, serialize } from 'v8';
 import { Numbers } from './simple';
 import { simple as pbjs, google } from './pbjs';
 import INumbers = pbjs.INumbers;
@@ -31,6 +32,26 @@ describe('number', () => {
     expect(simple.timestamp).toEqual(new Date('1970-02-03T04:05:06.0071Z'))
   });
 
+  it('can be passed through structuredClone', () => {
+    const n1 = Numbers.decode(
+      Numbers.encode(
+        Numbers.fromPartial({
+          int64: '9223372036854775807',
+          uint64: '18446744073709551615',
+          sint64: '-8',
+          fixed64: '10',
+          sfixed64: '-12',
+          guint64: '13',
+          timestamp: new Date('1970-02-03T04:05:06.007Z'),
+        })
+      ).finish()
+    );
+    // Unlike Long instances, the strings survive the structured clone algorithm as they are
+    const n2 = deserialize(serialize(n1));
+    expect(n2).toEqual(n1);
+    expect(Numbers.encode(n2).finish()).toEqual(Numbers.encode(n1).finish());
+  });
+
   it('can decode', () => {
     const s1: INumbers = {
       double: 1,
@@ -161,4 +182,14 @@ describe('number', () => {
   }
   `);
   });
+
+  it('writes 64-bit values and wrappers as strings in toJSON', () => {
+    const numbers = Numbers.fromPartial({ int64: '9007199254740993', guint64: '18446744073709551615' });
+    const json = Numbers.toJSON(numbers) as any;
+    expect(json.int64).toEqual('9007199254740993');
+    expect(json.uint64).toEqual('0');
+    expect(json.guint64).toEqual('18446744073709551615');
+    expect(Numbers.toJSON(Numbers.fromPartial({}))).not.toHaveProperty('guint64');
+    expect(Numbers.fromJSON(JSON.parse(JSON.stringify(json)))).toEqual(numbers);
+  });
 });
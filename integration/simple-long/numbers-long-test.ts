@@ -1,6 +1,6 @@
 import { Reader } from 'protobufjs';
 import * as Long from 'long';
-import { Numbers } from './simple';
+import { Numbers, SimpleWithWrappers } from './simple';
 import { simple as pbjs } from './pbjs';
 import INumbers = pbjs.INumbers;
 import PbNumbers = pbjs.Numbers;
@@ -133,4 +133,20 @@ describe('number', () => {
       }
     `);
   });
+
+  it('writes 64-bit values and wrappers as strings in toJSON', () => {
+    const numbers = Numbers.fromPartial({
+      int64: Long.fromString('9007199254740993'),
+      uint64: Long.fromString('18446744073709551615', true),
+    });
+    const json = Numbers.toJSON(numbers) as any;
+    expect(json.int64).toEqual('9007199254740993');
+    expect(json.uint64).toEqual('18446744073709551615');
+    expect(json.sint64).toEqual('0');
+
+    const wrappers = SimpleWithWrappers.toJSON({ ...SimpleWithWrappers.fromPartial({}), bananas: Long.fromNumber(-2) });
+    expect(wrappers).toMatchObject({ bananas: '-2' });
+    expect(SimpleWithWrappers.toJSON(SimpleWithWrappers.fromPartial({}))).not.toHaveProperty('bananas');
+    expect(SimpleWithWrappers.fromJSON(JSON.parse(JSON.stringify(wrappers))).bananas).toEqual(Long.fromNumber(-2));
+  });
 });
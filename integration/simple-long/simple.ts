@@ -129,7 +129,7 @@ export const SimpleWithWrappers = {
     message.name !== undefined && (obj.name = message.name);
     message.age !== undefined && (obj.age = message.age);
     message.enabled !== undefined && (obj.enabled = message.enabled);
-    message.bananas !== undefined && (obj.bananas = message.bananas);
+    message.bananas !== undefined && (obj.bananas = message.bananas?.toString());
     if (message.coins) {
       obj.coins = message.coins.map((e) => e);
     } else {
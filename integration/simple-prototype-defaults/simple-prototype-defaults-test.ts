@@ -1,4 +1,4 @@
-import { Simple } from './simple';
+import { Simple, SimpleWithWrappers } from './simple';
 
 describe('simple', () => {
   it('generates types correctly', () => {
@@ -21,4 +21,14 @@ describe('simple', () => {
     expect(Object.keys(decodedWithOne).includes('age')).toBeTruthy();
     expect(decodedWithOne.hasOwnProperty('age')).toBeTruthy();
   });
+  it('round-trips bytes and BytesValue wrappers as base64 strings', () => {
+    const wrappers = SimpleWithWrappers.fromPartial({ id: new Uint8Array([1, 2, 3, 4]) });
+    const json = SimpleWithWrappers.toJSON(wrappers) as any;
+    expect(json.id).toEqual('AQIDBA==');
+    expect(SimpleWithWrappers.fromJSON(json).id).toEqual(new Uint8Array([1, 2, 3, 4]));
+    expect(SimpleWithWrappers.fromJSON(wrappers).id).toEqual(new Uint8Array([1, 2, 3, 4]));
+
+    const simple = Simple.fromPartial({ blob: new Uint8Array([5]), blobs: [new Uint8Array([6])] });
+    expect(Simple.fromJSON(Simple.toJSON(simple))).toMatchObject({ blob: simple.blob, blobs: simple.blobs });
+  });
 });
@@ -441,9 +441,15 @@ export const Simple = {
       snacks: Array.isArray(object?.snacks) ? object.snacks.map((e: any) => String(e)) : [],
       oldStates: Array.isArray(object?.oldStates) ? object.oldStates.map((e: any) => stateEnumFromJSON(e)) : [],
       thing: isSet(object.thing) ? ImportedThing.fromJSON(object.thing) : undefined,
-      blobs: Array.isArray(object?.blobs) ? object.blobs.map((e: any) => bytesFromBase64(e)) : [],
+      blobs: Array.isArray(object?.blobs)
+        ? object.blobs.map((e: any) => (e instanceof Uint8Array ? e : bytesFromBase64(e)))
+        : [],
       birthday: isSet(object.birthday) ? DateMessage.fromJSON(object.birthday) : undefined,
-      blob: isSet(object.blob) ? bytesFromBase64(object.blob) : new Uint8Array(),
+      blob: isSet(object.blob)
+        ? object.blob instanceof Uint8Array
+          ? object.blob
+          : bytesFromBase64(object.blob)
+        : new Uint8Array(),
     };
   },
 
@@ -875,7 +881,7 @@ export const SimpleWithWrappers = {
       enabled: isSet(object.enabled) ? Boolean(object.enabled) : undefined,
       coins: Array.isArray(object?.coins) ? object.coins.map((e: any) => Number(e)) : [],
       snacks: Array.isArray(object?.snacks) ? object.snacks.map((e: any) => String(e)) : [],
-      id: isSet(object.id) ? new Uint8Array(object.id) : undefined,
+      id: isSet(object.id) ? (object.id instanceof Uint8Array ? object.id : bytesFromBase64(object.id)) : undefined,
     };
   },
 
@@ -894,7 +900,7 @@ export const SimpleWithWrappers = {
     } else {
       obj.snacks = [];
     }
-    message.id !== undefined && (obj.id = message.id);
+    message.id !== undefined && (obj.id = base64FromBytes(message.id));
     return obj;
   },
 
@@ -1084,7 +1090,7 @@ export const SimpleWithMap = {
         : {},
       mapOfBytes: isObject(object.mapOfBytes)
         ? Object.entries(object.mapOfBytes).reduce<{ [key: string]: Uint8Array }>((acc, [key, value]) => {
-            acc[key] = bytesFromBase64(value as string);
+            acc[key] = value instanceof Uint8Array ? value : bytesFromBase64(value as string);
             return acc;
           }, {})
         : {},
@@ -1498,7 +1504,11 @@ export const SimpleWithMap_MapOfBytesEntry = {
   fromJSON(object: any): SimpleWithMap_MapOfBytesEntry {
     return {
       key: isSet(object.key) ? String(object.key) : '',
-      value: isSet(object.value) ? bytesFromBase64(object.value) : new Uint8Array(),
+      value: isSet(object.value)
+        ? object.value instanceof Uint8Array
+          ? object.value
+          : bytesFromBase64(object.value)
+        : new Uint8Array(),
     };
   },
 
@@ -1815,7 +1825,7 @@ export const SimpleWithMapOfEnums = {
     return {
       enumsById: isObject(object.enumsById)
         ? Object.entries(object.enumsById).reduce<{ [key: number]: StateEnum }>((acc, [key, value]) => {
-            acc[Number(key)] = stateEnumFromJSON(value);
+            acc[Number(key)] = isSet(value) ? stateEnumFromJSON(value) : 0;
             return acc;
           }, {})
         : {},
@@ -2396,8 +2406,8 @@ export type Exact<P, I extends P> = P extends Builtin
   : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
 
 function toTimestamp(date: Date): Timestamp {
-  const seconds = date.getTime() / 1_000;
-  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  const seconds = Math.floor(date.getTime() / 1_000);
+  const nanos = (((date.getTime() % 1_000) + 1_000) % 1_000) * 1_000_000;
   return { seconds, nanos };
 }
 
@@ -306,26 +306,11 @@ export const Value = {
   },
 
   fromJSON(object: any): Value {
-    return {
-      null_value: isSet(object.null_value) ? nullValueFromJSON(object.null_value) : undefined,
-      number_value: isSet(object.number_value) ? Number(object.number_value) : undefined,
-      string_value: isSet(object.string_value) ? String(object.string_value) : undefined,
-      bool_value: isSet(object.bool_value) ? Boolean(object.bool_value) : undefined,
-      struct_value: isObject(object.struct_value) ? object.struct_value : undefined,
-      list_value: Array.isArray(object.list_value) ? [...object.list_value] : undefined,
-    };
+    return Value.wrap(object);
   },
 
   toJSON(message: Value): unknown {
-    const obj: any = {};
-    message.null_value !== undefined &&
-      (obj.null_value = message.null_value !== undefined ? nullValueToJSON(message.null_value) : undefined);
-    message.number_value !== undefined && (obj.number_value = message.number_value);
-    message.string_value !== undefined && (obj.string_value = message.string_value);
-    message.bool_value !== undefined && (obj.bool_value = message.bool_value);
-    message.struct_value !== undefined && (obj.struct_value = message.struct_value);
-    message.list_value !== undefined && (obj.list_value = message.list_value);
-    return obj;
+    return Value.unwrap(message);
   },
 
   fromPartial<I extends Exact<DeepPartial<Value>, I>>(object: I): Value {
@@ -144,7 +144,7 @@ export const Simple = {
       nullValue: isSet(object.nullValue) ? nullValueFromJSON(object.nullValue) : NullValue.NULL_VALUE,
       stateMap: isObject(object.stateMap)
         ? Object.entries(object.stateMap).reduce<{ [key: string]: StateEnum }>((acc, [key, value]) => {
-            acc[key] = stateEnumFromJSON(value);
+            acc[key] = isSet(value) ? stateEnumFromJSON(value) : StateEnum.UNKNOWN;
             return acc;
           }, {})
         : {},
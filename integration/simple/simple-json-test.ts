@@ -62,6 +62,11 @@ describe('simple json', () => {
     expect(s2.child!.type).toEqual(Child_Type.GOOD);
   });
 
+  it('defaults omitted enums to their zero value', () => {
+    expect(Simple.fromJSON({ name: 'a' }).state).toEqual(StateEnum.UNKNOWN);
+    expect(Simple.fromJSON({ child: {} }).child!.type).toEqual(Child_Type.UNKNOWN);
+  });
+
   it('decodes a null list as empty', () => {
     const s1 = { grandChildren: null };
     expect(Simple.fromJSON(s1).grandChildren).toEqual([]);
@@ -175,6 +180,17 @@ describe('simple json', () => {
     expect(s2.createdAt).toEqual(d);
   });
 
+  it('keeps the bytes of an already-decoded message', () => {
+    const s1 = Simple.fromPartial({
+      blob: new Uint8Array([1, 2, 255]),
+      blobs: [new Uint8Array([3]), new Uint8Array()],
+    });
+    const s2 = Simple.fromJSON(s1);
+    expect(s2.blob).toEqual(new Uint8Array([1, 2, 255]));
+    expect(s2.blobs).toEqual([new Uint8Array([3]), new Uint8Array()]);
+    expect(SimpleWithWrappers.fromJSON({ id: new Uint8Array([4, 5]) }).id).toEqual(new Uint8Array([4, 5]));
+  });
+
   it('decodes maps', () => {
     const s1 = {
       entitiesById: { '1': { id: '1' } },
@@ -288,6 +304,26 @@ describe('simple json', () => {
     `);
   });
 
+  it('round-trips maps of bytes', () => {
+    const s1 = SimpleWithMap.fromPartial({
+      mapOfBytes: {
+        a: new Uint8Array([1, 2]),
+        b: new Uint8Array([]),
+      },
+    });
+    expect(SimpleWithMap.fromJSON(SimpleWithMap.toJSON(s1))).toEqual(s1);
+    expect(SimpleWithMap.fromJSON(s1).mapOfBytes).toEqual(s1.mapOfBytes);
+  });
+
+  it('keeps the insertion order of string map keys', () => {
+    const s1 = SimpleWithMap.fromPartial({ nameLookup: { zebra: 'z', apple: 'a', mango: 'm' } });
+    const json = SimpleWithMap.toJSON(s1) as any;
+    expect(Object.keys(json.nameLookup)).toEqual(['zebra', 'apple', 'mango']);
+    expect(Object.keys(SimpleWithMap.fromJSON(json).nameLookup)).toEqual(['zebra', 'apple', 'mango']);
+    const decoded = SimpleWithMap.decode(SimpleWithMap.encode(s1).finish());
+    expect(Object.keys(decoded.nameLookup)).toEqual(['zebra', 'apple', 'mango']);
+  });
+
   it('can encode json', () => {
     const s1: Simple = {
       name: 'asdf',
@@ -394,12 +430,7 @@ describe('simple json', () => {
           2,
         ],
         "enabled": true,
-        "id": Uint8Array [
-          1,
-          2,
-          3,
-          4,
-        ],
+        "id": "AQIDBA==",
         "name": "first",
         "snacks": Array [
           "a",
@@ -409,6 +440,15 @@ describe('simple json', () => {
     `);
   });
 
+  it('round-trips BytesValue wrappers as base64 strings', () => {
+    const s1 = SimpleWithWrappers.fromPartial({ id: new Uint8Array([1, 2, 3, 4]) });
+    const json = SimpleWithWrappers.toJSON(s1) as any;
+    expect(json.id).toEqual('AQIDBA==');
+    expect(SimpleWithWrappers.fromJSON(json)).toEqual(s1);
+    expect(SimpleWithWrappers.fromJSON({ id: null }).id).toBeUndefined();
+    expect(SimpleWithWrappers.toJSON(SimpleWithWrappers.fromPartial({}))).not.toHaveProperty('id');
+  });
+
   it('can encode null value wrappers', () => {
     const s1: SimpleWithWrappers = {
       name: undefined,
@@ -1,4 +1,5 @@
 import { Reader } from 'protobufjs';
+import { deserialize, serialize } from 'v8';
 import {
   protobufPackage,
   Child_Type,
@@ -76,6 +77,40 @@ describe('simple', () => {
     });
   });
 
+  it('can be passed through structuredClone', () => {
+    const s1 = Simple.decode(
+      Simple.encode(
+        Simple.fromPartial({
+          name: 'asdf',
+          age: 1,
+          createdAt: jan1,
+          child: { name: 'child', type: Child_Type.GOOD },
+          state: StateEnum.ON,
+          grandChildren: [{ name: 'grand1', type: Child_Type.BAD }],
+          coins: [2, 4, 6],
+          snacks: ['a', 'b'],
+          oldStates: [StateEnum.ON, StateEnum.OFF],
+          thing: { createdAt: jan1 },
+          blobs: [new Uint8Array([1, 2])],
+          birthday: { year: 2000, month: 1, day: 2 },
+          blob: new Uint8Array([3]),
+        })
+      ).finish()
+    );
+    // v8's serializer implements the same structured clone algorithm as `structuredClone` and `postMessage`
+    const s2 = deserialize(serialize(s1));
+    expect(s2).toEqual(s1);
+    expect(Simple.encode(s2).finish()).toEqual(Simple.encode(s1).finish());
+
+    const m1 = SimpleWithMap.fromPartial({
+      entitiesById: { 1: { id: 1 } },
+      nameLookup: { a: 'b' },
+      mapOfTimestamps: { t: jan1 },
+      mapOfBytes: { b: new Uint8Array([4]) },
+    });
+    expect(deserialize(serialize(m1))).toEqual(m1);
+  });
+
   it('can encode', () => {
     const s1: Simple = {
       name: 'asdf',
@@ -331,6 +366,15 @@ describe('simple', () => {
     });
   });
 
+  it('has fromPartial fill in defaults of repeated message elements', () => {
+    const s1 = Simple.fromPartial({ grandChildren: [{ name: 'a' }, { type: Child_Type.GOOD }, {}] });
+    expect(s1.grandChildren).toEqual([
+      { name: 'a', type: Child_Type.UNKNOWN },
+      { name: '', type: Child_Type.GOOD },
+      { name: '', type: Child_Type.UNKNOWN },
+    ]);
+  });
+
   it('can fromPartial on maps with falsey values', () => {
     const s1 = SimpleWithMap.fromPartial({
       intLookup: { 1: 2, 2: 0 },
@@ -0,0 +1,53 @@
+import { Foo } from './foo';
+
+function encodeDelimited(message: Foo): Uint8Array {
+  return Foo.encode(message).ldelim().finish();
+}
+
+async function* chunks(...parts: Uint8Array[]): AsyncIterable<Uint8Array> {
+  yield* parts;
+}
+
+async function collect(source: AsyncIterable<Foo>): Promise<Foo[]> {
+  const messages: Foo[] = [];
+  for await (const message of source) {
+    messages.push(message);
+  }
+  return messages;
+}
+
+describe('stream-helpers', () => {
+  const a = { bar: 'a', baz: 'aa' };
+  const b = { bar: 'b'.repeat(200), baz: '' };
+
+  it('decodes several messages from one chunk', async () => {
+    const bytes = Uint8Array.from([...encodeDelimited(a), ...encodeDelimited(a)]);
+    expect(await collect(Foo.decodeStream(chunks(bytes)))).toEqual([a, a]);
+  });
+
+  it('decodes a length prefix that is split across chunks', async () => {
+    const bytes = encodeDelimited(b);
+    // 200+ bytes need a 2-byte varint prefix
+    expect(await collect(Foo.decodeStream(chunks(bytes.subarray(0, 1), bytes.subarray(1))))).toEqual([b]);
+  });
+
+  it('decodes messages split into single bytes', async () => {
+    const bytes = Uint8Array.from([...encodeDelimited(a), ...encodeDelimited(b)]);
+    const parts = Array.from(bytes, (byte) => Uint8Array.of(byte));
+    expect(await collect(Foo.decodeStream(chunks(...parts)))).toEqual([a, b]);
+  });
+
+  it('decodes empty messages', async () => {
+    expect(await collect(Foo.decodeStream(chunks(Uint8Array.of(0, 0))))).toEqual([
+      { bar: '', baz: '' },
+      { bar: '', baz: '' },
+    ]);
+  });
+
+  it('throws on a trailing partial message', async () => {
+    const bytes = encodeDelimited(a);
+    await expect(collect(Foo.decodeStream(chunks(bytes.subarray(0, bytes.length - 1))))).rejects.toThrow(
+      'Stream ended within a length-delimited message'
+    );
+  });
+});
@@ -0,0 +1,142 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'foo';
+
+export interface Foo {
+  bar: string;
+  baz: string;
+}
+
+function createBaseFoo(): Foo {
+  return { bar: '', baz: '' };
+}
+
+export const Foo = {
+  encode(message: Foo, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.bar !== '') {
+      writer.uint32(10).string(message.bar);
+    }
+    if (message.baz !== '') {
+      writer.uint32(18).string(message.baz);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Foo {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseFoo();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.bar = reader.string();
+          break;
+        case 2:
+          message.baz = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  // decodeStream decodes the varint length-prefixed messages of a byte stream, i.e. a file or a socket.
+  async *decodeStream(source: AsyncIterable<Uint8Array>): AsyncIterable<Foo> {
+    for await (const frame of readDelimited(source)) {
+      yield Foo.decode(frame);
+    }
+  },
+
+  fromJSON(object: any): Foo {
+    return {
+      bar: isSet(object.bar) ? String(object.bar) : '',
+      baz: isSet(object.baz) ? String(object.baz) : '',
+    };
+  },
+
+  toJSON(message: Foo): unknown {
+    const obj: any = {};
+    message.bar !== undefined && (obj.bar = message.bar);
+    message.baz !== undefined && (obj.baz = message.baz);
+    return obj;
+  },
+
+  fromPartial(object: DeepPartial<Foo>): Foo {
+    const message = createBaseFoo();
+    message.bar = object.bar ?? '';
+    message.baz = object.baz ?? '';
+    return message;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+async function* readDelimited(source: AsyncIterable<Uint8Array>): AsyncIterable<Uint8Array> {
+  let buffer = new Uint8Array(0);
+  for await (const chunk of source) {
+    if (buffer.length === 0) {
+      buffer = chunk;
+    } else {
+      const joined = new Uint8Array(buffer.length + chunk.length);
+      joined.set(buffer);
+      joined.set(chunk, buffer.length);
+      buffer = joined;
+    }
+    let pos = 0;
+    while (pos < buffer.length) {
+      // The length prefix itself may be split across chunks, in which case we wait for the next one
+      let length = 0;
+      let end = pos;
+      let complete = false;
+      while (end < buffer.length && !complete) {
+        const b = buffer[end];
+        if (end - pos === 5) {
+          throw new globalThis.Error("Invalid length prefix, it's longer than 5 bytes");
+        }
+        length += (b & 0x7f) * 2 ** (7 * (end - pos));
+        complete = (b & 0x80) === 0;
+        end++;
+      }
+      if (!complete || end + length > buffer.length) {
+        break;
+      }
+      yield buffer.subarray(end, end + length);
+      pos = end + length;
+    }
+    buffer = buffer.subarray(pos);
+  }
+  if (buffer.length > 0) {
+    throw new globalThis.Error(
+      'Stream ended within a length-delimited message, with ' + buffer.length + ' bytes of it left'
+    );
+  }
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
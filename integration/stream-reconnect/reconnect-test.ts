@@ -0,0 +1,86 @@
+import { Event, FeedClientImpl, ReconnectPolicy, WatchRequest } from './reconnect';
+
+function encode(...events: Event[]): Uint8Array[] {
+  return events.map((event) => Event.encode(event).finish());
+}
+
+/** Fakes a transport that sends each stream's messages, and then fails with the next error, if any. */
+function streamsOf(...streams: Array<{ events: Event[]; error?: unknown }>) {
+  const requests: WatchRequest[] = [];
+  const rpc = {
+    request: jest.fn(),
+    clientStreamingRequest: jest.fn(),
+    bidirectionalStreamingRequest: jest.fn(),
+    serverStreamingRequest: async function* (service: string, method: string, data: Uint8Array) {
+      requests.push(WatchRequest.decode(data));
+      const stream = streams[requests.length - 1];
+      yield* encode(...stream.events);
+      if (stream.error) {
+        throw stream.error;
+      }
+    },
+  };
+  return { client: new FeedClientImpl(rpc), requests };
+}
+
+async function collect(source: AsyncIterable<Event>): Promise<Event[]> {
+  const events: Event[] = [];
+  for await (const event of source) {
+    events.push(event);
+  }
+  return events;
+}
+
+describe('stream-reconnect', () => {
+  const a = { value: 'a', resumeToken: '1' };
+  const b = { value: 'b', resumeToken: '2' };
+  const c = { value: 'c', resumeToken: '3' };
+  const unavailable = { code: 14, message: 'unavailable' };
+  const policy: ReconnectPolicy<WatchRequest, Event> = {
+    maxReconnects: 2,
+    retryableCodes: [14],
+    backoffMs: 0,
+    resume: (request, last) => ({ ...request, resumeToken: last?.resumeToken ?? request.resumeToken }),
+  };
+
+  it('reconnects with the resume token of the last message', async () => {
+    const { client, requests } = streamsOf({ events: [a, b], error: unavailable }, { events: [c] });
+    const events = await collect(client.Watch({ topic: 't', resumeToken: '' }, policy));
+    expect(events).toEqual([a, b, c]);
+    expect(requests).toEqual([
+      { topic: 't', resumeToken: '' },
+      { topic: 't', resumeToken: '2' },
+    ]);
+  });
+
+  it('starts the count of reconnects over with each message', async () => {
+    const { client } = streamsOf(
+      { events: [], error: unavailable },
+      { events: [a], error: unavailable },
+      { events: [], error: unavailable },
+      { events: [b] }
+    );
+    expect(await collect(client.Watch({ topic: 't', resumeToken: '' }, policy))).toEqual([a, b]);
+  });
+
+  it('gives up after maxReconnects in a row', async () => {
+    const { client, requests } = streamsOf(
+      { events: [], error: unavailable },
+      { events: [], error: unavailable },
+      { events: [], error: unavailable }
+    );
+    await expect(collect(client.Watch({ topic: 't', resumeToken: '' }, policy))).rejects.toEqual(unavailable);
+    expect(requests.length).toEqual(3);
+  });
+
+  it('does not reconnect on other errors, or without a policy', async () => {
+    const denied = { code: 7, message: 'permission denied' };
+    const first = streamsOf({ events: [a], error: denied }, { events: [b] });
+    await expect(collect(first.client.Watch({ topic: 't', resumeToken: '' }, policy))).rejects.toEqual(denied);
+    expect(first.requests.length).toEqual(1);
+
+    const second = streamsOf({ events: [a], error: unavailable }, { events: [b] });
+    await expect(collect(second.client.Watch({ topic: 't', resumeToken: '' }))).rejects.toEqual(unavailable);
+    expect(second.requests.length).toEqual(1);
+  });
+});
@@ -0,0 +1,222 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'reconnect';
+
+export interface WatchRequest {
+  topic: string;
+  resumeToken: string;
+}
+
+export interface Event {
+  value: string;
+  resumeToken: string;
+}
+
+function createBaseWatchRequest(): WatchRequest {
+  return { topic: '', resumeToken: '' };
+}
+
+export const WatchRequest = {
+  encode(message: WatchRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.topic !== '') {
+      writer.uint32(10).string(message.topic);
+    }
+    if (message.resumeToken !== '') {
+      writer.uint32(18).string(message.resumeToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WatchRequest {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWatchRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.topic = reader.string();
+          break;
+        case 2:
+          message.resumeToken = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  // encodeTransform encodes a source of message objects.
+  // Transform<WatchRequest, Uint8Array>
+  async *encodeTransform(
+    source: AsyncIterable<WatchRequest | WatchRequest[]> | Iterable<WatchRequest | WatchRequest[]>
+  ): AsyncIterable<Uint8Array> {
+    for await (const pkt of source) {
+      if (Array.isArray(pkt)) {
+        for (const p of pkt) {
+          yield* [WatchRequest.encode(p).finish()];
+        }
+      } else {
+        yield* [WatchRequest.encode(pkt).finish()];
+      }
+    }
+  },
+
+  // decodeTransform decodes a source of encoded messages.
+  // Transform<Uint8Array, WatchRequest>
+  async *decodeTransform(
+    source: AsyncIterable<Uint8Array | Uint8Array[]> | Iterable<Uint8Array | Uint8Array[]>
+  ): AsyncIterable<WatchRequest> {
+    for await (const pkt of source) {
+      if (Array.isArray(pkt)) {
+        for (const p of pkt) {
+          yield* [WatchRequest.decode(p)];
+        }
+      } else {
+        yield* [WatchRequest.decode(pkt)];
+      }
+    }
+  },
+};
+
+function createBaseEvent(): Event {
+  return { value: '', resumeToken: '' };
+}
+
+export const Event = {
+  encode(message: Event, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.value !== '') {
+      writer.uint32(10).string(message.value);
+    }
+    if (message.resumeToken !== '') {
+      writer.uint32(18).string(message.resumeToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Event {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEvent();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.value = reader.string();
+          break;
+        case 2:
+          message.resumeToken = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  // encodeTransform encodes a source of message objects.
+  // Transform<Event, Uint8Array>
+  async *encodeTransform(
+    source: AsyncIterable<Event | Event[]> | Iterable<Event | Event[]>
+  ): AsyncIterable<Uint8Array> {
+    for await (const pkt of source) {
+      if (Array.isArray(pkt)) {
+        for (const p of pkt) {
+          yield* [Event.encode(p).finish()];
+        }
+      } else {
+        yield* [Event.encode(pkt).finish()];
+      }
+    }
+  },
+
+  // decodeTransform decodes a source of encoded messages.
+  // Transform<Uint8Array, Event>
+  async *decodeTransform(
+    source: AsyncIterable<Uint8Array | Uint8Array[]> | Iterable<Uint8Array | Uint8Array[]>
+  ): AsyncIterable<Event> {
+    for await (const pkt of source) {
+      if (Array.isArray(pkt)) {
+        for (const p of pkt) {
+          yield* [Event.decode(p)];
+        }
+      } else {
+        yield* [Event.decode(pkt)];
+      }
+    }
+  },
+};
+
+export interface Feed {
+  Watch(request: WatchRequest): AsyncIterable<Event>;
+}
+
+export class FeedClientImpl implements Feed {
+  private readonly rpc: Rpc;
+  constructor(rpc: Rpc) {
+    this.rpc = rpc;
+    this.Watch = this.Watch.bind(this);
+  }
+  Watch(request: WatchRequest, reconnect?: ReconnectPolicy<WatchRequest, Event>): AsyncIterable<Event> {
+    return withReconnect(reconnect, request, (request) => {
+      const data = WatchRequest.encode(request).finish();
+      const result = this.rpc.serverStreamingRequest('reconnect.Feed', 'Watch', data);
+      return Event.decodeTransform(result);
+    });
+  }
+}
+
+interface Rpc {
+  request(service: string, method: string, data: Uint8Array): Promise<Uint8Array>;
+  clientStreamingRequest(service: string, method: string, data: AsyncIterable<Uint8Array>): Promise<Uint8Array>;
+  serverStreamingRequest(service: string, method: string, data: Uint8Array): AsyncIterable<Uint8Array>;
+  bidirectionalStreamingRequest(
+    service: string,
+    method: string,
+    data: AsyncIterable<Uint8Array>
+  ): AsyncIterable<Uint8Array>;
+}
+
+export interface ReconnectPolicy<Req, Res> {
+  /** The maximum number of reconnects in a row, i.e. without receiving a message in between. */
+  maxReconnects: number;
+  /** The status codes of the errors to reconnect on. */
+  retryableCodes: Array<number | string>;
+  /** The delay before the first reconnect, which doubles with each further reconnect in a row. */
+  backoffMs: number;
+  /** Returns the request to reopen the stream with, i.e. with a resume token of the last message received. */
+  resume: (request: Req, last: Res | undefined) => Req;
+}
+
+async function* withReconnect<Req, Res>(
+  reconnect: ReconnectPolicy<Req, Res> | undefined,
+  request: Req,
+  open: (request: Req) => AsyncIterable<Res>
+): AsyncIterable<Res> {
+  let last: Res | undefined;
+  for (let failures = 0; ; ) {
+    try {
+      for await (const message of open(request)) {
+        last = message;
+        failures = 0;
+        yield message;
+      }
+      return;
+    } catch (e) {
+      failures++;
+      if (
+        !reconnect ||
+        failures > reconnect.maxReconnects ||
+        reconnect.retryableCodes.indexOf((e as any)?.code) === -1
+      ) {
+        throw e;
+      }
+      await new Promise((resolve) => setTimeout(resolve, reconnect.backoffMs * Math.pow(2, failures - 1)));
+      request = reconnect.resume(request, last);
+    }
+  }
+}
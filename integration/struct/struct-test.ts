@@ -60,4 +60,17 @@ describe('struct', () => {
 
     expect(StructMessage.toJSON(decodedValue)).toEqual(data);
   });
+
+  it('round-trips plain objects with nested values and nulls through the wire format', () => {
+    const message: StructMessage = {
+      value: {
+        pet: null,
+        tags: ['a', null, 1.5],
+        owner: { name: 'john', address: { city: null, zip: 12345 } },
+        active: false,
+      },
+    };
+    const decoded = StructMessage.decode(StructMessage.encode(message).finish());
+    expect(decoded).toEqual(message);
+  });
 });
@@ -0,0 +1,53 @@
+import { Address, makeAddress, makeUser, Role, User } from './user';
+
+describe('test-factories', () => {
+  it('fills the singular scalar and enum fields with non-default placeholders', () => {
+    expect(makeUser()).toEqual({
+      name: 'string',
+      age: 1,
+      active: true,
+      role: Role.ROLE_ADMIN,
+      score: 1,
+      tags: [],
+      address: undefined,
+      nickname: undefined,
+    });
+    expect(makeAddress()).toEqual({ city: 'string' });
+  });
+
+  it('leaves sub-messages, repeated and optional fields unset', () => {
+    const user = makeUser();
+    expect(user.address).toBeUndefined();
+    expect(user.tags).toEqual([]);
+    expect(user.nickname).toBeUndefined();
+  });
+
+  it('applies the overrides on top of the placeholders', () => {
+    const user = makeUser({ name: 'bob', role: Role.ROLE_GUEST, tags: ['a'], nickname: 'b' });
+    expect(user).toEqual({ ...makeUser(), name: 'bob', role: Role.ROLE_GUEST, tags: ['a'], nickname: 'b' });
+  });
+
+  it('overrides placeholders with default values', () => {
+    const user = makeUser({ name: '', age: 0, active: false, role: Role.ROLE_UNSPECIFIED });
+    expect(user).toEqual({ ...makeUser(), name: '', age: 0, active: false, role: Role.ROLE_UNSPECIFIED });
+  });
+
+  it('fills in partial sub-message overrides like fromPartial', () => {
+    expect(makeUser({ address: {} }).address).toEqual({ city: '' });
+    expect(makeUser({ address: makeAddress({ city: 'Paris' }) }).address).toEqual({ city: 'Paris' });
+    expect(makeUser({ address: { city: 'Paris' } }).address).toEqual(Address.fromPartial({ city: 'Paris' }));
+  });
+
+  it('differs from fromPartial only in the placeholders', () => {
+    const placeholders = { name: 'string', age: 1, active: true, role: Role.ROLE_ADMIN, score: 1 };
+    expect(makeUser({ tags: ['x'] })).toEqual(User.fromPartial({ ...placeholders, tags: ['x'] }));
+  });
+
+  it('returns a new message on every call', () => {
+    const a = makeUser();
+    const b = makeUser();
+    expect(a).not.toBe(b);
+    a.tags.push('changed');
+    expect(b.tags).toEqual([]);
+  });
+});
@@ -0,0 +1,169 @@
+/* eslint-disable */
+export const protobufPackage = 'factories';
+
+export enum Role {
+  ROLE_UNSPECIFIED = 0,
+  ROLE_ADMIN = 1,
+  ROLE_GUEST = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function roleFromJSON(object: any): Role {
+  switch (object) {
+    case 0:
+    case 'ROLE_UNSPECIFIED':
+      return Role.ROLE_UNSPECIFIED;
+    case 1:
+    case 'ROLE_ADMIN':
+      return Role.ROLE_ADMIN;
+    case 2:
+    case 'ROLE_GUEST':
+      return Role.ROLE_GUEST;
+    case -1:
+    case 'UNRECOGNIZED':
+    default:
+      return Role.UNRECOGNIZED;
+  }
+}
+
+export function roleToJSON(object: Role): string {
+  switch (object) {
+    case Role.ROLE_UNSPECIFIED:
+      return 'ROLE_UNSPECIFIED';
+    case Role.ROLE_ADMIN:
+      return 'ROLE_ADMIN';
+    case Role.ROLE_GUEST:
+      return 'ROLE_GUEST';
+    case Role.UNRECOGNIZED:
+    default:
+      return 'UNRECOGNIZED';
+  }
+}
+
+export interface User {
+  name: string;
+  age: number;
+  active: boolean;
+  role: Role;
+  score: number;
+  tags: string[];
+  address: Address | undefined;
+  nickname?: string | undefined;
+}
+
+export interface Address {
+  city: string;
+}
+
+function createBaseUser(): User {
+  return { name: '', age: 0, active: false, role: 0, score: 0, tags: [], address: undefined, nickname: undefined };
+}
+
+export const User = {
+  fromJSON(object: any): User {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+      age: isSet(object.age) ? Number(object.age) : 0,
+      active: isSet(object.active) ? Boolean(object.active) : false,
+      role: isSet(object.role) ? roleFromJSON(object.role) : 0,
+      score: isSet(object.score) ? Number(object.score) : 0,
+      tags: Array.isArray(object?.tags) ? object.tags.map((e: any) => String(e)) : [],
+      address: isSet(object.address) ? Address.fromJSON(object.address) : undefined,
+      nickname: isSet(object.nickname) ? String(object.nickname) : undefined,
+    };
+  },
+
+  toJSON(message: User): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.age !== undefined && (obj.age = Math.round(message.age));
+    message.active !== undefined && (obj.active = message.active);
+    message.role !== undefined && (obj.role = roleToJSON(message.role));
+    message.score !== undefined && (obj.score = message.score);
+    if (message.tags) {
+      obj.tags = message.tags.map((e) => e);
+    } else {
+      obj.tags = [];
+    }
+    message.address !== undefined && (obj.address = message.address ? Address.toJSON(message.address) : undefined);
+    message.nickname !== undefined && (obj.nickname = message.nickname);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<User>, I>>(object: I): User {
+    const message = createBaseUser();
+    message.name = object.name ?? '';
+    message.age = object.age ?? 0;
+    message.active = object.active ?? false;
+    message.role = object.role ?? 0;
+    message.score = object.score ?? 0;
+    message.tags = object.tags?.map((e) => e) || [];
+    message.address =
+      object.address !== undefined && object.address !== null ? Address.fromPartial(object.address) : undefined;
+    message.nickname = object.nickname ?? undefined;
+    return message;
+  },
+};
+
+export function makeUser(overrides: DeepPartial<User> = {}): User {
+  return User.fromPartial({
+    name: 'string',
+    age: 1,
+    active: true,
+    role: Role.ROLE_ADMIN,
+    score: 1,
+    ...overrides,
+  });
+}
+
+function createBaseAddress(): Address {
+  return { city: '' };
+}
+
+export const Address = {
+  fromJSON(object: any): Address {
+    return {
+      city: isSet(object.city) ? String(object.city) : '',
+    };
+  },
+
+  toJSON(message: Address): unknown {
+    const obj: any = {};
+    message.city !== undefined && (obj.city = message.city);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Address>, I>>(object: I): Address {
+    const message = createBaseAddress();
+    message.city = object.city ?? '';
+    return message;
+  },
+};
+
+export function makeAddress(overrides: DeepPartial<Address> = {}): Address {
+  return Address.fromPartial({
+    city: 'string',
+    ...overrides,
+  });
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
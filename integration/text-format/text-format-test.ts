@@ -0,0 +1,105 @@
+import { Color, Point, Shape } from './text-format';
+
+describe('text-format', () => {
+  const shape: Shape = {
+    name: 'café "quoted"\n',
+    color: Color.RED,
+    points: [
+      { x: 1.5, y: NaN },
+      { x: Infinity, y: -Infinity },
+    ],
+    tags: { a: 1, b: -2 },
+    data: new Uint8Array([0, 1, 0x22, 0x5c, 0x41, 0xff, 0x0a]),
+    labels: ['x', "tab\there's"],
+    origin: { x: 0, y: 0 },
+  };
+
+  const golden = [
+    'name: "caf\\303\\251 \\"quoted\\"\\n"',
+    'color: RED',
+    'points {',
+    '  x: 1.5',
+    '  y: nan',
+    '}',
+    'points {',
+    '  x: inf',
+    '  y: -inf',
+    '}',
+    'tags {',
+    '  key: "a"',
+    '  value: 1',
+    '}',
+    'tags {',
+    '  key: "b"',
+    '  value: -2',
+    '}',
+    'data: "\\000\\001\\"\\\\A\\377\\n"',
+    'labels: "x"',
+    'labels: "tab\\there\\\'s"',
+    'origin {}',
+  ].join('\n');
+
+  it('writes the golden text format', () => {
+    expect(Shape.toTextFormat(shape)).toEqual(golden);
+  });
+
+  it('writes strings as the escaped bytes of their UTF-8 encoding', () => {
+    const text = Shape.toTextFormat({ ...Shape.fromJSON({}), name: '日本 🎉' });
+    expect(text).toEqual('name: "\\346\\227\\245\\346\\234\\254 \\360\\237\\216\\211"');
+    expect(/^[\x20-\x7e]*$/.test(text)).toBe(true);
+  });
+
+  it('writes non-finite floats as nan and inf', () => {
+    expect(Point.toTextFormat({ x: -Infinity, y: NaN })).toEqual('x: -inf\ny: nan');
+  });
+
+  it('reads the golden text format back', () => {
+    expect(Shape.fromTextFormat(golden)).toEqual(shape);
+  });
+
+  it('round-trips nested messages, repeated fields, maps, enums, escapes and non-finite floats', () => {
+    const message: Shape = {
+      ...shape,
+      name: "日本 🎉 \\ '\t\r",
+      color: Color.GREEN,
+      points: [{ x: -0.25, y: NaN }, { x: 0, y: 0 }, { x: -Infinity, y: 3 }],
+      tags: { '': 0, 'ü': 7 },
+      data: new Uint8Array([0x80, 0x00, 0x7f, 0x27]),
+      labels: ['', 'é', '"'],
+      origin: { x: Infinity, y: -1 },
+    };
+    expect(Shape.fromTextFormat(Shape.toTextFormat(message))).toEqual(message);
+  });
+
+  it('decodes octal, hex and unicode escapes of strings as UTF-8', () => {
+    expect(Shape.fromTextFormat('name: "caf\\303\\251"').name).toEqual('café');
+    expect(Shape.fromTextFormat('name: "caf\\xc3\\xa9"').name).toEqual('café');
+    expect(Shape.fromTextFormat('name: "caf\\u00e9 \\U0001f389"').name).toEqual('café 🎉');
+    expect(Shape.fromTextFormat('name: "日本"').name).toEqual('日本');
+    expect(Shape.fromTextFormat('data: "\\303\\251"').data).toEqual(new Uint8Array([0xc3, 0xa9]));
+  });
+
+  it('reads angle brackets, comments, the short form of repeated fields and enums by number', () => {
+    const text = [
+      '# a comment',
+      'name: \'a\' "b" # adjacent strings are concatenated',
+      'color: 2',
+      'points < x: 1 y: 2.5f >',
+      'labels: ["x", "y"]',
+      'tags { key: "k" value: 3 }',
+      'unknown: 1',
+    ].join('\n');
+    expect(Shape.fromTextFormat(text)).toEqual({
+      ...Shape.fromJSON({}),
+      name: 'ab',
+      color: Color.GREEN,
+      points: [{ x: 1, y: 2.5 }],
+      labels: ['x', 'y'],
+      tags: { k: 3 },
+    });
+  });
+
+  it('leaves out unset fields', () => {
+    expect(Shape.toTextFormat(Shape.fromJSON({}))).toEqual('');
+  });
+});
@@ -0,0 +1,483 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'textformat';
+
+export enum Color {
+  COLOR_UNSPECIFIED = 0,
+  RED = 1,
+  GREEN = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function colorFromJSON(object: any): Color {
+  switch (object) {
+    case 0:
+    case 'COLOR_UNSPECIFIED':
+      return Color.COLOR_UNSPECIFIED;
+    case 1:
+    case 'RED':
+      return Color.RED;
+    case 2:
+    case 'GREEN':
+      return Color.GREEN;
+    case -1:
+    case 'UNRECOGNIZED':
+    default:
+      return Color.UNRECOGNIZED;
+  }
+}
+
+export function colorToJSON(object: Color): string {
+  switch (object) {
+    case Color.COLOR_UNSPECIFIED:
+      return 'COLOR_UNSPECIFIED';
+    case Color.RED:
+      return 'RED';
+    case Color.GREEN:
+      return 'GREEN';
+    case Color.UNRECOGNIZED:
+    default:
+      return 'UNRECOGNIZED';
+  }
+}
+
+export interface Point {
+  x: number;
+  y: number;
+}
+
+export interface Shape {
+  name: string;
+  color: Color;
+  points: Point[];
+  tags: { [key: string]: number };
+  data: Uint8Array;
+  labels: string[];
+  origin: Point | undefined;
+}
+
+export interface Shape_TagsEntry {
+  key: string;
+  value: number;
+}
+
+function createBasePoint(): Point {
+  return { x: 0, y: 0 };
+}
+
+export const Point = {
+  fromJSON(object: any): Point {
+    return {
+      x: isSet(object.x) ? Number(object.x) : 0,
+      y: isSet(object.y) ? Number(object.y) : 0,
+    };
+  },
+
+  toJSON(message: Point): unknown {
+    const obj: any = {};
+    message.x !== undefined && (obj.x = message.x);
+    message.y !== undefined && (obj.y = message.y);
+    return obj;
+  },
+
+  toTextFormat(message: Point, indent: string = ''): string {
+    const lines: string[] = [];
+    if (message.x !== 0) {
+      lines.push(indent + 'x: ' + textFormatFloat(message.x));
+    }
+    if (message.y !== 0) {
+      lines.push(indent + 'y: ' + textFormatFloat(message.y));
+    }
+    return lines.join('\n');
+  },
+
+  fromTextFormat(input: string | TextFormatNode): Point {
+    const message = createBasePoint();
+    const node = typeof input === 'string' ? parseTextFormat(input) : input;
+    for (const v of node['x'] || []) {
+      message.x = textFormatNumber(v as string);
+    }
+    for (const v of node['y'] || []) {
+      message.y = textFormatNumber(v as string);
+    }
+    return message;
+  },
+};
+
+function createBaseShape(): Shape {
+  return { name: '', color: 0, points: [], tags: {}, data: new Uint8Array(), labels: [], origin: undefined };
+}
+
+export const Shape = {
+  fromJSON(object: any): Shape {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+      color: isSet(object.color) ? colorFromJSON(object.color) : 0,
+      points: Array.isArray(object?.points) ? object.points.map((e: any) => Point.fromJSON(e)) : [],
+      tags: isObject(object.tags)
+        ? Object.entries(object.tags).reduce<{ [key: string]: number }>((acc, [key, value]) => {
+            acc[key] = Number(value);
+            return acc;
+          }, {})
+        : {},
+      data: isSet(object.data)
+        ? object.data instanceof Uint8Array
+          ? object.data
+          : bytesFromBase64(object.data)
+        : new Uint8Array(),
+      labels: Array.isArray(object?.labels) ? object.labels.map((e: any) => String(e)) : [],
+      origin: isSet(object.origin) ? Point.fromJSON(object.origin) : undefined,
+    };
+  },
+
+  toJSON(message: Shape): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.color !== undefined && (obj.color = colorToJSON(message.color));
+    if (message.points) {
+      obj.points = message.points.map((e) => (e ? Point.toJSON(e) : undefined));
+    } else {
+      obj.points = [];
+    }
+    obj.tags = {};
+    if (message.tags) {
+      Object.entries(message.tags).forEach(([k, v]) => {
+        obj.tags[k] = Math.round(v);
+      });
+    }
+    message.data !== undefined &&
+      (obj.data = base64FromBytes(message.data !== undefined ? message.data : new Uint8Array()));
+    if (message.labels) {
+      obj.labels = message.labels.map((e) => e);
+    } else {
+      obj.labels = [];
+    }
+    message.origin !== undefined && (obj.origin = message.origin ? Point.toJSON(message.origin) : undefined);
+    return obj;
+  },
+
+  toTextFormat(message: Shape, indent: string = ''): string {
+    const lines: string[] = [];
+    if (message.name !== '') {
+      lines.push(indent + 'name: ' + textFormatString(message.name));
+    }
+    if (message.color !== 0) {
+      lines.push(indent + 'color: ' + colorToJSON(message.color));
+    }
+    for (const v of message.points || []) {
+      lines.push(textFormatMessage(indent, 'points', Point.toTextFormat(v!, indent + '  ')));
+    }
+    Object.entries(message.tags || {}).forEach(([key, value]) => {
+      lines.push(
+        textFormatMessage(indent, 'tags', Shape_TagsEntry.toTextFormat({ key: key as any, value }, indent + '  '))
+      );
+    });
+    if (message.data.length !== 0) {
+      lines.push(indent + 'data: ' + textFormatBytes(message.data));
+    }
+    for (const v of message.labels || []) {
+      lines.push(indent + 'labels: ' + textFormatString(v!));
+    }
+    if (message.origin !== undefined) {
+      lines.push(textFormatMessage(indent, 'origin', Point.toTextFormat(message.origin, indent + '  ')));
+    }
+    return lines.join('\n');
+  },
+
+  fromTextFormat(input: string | TextFormatNode): Shape {
+    const message = createBaseShape();
+    const node = typeof input === 'string' ? parseTextFormat(input) : input;
+    for (const v of node['name'] || []) {
+      message.name = stringFromTextFormat(v as string);
+    }
+    for (const v of node['color'] || []) {
+      message.color = colorFromJSON(/^-?\d+$/.test(v as string) ? Number(v) : v);
+    }
+    if (node['points'] !== undefined) {
+      message.points = node['points'].map((v) => Point.fromTextFormat(v as TextFormatNode));
+    }
+    if (node['tags'] !== undefined) {
+      for (const v of node['tags']) {
+        const entry = Shape_TagsEntry.fromTextFormat(v as TextFormatNode);
+        if (entry.value !== undefined) {
+          message.tags[entry.key] = entry.value;
+        }
+      }
+    }
+    for (const v of node['data'] || []) {
+      message.data = bytesFromTextFormat(v as string);
+    }
+    if (node['labels'] !== undefined) {
+      message.labels = node['labels'].map((v) => stringFromTextFormat(v as string));
+    }
+    for (const v of node['origin'] || []) {
+      message.origin = Point.fromTextFormat(v as TextFormatNode);
+    }
+    return message;
+  },
+};
+
+function createBaseShape_TagsEntry(): Shape_TagsEntry {
+  return { key: '', value: 0 };
+}
+
+export const Shape_TagsEntry = {
+  fromJSON(object: any): Shape_TagsEntry {
+    return {
+      key: isSet(object.key) ? String(object.key) : '',
+      value: isSet(object.value) ? Number(object.value) : 0,
+    };
+  },
+
+  toJSON(message: Shape_TagsEntry): unknown {
+    const obj: any = {};
+    message.key !== undefined && (obj.key = message.key);
+    message.value !== undefined && (obj.value = Math.round(message.value));
+    return obj;
+  },
+
+  toTextFormat(message: Shape_TagsEntry, indent: string = ''): string {
+    const lines: string[] = [];
+    if (message.key !== '') {
+      lines.push(indent + 'key: ' + textFormatString(message.key));
+    }
+    if (message.value !== 0) {
+      lines.push(indent + 'value: ' + message.value);
+    }
+    return lines.join('\n');
+  },
+
+  fromTextFormat(input: string | TextFormatNode): Shape_TagsEntry {
+    const message = createBaseShape_TagsEntry();
+    const node = typeof input === 'string' ? parseTextFormat(input) : input;
+    for (const v of node['key'] || []) {
+      message.key = stringFromTextFormat(v as string);
+    }
+    for (const v of node['value'] || []) {
+      message.value = Number(v);
+    }
+    return message;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+const atob: (b64: string) => string =
+  globalThis.atob || ((b64) => globalThis.Buffer.from(b64, 'base64').toString('binary'));
+function bytesFromBase64(b64: string): Uint8Array {
+  const bin = atob(b64);
+  const arr = new Uint8Array(bin.length);
+  for (let i = 0; i < bin.length; ++i) {
+    arr[i] = bin.charCodeAt(i);
+  }
+  return arr;
+}
+
+const btoa: (bin: string) => string =
+  globalThis.btoa || ((bin) => globalThis.Buffer.from(bin, 'binary').toString('base64'));
+function base64FromBytes(arr: Uint8Array): string {
+  const bin: string[] = [];
+  arr.forEach((byte) => {
+    bin.push(String.fromCharCode(byte));
+  });
+  return btoa(bin.join(''));
+}
+
+function isObject(value: any): boolean {
+  return typeof value === 'object' && value !== null;
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
+
+function textFormatMessage(indent: string, name: string, body: string): string {
+  return body === '' ? indent + name + ' {}' : indent + name + ' {\n' + body + '\n' + indent + '}';
+}
+
+function textFormatBytes(b: Uint8Array): string {
+  const escapes: { [byte: number]: string } = {
+    9: '\\t',
+    10: '\\n',
+    13: '\\r',
+    34: '\\"',
+    39: "\\'",
+    92: '\\\\',
+  };
+  let result = '"';
+  for (let i = 0; i < b.length; i++) {
+    const byte = b[i];
+    if (escapes[byte] !== undefined) {
+      result += escapes[byte];
+    } else if (byte >= 0x20 && byte < 0x7f) {
+      result += String.fromCharCode(byte);
+    } else {
+      result += '\\' + ('00' + byte.toString(8)).slice(-3);
+    }
+  }
+  return result + '"';
+}
+
+function textFormatString(s: string): string {
+  const bytes = new Uint8Array(_m0.util.utf8.length(s));
+  _m0.util.utf8.write(s, bytes, 0);
+  return textFormatBytes(bytes);
+}
+
+function textFormatFloat(n: number): string {
+  return Number.isNaN(n) ? 'nan' : n === Infinity ? 'inf' : n === -Infinity ? '-inf' : String(n);
+}
+
+/** The fields of a parsed text format message, where scalars are kept as their token and strings as bytes. */
+type TextFormatNode = { [name: string]: Array<string | TextFormatNode> };
+
+function parseTextFormat(text: string): TextFormatNode {
+  let pos = 0;
+
+  function fail(expected: string): never {
+    throw new Error('Expected ' + expected + ' at position ' + pos + ' of text format input');
+  }
+
+  function skip(): void {
+    while (pos < text.length) {
+      const c = text[pos];
+      if (c === '#') {
+        while (pos < text.length && text[pos] !== '\n') pos++;
+      } else if (c === ' ' || c === '\t' || c === '\r' || c === '\n' || c === ',' || c === ';') {
+        pos++;
+      } else {
+        break;
+      }
+    }
+  }
+
+  function token(): string {
+    const start = pos;
+    while (pos < text.length && /[\w.+-]/.test(text[pos])) pos++;
+    return start === pos ? fail('a field name or value') : text.slice(start, pos);
+  }
+
+  function utf8(c: string): string {
+    const bytes = new Uint8Array(_m0.util.utf8.length(c));
+    _m0.util.utf8.write(c, bytes, 0);
+    return String.fromCharCode(...bytes);
+  }
+
+  // Octal and hex escapes are bytes, i.e. of a multi-byte UTF-8 character, so strings are kept as bytes
+  // too, one char per byte, and decoded as UTF-8 once all of their escapes are in place
+  function string(): string {
+    const quote = text[pos++];
+    let result = '';
+    while (text[pos] !== quote) {
+      if (pos >= text.length) fail(quote);
+      const c = String.fromCodePoint(text.codePointAt(pos)!);
+      pos += c.length;
+      if (c !== '\\') {
+        result += utf8(c);
+        continue;
+      }
+      const e = text[pos++];
+      if (e >= '0' && e <= '7') {
+        let octal = e;
+        while (octal.length < 3 && text[pos] >= '0' && text[pos] <= '7') octal += text[pos++];
+        result += String.fromCharCode(parseInt(octal, 8));
+      } else if (e === 'x') {
+        result += String.fromCharCode(parseInt(text.slice(pos, pos + 2), 16));
+        pos += 2;
+      } else if (e === 'u' || e === 'U') {
+        // Unicode escapes are characters rather than bytes
+        const digits = e === 'u' ? 4 : 8;
+        result += utf8(String.fromCodePoint(parseInt(text.slice(pos, pos + digits), 16)));
+        pos += digits;
+      } else {
+        const escapes: { [e: string]: string } = {
+          n: '\n',
+          r: '\r',
+          t: '\t',
+          b: '\b',
+          f: '\f',
+          v: '\v',
+          a: '\x07',
+        };
+        result += escapes[e] || e;
+      }
+    }
+    pos++;
+    return result;
+  }
+
+  function value(): string | TextFormatNode {
+    const c = text[pos];
+    if (c === '{' || c === '<') {
+      pos++;
+      const close = c === '{' ? '}' : '>';
+      const node = message(close);
+      if (text[pos++] !== close) fail(close);
+      return node;
+    } else if (c === '"' || c === "'") {
+      // Adjacent string literals are concatenated
+      let result = string();
+      for (skip(); text[pos] === '"' || text[pos] === "'"; skip()) result += string();
+      return result;
+    }
+    return token();
+  }
+
+  function message(close: string): TextFormatNode {
+    const node: TextFormatNode = {};
+    for (skip(); pos < text.length && text[pos] !== close; skip()) {
+      const name = token();
+      const values = node[name] || (node[name] = []);
+      skip();
+      if (text[pos] === ':') {
+        pos++;
+        skip();
+      }
+      if (text[pos] === '[') {
+        // The short form of repeated fields, i.e. `name: [1, 2, 3]`
+        for (pos++, skip(); text[pos] !== ']'; skip()) {
+          if (pos >= text.length) fail(']');
+          values.push(value());
+        }
+        pos++;
+      } else {
+        values.push(value());
+      }
+    }
+    return node;
+  }
+
+  return message('');
+}
+
+function textFormatNumber(token: string): number {
+  const t = token.toLowerCase();
+  if (t === 'inf' || t === 'infinity' || t === '+inf' || t === '+infinity') return Infinity;
+  if (t === '-inf' || t === '-infinity') return -Infinity;
+  if (t === 'nan') return NaN;
+  // Floats may have an `f` suffix, e.g. `1.5f`
+  return Number(/^[+-]?0x/.test(t) ? t : t.replace(/f$/, ''));
+}
+
+function bytesFromTextFormat(s: string): Uint8Array {
+  const bytes = new Uint8Array(s.length);
+  for (let i = 0; i < s.length; i++) {
+    bytes[i] = s.charCodeAt(i);
+  }
+  return bytes;
+}
+
+function stringFromTextFormat(s: string): string {
+  const bytes = bytesFromTextFormat(s);
+  return _m0.util.utf8.read(bytes, 0, bytes.length);
+}
@@ -0,0 +1,8 @@
+/** Writes timestamps as epoch seconds, like some legacy APIs do. */
+export function timestampToJson(value: Date): unknown {
+  return value.getTime() / 1_000;
+}
+
+export function timestampFromJson(json: any): Date {
+  return new Date(Number(json) * 1_000);
+}
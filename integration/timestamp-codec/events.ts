@@ -0,0 +1,40 @@
+/* eslint-disable */
+import { timestampFromJson, timestampToJson } from './epoch-codec';
+
+export const protobufPackage = 'events';
+
+export interface Event {
+  name: string;
+  at: Date | undefined;
+  history: Date[];
+}
+
+function createBaseEvent(): Event {
+  return { name: '', at: undefined, history: [] };
+}
+
+export const Event = {
+  fromJSON(object: any): Event {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+      at: isSet(object.at) ? timestampFromJson(object.at) : undefined,
+      history: Array.isArray(object?.history) ? object.history.map((e: any) => timestampFromJson(e)) : [],
+    };
+  },
+
+  toJSON(message: Event): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.at !== undefined && (obj.at = timestampToJson(message.at));
+    if (message.history) {
+      obj.history = message.history.map((e) => timestampToJson(e));
+    } else {
+      obj.history = [];
+    }
+    return obj;
+  },
+};
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,22 @@
+import { Event } from './events';
+
+describe('timestamp-codec', () => {
+  const event: Event = {
+    name: 'launch',
+    at: new Date('2020-01-01T00:00:01.500Z'),
+    history: [new Date(0), new Date(1_000)],
+  };
+
+  it('writes timestamps with the custom codec', () => {
+    expect(Event.toJSON(event)).toEqual({ name: 'launch', at: 1577836801.5, history: [0, 1] });
+  });
+
+  it('reads timestamps with the custom codec', () => {
+    expect(Event.fromJSON({ name: 'launch', at: 1577836801.5, history: [0, '1'] })).toEqual(event);
+  });
+
+  it('leaves unset timestamps unset', () => {
+    expect(Event.fromJSON({ name: 'launch' })).toEqual({ name: 'launch', at: undefined, history: [] });
+    expect(Event.toJSON({ name: 'launch', at: undefined, history: [] })).toEqual({ name: 'launch', history: [] });
+  });
+});
@@ -0,0 +1,31 @@
+import { compareTimestamp, timestampEquals } from './timestamp-helpers';
+
+describe('timestamp-helpers', () => {
+  it('compares timestamps by their seconds', () => {
+    expect(compareTimestamp('2020-01-01T00:00:00Z', '2021-01-01T00:00:00Z')).toEqual(-1);
+    expect(compareTimestamp('2021-01-01T00:00:00Z', '2020-01-01T00:00:00Z')).toEqual(1);
+    expect(compareTimestamp('2020-01-01T00:00:00Z', '2020-01-01T00:00:00Z')).toEqual(0);
+  });
+
+  it('compares the nanos within the same millisecond', () => {
+    const a = '2020-01-01T00:00:00.123456789Z';
+    const b = '2020-01-01T00:00:00.123456799Z';
+    expect(compareTimestamp(a, b)).toEqual(-1);
+    expect(compareTimestamp(b, a)).toEqual(1);
+    expect(timestampEquals(a, b)).toBe(false);
+  });
+
+  it('treats different forms of the same instant as equal', () => {
+    expect(timestampEquals('2020-01-01T00:00:00.5Z', '2020-01-01T01:00:00.500+01:00')).toBe(true);
+    expect(timestampEquals('2020-01-01T00:00:00Z', '2020-01-01T00:00:00.000000000Z')).toBe(true);
+  });
+
+  it('sorts timestamps, including those before the epoch', () => {
+    const timestamps = ['2020-01-01T00:00:00Z', '1969-12-31T23:59:59.999Z', '1970-01-01T00:00:00Z'];
+    expect(timestamps.sort(compareTimestamp)).toEqual([
+      '1969-12-31T23:59:59.999Z',
+      '1970-01-01T00:00:00Z',
+      '2020-01-01T00:00:00Z',
+    ]);
+  });
+});
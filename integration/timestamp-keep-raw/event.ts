@@ -0,0 +1,136 @@
+/* eslint-disable */
+import { Timestamp } from './google/protobuf/timestamp';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'keepraw';
+
+export interface Event {
+  name: string;
+  createdAt: Date | undefined;
+  createdAtRaw?: Timestamp | undefined;
+  history: Date[];
+}
+
+function createBaseEvent(): Event {
+  return { name: '', createdAt: undefined, createdAtRaw: undefined, history: [] };
+}
+
+export const Event = {
+  encode(message: Event, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    if (
+      message.createdAtRaw !== undefined &&
+      message.createdAt?.getTime() === fromTimestamp(message.createdAtRaw).getTime()
+    ) {
+      Timestamp.encode(message.createdAtRaw, writer.uint32(18).fork()).ldelim();
+    } else if (message.createdAt !== undefined) {
+      Timestamp.encode(toTimestamp(message.createdAt), writer.uint32(18).fork()).ldelim();
+    }
+    for (const v of message.history) {
+      Timestamp.encode(toTimestamp(v!), writer.uint32(26).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Event {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEvent();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        case 2:
+          message.createdAtRaw = Timestamp.decode(reader, reader.uint32());
+          message.createdAt = fromTimestamp(message.createdAtRaw);
+          break;
+        case 3:
+          message.history.push(fromTimestamp(Timestamp.decode(reader, reader.uint32())));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Event {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+      createdAt: isSet(object.createdAt) ? fromJsonTimestamp(object.createdAt) : undefined,
+      history: Array.isArray(object?.history) ? object.history.map((e: any) => fromJsonTimestamp(e)) : [],
+    };
+  },
+
+  toJSON(message: Event): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.createdAt !== undefined && (obj.createdAt = message.createdAt.toISOString());
+    if (message.history) {
+      obj.history = message.history.map((e) => e.toISOString());
+    } else {
+      obj.history = [];
+    }
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Event>, I>>(object: I): Event {
+    const message = createBaseEvent();
+    message.name = object.name ?? '';
+    message.createdAt = object.createdAt ?? undefined;
+    message.createdAtRaw =
+      object.createdAtRaw !== undefined && object.createdAtRaw !== null
+        ? Timestamp.fromPartial(object.createdAtRaw)
+        : undefined;
+    message.history = object.history?.map((e) => e) || [];
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+function toTimestamp(date: Date): Timestamp {
+  const seconds = Math.floor(date.getTime() / 1_000);
+  const nanos = (((date.getTime() % 1_000) + 1_000) % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = t.seconds * 1_000;
+  millis += t.nanos / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === 'string') {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,45 @@
+import { Event } from './event';
+
+const raw = { seconds: 1_700_000_000, nanos: 123_456_789 };
+const launch: Event = { name: 'launch', createdAt: new Date(1_700_000_000_123), createdAtRaw: raw, history: [] };
+
+function roundTrip(event: Event): Event {
+  return Event.decode(Event.encode(event).finish());
+}
+
+describe('timestamp-keep-raw', () => {
+  it('keeps the decoded Timestamp next to the Date', () => {
+    const decoded = roundTrip(launch);
+    expect(decoded.createdAt).toEqual(new Date(1_700_000_000_123));
+    expect(decoded.createdAtRaw).toEqual(raw);
+  });
+
+  it('re-encodes the nanoseconds of an unchanged Date', () => {
+    const decoded = roundTrip(launch);
+    expect(roundTrip(decoded).createdAtRaw).toEqual(raw);
+  });
+
+  it('encodes from the Date once it has been changed', () => {
+    const decoded = roundTrip(launch);
+    decoded.createdAt = new Date(1_800_000_000_456);
+    const again = roundTrip(decoded);
+    expect(again.createdAt).toEqual(new Date(1_800_000_000_456));
+    expect(again.createdAtRaw).toEqual({ seconds: 1_800_000_000, nanos: 456_000_000 });
+  });
+
+  it('encodes from the Date when there is no raw Timestamp', () => {
+    const decoded = roundTrip({ ...launch, createdAtRaw: undefined });
+    expect(decoded.createdAtRaw).toEqual({ seconds: 1_700_000_000, nanos: 123_000_000 });
+  });
+
+  it('copies the raw Timestamp in fromPartial', () => {
+    const message = Event.fromPartial({ createdAt: new Date(1_700_000_000_123), createdAtRaw: raw });
+    expect(message.createdAtRaw).toEqual(raw);
+    expect(message.createdAtRaw).not.toBe(raw);
+    expect(Event.fromPartial({}).createdAtRaw).toBeUndefined();
+  });
+
+  it('only adds raw fields for singular Timestamps', () => {
+    expect(Object.keys(Event.fromPartial({}))).toEqual(['name', 'createdAt', 'createdAtRaw', 'history']);
+  });
+});
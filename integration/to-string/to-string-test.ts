@@ -0,0 +1,33 @@
+import { Post, postToString, Status } from './to-string';
+
+describe('to-string', () => {
+  const post: Post = {
+    id: 1,
+    title: 'hello',
+    data: new Uint8Array(16),
+    tags: ['a', 'b'],
+    author: { name: 'ada' },
+    status: Status.STATUS_ACTIVE,
+    target: { $case: 'userId', userId: 'u1' },
+  };
+
+  it('summarizes the message on one line', () => {
+    expect(postToString(post)).toEqual(
+      'Post{id=1, title="hello", data=bytes[16], tags=["a", "b"], author={name="ada"}, ' +
+        'status=STATUS_ACTIVE, target={$case="userId", userId="u1"}}'
+    );
+  });
+
+  it('leaves out unset fields but keeps fields at their default value', () => {
+    expect(postToString({ ...post, id: 0, author: undefined, target: undefined })).toEqual(
+      'Post{id=0, title="hello", data=bytes[16], tags=["a", "b"], status=STATUS_ACTIVE}'
+    );
+  });
+
+  it('truncates long arrays', () => {
+    const tags = Array.from({ length: 12 }, (_, i) => String(i));
+    expect(postToString({ ...post, tags })).toContain(
+      'tags=["0", "1", "2", "3", "4", "5", "6", "7", "8", "9", ... 2 more]'
+    );
+  });
+});
@@ -0,0 +1,206 @@
+/* eslint-disable */
+
+export const protobufPackage = 'tostring';
+
+export enum Status {
+  STATUS_UNKNOWN = 0,
+  STATUS_ACTIVE = 1,
+  UNRECOGNIZED = -1,
+}
+
+export function statusFromJSON(object: any): Status {
+  switch (object) {
+    case 0:
+    case 'STATUS_UNKNOWN':
+      return Status.STATUS_UNKNOWN;
+    case 1:
+    case 'STATUS_ACTIVE':
+      return Status.STATUS_ACTIVE;
+    case -1:
+    case 'UNRECOGNIZED':
+    default:
+      return Status.UNRECOGNIZED;
+  }
+}
+
+export function statusToJSON(object: Status): string {
+  switch (object) {
+    case Status.STATUS_UNKNOWN:
+      return 'STATUS_UNKNOWN';
+    case Status.STATUS_ACTIVE:
+      return 'STATUS_ACTIVE';
+    case Status.UNRECOGNIZED:
+    default:
+      return 'UNRECOGNIZED';
+  }
+}
+
+export interface Author {
+  name: string;
+}
+
+export function authorToString(message: Author): string {
+  const parts: string[] = [];
+  if (message.name !== undefined) {
+    parts.push('name=' + debugString(message.name));
+  }
+  return 'Author{' + parts.join(', ') + '}';
+}
+
+export interface Post {
+  id: number;
+  title: string;
+  data: Uint8Array;
+  tags: string[];
+  author: Author | undefined;
+  status: Status;
+  target?: { $case: 'userId'; userId: string } | { $case: 'groupId'; groupId: string };
+}
+
+export function postToString(message: Post): string {
+  const parts: string[] = [];
+  if (message.id !== undefined) {
+    parts.push('id=' + debugString(message.id));
+  }
+  if (message.title !== undefined) {
+    parts.push('title=' + debugString(message.title));
+  }
+  if (message.data !== undefined) {
+    parts.push('data=' + debugString(message.data));
+  }
+  if (message.tags !== undefined) {
+    parts.push('tags=' + debugString(message.tags));
+  }
+  if (message.author !== undefined) {
+    parts.push('author=' + debugString(message.author));
+  }
+  if (message.status !== undefined) {
+    parts.push('status=' + statusToJSON(message.status));
+  }
+  if (message.target !== undefined) {
+    parts.push('target=' + debugString(message.target));
+  }
+  return 'Post{' + parts.join(', ') + '}';
+}
+
+function createBaseAuthor(): Author {
+  return { name: '' };
+}
+
+export const Author = {
+  fromJSON(object: any): Author {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+    };
+  },
+
+  toJSON(message: Author): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+};
+
+function createBasePost(): Post {
+  return { id: 0, title: '', data: new Uint8Array(), tags: [], author: undefined, status: 0, target: undefined };
+}
+
+export const Post = {
+  fromJSON(object: any): Post {
+    return {
+      id: isSet(object.id) ? Number(object.id) : 0,
+      title: isSet(object.title) ? String(object.title) : '',
+      data: isSet(object.data)
+        ? object.data instanceof Uint8Array
+          ? object.data
+          : bytesFromBase64(object.data)
+        : new Uint8Array(),
+      tags: Array.isArray(object?.tags) ? object.tags.map((e: any) => String(e)) : [],
+      author: isSet(object.author) ? Author.fromJSON(object.author) : undefined,
+      status: isSet(object.status) ? statusFromJSON(object.status) : 0,
+      target: isSet(object.userId)
+        ? { $case: 'userId', userId: String(object.userId) }
+        : isSet(object.groupId)
+        ? { $case: 'groupId', groupId: String(object.groupId) }
+        : undefined,
+    };
+  },
+
+  toJSON(message: Post): unknown {
+    const obj: any = {};
+    message.id !== undefined && (obj.id = Math.round(message.id));
+    message.title !== undefined && (obj.title = message.title);
+    message.data !== undefined &&
+      (obj.data = base64FromBytes(message.data !== undefined ? message.data : new Uint8Array()));
+    if (message.tags) {
+      obj.tags = message.tags.map((e) => e);
+    } else {
+      obj.tags = [];
+    }
+    message.author !== undefined && (obj.author = message.author ? Author.toJSON(message.author) : undefined);
+    message.status !== undefined && (obj.status = statusToJSON(message.status));
+    message.target?.$case === 'userId' && (obj.userId = message.target?.userId);
+    message.target?.$case === 'groupId' && (obj.groupId = message.target?.groupId);
+    return obj;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+const atob: (b64: string) => string =
+  globalThis.atob || ((b64) => globalThis.Buffer.from(b64, 'base64').toString('binary'));
+function bytesFromBase64(b64: string): Uint8Array {
+  const bin = atob(b64);
+  const arr = new Uint8Array(bin.length);
+  for (let i = 0; i < bin.length; ++i) {
+    arr[i] = bin.charCodeAt(i);
+  }
+  return arr;
+}
+
+const btoa: (bin: string) => string =
+  globalThis.btoa || ((bin) => globalThis.Buffer.from(bin, 'binary').toString('base64'));
+function base64FromBytes(arr: Uint8Array): string {
+  const bin: string[] = [];
+  arr.forEach((byte) => {
+    bin.push(String.fromCharCode(byte));
+  });
+  return btoa(bin.join(''));
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
+
+function debugString(value: any, maxLength?: number): string {
+  if (typeof value === 'string') {
+    const truncated = maxLength !== undefined && value.length > maxLength;
+    return JSON.stringify(truncated ? value.slice(0, maxLength) : value) + (truncated ? '...' : '');
+  } else if (value instanceof Uint8Array) {
+    return `bytes[${value.length}]`;
+  } else if (value instanceof Date) {
+    return value.toISOString();
+  } else if (Array.isArray(value)) {
+    const shown = value.slice(0, 10).map((v) => debugString(v, maxLength));
+    if (value.length > 10) {
+      shown.push(`... ${value.length - 10} more`);
+    }
+    return '[' + shown.join(', ') + ']';
+  } else if (typeof value === 'object' && value !== null && value.toString === Object.prototype.toString) {
+    const parts = Object.entries(value)
+      .filter(([_, v]) => v !== undefined)
+      .map(([k, v]) => k + '=' + debugString(v, maxLength));
+    return '{' + parts.join(', ') + '}';
+  }
+  // Numbers, booleans and anything with its own toString, i.e. Long and ObjectId
+  return String(value);
+}
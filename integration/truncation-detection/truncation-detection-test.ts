@@ -0,0 +1,37 @@
+import { Writer } from 'protobufjs';
+import { Area, Point, TruncatedError } from './point';
+
+describe('truncation-detection', () => {
+  const bytes = Area.encode({ nw: { lat: 1, lng: 2 }, se: { lat: 3, lng: 4 } }).finish();
+
+  it('decodes complete input', () => {
+    expect(Area.decode(bytes)).toEqual({ nw: { lat: 1, lng: 2 }, se: { lat: 3, lng: 4 } });
+  });
+
+  it('throws a TruncatedError for input that ends within a field', () => {
+    const p = Point.encode({ lat: 1, lng: 2 }).finish();
+    expect(() => Point.decode(p.subarray(0, p.length - 3))).toThrow(TruncatedError);
+  });
+
+  it('throws a TruncatedError for input that ends within a sub-message', () => {
+    // Cutting the input between the two sub-messages leaves a complete message, with only `nw` set
+    const boundary = bytes.length / 2;
+    expect(Area.decode(bytes.subarray(0, boundary))).toEqual({ nw: { lat: 1, lng: 2 }, se: undefined });
+    for (let length = 1; length < bytes.length; length++) {
+      if (length === boundary) {
+        continue;
+      }
+      expect(() => Area.decode(bytes.subarray(0, length))).toThrow(TruncatedError);
+    }
+  });
+
+  it('throws a TruncatedError when the length is past the end of the input', () => {
+    expect(() => Area.decode(bytes, bytes.length + 1)).toThrow(TruncatedError);
+  });
+
+  it('passes other errors through', () => {
+    const corrupt = Writer.create().uint32(8).uint32(1).finish();
+    expect(() => Point.decode(corrupt)).toThrow('Invalid wire type 0 for field lat of Point');
+    expect(() => Point.decode(corrupt)).not.toThrow(TruncatedError);
+  });
+});
@@ -0,0 +1,10 @@
+/* eslint-disable */
+export const protobufPackage = 'bar';
+
+export interface Bar {
+  value: string;
+}
+
+export interface Status {
+  code: number;
+}
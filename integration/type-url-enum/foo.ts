@@ -0,0 +1,22 @@
+/* eslint-disable */
+import type { Bar } from './bar/bar';
+
+export const protobufPackage = 'foo';
+
+export interface Foo {
+  labels: { [key: string]: string };
+  bar: Bar | undefined;
+}
+
+export interface Foo_Inner {
+  value: string;
+}
+
+export interface Foo_LabelsEntry {
+  key: string;
+  value: string;
+}
+
+export interface Status {
+  value: string;
+}
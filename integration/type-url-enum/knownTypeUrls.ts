@@ -0,0 +1,14 @@
+/* eslint-disable */
+export enum KnownTypeUrl {
+  Bar = 'type.googleapis.com/bar.Bar',
+  bar_Status = 'type.googleapis.com/bar.Status',
+  Foo = 'type.googleapis.com/foo.Foo',
+  Foo_Inner = 'type.googleapis.com/foo.Foo.Inner',
+  foo_Status = 'type.googleapis.com/foo.Status',
+}
+
+const knownTypeUrls = new Set<string>(Object.values(KnownTypeUrl));
+
+export function isKnownTypeUrl(typeUrl: string): typeUrl is KnownTypeUrl {
+  return knownTypeUrls.has(typeUrl);
+}
@@ -0,0 +1,28 @@
+import { isKnownTypeUrl, KnownTypeUrl } from './knownTypeUrls';
+
+describe('type-url-enum', () => {
+  it('has the type URLs of all messages, but not of map entries', () => {
+    expect(Object.values(KnownTypeUrl)).toEqual([
+      'type.googleapis.com/bar.Bar',
+      'type.googleapis.com/bar.Status',
+      'type.googleapis.com/foo.Foo',
+      'type.googleapis.com/foo.Foo.Inner',
+      'type.googleapis.com/foo.Status',
+    ]);
+  });
+
+  it('names the messages of the same name by their package', () => {
+    expect(KnownTypeUrl.bar_Status).toEqual('type.googleapis.com/bar.Status');
+    expect(KnownTypeUrl.foo_Status).toEqual('type.googleapis.com/foo.Status');
+  });
+
+  it('narrows strings to known type URLs', () => {
+    const typeUrl: string = 'type.googleapis.com/foo.Foo.Inner';
+    expect(isKnownTypeUrl(typeUrl)).toBe(true);
+    expect(isKnownTypeUrl('type.googleapis.com/foo.Unknown')).toBe(false);
+    if (isKnownTypeUrl(typeUrl)) {
+      const urls: KnownTypeUrl[] = [typeUrl];
+      expect(urls).toEqual([KnownTypeUrl.Foo_Inner]);
+    }
+  });
+});
@@ -25,8 +25,31 @@ describe('useDate=false', () => {
     expect(Metadata.fromJSON(json).lastEdited).toMatchInlineSnapshot(`
       Object {
         "nanos": 234000000,
-        "seconds": 123456789.234,
+        "seconds": 123456789,
       }
     `);
   });
+
+  it('converts json with offsets other than Z to UTC', () => {
+    const utc = { seconds: 1577836800, nanos: 0 };
+    expect(Metadata.fromJSON({ lastEdited: '2020-01-01T09:00:00+09:00' }).lastEdited).toEqual(utc);
+    expect(Metadata.fromJSON({ lastEdited: '2019-12-31T19:00:00-05:00' }).lastEdited).toEqual(utc);
+    expect(Metadata.fromJSON({ lastEdited: '2020-01-01T05:30:00+05:30' }).lastEdited).toEqual(utc);
+  });
+
+  it('converts json with fractional seconds and offsets to UTC', () => {
+    expect(Metadata.fromJSON({ lastEdited: '2020-01-01T09:00:00.250+09:00' }).lastEdited).toEqual({
+      seconds: 1577836800,
+      nanos: 250000000,
+    });
+    expect(Metadata.fromJSON({ lastEdited: '2019-12-31T19:00:01.5-05:00' }).lastEdited).toEqual({
+      seconds: 1577836801,
+      nanos: 500000000,
+    });
+  });
+
+  it('always encodes json in UTC', () => {
+    const message = Metadata.fromJSON({ lastEdited: '2020-01-01T09:00:00.250+09:00' });
+    expect(Metadata.toJSON(message)).toEqual({ lastEdited: '2020-01-01T00:00:00.250Z' });
+  });
 });
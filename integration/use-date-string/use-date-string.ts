@@ -206,8 +206,8 @@ export type Exact<P, I extends P> = P extends Builtin
 
 function toTimestamp(dateStr: string): Timestamp {
   const date = new Date(dateStr);
-  const seconds = date.getTime() / 1_000;
-  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  const seconds = Math.floor(date.getTime() / 1_000);
+  const nanos = (((date.getTime() % 1_000) + 1_000) % 1_000) * 1_000_000;
   return { seconds, nanos };
 }
 
@@ -1,3 +1,5 @@
+import { Reader } from 'protobufjs/minimal';
+import { Timestamp } from './google/protobuf/timestamp';
 import { Todo, Clock } from './use-date-true';
 
 const jan1 = new Date('1970-01-01T00:00:00.000Z');
@@ -51,4 +53,33 @@ describe('useDate=true', () => {
       }
     `);
   });
+
+  it('converts json with offsets other than Z to UTC', () => {
+    const json = {
+      id: 'offsets',
+      timestamp: '1970-02-01T09:00:00+09:00',
+      repeatedTimestamp: ['1969-12-31T19:00:00-05:00', '1970-02-01T09:00:00.125+09:00'],
+      mapOfTimestamps: { jan1: '1970-01-01T05:30:00.5+05:30' },
+    };
+    const todo = Todo.fromJSON(json);
+    expect(todo.timestamp).toEqual(feb1);
+    expect(todo.repeatedTimestamp).toEqual([jan1, new Date(feb1.getTime() + 125)]);
+    expect(todo.mapOfTimestamps.jan1).toEqual(new Date(jan1.getTime() + 500));
+    expect(Todo.decode(Todo.encode(todo).finish())).toEqual(todo);
+
+    expect(Todo.toJSON(todo)).toEqual({
+      id: 'offsets',
+      timestamp: '1970-02-01T00:00:00.000Z',
+      repeatedTimestamp: ['1970-01-01T00:00:00.000Z', '1970-02-01T00:00:00.125Z'],
+      mapOfTimestamps: { jan1: '1970-01-01T00:00:00.500Z' },
+    });
+  });
+
+  it('encodes dates before the epoch with non-negative nanos', () => {
+    const bytes = Todo.encode(Todo.fromPartial({ timestamp: new Date(-1_500) })).finish();
+    const reader = new Reader(bytes);
+    expect(reader.uint32()).toEqual(18);
+    expect(Timestamp.decode(reader, reader.uint32())).toEqual({ seconds: -2, nanos: 500_000_000 });
+    expect(Todo.decode(bytes).timestamp).toEqual(new Date(-1_500));
+  });
 });
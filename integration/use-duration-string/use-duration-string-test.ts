@@ -0,0 +1,33 @@
+import { Duration } from './google/protobuf/duration';
+import { Timer } from './use-duration-string';
+
+describe('useDuration=string', () => {
+  it('parses the seconds and nanos of the JSON form exactly', () => {
+    const timer = Timer.fromJSON({ timeout: '3.000000001s', intervals: ['-0.000000001s', '315576000000.999999999s'] });
+    expect(timer).toEqual({ timeout: '3.000000001s', intervals: ['-0.000000001s', '315576000000.999999999s'] });
+  });
+
+  it('encodes nanosecond durations without going through a float', () => {
+    const timer: Timer = { timeout: '3.000000001s', intervals: ['-1.999999999s'] };
+    const bytes = Timer.encode(timer).finish();
+    // Field 1 is the timeout's Duration message, which is written as seconds=3 and nanos=1
+    expect(Duration.decode(bytes.subarray(2, 2 + bytes[1]))).toEqual({ seconds: 3, nanos: 1 });
+    expect(Timer.decode(bytes)).toEqual(timer);
+  });
+
+  it('round-trips through JSON losslessly', () => {
+    const timer: Timer = { timeout: '0.123456789s', intervals: ['1s', '1.500s', '-2.000001s'] };
+    expect(Timer.fromJSON(Timer.toJSON(timer))).toEqual(timer);
+    expect(Timer.decode(Timer.encode(Timer.fromJSON(Timer.toJSON(timer))).finish())).toEqual(timer);
+  });
+
+  it('formats fractions with 0, 3, 6 or 9 digits', () => {
+    const timer = Timer.fromJSON({ intervals: ['1.5s', '1.0000005s', '2.000000000s'] });
+    expect(timer.intervals).toEqual(['1.500s', '1.000000500s', '2s']);
+  });
+
+  it('rejects durations that are not in the JSON form', () => {
+    expect(() => Timer.fromJSON({ timeout: '1.0000000001s' })).toThrow('Invalid duration 1.0000000001s');
+    expect(() => Timer.fromJSON({ timeout: '1.5' })).toThrow('Invalid duration 1.5');
+  });
+});
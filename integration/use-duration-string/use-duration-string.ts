@@ -0,0 +1,110 @@
+/* eslint-disable */
+import { Duration } from './google/protobuf/duration';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = '';
+
+export interface Timer {
+  timeout: string | undefined;
+  intervals: string[];
+}
+
+function createBaseTimer(): Timer {
+  return { timeout: undefined, intervals: [] };
+}
+
+export const Timer = {
+  encode(message: Timer, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.timeout !== undefined) {
+      Duration.encode(toDuration(message.timeout), writer.uint32(10).fork()).ldelim();
+    }
+    for (const v of message.intervals) {
+      Duration.encode(toDuration(v!), writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Timer {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTimer();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.timeout = fromDuration(Duration.decode(reader, reader.uint32()));
+          break;
+        case 2:
+          message.intervals.push(fromDuration(Duration.decode(reader, reader.uint32())));
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Timer {
+    return {
+      timeout: isSet(object.timeout) ? fromJsonDuration(object.timeout) : undefined,
+      intervals: Array.isArray(object?.intervals) ? object.intervals.map((e: any) => fromJsonDuration(e)) : [],
+    };
+  },
+
+  toJSON(message: Timer): unknown {
+    const obj: any = {};
+    message.timeout !== undefined && (obj.timeout = message.timeout);
+    if (message.intervals) {
+      obj.intervals = message.intervals.map((e) => e);
+    } else {
+      obj.intervals = [];
+    }
+    return obj;
+  },
+};
+
+declare var self: any | undefined;
+declare var window: any | undefined;
+declare var global: any | undefined;
+var globalThis: any = (() => {
+  if (typeof globalThis !== 'undefined') return globalThis;
+  if (typeof self !== 'undefined') return self;
+  if (typeof window !== 'undefined') return window;
+  if (typeof global !== 'undefined') return global;
+  throw 'Unable to locate global object';
+})();
+
+function toDuration(text: string): Duration {
+  const match = /^(-)?(\d+)(?:\.(\d{1,9}))?s$/.exec(text);
+  if (!match || Number(match[2]) > 315_576_000_000) {
+    throw new globalThis.Error('Invalid duration ' + text);
+  }
+  const sign = match[1] ? -1 : 1;
+  const seconds = sign * Number(match[2]) || 0;
+  const nanos = sign * Number((match[3] ?? '').padEnd(9, '0')) || 0;
+  return { seconds: seconds, nanos };
+}
+
+function fromDuration(d: Duration): string {
+  const seconds = d.seconds;
+  const sign = seconds < 0 || d.nanos < 0 ? '-' : '';
+  let fraction = '';
+  if (d.nanos !== 0) {
+    fraction = '.' + Math.abs(d.nanos).toString().padStart(9, '0');
+    fraction = fraction.replace(/000000$/, '').replace(/000$/, '');
+  }
+  return sign + Math.abs(seconds) + fraction + 's';
+}
+
+function fromJsonDuration(o: any): string {
+  if (typeof o === 'string') {
+    return fromDuration(toDuration(o));
+  } else {
+    return fromDuration(Duration.fromJSON(o));
+  }
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
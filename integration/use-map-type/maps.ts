@@ -0,0 +1,437 @@
+/* eslint-disable */
+import * as Long from 'long';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'maps';
+
+export interface Entity {
+  name: string;
+}
+
+export interface Maps {
+  entities: Map<string, Entity>;
+  namesById: Map<Long, string>;
+  counts: Map<boolean, number>;
+}
+
+export interface Maps_EntitiesEntry {
+  key: string;
+  value: Entity | undefined;
+}
+
+export interface Maps_NamesByIdEntry {
+  key: Long;
+  value: string;
+}
+
+export interface Maps_CountsEntry {
+  key: boolean;
+  value: number;
+}
+
+function createBaseEntity(): Entity {
+  return { name: '' };
+}
+
+export const Entity = {
+  encode(message: Entity, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== '') {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Entity {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEntity();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.name = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Entity {
+    return {
+      name: isSet(object.name) ? String(object.name) : '',
+    };
+  },
+
+  toJSON(message: Entity): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Entity>, I>>(object: I): Entity {
+    const message = createBaseEntity();
+    message.name = object.name ?? '';
+    return message;
+  },
+};
+
+function createBaseMaps(): Maps {
+  return { entities: new Map(), namesById: new Map(), counts: new Map() };
+}
+
+export const Maps = {
+  encode(message: Maps, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    [...message.entities.entries()].forEach(([key, value]) => {
+      Maps_EntitiesEntry.encode({ key: key as any, value }, writer.uint32(10).fork()).ldelim();
+    });
+    [...message.namesById.entries()].forEach(([key, value]) => {
+      Maps_NamesByIdEntry.encode({ key: key as any, value }, writer.uint32(18).fork()).ldelim();
+    });
+    [...message.counts.entries()].forEach(([key, value]) => {
+      Maps_CountsEntry.encode({ key: key as any, value }, writer.uint32(26).fork()).ldelim();
+    });
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Maps {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaps();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          const entry1 = Maps_EntitiesEntry.decode(reader, reader.uint32());
+          if (entry1.value !== undefined) {
+            message.entities.set(entry1.key, entry1.value);
+          }
+          break;
+        case 2:
+          const entry2 = Maps_NamesByIdEntry.decode(reader, reader.uint32());
+          if (entry2.value !== undefined) {
+            message.namesById.set(entry2.key, entry2.value);
+          }
+          break;
+        case 3:
+          const entry3 = Maps_CountsEntry.decode(reader, reader.uint32());
+          if (entry3.value !== undefined) {
+            message.counts.set(entry3.key, entry3.value);
+          }
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Maps {
+    return {
+      entities: isObject(object.entities)
+        ? new Map(
+            Object.entries(object.entities).map(([key, value]): [string, Entity] => [key, Entity.fromJSON(value)])
+          )
+        : new Map(),
+      namesById: isObject(object.namesById)
+        ? new Map(
+            Object.entries(object.namesById).map(([key, value]): [Long, string] => [
+              Long.fromString(key, false),
+              String(value),
+            ])
+          )
+        : new Map(),
+      counts: isObject(object.counts)
+        ? new Map(
+            Object.entries(object.counts).map(([key, value]): [boolean, number] => [key === 'true', Number(value)])
+          )
+        : new Map(),
+    };
+  },
+
+  toJSON(message: Maps): unknown {
+    const obj: any = {};
+    obj.entities = {};
+    if (message.entities) {
+      message.entities.forEach((v, k) => {
+        obj.entities[String(k)] = Entity.toJSON(v);
+      });
+    }
+    obj.namesById = {};
+    if (message.namesById) {
+      message.namesById.forEach((v, k) => {
+        obj.namesById[String(k)] = v;
+      });
+    }
+    obj.counts = {};
+    if (message.counts) {
+      message.counts.forEach((v, k) => {
+        obj.counts[String(k)] = Math.round(v);
+      });
+    }
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Maps>, I>>(object: I): Maps {
+    const message = createBaseMaps();
+    const map1: Map<string, Entity> = new Map();
+    if (object.entities instanceof Map) {
+      object.entities.forEach((value, key) => {
+        if (value !== undefined) {
+          map1.set(key, Entity.fromPartial(value));
+        }
+      });
+    } else {
+      Object.entries(object.entities ?? {}).forEach(([key, value]) => {
+        if (value !== undefined) {
+          map1.set(key, Entity.fromPartial(value));
+        }
+      });
+    }
+    message.entities = map1;
+    const map2: Map<Long, string> = new Map();
+    if (object.namesById instanceof Map) {
+      object.namesById.forEach((value, key) => {
+        if (value !== undefined) {
+          map2.set(key, String(value));
+        }
+      });
+    } else {
+      Object.entries(object.namesById ?? {}).forEach(([key, value]) => {
+        if (value !== undefined) {
+          map2.set(Long.fromString(key, false), String(value));
+        }
+      });
+    }
+    message.namesById = map2;
+    const map3: Map<boolean, number> = new Map();
+    if (object.counts instanceof Map) {
+      object.counts.forEach((value, key) => {
+        if (value !== undefined) {
+          map3.set(key, Number(value));
+        }
+      });
+    } else {
+      Object.entries(object.counts ?? {}).forEach(([key, value]) => {
+        if (value !== undefined) {
+          map3.set(key === 'true', Number(value));
+        }
+      });
+    }
+    message.counts = map3;
+    return message;
+  },
+};
+
+function createBaseMaps_EntitiesEntry(): Maps_EntitiesEntry {
+  return { key: '', value: undefined };
+}
+
+export const Maps_EntitiesEntry = {
+  encode(message: Maps_EntitiesEntry, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.key !== '') {
+      writer.uint32(10).string(message.key);
+    }
+    if (message.value !== undefined) {
+      Entity.encode(message.value, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Maps_EntitiesEntry {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaps_EntitiesEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.key = reader.string();
+          break;
+        case 2:
+          message.value = Entity.decode(reader, reader.uint32());
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Maps_EntitiesEntry {
+    return {
+      key: isSet(object.key) ? String(object.key) : '',
+      value: isSet(object.value) ? Entity.fromJSON(object.value) : undefined,
+    };
+  },
+
+  toJSON(message: Maps_EntitiesEntry): unknown {
+    const obj: any = {};
+    message.key !== undefined && (obj.key = message.key);
+    message.value !== undefined && (obj.value = message.value ? Entity.toJSON(message.value) : undefined);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Maps_EntitiesEntry>, I>>(object: I): Maps_EntitiesEntry {
+    const message = createBaseMaps_EntitiesEntry();
+    message.key = object.key ?? '';
+    message.value = object.value !== undefined && object.value !== null ? Entity.fromPartial(object.value) : undefined;
+    return message;
+  },
+};
+
+function createBaseMaps_NamesByIdEntry(): Maps_NamesByIdEntry {
+  return { key: Long.ZERO, value: '' };
+}
+
+export const Maps_NamesByIdEntry = {
+  encode(message: Maps_NamesByIdEntry, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (!message.key.isZero()) {
+      writer.uint32(8).int64(message.key);
+    }
+    if (message.value !== '') {
+      writer.uint32(18).string(message.value);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Maps_NamesByIdEntry {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaps_NamesByIdEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.key = reader.int64() as Long;
+          break;
+        case 2:
+          message.value = reader.string();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Maps_NamesByIdEntry {
+    return {
+      key: isSet(object.key) ? Long.fromValue(object.key) : Long.ZERO,
+      value: isSet(object.value) ? String(object.value) : '',
+    };
+  },
+
+  toJSON(message: Maps_NamesByIdEntry): unknown {
+    const obj: any = {};
+    message.key !== undefined && (obj.key = (message.key || Long.ZERO).toString());
+    message.value !== undefined && (obj.value = message.value);
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Maps_NamesByIdEntry>, I>>(object: I): Maps_NamesByIdEntry {
+    const message = createBaseMaps_NamesByIdEntry();
+    message.key = object.key !== undefined && object.key !== null ? Long.fromValue(object.key) : Long.ZERO;
+    message.value = object.value ?? '';
+    return message;
+  },
+};
+
+function createBaseMaps_CountsEntry(): Maps_CountsEntry {
+  return { key: false, value: 0 };
+}
+
+export const Maps_CountsEntry = {
+  encode(message: Maps_CountsEntry, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.key === true) {
+      writer.uint32(8).bool(message.key);
+    }
+    if (message.value !== 0) {
+      writer.uint32(16).int32(message.value);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Maps_CountsEntry {
+    const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaps_CountsEntry();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          message.key = reader.bool();
+          break;
+        case 2:
+          message.value = reader.int32();
+          break;
+        default:
+          reader.skipType(tag & 7);
+          break;
+      }
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Maps_CountsEntry {
+    return {
+      key: isSet(object.key) ? Boolean(object.key) : false,
+      value: isSet(object.value) ? Number(object.value) : 0,
+    };
+  },
+
+  toJSON(message: Maps_CountsEntry): unknown {
+    const obj: any = {};
+    message.key !== undefined && (obj.key = message.key);
+    message.value !== undefined && (obj.value = Math.round(message.value));
+    return obj;
+  },
+
+  fromPartial<I extends Exact<DeepPartial<Maps_CountsEntry>, I>>(object: I): Maps_CountsEntry {
+    const message = createBaseMaps_CountsEntry();
+    message.key = object.key ?? false;
+    message.value = object.value ?? 0;
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Long
+  ? string | number | Long
+  : T extends Map<infer K, infer V>
+  ? Map<K, DeepPartial<V>> | { [key: string]: DeepPartial<V> }
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
+
+// If you get a compile-error about 'Constructor<Long> and ... have no overlap',
+// add '--ts_proto_opt=esModuleInterop=true' as a flag when calling 'protoc'.
+if (_m0.util.Long !== Long) {
+  _m0.util.Long = Long as any;
+  _m0.configure();
+}
+
+function isObject(value: any): boolean {
+  return typeof value === 'object' && value !== null;
+}
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
@@ -0,0 +1,85 @@
+import * as Long from 'long';
+import { Maps } from './maps';
+
+const maps: Maps = {
+  entities: new Map([
+    ['a', { name: 'Ada' }],
+    ['b', { name: 'Bob' }],
+  ]),
+  namesById: new Map([
+    [Long.fromNumber(1), 'one'],
+    [Long.fromString('9223372036854775807'), 'max'],
+  ]),
+  counts: new Map([
+    [true, 3],
+    [false, 1],
+  ]),
+};
+
+describe('use-map-type', () => {
+  it('creates empty Maps', () => {
+    expect(Maps.fromPartial({})).toEqual({ entities: new Map(), namesById: new Map(), counts: new Map() });
+  });
+
+  it('round-trips through encode and decode', () => {
+    const decoded = Maps.decode(Maps.encode(maps).finish());
+    expect(decoded).toEqual(maps);
+    expect(decoded.entities).toBeInstanceOf(Map);
+    expect([...decoded.namesById.keys()].map((key) => key.toString())).toEqual(['1', '9223372036854775807']);
+    expect([...decoded.counts.keys()]).toEqual([true, false]);
+  });
+
+  it('writes the maps as JSON objects with string keys', () => {
+    expect(Maps.toJSON(maps)).toEqual({
+      entities: { a: { name: 'Ada' }, b: { name: 'Bob' } },
+      namesById: { '1': 'one', '9223372036854775807': 'max' },
+      counts: { true: 3, false: 1 },
+    });
+  });
+
+  it('parses the string keys of the JSON back to the key types', () => {
+    const parsed = Maps.fromJSON({
+      entities: { a: { name: 'Ada' } },
+      namesById: { '9223372036854775807': 'max' },
+      counts: { true: 3, false: 1 },
+    });
+    expect(parsed.entities).toEqual(new Map([['a', { name: 'Ada' }]]));
+    expect(parsed.namesById).toEqual(new Map([[Long.fromString('9223372036854775807'), 'max']]));
+    expect(parsed.counts).toEqual(
+      new Map([
+        [true, 3],
+        [false, 1],
+      ])
+    );
+    expect(Maps.fromJSON(Maps.toJSON(maps))).toEqual(maps);
+  });
+
+  it('accepts Maps in fromPartial, filling in the defaults of message values', () => {
+    const message = Maps.fromPartial({
+      entities: new Map([['a', {}]]),
+      namesById: new Map([[Long.fromNumber(2), 'two']]),
+      counts: new Map([[true, 5]]),
+    });
+    expect(message).toEqual({
+      entities: new Map([['a', { name: '' }]]),
+      namesById: new Map([[Long.fromNumber(2), 'two']]),
+      counts: new Map([[true, 5]]),
+    });
+  });
+
+  it('accepts plain objects with string keys in fromPartial', () => {
+    const message = Maps.fromPartial({
+      entities: { a: { name: 'Ada' } },
+      namesById: { '9223372036854775807': 'max' },
+      counts: { true: 3, false: 1 },
+    });
+    expect(message.entities).toEqual(new Map([['a', { name: 'Ada' }]]));
+    expect(message.namesById).toEqual(new Map([[Long.fromString('9223372036854775807'), 'max']]));
+    expect(message.counts).toEqual(
+      new Map([
+        [true, 3],
+        [false, 1],
+      ])
+    );
+  });
+});
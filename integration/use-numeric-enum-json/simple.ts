@@ -130,7 +130,7 @@ export const Simple = {
       nullValue: isSet(object.nullValue) ? nullValueFromJSON(object.nullValue) : 0,
       stateMap: isObject(object.stateMap)
         ? Object.entries(object.stateMap).reduce<{ [key: string]: StateEnum }>((acc, [key, value]) => {
-            acc[key] = stateEnumFromJSON(value);
+            acc[key] = isSet(value) ? stateEnumFromJSON(value) : 0;
             return acc;
           }, {})
         : {},
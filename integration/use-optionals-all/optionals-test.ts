@@ -98,4 +98,12 @@ describe('useOptionals=all', () => {
       },
     });
   });
+
+  it('defaults omitted enums like the other scalars in fromJSON', () => {
+    const test = OptionalsTest.fromJSON({});
+    expect(test.state).toEqual(StateEnum.UNKNOWN);
+    expect(test.id).toEqual(0);
+    expect(test.optState).toBeUndefined();
+    expect(test.optId).toBeUndefined();
+  });
 })
@@ -315,21 +315,31 @@ export const OptionalsTest = {
       long: isSet(object.long) ? Number(object.long) : 0,
       truth: isSet(object.truth) ? Boolean(object.truth) : false,
       description: isSet(object.description) ? String(object.description) : '',
-      data: isSet(object.data) ? bytesFromBase64(object.data) : new Uint8Array(),
+      data: isSet(object.data)
+        ? object.data instanceof Uint8Array
+          ? object.data
+          : bytesFromBase64(object.data)
+        : new Uint8Array(),
       repId: Array.isArray(object?.repId) ? object.repId.map((e: any) => Number(e)) : [],
       repChild: Array.isArray(object?.repChild) ? object.repChild.map((e: any) => Child.fromJSON(e)) : [],
       repState: Array.isArray(object?.repState) ? object.repState.map((e: any) => stateEnumFromJSON(e)) : [],
       repLong: Array.isArray(object?.repLong) ? object.repLong.map((e: any) => Number(e)) : [],
       repTruth: Array.isArray(object?.repTruth) ? object.repTruth.map((e: any) => Boolean(e)) : [],
       repDescription: Array.isArray(object?.repDescription) ? object.repDescription.map((e: any) => String(e)) : [],
-      repData: Array.isArray(object?.repData) ? object.repData.map((e: any) => bytesFromBase64(e)) : [],
+      repData: Array.isArray(object?.repData)
+        ? object.repData.map((e: any) => (e instanceof Uint8Array ? e : bytesFromBase64(e)))
+        : [],
       optId: isSet(object.optId) ? Number(object.optId) : undefined,
       optChild: isSet(object.optChild) ? Child.fromJSON(object.optChild) : undefined,
       optState: isSet(object.optState) ? stateEnumFromJSON(object.optState) : undefined,
       optLong: isSet(object.optLong) ? Number(object.optLong) : undefined,
       optTruth: isSet(object.optTruth) ? Boolean(object.optTruth) : undefined,
       optDescription: isSet(object.optDescription) ? String(object.optDescription) : undefined,
-      optData: isSet(object.optData) ? bytesFromBase64(object.optData) : undefined,
+      optData: isSet(object.optData)
+        ? object.optData instanceof Uint8Array
+          ? object.optData
+          : bytesFromBase64(object.optData)
+        : undefined,
       translations: isObject(object.translations)
         ? Object.entries(object.translations).reduce<{ [key: string]: string }>((acc, [key, value]) => {
             acc[key] = String(value);
@@ -1,4 +1,5 @@
 import { Reader } from 'protobufjs';
+import { Value } from './google/protobuf/struct';
 import { ValueMessage } from './value';
 
 import { ValueMessage as PbValueMessage } from './pbjs';
@@ -66,4 +67,35 @@ describe('values', () => {
     });
     expect(s1).toEqual({ anyList: [1], repeatedAny: [2], repeatedStrings: [] });
   });
+
+  describe('Value', () => {
+    const kinds: { [kind: string]: unknown } = {
+      null: null,
+      number: 1.5,
+      string: 'foo',
+      bool: false,
+      struct: { a: { b: [1, null] }, c: 'd' },
+      list: [1, 'foo', true, null, { a: [] }, [2]],
+    };
+
+    Object.entries(kinds).forEach(([kind, json]) => {
+      it(`reads and writes a ${kind} value as plain JSON`, () => {
+        const value = Value.fromJSON(json);
+        expect(Value.toJSON(value)).toEqual(json);
+        expect(Value.toJSON(Value.decode(Value.encode(value).finish()))).toEqual(json);
+      });
+    });
+
+    it('wraps the JSON into the matching kind', () => {
+      expect(Value.fromJSON(null)).toEqual({ nullValue: 0 });
+      expect(Value.fromJSON(1)).toEqual({ numberValue: 1 });
+      expect(Value.fromJSON([{ a: 1 }])).toEqual({ listValue: [{ a: 1 }] });
+      expect(Value.fromJSON({ a: [1] })).toEqual({ structValue: { a: [1] } });
+    });
+
+    it('does not write the internal kind structure', () => {
+      expect(Value.toJSON(Value.fromPartial({ stringValue: 'foo' }))).toEqual('foo');
+      expect(Value.toJSON(Value.fromPartial({ structValue: { listValue: [1] } }))).toEqual({ listValue: [1] });
+    });
+  });
 });
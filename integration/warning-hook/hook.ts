@@ -0,0 +1,13 @@
+export interface ProtoWarning {
+  kind: 'unknownEnum' | 'unknownField';
+  type: string;
+  value?: unknown;
+  fieldNumber?: number;
+  wireType?: number;
+}
+
+export const warnings: ProtoWarning[] = [];
+
+export function onProtoWarning(warning: ProtoWarning): void {
+  warnings.push(warning);
+}
@@ -0,0 +1,26 @@
+import { Writer } from 'protobufjs';
+import { warnings } from './hook';
+import { Child, ChildEnum, childEnumFromJSON } from './warning-hook';
+
+describe('warning-hook', () => {
+  beforeEach(() => {
+    warnings.length = 0;
+  });
+
+  it('calls the hook for unknown enum values', () => {
+    expect(childEnumFromJSON('BAR')).toEqual(ChildEnum.UNRECOGNIZED);
+    expect(warnings).toEqual([{ kind: 'unknownEnum', type: 'ChildEnum', value: 'BAR' }]);
+  });
+
+  it('does not call the hook for known values or an explicit UNRECOGNIZED', () => {
+    expect(childEnumFromJSON('FOO')).toEqual(ChildEnum.FOO);
+    expect(childEnumFromJSON('UNRECOGNIZED')).toEqual(ChildEnum.UNRECOGNIZED);
+    expect(warnings).toEqual([]);
+  });
+
+  it('calls the hook for skipped unknown fields', () => {
+    const bytes = Writer.create().uint32(10).string('a').uint32(16).int32(5).finish();
+    expect(Child.decode(bytes)).toEqual({ name: 'a' });
+    expect(warnings).toEqual([{ kind: 'unknownField', type: 'Child', fieldNumber: 2, wireType: 0 }]);
+  });
+});
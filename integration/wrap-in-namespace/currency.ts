@@ -0,0 +1,72 @@
+/* eslint-disable */
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'fin';
+
+export namespace fin {
+  export interface Money {
+    currencyCode: string;
+    units: number;
+  }
+
+  function createBaseMoney(): Money {
+    return { currencyCode: '', units: 0 };
+  }
+
+  export const Money = {
+    encode(message: Money, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+      if (message.currencyCode !== '') {
+        writer.uint32(10).string(message.currencyCode);
+      }
+      if (message.units !== 0) {
+        writer.uint32(16).int32(message.units);
+      }
+      return writer;
+    },
+
+    decode(input: _m0.Reader | Uint8Array, length?: number): Money {
+      const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+      let end = length === undefined ? reader.len : reader.pos + length;
+      const message = createBaseMoney();
+      while (reader.pos < end) {
+        const tag = reader.uint32();
+        switch (tag >>> 3) {
+          case 1:
+            message.currencyCode = reader.string();
+            break;
+          case 2:
+            message.units = reader.int32();
+            break;
+          default:
+            reader.skipType(tag & 7);
+            break;
+        }
+      }
+      return message;
+    },
+
+    fromPartial<I extends Exact<DeepPartial<Money>, I>>(object: I): Money {
+      const message = createBaseMoney();
+      message.currencyCode = object.currencyCode ?? '';
+      message.units = object.units ?? 0;
+      return message;
+    },
+  };
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
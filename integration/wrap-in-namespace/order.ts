@@ -0,0 +1,88 @@
+/* eslint-disable */
+import * as currency from './currency';
+import * as _m0 from 'protobufjs/minimal';
+
+export const protobufPackage = 'shop.orders';
+
+export namespace shop.orders {
+  export enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_PAID = 1,
+    UNRECOGNIZED = -1,
+  }
+
+  export interface Order {
+    id: string;
+    total: currency.fin.Money | undefined;
+    status: Status;
+  }
+
+  function createBaseOrder(): Order {
+    return { id: '', total: undefined, status: 0 };
+  }
+
+  export const Order = {
+    encode(message: Order, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+      if (message.id !== '') {
+        writer.uint32(10).string(message.id);
+      }
+      if (message.total !== undefined) {
+        currency.fin.Money.encode(message.total, writer.uint32(18).fork()).ldelim();
+      }
+      if (message.status !== 0) {
+        writer.uint32(24).int32(message.status);
+      }
+      return writer;
+    },
+
+    decode(input: _m0.Reader | Uint8Array, length?: number): Order {
+      const reader = input instanceof _m0.Reader ? input : new _m0.Reader(input);
+      let end = length === undefined ? reader.len : reader.pos + length;
+      const message = createBaseOrder();
+      while (reader.pos < end) {
+        const tag = reader.uint32();
+        switch (tag >>> 3) {
+          case 1:
+            message.id = reader.string();
+            break;
+          case 2:
+            message.total = currency.fin.Money.decode(reader, reader.uint32());
+            break;
+          case 3:
+            message.status = reader.int32() as any;
+            break;
+          default:
+            reader.skipType(tag & 7);
+            break;
+        }
+      }
+      return message;
+    },
+
+    fromPartial<I extends Exact<DeepPartial<Order>, I>>(object: I): Order {
+      const message = createBaseOrder();
+      message.id = object.id ?? '';
+      message.total =
+        object.total !== undefined && object.total !== null ? currency.fin.Money.fromPartial(object.total) : undefined;
+      message.status = object.status ?? 0;
+      return message;
+    },
+  };
+}
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin
+  ? T
+  : T extends Array<infer U>
+  ? Array<DeepPartial<U>>
+  : T extends ReadonlyArray<infer U>
+  ? ReadonlyArray<DeepPartial<U>>
+  : T extends {}
+  ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+type KeysOfUnion<T> = T extends T ? keyof T : never;
+export type Exact<P, I extends P> = P extends Builtin
+  ? P
+  : P & { [K in keyof P]: Exact<P[K], I[K]> } & Record<Exclude<keyof I, KeysOfUnion<P>>, never>;
@@ -0,0 +1,33 @@
+import { fin } from './currency';
+import * as exports from './order';
+import { shop } from './order';
+
+const order: shop.orders.Order = {
+  id: 'o-1',
+  total: { currencyCode: 'EUR', units: 12 },
+  status: shop.orders.Status.STATUS_PAID,
+};
+
+describe('wrap-in-namespace', () => {
+  it('exports the messages and enums under the package namespace', () => {
+    expect(Object.keys(exports).sort()).toEqual(['protobufPackage', 'shop']);
+    expect(exports.protobufPackage).toEqual('shop.orders');
+    expect(typeof shop.orders.Order.encode).toEqual('function');
+    expect(shop.orders.Status.STATUS_PAID).toEqual(1);
+  });
+
+  it('round-trips a message that refers to another file', () => {
+    const bytes = shop.orders.Order.encode(order).finish();
+    expect(shop.orders.Order.decode(bytes)).toEqual(order);
+  });
+
+  it('uses the other file namespace for its types', () => {
+    const total: fin.Money = fin.Money.fromPartial({ currencyCode: 'EUR' });
+    expect(shop.orders.Order.fromPartial({ total })).toEqual({
+      id: '',
+      total: { currencyCode: 'EUR', units: 0 },
+      status: shop.orders.Status.STATUS_UNKNOWN,
+    });
+    expect(fin.Money.decode(fin.Money.encode(total).finish())).toEqual(total);
+  });
+});
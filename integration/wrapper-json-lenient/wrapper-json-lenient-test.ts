@@ -0,0 +1,47 @@
+import * as Long from 'long';
+import { SimpleWithWrappers } from './simple';
+
+describe('wrapper-json-lenient', () => {
+  it('reads the canonical bare values of wrapper fields', () => {
+    const s = SimpleWithWrappers.fromJSON({
+      name: 'a',
+      age: 1,
+      enabled: true,
+      bananas: '2',
+      coins: [3, 4],
+      snacks: ['b'],
+    });
+    expect(s).toEqual({
+      name: 'a',
+      age: 1,
+      enabled: true,
+      bananas: Long.fromNumber(2),
+      coins: [3, 4],
+      snacks: ['b'],
+    });
+  });
+
+  it('also reads wrapper fields in the { value } object form', () => {
+    const s = SimpleWithWrappers.fromJSON({
+      name: { value: 'a' },
+      age: { value: 1 },
+      enabled: { value: true },
+      bananas: { value: '2' },
+      coins: [{ value: 3 }, 4],
+      snacks: [{ value: 'b' }],
+    });
+    expect(s).toEqual({
+      name: 'a',
+      age: 1,
+      enabled: true,
+      bananas: Long.fromNumber(2),
+      coins: [3, 4],
+      snacks: ['b'],
+    });
+  });
+
+  it('still round-trips through the canonical form', () => {
+    const s = SimpleWithWrappers.fromJSON({ age: { value: 1 }, snacks: [{ value: 'b' }] });
+    expect(SimpleWithWrappers.fromJSON(SimpleWithWrappers.toJSON(s))).toEqual(s);
+  });
+});
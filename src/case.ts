@@ -31,3 +31,12 @@ export function capitalize(s: string): string {
 export function camelCase(s: string): string {
   return s.substring(0, 1).toLowerCase() + s.substring(1);
 }
+
+/** Turns an enum value name like `IN_PROGRESS` into a `In Progress` label. */
+export function titleCase(s: string): string {
+  return s
+    .split('_')
+    .filter((word) => word.length > 0)
+    .map((word) => capitalize(word.toLowerCase()))
+    .join(' ');
+}
@@ -7,4 +7,6 @@ export interface Context {
   options: Options;
   typeMap: TypeMap;
   utils: Utils;
+  /** The module of the file being generated, whose own types never need to be imported. */
+  currentModule?: string;
 }
@@ -1,52 +1,53 @@
 import { Context } from './context';
-import { code, Code, Import } from 'ts-poet';
-import { messageToTypeName, wrapperTypeName } from './types';
+import { code, Code } from 'ts-poet';
+import { defaultBytes, getMessageMethod, wrapperTypeName } from './types';
 import { LongOption } from './options';
-import { impProto } from './utils';
+import { impProtoMethod } from './utils';
 
 export function generateEncoder(ctx: Context, typeName: string): Code {
   const name = wrapperTypeName(typeName);
   if (!name) {
-    return code`${messageToTypeName(ctx, typeName, { keepValueType: true })}.encode(value).finish()`;
+    return code`${getMessageMethod(ctx, typeName, 'encode')}(value).finish()`;
   }
 
   if (name == 'Timestamp') {
-    const TimestampValue = impProto(ctx.options, 'google/protobuf/timestamp', name);
+    const encode = impProtoMethod(ctx, 'google/protobuf/timestamp', 'google.protobuf', name, 'encode');
 
-    return code`${TimestampValue}.encode(${ctx.utils.toTimestamp}(value)).finish()`;
+    return code`${encode}(${ctx.utils.toTimestamp}(value)).finish()`;
   }
 
   if (name == 'Struct') {
-    const StructType = impProto(ctx.options, 'google/protobuf/struct', name);
-    return code`${StructType}.encode(${StructType}.wrap(value)).finish()`;
+    const encode = impProtoMethod(ctx, 'google/protobuf/struct', 'google.protobuf', name, 'encode');
+    const wrap = impProtoMethod(ctx, 'google/protobuf/struct', 'google.protobuf', name, 'wrap');
+    return code`${encode}(${wrap}(value)).finish()`;
   }
 
   if (name == 'ListValue') {
-    const ListValueType = impProto(ctx.options, 'google/protobuf/struct', name);
-    return code`${ListValueType}.encode({values: value ?? []}).finish()`;
+    const encode = impProtoMethod(ctx, 'google/protobuf/struct', 'google.protobuf', name, 'encode');
+    return code`${encode}({values: value ?? []}).finish()`;
   }
 
-  const TypeValue = impProto(ctx.options, 'google/protobuf/wrappers', name);
+  const encode = impProtoMethod(ctx, 'google/protobuf/wrappers', 'google.protobuf', name, 'encode');
 
   switch (name) {
     case 'StringValue':
-      return code`${TypeValue}.encode({value: value ?? ""}).finish()`;
+      return code`${encode}({value: value ?? ""}).finish()`;
     case 'Int32Value':
     case 'UInt32Value':
     case 'DoubleValue':
     case 'FloatValue':
-      return code`${TypeValue}.encode({value: value ?? 0}).finish()`;
+      return code`${encode}({value: value ?? 0}).finish()`;
     case 'Int64Value':
     case 'UInt64Value':
       if (ctx.options.forceLong === LongOption.LONG) {
-        return code`${TypeValue}.encode({value: value ? value.toNumber(): 0}).finish()`;
+        return code`${encode}({value: value ? value.toNumber(): 0}).finish()`;
       }
 
-      return code`${TypeValue}.encode({value: value ?? 0 }).finish()`;
+      return code`${encode}({value: value ?? 0 }).finish()`;
     case 'BoolValue':
-      return code`${TypeValue}.encode({value: value ?? false}).finish()`;
+      return code`${encode}({value: value ?? false}).finish()`;
     case 'BytesValue':
-      return code`${TypeValue}.encode({value: value ?? new Uint8Array()}).finish()`;
+      return code`${encode}({value: value ?? ${defaultBytes(ctx.options)}}).finish()`;
   }
 
   throw new Error(`unknown wrapper type: ${name}`);
@@ -56,22 +57,21 @@ export function generateDecoder(ctx: Context, typeName: string): Code {
   const { options } = ctx;
   let name = wrapperTypeName(typeName);
   if (!name) {
-    return code`${messageToTypeName(ctx, typeName, { keepValueType: true })}.decode(value)`;
+    return code`${getMessageMethod(ctx, typeName, 'decode')}(value)`;
   }
 
-  let TypeValue: Import;
-
   if (name == 'Timestamp') {
-    TypeValue = impProto(ctx.options, 'google/protobuf/timestamp', name);
-    return code`${TypeValue}.decode(value)`;
+    const decode = impProtoMethod(ctx, 'google/protobuf/timestamp', 'google.protobuf', name, 'decode');
+    return code`${decode}(value)`;
   }
 
   if (name == 'Struct' || name == 'ListValue') {
-    TypeValue = impProto(ctx.options, 'google/protobuf/struct', name);
-    return code`${TypeValue}.unwrap(${TypeValue}.decode(value))`;
+    const decode = impProtoMethod(ctx, 'google/protobuf/struct', 'google.protobuf', name, 'decode');
+    const unwrap = impProtoMethod(ctx, 'google/protobuf/struct', 'google.protobuf', name, 'unwrap');
+    return code`${unwrap}(${decode}(value))`;
   }
 
-  TypeValue = impProto(ctx.options, 'google/protobuf/wrappers', name);
+  const decode = impProtoMethod(ctx, 'google/protobuf/wrappers', 'google.protobuf', name, 'decode');
 
-  return code`${TypeValue}.decode(value).value`;
+  return code`${decode}(value).value`;
 }
@@ -1,9 +1,10 @@
 import { code, def, Code, joinCode } from 'ts-poet';
-import { EnumDescriptorProto } from 'ts-proto-descriptors';
-import { maybeAddComment } from './utils';
-import { camelCase } from './case';
+import { EnumDescriptorProto, EnumValueDescriptorProto } from 'ts-proto-descriptors';
+import { maybeAddComment, maybeWarningHookCall } from './utils';
+import { camelCase, titleCase } from './case';
 import SourceInfo, { Fields } from './sourceInfo';
 import { Context } from './context';
+import { Options } from './options';
 
 const UNRECOGNIZED_ENUM_NAME = 'UNRECOGNIZED';
 const UNRECOGNIZED_ENUM_VALUE = -1;
@@ -50,7 +51,9 @@ export function generateEnum(
     chunks.push(code`}`);
   }
 
-  if (options.outputJsonMethods || (options.stringEnums && options.outputEncodeMethods)) {
+  // Decoding maps string enums, and unknown values for exhaustive switches, through fromJSON
+  const decodesFromJson = (options.stringEnums || options.outputEnumExhaustive) && options.outputEncodeMethods;
+  if (options.outputJsonMethods || decodesFromJson) {
     chunks.push(code`\n`);
     chunks.push(generateEnumFromJson(ctx, fullName, enumDesc));
   }
@@ -62,6 +65,22 @@ export function generateEnum(
     chunks.push(code`\n`);
     chunks.push(generateEnumToNumber(ctx, fullName, enumDesc));
   }
+  if (enumDesc.options?.allowAlias) {
+    chunks.push(code`\n`);
+    chunks.push(generateEnumAliases(ctx, fullName, enumDesc));
+  }
+  if (options.outputEnumHelpers) {
+    chunks.push(code`\n`);
+    chunks.push(generateEnumValues(ctx, fullName, enumDesc));
+  }
+  if (options.outputEnumExhaustive) {
+    chunks.push(code`\n`);
+    chunks.push(generateEnumAssertUnreachable(ctx, fullName));
+  }
+  if (options.outputEnumLabels) {
+    chunks.push(code`\n`);
+    chunks.push(generateEnumLabel(ctx, fullName, enumDesc));
+  }
 
   return joinCode(chunks, { on: '\n' });
 }
@@ -75,15 +94,30 @@ export function generateEnumFromJson(ctx: Context, fullName: string, enumDesc: E
   chunks.push(code`export function ${def(functionName)}(object: any): ${fullName} {`);
   chunks.push(code`switch (object) {`);
 
+  // With `allow_alias`, only the first name for each number gets the numeric case
+  const seenNumbers = new Set<number>();
   for (const valueDesc of enumDesc.value) {
+    const maybeNumberCase = seenNumbers.has(valueDesc.number) ? '' : `case ${valueDesc.number}:`;
+    seenNumbers.add(valueDesc.number);
     chunks.push(code`
-      case ${valueDesc.number}:
+      ${maybeNumberCase}
       case "${valueDesc.name}":
         return ${fullName}.${valueDesc.name};
     `);
   }
 
-  if (options.unrecognizedEnum) {
+  if (options.unrecognizedEnum && options.warningHookImport !== undefined) {
+    // Only values that we don't know of are worth a warning, and not an explicit `UNRECOGNIZED`
+    const warning = `{ kind: "unknownEnum", type: "${fullName}", value: object }`;
+    chunks.push(code`
+      case ${UNRECOGNIZED_ENUM_VALUE}:
+      case "${UNRECOGNIZED_ENUM_NAME}":
+        return ${fullName}.${UNRECOGNIZED_ENUM_NAME};
+      default:
+        ${maybeWarningHookCall(options, warning)}
+        return ${fullName}.${UNRECOGNIZED_ENUM_NAME};
+    `);
+  } else if (options.unrecognizedEnum) {
     chunks.push(code`
       case ${UNRECOGNIZED_ENUM_VALUE}:
       case "${UNRECOGNIZED_ENUM_NAME}":
@@ -118,10 +152,15 @@ export function generateEnumToJson(ctx: Context, fullName: string, enumDesc: Enu
   chunks.push(code`switch (object) {`);
 
   for (const valueDesc of enumDesc.value) {
+    // Aliases of numeric enums are the same value as their canonical name, so they're already covered
+    const canonical = canonicalValue(enumDesc, valueDesc.number);
+    if (canonical !== valueDesc && !options.stringEnums) {
+      continue;
+    }
     if (ctx.options.useNumericEnumForJson) {
       chunks.push(code`case ${fullName}.${valueDesc.name}: return ${valueDesc.number};`);
     } else {
-      chunks.push(code`case ${fullName}.${valueDesc.name}: return "${valueDesc.name}";`);
+      chunks.push(code`case ${fullName}.${valueDesc.name}: return "${canonical.name}";`);
     }
   }
 
@@ -184,3 +223,103 @@ export function generateEnumToNumber(ctx: Context, fullName: string, enumDesc: E
   chunks.push(code`}`);
   return joinCode(chunks, { on: '\n' });
 }
+
+/** Generates a function that returns the declared values of our enum, without reverse mappings or `UNRECOGNIZED`. */
+export function generateEnumValues(ctx: Context, fullName: string, enumDesc: EnumDescriptorProto): Code {
+  const functionName = camelCase(fullName) + 'Values';
+  const values = enumDesc.value.map((valueDesc) => code`${fullName}.${valueDesc.name}`);
+  return code`
+    export function ${def(functionName)}(): ${fullName}[] {
+      return [${joinCode(values, { on: ', ' })}];
+    }
+  `;
+}
+
+/**
+ * Generates an `assertUnreachableFoo(x: never): never` function for the `default:` branch of a `switch` on
+ * our enum, so that TS flags the switch when it misses one of the values, including `UNRECOGNIZED`.
+ */
+export function generateEnumAssertUnreachable(ctx: Context, fullName: string): Code {
+  const { utils } = ctx;
+  return code`
+    export function ${def(`assertUnreachable${fullName}`)}(x: never): never {
+      throw new ${utils.globalThis}.Error("Unhandled value " + x + " of enum ${fullName}");
+    }
+  `;
+}
+
+/**
+ * Generates a function with a big switch statement to get the display label of our enum, i.e. the string
+ * `EnumValueOptions` extension numbered `enumLabelOption`, or otherwise the title-cased value name.
+ */
+export function generateEnumLabel(ctx: Context, fullName: string, enumDesc: EnumDescriptorProto): Code {
+  const { options, utils } = ctx;
+  const chunks: Code[] = [];
+
+  const functionName = camelCase(fullName) + 'Label';
+  chunks.push(code`export function ${def(functionName)}(object: ${fullName}): string {`);
+  chunks.push(code`switch (object) {`);
+  for (const valueDesc of enumDesc.value) {
+    // Like in toJSON, aliases of numeric enums are the same value as their canonical name
+    const canonical = canonicalValue(enumDesc, valueDesc.number);
+    if (canonical !== valueDesc && !options.stringEnums) {
+      continue;
+    }
+    const label = enumValueLabel(valueDesc, options) ?? titleCase(valueDesc.name);
+    chunks.push(code`case ${fullName}.${valueDesc.name}: return ${JSON.stringify(label)};`);
+  }
+
+  if (options.unrecognizedEnum) {
+    chunks.push(code`
+      case ${fullName}.${UNRECOGNIZED_ENUM_NAME}:
+      default:
+        return "${titleCase(UNRECOGNIZED_ENUM_NAME)}";
+    `);
+  } else {
+    // We use globalThis to avoid conflicts on protobuf types named `Error`.
+    chunks.push(code`
+      default:
+        throw new ${utils.globalThis}.Error("Unrecognized enum value " + object + " for enum ${fullName}");
+    `);
+  }
+
+  chunks.push(code`}`);
+  chunks.push(code`}`);
+  return joinCode(chunks, { on: '\n' });
+}
+
+/**
+ * Returns the string `EnumValueOptions` extension numbered `enumLabelOption` of `valueDesc`, i.e.
+ * `(ts_proto.label) = "Active"`. Like for `sensitiveFieldOption`, protoc hands it to us as an unknown field,
+ * whose bytes are the string's length prefix and its UTF-8.
+ */
+function enumValueLabel(valueDesc: EnumValueDescriptorProto, options: Options): string | undefined {
+  if (options.enumLabelOption === undefined) {
+    return undefined;
+  }
+  const unknownFields: { [tag: number]: Uint8Array[] } = (valueDesc.options as any)?._unknownFields ?? {};
+  const values = Object.entries(unknownFields).find(([tag]) => Number(tag) >>> 3 === options.enumLabelOption);
+  if (!values) {
+    return undefined;
+  }
+  // The last value wins, and its varint length prefix ends with the first byte without the high bit
+  const bytes = values[1][values[1].length - 1];
+  const start = bytes.findIndex((byte) => (byte & 0x80) === 0) + 1;
+  return Buffer.from(bytes.subarray(start)).toString('utf8');
+}
+
+/** Generates a map of every declared name of an `allow_alias` enum, including the aliases, to its value. */
+export function generateEnumAliases(ctx: Context, fullName: string, enumDesc: EnumDescriptorProto): Code {
+  const constName = camelCase(fullName) + 'Aliases';
+  const entries = enumDesc.value.map((valueDesc) => code`${valueDesc.name}: ${fullName}.${valueDesc.name},`);
+  return code`
+    export const ${def(constName)}: Record<string, ${fullName}> = {
+      ${joinCode(entries, { on: '\n' })}
+    };
+  `;
+}
+
+/** Returns the first value declared with `number`, which is the canonical name when an enum has aliases. */
+function canonicalValue(enumDesc: EnumDescriptorProto, number: number): EnumValueDescriptorProto {
+  return enumDesc.value.find((v) => v.number === number)!;
+}
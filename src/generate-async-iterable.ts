@@ -1,4 +1,5 @@
 import { code, Code } from 'ts-poet';
+import { Context } from './context';
 
 /** Creates a function to transform a message Source to a Uint8Array Source. */
 export function generateEncodeTransform(fullName: string): Code {
@@ -22,13 +23,14 @@ export function generateEncodeTransform(fullName: string): Code {
 }
 
 /** Creates a function to transform a Uint8Array Source to a message Source. */
-export function generateDecodeTransform(fullName: string): Code {
+export function generateDecodeTransform(ctx: Context, fullName: string): Code {
+  const output = ctx.options.useReadonlyTypes === 'output' ? code`${ctx.utils.DeepReadonly}<${fullName}>` : fullName;
   return code`
     // decodeTransform decodes a source of encoded messages.
     // Transform<Uint8Array, ${fullName}>
     async *decodeTransform(
       source: AsyncIterable<Uint8Array | Uint8Array[]> | Iterable<Uint8Array | Uint8Array[]>
-    ): AsyncIterable<${fullName}> {
+    ): AsyncIterable<${output}> {
       for await (const pkt of source) {
         if (Array.isArray(pkt)) {
           for (const p of pkt) {
@@ -41,3 +43,16 @@ export function generateDecodeTransform(fullName: string): Code {
     }
   `;
 }
+
+/** Creates a function to decode a source of length-delimited messages, regardless of how it's chunked. */
+export function generateDecodeStream(ctx: Context, fullName: string): Code {
+  const output = ctx.options.useReadonlyTypes === 'output' ? code`${ctx.utils.DeepReadonly}<${fullName}>` : fullName;
+  return code`
+    // decodeStream decodes the varint length-prefixed messages of a byte stream, i.e. a file or a socket.
+    async *decodeStream(source: AsyncIterable<Uint8Array>): AsyncIterable<${output}> {
+      for await (const frame of ${ctx.utils.readDelimited}(source)) {
+        yield ${fullName}.decode(frame);
+      }
+    }
+  `;
+}
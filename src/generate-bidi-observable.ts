@@ -0,0 +1,82 @@
+import { Code, code, imp } from 'ts-poet';
+import { ServiceDescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import { messageToTypeName } from './types';
+import { assertInstanceOf, FormattedMethodDescriptor } from './utils';
+import { Options } from './options';
+
+const CallOptions = imp('CallOptions@@grpc/grpc-js');
+const Metadata = imp('Metadata@@grpc/grpc-js');
+
+/** Imports `Observable` and `Subject` from `bidiObservableImport`, i.e. `rxjs`, or a module that re-exports them. */
+function rxjsImports(options: Options) {
+  return {
+    Observable: imp(`Observable@${options.bidiObservableImport}`),
+    Subject: imp(`Subject@${options.bidiObservableImport}`),
+  };
+}
+
+/**
+ * Creates an `observeFooBar(client)` helper for each bidi-streaming method of a grpc-js service, which
+ * returns an `outgoing` subject to push requests into, and an `incoming` observable of the responses.
+ */
+export function generateGrpcJsBidiObservables(ctx: Context, serviceDesc: ServiceDescriptorProto): Code[] {
+  const { Observable, Subject } = rxjsImports(ctx.options);
+  return serviceDesc.method
+    .filter((methodDesc) => methodDesc.clientStreaming && methodDesc.serverStreaming)
+    .map((methodDesc) => {
+      assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+      const inputType = messageToTypeName(ctx, methodDesc.inputType);
+      const outputType = messageToTypeName(ctx, methodDesc.outputType);
+      return code`
+        export function observe${serviceDesc.name}${methodDesc.name}(
+          client: ${serviceDesc.name}Client,
+          metadata: ${Metadata} = new ${Metadata}(),
+          options: Partial<${CallOptions}> = {},
+        ): { outgoing: ${Subject}<${inputType}>; incoming: ${Observable}<${outputType}> } {
+          const call = client.${methodDesc.formattedName}(metadata, options);
+
+          // Listen right away, so responses are forwarded even before the first subscriber
+          const incoming = new ${Subject}<${outputType}>();
+          call.on("data", (response: ${outputType}) => incoming.next(response));
+          call.on("error", (error: Error) => incoming.error(error));
+          call.on("end", () => incoming.complete());
+
+          // Completing the subject half-closes the call, and erroring it cancels the call
+          const outgoing = new ${Subject}<${inputType}>();
+          outgoing.subscribe({
+            next: (request) => call.write(request),
+            error: () => call.cancel(),
+            complete: () => call.end(),
+          });
+
+          return { outgoing, incoming: incoming.asObservable() };
+        }
+      `;
+    });
+}
+
+/**
+ * Creates an `observeFooBar(client)` helper for each bidi-streaming method of a grpc-web service, which
+ * feeds an `outgoing` subject into the client's request stream and returns its response stream.
+ */
+export function generateGrpcWebBidiObservables(ctx: Context, serviceDesc: ServiceDescriptorProto): Code[] {
+  const { Observable, Subject } = rxjsImports(ctx.options);
+  return serviceDesc.method
+    .filter((methodDesc) => methodDesc.clientStreaming && methodDesc.serverStreaming)
+    .map((methodDesc) => {
+      assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+      const inputType = messageToTypeName(ctx, methodDesc.inputType);
+      const outputType = messageToTypeName(ctx, methodDesc.outputType);
+      return code`
+        export function observe${serviceDesc.name}${methodDesc.name}(
+          client: ${serviceDesc.name},
+        ): { outgoing: ${Subject}<${inputType}>; incoming: ${Observable}<${outputType}> } {
+          const outgoing = new ${Subject}<${inputType}>();
+          // Errors and completion of the response stream already propagate through the returned observable
+          const incoming = client.${methodDesc.formattedName}(outgoing);
+          return { outgoing, incoming };
+        }
+      `;
+    });
+}
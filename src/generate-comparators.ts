@@ -0,0 +1,80 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import { DescriptorProto, FieldDescriptorProto, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  isBytes,
+  isLong,
+  isMessage,
+  isRepeated,
+  isSortKeyField,
+  isTimestamp,
+  isWithinOneOfThatShouldBeUnion,
+  longOption,
+} from './types';
+import { maybeSnakeToCamel } from './case';
+import { DateOption, LongOption } from './options';
+
+/** Whether the message has fields marked as sort keys, and so gets a comparator. */
+export function hasSortKeys(ctx: Context, messageDesc: DescriptorProto): boolean {
+  return messageDesc.field.some((field) => isSortKeyField(field, ctx.options));
+}
+
+/**
+ * Creates a `compareFoo(a, b)` function of the `Foo` message that compares by its sort key fields, i.e. for
+ * `messages.sort(compareFoo)`, in the order that the fields are declared in, so that later keys break ties.
+ *
+ * Strings compare by their UTF-16 code units, and numbers, 64-bit numbers and timestamps by their value,
+ * whichever their representation. Unset keys sort before set ones.
+ */
+export function generateComparator(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const comparisons = messageDesc.field
+    .filter((field) => isSortKeyField(field, options))
+    .map((field) => {
+      const name = maybeSnakeToCamel(field.name, options);
+      return code`${utils.compareSortKey}(a.${name}, b.${name}, (x, y) => ${sortKeyComparison(ctx, fullName, field)})`;
+    });
+  return code`
+    export function ${def(`compare${fullName}`)}(a: ${fullName}, b: ${fullName}): number {
+      return ${joinCode(comparisons, { on: ' || ' })};
+    }
+  `;
+}
+
+/** Returns the comparison of the set `x` and `y` values of a sort key `field`. */
+function sortKeyComparison(ctx: Context, fullName: string, field: FieldDescriptorProto): Code {
+  const { options, utils } = ctx;
+  if (isRepeated(field) || isBytes(field) || (isMessage(field) && !isTimestamp(field))) {
+    throw new Error(`Sort key ${fullName}.${field.name} must be a string, number, bool, enum or Timestamp field`);
+  } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
+    // The members of oneof=unions aren't properties of the message
+    throw new Error(`Sort key ${fullName}.${field.name} can't be a member of a oneof with oneof=unions`);
+  } else if (isTimestamp(field)) {
+    if (options.useDate === DateOption.DATE) {
+      return code`x.getTime() - y.getTime()`;
+    } else if (options.useDate === DateOption.STRING) {
+      // A Date only keeps the milliseconds of the strings
+      return code`Date.parse(x) - Date.parse(y)`;
+    }
+    // Timestamps are within ±2^38 seconds, so even Long and string seconds are exact as numbers
+    const seconds = (t: string) =>
+      options.forceLong === LongOption.LONG
+        ? `${t}.seconds.toNumber()`
+        : options.forceLong === LongOption.STRING
+        ? `Number(${t}.seconds)`
+        : `${t}.seconds`;
+    return code`${seconds('x')} - ${seconds('y')} || x.nanos - y.nanos`;
+  } else if (isLong(field) && longOption(field, options) === LongOption.LONG) {
+    return code`x.compare(y)`;
+  } else if (isLong(field) && longOption(field, options) === LongOption.STRING) {
+    // 64-bit strings may be out of the range that numbers can represent exactly
+    const unsigned =
+      field.type === FieldDescriptorProto_Type.TYPE_UINT64 || field.type === FieldDescriptorProto_Type.TYPE_FIXED64;
+    return code`${utils.Long}.fromString(x, ${unsigned}).compare(${utils.Long}.fromString(y, ${unsigned}))`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BOOL) {
+    // `false` comes before `true`
+    return code`Number(x) - Number(y)`;
+  }
+  // Strings, numbers and enums, including string enums
+  return code`(x < y ? -1 : x > y ? 1 : 0)`;
+}
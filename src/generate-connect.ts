@@ -0,0 +1,84 @@
+import { Code, code, def, imp, joinCode } from 'ts-poet';
+import {
+  FileDescriptorProto,
+  MethodDescriptorProto,
+  MethodOptions_IdempotencyLevel,
+  ServiceDescriptorProto,
+} from 'ts-proto-descriptors';
+import { camelCase } from './case';
+import { Context } from './context';
+import SourceInfo, { Fields } from './sourceInfo';
+import { messageToTypeName } from './types';
+import { maybeAddComment, maybePrefixPackage, maybePrefixServicePath } from './utils';
+
+// Named imports of the package itself resolve under both bundler and Node16 resolution, with or without esModuleInterop
+const MethodKind = imp('MethodKind@@bufbuild/protobuf');
+const MethodIdempotency = imp('MethodIdempotency@@bufbuild/protobuf');
+
+/**
+ * Generates a `FooServiceConnect` service descriptor in the `{ typeName, methods }` shape of Connect-ES, i.e.
+ * `@connectrpc/connect`, where each method references the generated request/response messages as its `I`/`O`.
+ */
+export function generateConnectService(
+  ctx: Context,
+  fileDesc: FileDescriptorProto,
+  sourceInfo: SourceInfo,
+  serviceDesc: ServiceDescriptorProto
+): Code {
+  const chunks: Code[] = [];
+
+  maybeAddComment(sourceInfo, chunks, serviceDesc.options?.deprecated);
+
+  chunks.push(code`
+    export const ${def(`${serviceDesc.name}Connect`)} = {
+      typeName: '${maybePrefixServicePath(ctx.options, maybePrefixPackage(fileDesc, serviceDesc.name))}',
+      methods: {
+  `);
+
+  for (const [index, methodDesc] of serviceDesc.method.entries()) {
+    const info = sourceInfo.lookup(Fields.service.method, index);
+    maybeAddComment(info, chunks, methodDesc.options?.deprecated);
+    chunks.push(code`${camelCase(methodDesc.name)}: ${generateConnectMethod(ctx, methodDesc)},`);
+  }
+
+  chunks.push(code`
+      },
+    } as const;
+  `);
+
+  return joinCode(chunks, { on: '\n' });
+}
+
+function generateConnectMethod(ctx: Context, methodDesc: MethodDescriptorProto): Code {
+  const inputType = messageToTypeName(ctx, methodDesc.inputType, { keepValueType: true });
+  const outputType = messageToTypeName(ctx, methodDesc.outputType, { keepValueType: true });
+
+  let kind: string;
+  if (methodDesc.clientStreaming && methodDesc.serverStreaming) {
+    kind = 'BiDiStreaming';
+  } else if (methodDesc.clientStreaming) {
+    kind = 'ClientStreaming';
+  } else if (methodDesc.serverStreaming) {
+    kind = 'ServerStreaming';
+  } else {
+    kind = 'Unary';
+  }
+
+  const idempotencyLevel = methodDesc.options?.idempotencyLevel;
+  const maybeIdempotency =
+    idempotencyLevel === MethodOptions_IdempotencyLevel.NO_SIDE_EFFECTS
+      ? code`idempotency: ${MethodIdempotency}.NoSideEffects,`
+      : idempotencyLevel === MethodOptions_IdempotencyLevel.IDEMPOTENT
+      ? code`idempotency: ${MethodIdempotency}.Idempotent,`
+      : '';
+
+  return code`
+    {
+      name: '${methodDesc.name}',
+      I: ${inputType},
+      O: ${outputType},
+      kind: ${MethodKind}.${kind},
+      ${maybeIdempotency}
+    }
+  `;
+}
@@ -0,0 +1,378 @@
+import { code, Code, joinCode } from 'ts-poet';
+import { DescriptorProto, EnumDescriptorProto, FieldDescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  detectMapType,
+  isEnum,
+  isMessage,
+  isOptionalProperty,
+  isRepeated,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  messageToTypeName,
+  oneofCaseName,
+  oneofMembers,
+  oneofValueName,
+  toTypeName,
+} from './types';
+import { capitalize, maybeSnakeToCamel } from './case';
+import { emptyObject, impProtoType } from './utils';
+
+/** A `messageConversions=pkg.v1.Foo:pkg.v2.Foo` entry, i.e. convert from `pkg.v1.Foo` to `pkg.v2.Foo`. */
+interface MessageConversion {
+  source: string;
+  target: string;
+}
+
+/**
+ * Parses the `messageConversions` option into the conversions that produce `fullTypeName`, including the ones
+ * that the fields of the configured conversions need, i.e. `pkg.v1.Bar:pkg.v2.Bar` for a shared `Bar bar = 1;`.
+ */
+export function conversionsTo(ctx: Context, fullTypeName: string): MessageConversion[] {
+  return allConversions(ctx).messages.filter((conversion) => conversion.target === fullTypeName);
+}
+
+/**
+ * Returns the configured conversions, plus the ones that converting their shared fields needs: message and enum
+ * types with the same name within their packages, i.e. `pkg.v1.Bar` and `pkg.v2.Bar`, are compatible, and their
+ * values are converted with the conversion between them, which is then generated as well.
+ */
+function allConversions(ctx: Context): { messages: MessageConversion[]; enums: EnumConversion[] } {
+  const messages = ctx.options.messageConversions.map((entry) => {
+    const [source, target] = entry.split(':');
+    if (!source || !target) {
+      throw new Error(`messageConversions entries must look like 'pkg.v1.Foo:pkg.v2.Foo', got '${entry}'`);
+    }
+    return { source, target };
+  });
+  const enums: EnumConversion[] = ctx.options.enumConversions.map((entry) => {
+    const [source, target, fallback, ...rest] = entry.split(':');
+    if (!source || !target || fallback === '' || rest.length > 0) {
+      throw new Error(`enumConversions entries must look like 'pkg.v1.Color:pkg.v2.Color[:FALLBACK]', got '${entry}'`);
+    }
+    return { source, target, fallback };
+  });
+
+  // Grows while we iterate over it, so that the conversions of nested messages are visited as well
+  for (let i = 0; i < messages.length; i++) {
+    const sourceDesc = ctx.typeMap.get(`.${messages[i].source}`)?.[2];
+    const messageDesc = ctx.typeMap.get(`.${messages[i].target}`)?.[2];
+    if (!sourceDesc || !messageDesc || !('field' in sourceDesc) || !('field' in messageDesc)) {
+      continue;
+    }
+    messageDesc.field.forEach((field) => {
+      const sourceField = sourceDesc.field.find((f) => f.name === field.name);
+      if (!sourceField || !isCompatible(ctx, field, sourceField)) {
+        return;
+      }
+      const [element, sourceElement] = elementFields(ctx, field, sourceField);
+      if (!needsConversion(element, sourceElement)) {
+        return;
+      }
+      const source = sourceElement.typeName.slice(1);
+      const target = element.typeName.slice(1);
+      if (isMessage(element) && !messages.some((c) => c.source === source && c.target === target)) {
+        messages.push({ source, target });
+      } else if (isEnum(element) && !enums.some((c) => c.source === source && c.target === target)) {
+        enums.push({ source, target, fallback: undefined });
+      }
+    });
+  }
+  return { messages, enums };
+}
+
+/**
+ * Creates a `fromV1Foo(source: V1Foo): Foo` function that copies each field that `source` shares with
+ * the target message, and leaves the target's other fields at their defaults.
+ *
+ * Fields are shared if they have the same name, and must then have compatible types, or we fail the codegen.
+ * Messages, enums, repeated fields and maps are copied, converting their values between packages if needed.
+ */
+export function generateConversion(
+  ctx: Context,
+  fullName: string,
+  messageDesc: DescriptorProto,
+  conversion: MessageConversion
+): Code {
+  const { options, typeMap } = ctx;
+  const mapping = typeMap.get(`.${conversion.source}`);
+  if (!mapping || !('field' in mapping[2])) {
+    throw new Error(`messageConversions: could not find message ${conversion.source}`);
+  }
+  const sourceDesc = mapping[2] as DescriptorProto;
+  const sourceType = messageToTypeName(ctx, `.${conversion.source}`, { keepValueType: true });
+
+  const chunks: Code[] = [];
+  const processedOneofs = new Set<number>();
+
+  messageDesc.field.forEach((field) => {
+    const sourceField = sourceDesc.field.find((f) => f.name === field.name);
+    if (!sourceField) {
+      return;
+    }
+    assertCompatible(ctx, conversion, field, sourceField);
+
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      // The whole `oneof` is a single union property, so it's only shared if all of its members are
+      if (processedOneofs.has(field.oneofIndex)) {
+        return;
+      }
+      processedOneofs.add(field.oneofIndex);
+      const oneofName = messageDesc.oneofDecl[field.oneofIndex].name;
+      const members = oneofMembers(messageDesc, field.oneofIndex);
+      const sourceMembers = oneofMembers(sourceDesc, sourceField.oneofIndex);
+      const sourceOneofName = sourceDesc.oneofDecl[sourceField.oneofIndex]?.name;
+      if (
+        sourceOneofName !== oneofName ||
+        members.length !== sourceMembers.length ||
+        !members.every((m) => sourceMembers.some((s) => s.name === m.name && isCompatible(ctx, m, s)))
+      ) {
+        throw new Error(
+          `messageConversions: oneof ${oneofName} of ${conversion.source} and ${conversion.target} have different members`
+        );
+      }
+      const name = maybeSnakeToCamel(oneofName, options);
+      const pairs = members.map((m) => [m, sourceMembers.find((s) => s.name === m.name)!] as const);
+      if (!pairs.some(([m, s]) => needsConversion(m, s))) {
+        chunks.push(code`message.${name} = source.${name};`);
+        return;
+      }
+      // Members of other packages need their values converted, so we rebuild the union case by case
+      const caseName = oneofCaseName(options);
+      const cases = pairs.map(([m, s]) => {
+        const memberName = maybeSnakeToCamel(m.name, options);
+        const valueName = oneofValueName(memberName, options);
+        const value = convertValue(ctx, m, s, `source.${name}.${valueName}`);
+        return code`
+          case '${memberName}':
+            message.${name} = { ${caseName}: '${memberName}', ${valueName}: ${value} };
+            break;
+        `;
+      });
+      chunks.push(code`
+        switch (source.${name}?.${caseName}) {
+          ${joinCode(cases, { on: '\n' })}
+        }
+      `);
+    } else {
+      const name = maybeSnakeToCamel(field.name, options);
+      chunks.push(code`message.${name} = ${copyField(ctx, messageDesc, field, sourceField, `source.${name}`)};`);
+    }
+  });
+
+  const paramName = chunks.length > 0 ? 'source' : '_';
+  return code`
+    export function ${conversionName(ctx, conversion.source)}(${paramName}: ${sourceType}): ${fullName} {
+      const message = createBase${fullName}();
+      ${joinCode(chunks, { on: '\n' })}
+      return message;
+    }
+  `;
+}
+
+/** Copies `from`, the value of `sourceField`, into a new value of `field`, i.e. a new array or map. */
+function copyField(
+  ctx: Context,
+  messageDesc: DescriptorProto,
+  field: FieldDescriptorProto,
+  sourceField: FieldDescriptorProto,
+  from: string
+): Code {
+  const { options } = ctx;
+  const maybeUnset = options.emptyRepeated === 'undefined';
+  const mapType = detectMapType(ctx, messageDesc, field);
+  if (mapType) {
+    const [valueField, sourceValueField] = elementFields(ctx, field, sourceField);
+    const value = convertValue(ctx, valueField, sourceValueField, 'value');
+    const fieldType = toTypeName(ctx, messageDesc, field);
+    const { keyType, valueType } = mapType;
+    const entries = options.useMapType
+      ? code`new Map(Array.from(${from}, ([key, value]): [${keyType}, ${valueType}] => [key, ${value}]))`
+      : code`
+        Object.entries(${from}).reduce<${fieldType}>((acc, [key, value]) => {
+          acc[${keyType.toCodeString() === 'string' ? 'key' : 'Number(key)'}] = ${value};
+          return acc;
+        }, ${emptyObject(options)})
+      `;
+    return maybeUnset ? code`${from} === undefined ? undefined : ${entries}` : entries;
+  } else if (isRepeated(field)) {
+    return code`${from}${maybeUnset ? '?' : ''}.map((e) => ${convertValue(ctx, field, sourceField, 'e')})`;
+  } else if (!needsConversion(field, sourceField)) {
+    return code`${from}`;
+  } else if (isMessage(field) || isOptionalProperty(field, messageDesc.options, options) || isWithinOneOf(field)) {
+    const value = convertValue(ctx, field, sourceField, from);
+    return code`${from} !== undefined && ${from} !== null ? ${value} : undefined`;
+  }
+  return convertValue(ctx, field, sourceField, from);
+}
+
+/** Converts `from`, a value of `sourceField`'s type, to `field`'s type with the conversion between them. */
+function convertValue(
+  ctx: Context,
+  field: FieldDescriptorProto,
+  sourceField: FieldDescriptorProto,
+  from: string
+): Code {
+  if (!needsConversion(field, sourceField)) {
+    return code`${from}`;
+  }
+  const [module, , , pkg] = ctx.typeMap.get(field.typeName)!;
+  return code`${impProtoType(ctx, module, pkg, conversionName(ctx, sourceField.typeName.slice(1)))}(${from})`;
+}
+
+/** Returns the name of the conversion from `source`, i.e. `fromV1Foo` for `pkg.v1.Foo`. */
+function conversionName(ctx: Context, source: string): string {
+  const [, type, , pkg] = ctx.typeMap.get(`.${source}`)!;
+  return `from${capitalize(pkg.split('.').pop() ?? '')}${type}`;
+}
+
+/**
+ * Whether `field` can be converted from `sourceField`, i.e. they have the same type, except that messages and
+ * enums only need the same name within their packages, and maps the same key type and compatible values.
+ */
+function isCompatible(ctx: Context, field: FieldDescriptorProto, sourceField: FieldDescriptorProto): boolean {
+  if (field.type !== sourceField.type || field.label !== sourceField.label) {
+    return false;
+  } else if (field.typeName === sourceField.typeName) {
+    return true;
+  }
+  const entry = mapEntry(ctx, field);
+  const sourceEntry = mapEntry(ctx, sourceField);
+  if (entry || sourceEntry) {
+    return (
+      !!entry &&
+      !!sourceEntry &&
+      isCompatible(ctx, entry.field[0], sourceEntry.field[0]) &&
+      isCompatible(ctx, entry.field[1], sourceEntry.field[1])
+    );
+  }
+  return relativeTypeName(ctx, field.typeName) === relativeTypeName(ctx, sourceField.typeName);
+}
+
+/** Whether the values of compatible fields need the conversion between their messages or enums. */
+function needsConversion(field: FieldDescriptorProto, sourceField: FieldDescriptorProto): boolean {
+  return (isMessage(field) || isEnum(field)) && field.typeName !== sourceField.typeName;
+}
+
+/** Returns the fields that hold the values of `field` and `sourceField`, i.e. the value fields of map entries. */
+function elementFields(
+  ctx: Context,
+  field: FieldDescriptorProto,
+  sourceField: FieldDescriptorProto
+): [FieldDescriptorProto, FieldDescriptorProto] {
+  const entry = mapEntry(ctx, field);
+  const sourceEntry = mapEntry(ctx, sourceField);
+  return entry && sourceEntry ? [entry.field[1], sourceEntry.field[1]] : [field, sourceField];
+}
+
+function mapEntry(ctx: Context, field: FieldDescriptorProto): DescriptorProto | undefined {
+  const desc = isMessage(field) ? ctx.typeMap.get(field.typeName)?.[2] : undefined;
+  return desc && 'field' in desc && desc.options?.mapEntry ? desc : undefined;
+}
+
+/** Returns the name of `typeName` within its package, i.e. `Foo.Bar` for `.pkg.v1.Foo.Bar`. */
+function relativeTypeName(ctx: Context, typeName: string): string {
+  const pkg = ctx.typeMap.get(typeName)?.[3] ?? '';
+  return pkg === '' ? typeName.slice(1) : typeName.slice(pkg.length + 2);
+}
+
+function assertCompatible(
+  ctx: Context,
+  conversion: MessageConversion,
+  field: FieldDescriptorProto,
+  sourceField: FieldDescriptorProto
+) {
+  if (!isCompatible(ctx, field, sourceField)) {
+    throw new Error(
+      `messageConversions: field ${field.name} of ${conversion.source} and ${conversion.target} have incompatible types`
+    );
+  }
+}
+
+/**
+ * A `enumConversions=pkg.v1.Color:pkg.v2.Color:COLOR_UNSPECIFIED` entry, i.e. convert from `pkg.v1.Color` to
+ * `pkg.v2.Color`, and the values that `pkg.v2.Color` has no counterpart of to its optional `fallback` value.
+ */
+interface EnumConversion {
+  source: string;
+  target: string;
+  fallback: string | undefined;
+}
+
+/**
+ * Parses the `enumConversions` option into the conversions that produce `fullTypeName`, including the ones that
+ * the fields of `messageConversions` need, which convert unmapped values like entries without a fallback.
+ */
+export function enumConversionsTo(ctx: Context, fullTypeName: string): EnumConversion[] {
+  return allConversions(ctx).enums.filter((conversion) => conversion.target === fullTypeName);
+}
+
+/**
+ * Creates a `fromV1Color(value: V1Color): Color` function that converts each value of the source enum to the
+ * target's value with the same name, or with `enumConversionMatch=number`, the same number.
+ *
+ * The values without a counterpart are converted to the `fallback` of the entry, or else `UNRECOGNIZED`, and
+ * are reported as warnings, as they are usually a mistake in the schema. Enums without any shared values fail
+ * the codegen, and so do fallbacks that aren't values of the target.
+ */
+export function generateEnumConversion(
+  ctx: Context,
+  fullName: string,
+  enumDesc: EnumDescriptorProto,
+  conversion: EnumConversion
+): Code {
+  const { options, typeMap, utils } = ctx;
+  const mapping = typeMap.get(`.${conversion.source}`);
+  if (!mapping || !('value' in mapping[2])) {
+    throw new Error(`enumConversions: could not find enum ${conversion.source}`);
+  }
+  const sourceDesc = mapping[2] as EnumDescriptorProto;
+  const sourceType = messageToTypeName(ctx, `.${conversion.source}`);
+
+  const cases: Code[] = [];
+  const unmapped: string[] = [];
+  sourceDesc.value.forEach((sourceValue) => {
+    const value = enumDesc.value.find((v) =>
+      options.enumConversionMatch === 'number' ? v.number === sourceValue.number : v.name === sourceValue.name
+    );
+    if (value) {
+      cases.push(code`case ${sourceType}.${sourceValue.name}: return ${fullName}.${value.name};`);
+    } else {
+      unmapped.push(sourceValue.name);
+    }
+  });
+  if (cases.length === 0) {
+    throw new Error(`enumConversions: ${conversion.source} and ${conversion.target} have no values in common`);
+  }
+  if (unmapped.length > 0) {
+    console.warn(
+      `ts-proto: enumConversions: ${unmapped.join(', ')} of ${conversion.source} ` +
+        `have no counterpart in ${conversion.target}, and convert to ${conversion.fallback ?? 'UNRECOGNIZED'}`
+    );
+  }
+
+  let fallback: Code;
+  if (conversion.fallback !== undefined) {
+    const isValue = enumDesc.value.some((v) => v.name === conversion.fallback);
+    if (!isValue && !(options.unrecognizedEnum && conversion.fallback === 'UNRECOGNIZED')) {
+      throw new Error(`enumConversions: ${conversion.fallback} is not a value of ${conversion.target}`);
+    }
+    fallback = code`return ${fullName}.${conversion.fallback};`;
+  } else if (options.unrecognizedEnum) {
+    fallback = code`return ${fullName}.UNRECOGNIZED;`;
+  } else {
+    fallback = code`
+      throw new ${utils.globalThis}.Error("Cannot convert " + value + " of ${mapping[1]} to ${fullName}");
+    `;
+  }
+
+  return code`
+    export function ${conversionName(ctx, conversion.source)}(value: ${sourceType}): ${fullName} {
+      switch (value) {
+        ${joinCode(cases, { on: '\n' })}
+        default:
+          ${fallback}
+      }
+    }
+  `;
+}
@@ -0,0 +1,102 @@
+import { code, Code } from 'ts-poet';
+import { DescriptorProto, FileDescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import { isDuration } from './types';
+import { DurationOption, LongOption } from './options';
+import { impProtoType } from './utils';
+
+/** Returns whether any message of the file, including nested ones, has a `google.protobuf.Duration` field. */
+export function usesDuration(fileDesc: FileDescriptorProto): boolean {
+  const hasDuration = (message: DescriptorProto): boolean =>
+    message.field.some(isDuration) || message.nestedType.some(hasDuration);
+  return fileDesc.messageType.some(hasDuration);
+}
+
+/**
+ * Creates `addDuration(a, b)`, `durationToMillis(d)` and `durationFromMillis(ms)` helpers for the representation
+ * of durations that useDuration chose, i.e. for scheduling logic.
+ *
+ * The math is done on whole seconds and nanos, which carry into and borrow from each other, so that adding
+ * durations doesn't pick up floating point errors, and the results have the same sign in both like protobuf
+ * requires. Nanos that are below a millisecond are kept as the fraction of `durationToMillis`.
+ */
+export function generateDurationHelpers(ctx: Context): Code {
+  const { options, utils } = ctx;
+  const Duration = impProtoType(ctx, 'google/protobuf/duration', 'google.protobuf', 'Duration');
+
+  // Durations are capped at 10,000 years, so even Long and string seconds are exact as numbers
+  const secondsOf = (place: string) =>
+    options.forceLong === LongOption.LONG
+      ? `${place}.seconds.toNumber()`
+      : options.forceLong === LongOption.STRING
+      ? `Number(${place}.seconds)`
+      : `${place}.seconds`;
+  const toSeconds =
+    options.forceLong === LongOption.LONG
+      ? code`${utils.Long}.fromNumber(seconds)`
+      : options.forceLong === LongOption.STRING
+      ? 'seconds.toString()'
+      : 'seconds';
+  const maybeTypeField = options.outputTypeRegistry ? `$type: 'google.protobuf.Duration',` : '';
+
+  let type: Code | string;
+  let parts: Code;
+  let fromParts: Code;
+  if (options.useDuration === DurationOption.NUMBER) {
+    type = 'number';
+    parts = code`
+      const seconds = Math.trunc(d);
+      return [seconds, Math.round((d - seconds) * 1_000_000_000)];
+    `;
+    fromParts = code`return seconds + nanos / 1_000_000_000;`;
+  } else if (options.useDuration === DurationOption.STRING) {
+    type = 'string';
+    parts = code`
+      const duration = ${utils.toDuration}(d);
+      return [${secondsOf('duration')}, duration.nanos];
+    `;
+    fromParts = code`return ${utils.fromDuration}({ ${maybeTypeField} seconds: ${toSeconds}, nanos });`;
+  } else {
+    type = Duration;
+    parts = code`return [${secondsOf('d')}, d.nanos];`;
+    fromParts = code`return { ${maybeTypeField} seconds: ${toSeconds}, nanos };`;
+  }
+
+  return code`
+    function durationParts(d: ${type}): [number, number] {
+      ${parts}
+    }
+
+    function durationFromParts(seconds: number, nanos: number): ${type} {
+      // Carry the whole seconds out of the nanos, and then borrow so that both have the same sign
+      seconds += Math.trunc(nanos / 1_000_000_000);
+      nanos %= 1_000_000_000;
+      if (seconds > 0 && nanos < 0) {
+        seconds -= 1;
+        nanos += 1_000_000_000;
+      } else if (seconds < 0 && nanos > 0) {
+        seconds += 1;
+        nanos -= 1_000_000_000;
+      }
+      seconds = seconds || 0;
+      nanos = nanos || 0;
+      ${fromParts}
+    }
+
+    export function addDuration(a: ${type}, b: ${type}): ${type} {
+      const [aSeconds, aNanos] = durationParts(a);
+      const [bSeconds, bNanos] = durationParts(b);
+      return durationFromParts(aSeconds + bSeconds, aNanos + bNanos);
+    }
+
+    export function durationToMillis(d: ${type}): number {
+      const [seconds, nanos] = durationParts(d);
+      return seconds * 1_000 + nanos / 1_000_000;
+    }
+
+    export function durationFromMillis(ms: number): ${type} {
+      const seconds = Math.trunc(ms / 1_000);
+      return durationFromParts(seconds, Math.round((ms - seconds * 1_000) * 1_000_000));
+    }
+  `;
+}
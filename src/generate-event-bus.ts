@@ -0,0 +1,29 @@
+import { code, Code, def, imp } from 'ts-poet';
+import { Context } from './context';
+import { messageMethod } from './utils';
+
+/**
+ * Creates `publishFoo(bus, message)` and `subscribeFoo(bus, handler)` functions of the `Foo` message, which send
+ * and receive it over a message queue as the bytes of `Foo.encode`, on the topic of its fully-qualified name.
+ *
+ * The bus is the `Bus` interface of the file, or the one that `busImport` refers to, and whatever its `publish`
+ * and `subscribe` return, i.e. a promise or a function that unsubscribes, is passed back to the caller.
+ */
+export function generateEventBus(ctx: Context, fullName: string, fullTypeName: string): Code {
+  const { options, utils } = ctx;
+  const Bus = options.busImport ? imp(`Bus@${options.busImport}`) : utils.Bus;
+  const encode = messageMethod(options, fullName, 'encode');
+  const decode = messageMethod(options, fullName, 'decode');
+  return code`
+    export function ${def(`publish${fullName}`)}(bus: ${Bus}, message: ${fullName}): ReturnType<${Bus}['publish']> {
+      return bus.publish('${fullTypeName}', ${encode}(message).finish());
+    }
+
+    export function ${def(`subscribe${fullName}`)}(
+      bus: ${Bus},
+      handler: (message: ${fullName}) => void,
+    ): ReturnType<${Bus}['subscribe']> {
+      return bus.subscribe('${fullTypeName}', (data) => handler(${decode}(data)));
+    }
+  `;
+}
@@ -0,0 +1,180 @@
+import { code, Code, joinCode } from 'ts-poet';
+import { DescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  basicTypeName,
+  isMapType,
+  isMessage,
+  isRepeated,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  notDefaultCheck,
+  oneofCaseName,
+} from './types';
+import { maybeSnakeToCamel } from './case';
+import { isMergeableMessage } from './generate-merge';
+
+/**
+ * Creates an `applyUpdate(existing, update, mask)` function that copies the fields named by the `FieldMask`
+ * paths in `mask` from `update` onto a copy of `existing`, as update RPCs do on the server.
+ *
+ * Paths use the proto field names. A path to a field replaces it wholesale, including repeated, map and
+ * message fields, while a dotted path like `author.name` only updates the named field of the sub-message.
+ * The `*` path replaces the whole message, and unknown paths throw.
+ */
+export function generateApplyUpdate(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+
+  const copy = (from: string) =>
+    options.usePrototypeForDefaults
+      ? code`Object.assign(Object.create(createBase${fullName}()), ${from}) as ${fullName}`
+      : code`{ ...${from} }`;
+
+  chunks.push(code`
+    applyUpdate(existing: ${fullName} | undefined, update: ${fullName} | undefined, mask: string[]): ${fullName} {
+      const source = update ?? createBase${fullName}();
+      const message = ${copy(`existing ?? createBase${fullName}()`)};
+      for (const path of mask) {
+        if (path === '*') {
+          return ${copy('source')};
+        }
+        const dot = path.indexOf('.');
+        const head = dot === -1 ? path : path.slice(0, dot);
+        const rest = dot === -1 ? [] : [path.slice(dot + 1)];
+        switch (head) {
+  `);
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    const notNested = code`
+      if (rest.length > 0) {
+        throw new Error(\`Cannot apply field mask path \${path} to the non-message field ${fieldName}\`);
+      }
+    `;
+
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      // Masking one member of a oneof sets it if it's the update's case, otherwise it clears it
+      const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      const caseName = oneofCaseName(options);
+      chunks.push(code`
+        case '${field.name}':
+          ${notNested}
+          if (source.${oneofName}?.${caseName} === '${fieldName}') {
+            message.${oneofName} = source.${oneofName};
+          } else if (message.${oneofName}?.${caseName} === '${fieldName}') {
+            message.${oneofName} = undefined;
+          }
+          break;
+      `);
+    } else if (isWithinOneOf(field) && !field.proto3Optional) {
+      const siblings = messageDesc.field
+        .filter((f) => f !== field && isWithinOneOf(f) && f.oneofIndex === field.oneofIndex)
+        .map((f) => code`message.${maybeSnakeToCamel(f.name, options)} = undefined;`);
+      chunks.push(code`
+        case '${field.name}':
+          ${notNested}
+          message.${fieldName} = source.${fieldName};
+          if (source.${fieldName} !== undefined) {
+            ${joinCode(siblings, { on: '\n' })}
+          }
+          break;
+      `);
+    } else if (
+      isMessage(field) &&
+      !isRepeated(field) &&
+      !isMapType(ctx, messageDesc, field) &&
+      isMergeableMessage(ctx, field)
+    ) {
+      const type = basicTypeName(ctx, field);
+      chunks.push(code`
+        case '${field.name}':
+          message.${fieldName} = rest.length > 0
+            ? ${type}.applyUpdate(message.${fieldName}, source.${fieldName}, rest)
+            : source.${fieldName};
+          break;
+      `);
+    } else {
+      chunks.push(code`
+        case '${field.name}':
+          ${notNested}
+          message.${fieldName} = source.${fieldName};
+          break;
+      `);
+    }
+  });
+
+  chunks.push(code`
+          default:
+            throw new Error(\`Unknown field mask path \${path} for ${fullName}\`);
+        }
+      }
+      return message;
+    }
+  `);
+  return joinCode(chunks, { on: '\n' });
+}
+
+/**
+ * Creates an `assertMasked(message, mask)` function that checks that a response honored the request's
+ * `read_mask`, i.e. that every set field of `message` is covered by a path in `mask`, and throws otherwise.
+ *
+ * The result is typed as a `Pick` of the masked fields, which TS can narrow when `mask` is a literal, i.e.
+ * `Foo.assertMasked(foo, ['display_name'] as const)` is a `Pick<Foo, 'displayName'>`, and falls back to
+ * `Foo` when `mask` is a wide `string[]`. Dotted paths count towards their top-level field.
+ */
+export function generateAssertMasked(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options } = ctx;
+  const checks: Code[] = [];
+  const keyCases: string[] = [];
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    let key = fieldName;
+    let isSet: Code;
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      key = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      isSet = code`message.${key}?.${oneofCaseName(options)} === '${fieldName}'`;
+    } else if (isRepeated(field) && isMapType(ctx, messageDesc, field)) {
+      isSet = options.useMapType
+        ? code`(message.${fieldName}?.size ?? 0) > 0`
+        : code`Object.keys(message.${fieldName} ?? {}).length > 0`;
+    } else if (isRepeated(field)) {
+      isSet = code`(message.${fieldName}?.length ?? 0) > 0`;
+    } else if (isWithinOneOf(field) || isMessage(field)) {
+      isSet = code`message.${fieldName} !== undefined`;
+    } else {
+      isSet = notDefaultCheck(ctx, field, messageDesc.options, `message.${fieldName}`);
+    }
+
+    keyCases.push(`P extends '${field.name}' | \`${field.name}.\${string}\` ? '${key}' :`);
+    checks.push(code`
+      if (!paths.has('${field.name}') && ${isSet}) {
+        throw new Error('Field ${field.name} of ${fullName} is set, but not in the mask');
+      }
+    `);
+  });
+
+  // A wide `string[]` mask could name any field, so it can't narrow the result
+  const maskedKeys =
+    keyCases.length > 0 ? `string extends P ? keyof ${fullName} : ${keyCases.join(' ')} never` : 'never';
+  return code`
+    assertMasked<P extends string>(
+      ${checks.length > 0 ? 'message' : '_'}: ${fullName},
+      ${checks.length > 0 ? 'mask' : '_mask'}: readonly P[],
+    ): Pick<${fullName}, ${maskedKeys}> {
+      ${
+        checks.length > 0
+          ? code`
+            if (mask.includes('*' as P)) {
+              return message;
+            }
+            const paths = new Set(mask.map((path) => path.split('.')[0]));
+            ${joinCode(checks, { on: '\n' })}
+            return message;
+          `
+          : code`return {};`
+      }
+    }
+  `;
+}
@@ -0,0 +1,85 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import { DescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  isBytes,
+  isMapType,
+  isMessage,
+  isRepeated,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  notDefaultCheck,
+  oneofCaseName,
+  oneofValueName,
+} from './types';
+import { camelCase, maybeSnakeToCamel } from './case';
+import { getFieldJsonName, getPropertyAccessor, messageMethod } from './utils';
+import { BytesOption } from './options';
+
+/**
+ * Creates a `fooToFormData(message)` function that appends the message's set fields to a `FormData` under their
+ * JSON names, for multipart uploads.
+ *
+ * The values are taken from `toJSON`, so scalars, enums and wrappers are appended as the strings of their JSON
+ * form, while sub-messages, maps and other well-known types are appended as their JSON. Repeated fields are
+ * appended once per element, and `bytes` fields as `Blob`s rather than as base64.
+ */
+export function generateFormData(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const chunks: Code[] = [];
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    const key = getFieldJsonName(field, options);
+    const json = getPropertyAccessor('json', key);
+    const isBlob = isBytes(field) && options.bytesAs !== BytesOption.BASE64_STRING;
+
+    if (isRepeated(field) && !isMapType(ctx, messageDesc, field)) {
+      chunks.push(code`
+        for (const v of ${isBlob ? `message.${fieldName}` : json} ?? []) {
+          form.append('${key}', ${isBlob ? code`new Blob([v])` : code`${utils.formDataValue}(v)`});
+        }
+      `);
+      return;
+    }
+
+    let isSet: Code;
+    let value = code`${utils.formDataValue}(${json})`;
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      isSet = code`message.${oneofName}?.${oneofCaseName(options)} === '${fieldName}'`;
+      if (isBlob) {
+        value = code`new Blob([message.${oneofName}.${oneofValueName(fieldName, options)}])`;
+      }
+    } else {
+      if (isMapType(ctx, messageDesc, field)) {
+        // Regardless of useMapType, the JSON of a map is a plain object
+        isSet = code`Object.keys(${json} ?? {}).length > 0`;
+      } else if (isWithinOneOf(field) || isMessage(field)) {
+        isSet = code`message.${fieldName} !== undefined`;
+      } else {
+        isSet = notDefaultCheck(ctx, field, messageDesc.options, `message.${fieldName}`);
+      }
+      if (isBlob) {
+        value = code`new Blob([message.${fieldName}!])`;
+      }
+    }
+    chunks.push(code`
+      if (${isSet}) {
+        form.append('${key}', ${value});
+      }
+    `);
+  });
+
+  // Sensitive fields are redacted by `toJSON`, but the form is meant for the actual upload
+  const toJson = messageMethod(options, fullName, options.sensitiveFieldOption !== undefined ? 'toJSONFull' : 'toJSON');
+  const hasFields = chunks.length > 0;
+  return code`
+    export function ${def(`${camelCase(fullName)}ToFormData`)}(${hasFields ? 'message' : '_'}: ${fullName}): FormData {
+      const form = new FormData();
+      ${hasFields ? code`const json = ${toJson}(message) as any;` : ''}
+      ${joinCode(chunks, { on: '\n' })}
+      return form;
+    }
+  `;
+}
@@ -10,7 +10,7 @@ import { camelCase } from './case';
 import { Context } from './context';
 import SourceInfo, { Fields } from './sourceInfo';
 import { messageToTypeName } from './types';
-import { maybeAddComment, maybePrefixPackage } from './utils';
+import { maybeAddComment, maybePrefixPackage, maybePrefixServicePath } from './utils';
 
 /**
  * Generates a framework-agnostic service descriptor.
@@ -39,7 +39,7 @@ export function generateGenericServiceDefinition(
   serviceDesc.options?.uninterpretedOption;
   chunks.push(code`
       name: '${serviceDesc.name}',
-      fullName: '${maybePrefixPackage(fileDesc, serviceDesc.name)}',
+      fullName: '${maybePrefixServicePath(ctx.options, maybePrefixPackage(fileDesc, serviceDesc.name))}',
       methods: {
   `);
 
@@ -4,7 +4,13 @@ import { camelCase } from './case';
 import { Context } from './context';
 import SourceInfo, { Fields } from './sourceInfo';
 import { messageToTypeName, wrapperTypeName } from './types';
-import { assertInstanceOf, FormattedMethodDescriptor, maybeAddComment, maybePrefixPackage } from './utils';
+import {
+  assertInstanceOf,
+  FormattedMethodDescriptor,
+  maybeAddComment,
+  maybePrefixPackage,
+  maybePrefixServicePath,
+} from './utils';
 import { generateDecoder, generateEncoder } from './encode';
 
 const CallOptions = imp('CallOptions@@grpc/grpc-js');
@@ -19,9 +25,12 @@ const handleBidiStreamingCall = imp('handleBidiStreamingCall@@grpc/grpc-js');
 const handleClientStreamingCall = imp('handleClientStreamingCall@@grpc/grpc-js');
 const handleServerStreamingCall = imp('handleServerStreamingCall@@grpc/grpc-js');
 const handleUnaryCall = imp('handleUnaryCall@@grpc/grpc-js');
+const InterceptingCall = imp('InterceptingCall@@grpc/grpc-js');
+const Interceptor = imp('Interceptor@@grpc/grpc-js');
 const UntypedServiceImplementation = imp('UntypedServiceImplementation@@grpc/grpc-js');
 const makeGenericClientConstructor = imp('makeGenericClientConstructor@@grpc/grpc-js');
 const Metadata = imp('Metadata@@grpc/grpc-js');
+const Server = imp('Server@@grpc/grpc-js');
 const ServiceError = imp('ServiceError@@grpc/grpc-js');
 
 /**
@@ -41,7 +50,7 @@ export function generateGrpcJsService(
   chunks.push(generateServerStub(ctx, sourceInfo, serviceDesc));
   if (options.outputClientImpl) {
     chunks.push(generateClientStub(ctx, sourceInfo, serviceDesc));
-    chunks.push(generateClientConstructor(fileDesc, serviceDesc));
+    chunks.push(generateClientConstructor(ctx, fileDesc, serviceDesc));
   }
 
   return joinCode(chunks, { on: '\n\n' });
@@ -64,6 +73,7 @@ function generateServiceDefinition(
   `);
 
   // Service definition
+  const servicePath = maybePrefixServicePath(ctx.options, maybePrefixPackage(fileDesc, serviceDesc.name));
   chunks.push(code`
     export const ${name} = {
   `);
@@ -85,7 +95,7 @@ function generateServiceDefinition(
 
     chunks.push(code`
       ${methodDesc.formattedName}: {
-        path: '/${maybePrefixPackage(fileDesc, serviceDesc.name)}/${methodDesc.name}',
+        path: '/${servicePath}/${methodDesc.name}',
         requestStream: ${methodDesc.clientStreaming},
         responseStream: ${methodDesc.serverStreaming},
         requestSerialize: (value: ${inputType}) =>
@@ -227,18 +237,157 @@ function generateClientStub(ctx: Context, sourceInfo: SourceInfo, serviceDesc: S
   return joinCode(chunks, { on: '\n' });
 }
 
-function generateClientConstructor(fileDesc: FileDescriptorProto, serviceDesc: ServiceDescriptorProto) {
-  return code`
-    export const ${def(`${serviceDesc.name}Client`)} = ${makeGenericClientConstructor}(
-      ${serviceDesc.name}Service,
-      '${maybePrefixPackage(fileDesc, serviceDesc.name)}'
-    ) as unknown as {
+function generateClientConstructor(ctx: Context, fileDesc: FileDescriptorProto, serviceDesc: ServiceDescriptorProto) {
+  const constructorType = code`
+    {
       new (
         address: string,
         credentials: ${ChannelCredentials},
         options?: Partial<${ChannelOptions}>,
+        ${ctx.options.defaultMetadata ? code`defaultMetadata?: ${Metadata},` : ''}
       ): ${serviceDesc.name}Client;
       service: typeof ${serviceDesc.name}Service;
     }
   `;
+  const genericClient = code`
+    ${makeGenericClientConstructor}(${serviceDesc.name}Service, '${maybePrefixPackage(fileDesc, serviceDesc.name)}')
+  `;
+  if (!ctx.options.defaultMetadata) {
+    return code`
+      export const ${def(`${serviceDesc.name}Client`)} = ${genericClient} as unknown as ${constructorType}
+    `;
+  }
+  // The defaults are merged by an interceptor that runs before the caller's own, which then see the merged metadata
+  return code`
+    export const ${def(`${serviceDesc.name}Client`)} = class extends ${genericClient} {
+      constructor(
+        address: string,
+        credentials: ${ChannelCredentials},
+        options?: Partial<${ChannelOptions}>,
+        defaultMetadata?: ${Metadata},
+      ) {
+        super(
+          address,
+          credentials,
+          defaultMetadata === undefined
+            ? options
+            : {
+                ...options,
+                interceptors: [defaultMetadataInterceptor(defaultMetadata), ...(options?.interceptors ?? [])],
+              }
+        );
+      }
+    } as unknown as ${constructorType}
+  `;
+}
+
+/**
+ * Generates a `registerAllServices(server, impls)` function that adds each of the file's
+ * services to a grpc-js `Server`, keyed by the camel-cased service name.
+ */
+export function generateGrpcJsServiceRegistrar(fileDesc: FileDescriptorProto): Code {
+  const implTypes = fileDesc.service.map(
+    (serviceDesc) => code`${camelCase(serviceDesc.name)}: ${serviceDesc.name}Server;`
+  );
+  const addServices = fileDesc.service.map(
+    (serviceDesc) => code`server.addService(${serviceDesc.name}Service, impls.${camelCase(serviceDesc.name)});`
+  );
+  return code`
+    export function registerAllServices(
+      server: ${Server},
+      impls: { ${joinCode(implTypes, { on: '\n' })} }
+    ): void {
+      ${joinCode(addServices, { on: '\n' })}
+    }
+  `;
+}
+
+/**
+ * Generates the `defaultMetadataInterceptor(defaultMetadata)` function that clients constructed with
+ * `new FooClient(address, creds, options, defaultMetadata)` use to add `defaultMetadata` to each call.
+ */
+export function generateGrpcJsDefaultMetadataInterceptor(): Code {
+  return code`
+    function defaultMetadataInterceptor(defaultMetadata: ${Metadata}): ${Interceptor} {
+      return (options, nextCall) =>
+        new ${InterceptingCall}(nextCall(options), {
+          start(metadata, listener, next) {
+            // Per-call metadata wins over the defaults, and keys can have multiple values, which getMap() drops
+            const merged = metadata.clone();
+            Object.keys(defaultMetadata.getMap()).forEach((key) => {
+              if (merged.get(key).length === 0) {
+                defaultMetadata.get(key).forEach((value) => merged.add(key, value));
+              }
+            });
+            next(merged, listener);
+          },
+        });
+    }
+  `;
+}
+
+/**
+ * Generates a `readWithBackpressure(call, highWaterMark)` function, which adapts a grpc-js server-streaming
+ * call to an `AsyncIterable` that buffers at most `highWaterMark` responses, pausing the call while the
+ * buffer is full and resuming it once the consumer has drained it.
+ */
+export function generateGrpcJsBackpressureReader(): Code {
+  return code`
+    export function readWithBackpressure<T>(
+      call: ${ClientReadableStream}<T>,
+      highWaterMark: number = 16,
+    ): AsyncIterable<T> {
+      return {
+        [Symbol.asyncIterator](): AsyncIterator<T> {
+          const queue: T[] = [];
+          let ended = false;
+          let error: Error | undefined;
+          let wake: (() => void) | undefined;
+          const notify = () => {
+            const resolve = wake;
+            wake = undefined;
+            resolve?.();
+          };
+          call.on('data', (message: T) => {
+            queue.push(message);
+            if (queue.length >= highWaterMark) {
+              call.pause();
+            }
+            notify();
+          });
+          call.on('end', () => {
+            ended = true;
+            notify();
+          });
+          call.on('error', (err: Error) => {
+            error = err;
+            notify();
+          });
+          return {
+            async next(): Promise<IteratorResult<T>> {
+              while (queue.length === 0 && !ended && error === undefined) {
+                await new Promise<void>((resolve) => (wake = resolve));
+              }
+              if (queue.length > 0) {
+                const value = queue.shift()!;
+                if (queue.length === 0 && call.isPaused()) {
+                  call.resume();
+                }
+                return { value, done: false };
+              }
+              if (error !== undefined) {
+                throw error;
+              }
+              return { value: undefined, done: true };
+            },
+            async return(): Promise<IteratorResult<T>> {
+              // The consumer stopped early, i.e. broke out of its for await loop
+              call.cancel();
+              return { value: undefined, done: true };
+            },
+          };
+        },
+      };
+    }
+  `;
 }
@@ -0,0 +1,274 @@
+import { MethodDescriptorProto, FileDescriptorProto, ServiceDescriptorProto } from 'ts-proto-descriptors';
+import { getMessageMethod, messageToTypeName, requestType, responsePromiseOrObservable } from './types';
+import { Code, code, imp, joinCode } from 'ts-poet';
+import { Context } from './context';
+import {
+  assertInstanceOf,
+  FormattedMethodDescriptor,
+  impFile,
+  maybePrefixPackage,
+  maybePrefixServicePath,
+} from './utils';
+
+const from = imp('from@rxjs');
+const map = imp('map@rxjs/operators');
+
+/** The metadata type accepted by the fetch-based grpc-web client, i.e. a plain object of header names to values. */
+export const grpcWebFetchMetadataType = code`{ [key: string]: string }`;
+
+/** Generates a grpc-web client that speaks the protocol directly over `fetch`, without `@improbable-eng/grpc-web`. */
+export function generateGrpcWebFetchClientImpl(
+  ctx: Context,
+  fileDesc: FileDescriptorProto,
+  serviceDesc: ServiceDescriptorProto
+): Code {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+
+  // Define the FooServiceImpl class
+  chunks.push(code`
+    export class ${serviceDesc.name}ClientImpl implements ${serviceDesc.name} {
+  `);
+
+  // Create the constructor(rpc: Rpc)
+  if (options.defaultMetadata) {
+    chunks.push(code`
+      private readonly rpc: GrpcWebFetchRpc;
+      private readonly defaultMetadata: ${grpcWebFetchMetadataType} | undefined;
+
+      constructor(rpc: GrpcWebFetchRpc, defaultMetadata?: ${grpcWebFetchMetadataType}) {
+    `);
+    chunks.push(code`this.defaultMetadata = defaultMetadata;`);
+  } else {
+    chunks.push(code`
+      private readonly rpc: GrpcWebFetchRpc;
+
+      constructor(rpc: GrpcWebFetchRpc) {
+    `);
+  }
+  chunks.push(code`this.rpc = rpc;`);
+  // Bind each FooService method to the FooServiceImpl class
+  for (const methodDesc of serviceDesc.method) {
+    assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+    chunks.push(code`this.${methodDesc.formattedName} = this.${methodDesc.formattedName}.bind(this);`);
+  }
+  chunks.push(code`}`);
+
+  // Create a method for each FooService method
+  for (const methodDesc of serviceDesc.method) {
+    chunks.push(generateRpcMethod(ctx, fileDesc, serviceDesc, methodDesc));
+  }
+
+  chunks.push(code`}`);
+  return joinCode(chunks, { trim: false, on: '\n' });
+}
+
+/** Creates the RPC methods that client code actually calls. */
+function generateRpcMethod(
+  ctx: Context,
+  fileDesc: FileDescriptorProto,
+  serviceDesc: ServiceDescriptorProto,
+  methodDesc: MethodDescriptorProto
+): Code {
+  assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+  const { options } = ctx;
+  const encodeRequest = getMessageMethod(ctx, methodDesc.inputType, 'encode');
+  const fromPartialRequest = getMessageMethod(ctx, methodDesc.inputType, 'fromPartial');
+  const decodeResponse = getMessageMethod(ctx, methodDesc.outputType, 'decode');
+  const inputType = requestType(ctx, methodDesc, true);
+  const outputType = messageToTypeName(ctx, methodDesc.outputType, { keepValueType: true });
+  const returns = responsePromiseOrObservable(ctx, methodDesc);
+  const serviceName = maybePrefixPackage(fileDesc, serviceDesc.name);
+  const servicePath = maybePrefixServicePath(options, serviceName);
+
+  if (methodDesc.clientStreaming) {
+    // grpc-web has no way of streaming requests from the browser
+    return code`
+      ${methodDesc.formattedName}(
+        _request: ${inputType},
+        _metadata?: ${grpcWebFetchMetadataType},
+      ): ${returns} {
+        throw new Error("${serviceName}.${methodDesc.name} is client-streaming, which grpc-web does not support");
+      }
+    `;
+  }
+
+  const data = code`${encodeRequest}(${fromPartialRequest}(request)).finish()`;
+  // Per-call metadata wins over the defaults given to the constructor
+  const metadata = options.defaultMetadata ? '{ ...this.defaultMetadata, ...metadata }' : 'metadata';
+
+  if (methodDesc.serverStreaming) {
+    const stream = code`this.rpc.serverStream("${servicePath}", "${methodDesc.name}", ${data}, ${metadata})`;
+    const result = options.useAsyncIterable
+      ? code`${outputType}.decodeTransform(${stream})`
+      : code`${from}(${stream}).pipe(${map}((data) => ${decodeResponse}(data)))`;
+    return code`
+      ${methodDesc.formattedName}(
+        request: ${inputType},
+        metadata?: ${grpcWebFetchMetadataType},
+      ): ${returns} {
+        return ${result};
+      }
+    `;
+  }
+
+  const promise = code`
+    this.rpc
+      .unary("${servicePath}", "${methodDesc.name}", ${data}, ${metadata})
+      .then((data) => ${decodeResponse}(data))
+  `;
+  return code`
+    ${methodDesc.formattedName}(
+      request: ${inputType},
+      metadata?: ${grpcWebFetchMetadataType},
+    ): ${returns} {
+      return ${options.returnObservable ? code`${from}(${promise})` : promise};
+    }
+  `;
+}
+
+/** Adds the `GrpcWebFetchRpc` interface and its `fetch`-based implementation. */
+export function addGrpcWebFetchMisc(ctx: Context, hasStreamingMethods: boolean): Code {
+  const { options, utils } = ctx;
+  const metadata = grpcWebFetchMetadataType;
+  const util = impFile(options, 'util@protobufjs/minimal');
+
+  // Unary calls are read as a stream with exactly one message, so we always need the frame reader,
+  // but only declare `serverStream` on the interface when something will call it.
+  const maybeServerStream = hasStreamingMethods
+    ? code`
+      serverStream(service: string, method: string, data: Uint8Array, metadata?: ${metadata}): AsyncIterable<Uint8Array>;
+    `
+    : '';
+
+  return code`
+    interface GrpcWebFetchRpc {
+      unary(service: string, method: string, data: Uint8Array, metadata?: ${metadata}): Promise<Uint8Array>;
+      ${maybeServerStream}
+    }
+
+    type GrpcWebFetchOptions = {
+      metadata?: ${metadata};
+      fetch?: (input: string, init: { method: string; headers: ${metadata}; body: Uint8Array }) => Promise<any>;
+    };
+
+    export class GrpcWebFetchImpl implements GrpcWebFetchRpc {
+      private host: string;
+      private options: GrpcWebFetchOptions;
+
+      constructor(host: string, options: GrpcWebFetchOptions = {}) {
+        this.host = host;
+        this.options = options;
+      }
+
+      async unary(service: string, method: string, data: Uint8Array, metadata?: ${metadata}): Promise<Uint8Array> {
+        const messages: Uint8Array[] = [];
+        for await (const message of this.serverStream(service, method, data, metadata)) {
+          messages.push(message);
+        }
+        if (messages.length !== 1) {
+          throw new GrpcWebError(\`Expected a single response message but received \${messages.length}\`, 13, {});
+        }
+        return messages[0];
+      }
+
+      async *serverStream(
+        service: string,
+        method: string,
+        data: Uint8Array,
+        metadata?: ${metadata},
+      ): AsyncIterable<Uint8Array> {
+        // Every grpc-web message is framed with a flag byte and a big-endian uint32 length
+        const body = new Uint8Array(5 + data.length);
+        new DataView(body.buffer).setUint32(1, data.length);
+        body.set(data, 5);
+
+        const fetch = this.options.fetch ?? ${utils.globalThis}.fetch;
+        const response = await fetch(\`\${this.host}/\${service}/\${method}\`, {
+          method: "POST",
+          headers: {
+            "content-type": "application/grpc-web+proto",
+            "x-grpc-web": "1",
+            ...this.options.metadata,
+            ...metadata,
+          },
+          body,
+        });
+
+        const headers: ${metadata} = {};
+        response.headers.forEach((value: string, key: string) => (headers[key] = value));
+        if (!response.ok) {
+          throw new GrpcWebError(response.statusText, httpStatusToGrpcCode(response.status), headers);
+        }
+
+        // Trailers-only responses put the status in the headers
+        let trailers: ${metadata} = headers;
+        const reader = response.body.getReader();
+        let buffer = new Uint8Array(0);
+        while (true) {
+          const { done, value } = await reader.read();
+          if (value) {
+            const next = new Uint8Array(buffer.length + value.length);
+            next.set(buffer);
+            next.set(value, buffer.length);
+            buffer = next;
+          }
+          while (buffer.length >= 5) {
+            const length = new DataView(buffer.buffer, buffer.byteOffset).getUint32(1);
+            if (buffer.length < 5 + length) {
+              break;
+            }
+            const flag = buffer[0];
+            const frame = buffer.slice(5, 5 + length);
+            buffer = buffer.slice(5 + length);
+            if (flag & 0x80) {
+              trailers = parseGrpcWebTrailers(frame);
+            } else {
+              yield frame;
+            }
+          }
+          if (done) {
+            break;
+          }
+        }
+
+        const status = Number(trailers["grpc-status"] ?? 0);
+        if (status !== 0) {
+          throw new GrpcWebError(decodeURIComponent(trailers["grpc-message"] ?? ""), status, trailers);
+        }
+      }
+    }
+
+    function parseGrpcWebTrailers(frame: Uint8Array): ${metadata} {
+      const trailers: ${metadata} = {};
+      for (const line of ${util}.utf8.read(frame, 0, frame.length).split("\\r\\n")) {
+        const index = line.indexOf(":");
+        if (index > 0) {
+          trailers[line.slice(0, index).trim().toLowerCase()] = line.slice(index + 1).trim();
+        }
+      }
+      return trailers;
+    }
+
+    function httpStatusToGrpcCode(status: number): number {
+      switch (status) {
+        case 400: return 13; // INTERNAL
+        case 401: return 16; // UNAUTHENTICATED
+        case 403: return 7; // PERMISSION_DENIED
+        case 404: return 12; // UNIMPLEMENTED
+        case 429:
+        case 502:
+        case 503:
+        case 504: return 14; // UNAVAILABLE
+        default: return 2; // UNKNOWN
+      }
+    }
+
+    // This runs when the module loads, i.e. before the \`globalThis\` helper below has been initialized
+    export class GrpcWebError extends Error {
+      constructor(message: string, public code: number, public metadata: ${metadata}) {
+        super(message);
+      }
+    }
+  `;
+}
@@ -1,8 +1,8 @@
 import { MethodDescriptorProto, FileDescriptorProto, ServiceDescriptorProto } from 'ts-proto-descriptors';
-import { rawRequestType, requestType, responsePromiseOrObservable, responseType, observableType } from './types';
+import { getMessageMethod, requestType, responsePromiseOrObservable, observableType } from './types';
 import { Code, code, imp, joinCode } from 'ts-poet';
 import { Context } from './context';
-import { assertInstanceOf, FormattedMethodDescriptor, maybePrefixPackage } from './utils';
+import { assertInstanceOf, FormattedMethodDescriptor, maybePrefixPackage, maybePrefixServicePath } from './utils';
 
 const grpc = imp('grpc@@improbable-eng/grpc-web');
 const share = imp('share@rxjs/operators');
@@ -15,6 +15,7 @@ export function generateGrpcClientImpl(
   _fileDesc: FileDescriptorProto,
   serviceDesc: ServiceDescriptorProto
 ): Code {
+  const { options } = ctx;
   const chunks: Code[] = [];
 
   // Define the FooServiceImpl class
@@ -23,11 +24,21 @@ export function generateGrpcClientImpl(
   `);
 
   // Create the constructor(rpc: Rpc)
-  chunks.push(code`
-    private readonly rpc: Rpc;
+  if (options.defaultMetadata) {
+    chunks.push(code`
+      private readonly rpc: Rpc;
+      private readonly defaultMetadata: grpc.Metadata | undefined;
 
-    constructor(rpc: Rpc) {
-  `);
+      constructor(rpc: Rpc, defaultMetadata?: grpc.Metadata) {
+    `);
+    chunks.push(code`this.defaultMetadata = defaultMetadata;`);
+  } else {
+    chunks.push(code`
+      private readonly rpc: Rpc;
+
+      constructor(rpc: Rpc) {
+    `);
+  }
   chunks.push(code`this.rpc = rpc;`);
   // Bind each FooService method to the FooServiceImpl class
   for (const methodDesc of serviceDesc.method) {
@@ -41,6 +52,18 @@ export function generateGrpcClientImpl(
     chunks.push(generateRpcMethod(ctx, serviceDesc, methodDesc));
   }
 
+  if (options.defaultMetadata) {
+    // Per-call metadata wins over the defaults given to the constructor
+    chunks.push(code`
+      private withDefaultMetadata(metadata: grpc.Metadata | undefined): grpc.Metadata | undefined {
+        if (this.defaultMetadata === undefined) {
+          return metadata;
+        }
+        return new ${BrowserHeaders}({ ...this.defaultMetadata.headersMap, ...metadata?.headersMap });
+      }
+    `);
+  }
+
   chunks.push(code`}`);
   return joinCode(chunks, { trim: false, on: '\n' });
 }
@@ -48,9 +71,11 @@ export function generateGrpcClientImpl(
 /** Creates the RPC methods that client code actually calls. */
 function generateRpcMethod(ctx: Context, serviceDesc: ServiceDescriptorProto, methodDesc: MethodDescriptorProto) {
   assertInstanceOf(methodDesc, FormattedMethodDescriptor);
-  const requestMessage = rawRequestType(ctx, methodDesc);
+  const fromPartialRequest = getMessageMethod(ctx, methodDesc.inputType, 'fromPartial');
   const inputType = requestType(ctx, methodDesc, true);
   const returns = responsePromiseOrObservable(ctx, methodDesc);
+  const withDefaults = (metadata: string) =>
+    ctx.options.defaultMetadata ? `this.withDefaultMetadata(${metadata})` : metadata;
 
   if (methodDesc.clientStreaming) {
     return code`
@@ -63,31 +88,38 @@ function generateRpcMethod(ctx: Context, serviceDesc: ServiceDescriptorProto, me
       return this.rpc.stream(${methodDescName(
         serviceDesc,
         methodDesc
-      )}, request, options?.metadata, options?.rpcOptions)
+      )}, request, ${withDefaults('options?.metadata')}, options?.rpcOptions)
     }
   `;
   }
 
   const method = methodDesc.serverStreaming ? 'invoke' : 'unary';
+  const { useAbortSignal } = ctx.options;
   return code`
     ${methodDesc.formattedName}(
       request: ${inputType},
       metadata?: grpc.Metadata,
+      ${useAbortSignal ? 'abortSignal?: AbortSignal,' : ''}
     ): ${returns} {
       return this.rpc.${method}(
         ${methodDescName(serviceDesc, methodDesc)},
-        ${requestMessage}.fromPartial(request),
-        metadata,
+        ${fromPartialRequest}(request),
+        ${withDefaults('metadata')},
+        ${useAbortSignal ? 'abortSignal,' : ''}
       );
     }
   `;
 }
 
 /** Creates the service descriptor that grpc-web needs at runtime. */
-export function generateGrpcServiceDesc(fileDesc: FileDescriptorProto, serviceDesc: ServiceDescriptorProto): Code {
+export function generateGrpcServiceDesc(
+  ctx: Context,
+  fileDesc: FileDescriptorProto,
+  serviceDesc: ServiceDescriptorProto
+): Code {
   return code`
     export const ${serviceDesc.name}Desc = {
-      serviceName: "${maybePrefixPackage(fileDesc, serviceDesc.name)}",
+      serviceName: "${maybePrefixServicePath(ctx.options, maybePrefixPackage(fileDesc, serviceDesc.name))}",
     };
   `;
 }
@@ -104,8 +136,8 @@ export function generateGrpcMethodDesc(
   serviceDesc: ServiceDescriptorProto,
   methodDesc: MethodDescriptorProto
 ): Code {
-  const inputType = rawRequestType(ctx, methodDesc);
-  const outputType = responseType(ctx, methodDesc);
+  const encodeRequest = getMessageMethod(ctx, methodDesc.inputType, 'encode');
+  const decodeResponse = getMessageMethod(ctx, methodDesc.outputType, 'decode');
 
   // grpc-web expects this to be a class, but the ts-proto messages are just interfaces.
   //
@@ -120,7 +152,7 @@ export function generateGrpcMethodDesc(
   // This makes our data look enough like an object/class that grpc-web works just fine.
   const requestFn = code`{
     serializeBinary() {
-      return ${inputType}.encode(this).finish();
+      return ${encodeRequest}(this).finish();
     },
   }`;
 
@@ -130,7 +162,7 @@ export function generateGrpcMethodDesc(
   // we want/what grpc-web's runtime needs.
   const responseFn = code`{
     deserializeBinary(data: Uint8Array) {
-      return { ...${outputType}.decode(data), toObject() { return this; } };
+      return { ...${decodeResponse}(data), toObject() { return this; } };
     }
 }`;
 
@@ -179,11 +211,13 @@ function generateGrpcWebRpcType(ctx: Context, returnObservable: boolean, hasStre
   chunks.push(code`interface Rpc {`);
 
   const wrapper = returnObservable ? observableType(ctx) : 'Promise';
+  const maybeAbortSignal = ctx.options.useAbortSignal ? 'abortSignal?: AbortSignal,' : '';
   chunks.push(code`
     unary<T extends UnaryMethodDefinitionish>(
       methodDesc: T,
       request: any,
       metadata: grpc.Metadata | undefined,
+      ${maybeAbortSignal}
     ): ${wrapper}<any>;
   `);
 
@@ -193,6 +227,7 @@ function generateGrpcWebRpcType(ctx: Context, returnObservable: boolean, hasStre
         methodDesc: T,
         request: any,
         metadata: grpc.Metadata | undefined,
+        ${maybeAbortSignal}
       ): ${observableType(ctx)}<any>;`);
 
     chunks.push(code`
@@ -235,7 +270,7 @@ function generateGrpcWebImpl(ctx: Context, returnObservable: boolean, hasStreami
   if (returnObservable) {
     chunks.push(createObservableUnaryMethod(ctx));
   } else {
-    chunks.push(createPromiseUnaryMethod());
+    chunks.push(createPromiseUnaryMethod(ctx));
   }
 
   if (hasStreamingMethods) {
@@ -247,12 +282,22 @@ function generateGrpcWebImpl(ctx: Context, returnObservable: boolean, hasStreami
   return joinCode(chunks, { trim: false });
 }
 
-function createPromiseUnaryMethod(): Code {
+/** Creates the `CANCELLED` error that aborted calls fail with, shaped like the errors of failed calls. */
+function abortedError(): Code {
+  return code`
+    const err = new Error('Request was aborted') as any;
+    err.code = ${grpc}.Code.Canceled;
+  `;
+}
+
+function createPromiseUnaryMethod(ctx: Context): Code {
+  const { useAbortSignal } = ctx.options;
   return code`
     unary<T extends UnaryMethodDefinitionish>(
       methodDesc: T,
       _request: any,
-      metadata: grpc.Metadata | undefined
+      metadata: grpc.Metadata | undefined,
+      ${useAbortSignal ? 'abortSignal?: AbortSignal,' : ''}
     ): Promise<any> {
       const request = { ..._request, ...methodDesc.requestType };
       const maybeCombinedMetadata =
@@ -260,13 +305,30 @@ function createPromiseUnaryMethod(): Code {
           ? new ${BrowserHeaders}({ ...this.options?.metadata.headersMap, ...metadata?.headersMap })
           : metadata || this.options.metadata;
       return new Promise((resolve, reject) => {
-      ${grpc}.unary(methodDesc, {
+      ${
+        useAbortSignal
+          ? code`
+            if (abortSignal?.aborted) {
+              ${abortedError()}
+              reject(err);
+              return;
+            }
+            const onAbort = () => {
+              client.close();
+              ${abortedError()}
+              reject(err);
+            };
+          `
+          : ''
+      }
+      ${useAbortSignal ? 'const client = ' : ''}${grpc}.unary(methodDesc, {
           request,
           host: this.host,
           metadata: maybeCombinedMetadata,
           transport: this.options.transport,
           debug: this.options.debug,
           onEnd: function (response) {
+            ${useAbortSignal ? `abortSignal?.removeEventListener('abort', onAbort);` : ''}
             if (response.status === grpc.Code.OK) {
               resolve(response.message);
             } else {
@@ -277,17 +339,20 @@ function createPromiseUnaryMethod(): Code {
             }
           },
         });
+        ${useAbortSignal ? `abortSignal?.addEventListener('abort', onAbort);` : ''}
       });
     }
   `;
 }
 
 function createObservableUnaryMethod(ctx: Context): Code {
+  const { useAbortSignal } = ctx.options;
   return code`
     unary<T extends UnaryMethodDefinitionish>(
       methodDesc: T,
       _request: any,
-      metadata: grpc.Metadata | undefined
+      metadata: grpc.Metadata | undefined,
+      ${useAbortSignal ? 'abortSignal?: AbortSignal,' : ''}
     ): ${observableType(ctx)}<any> {
       const request = { ..._request, ...methodDesc.requestType };
       const maybeCombinedMetadata =
@@ -295,7 +360,8 @@ function createObservableUnaryMethod(ctx: Context): Code {
           ? new ${BrowserHeaders}({ ...this.options?.metadata.headersMap, ...metadata?.headersMap })
           : metadata || this.options.metadata;
       return new Observable(observer => {
-        ${grpc}.unary(methodDesc, {
+        ${useAbortSignal ? abortObserver() : ''}
+        ${useAbortSignal ? 'const client = ' : ''}${grpc}.unary(methodDesc, {
           request,
           host: this.host,
           metadata: maybeCombinedMetadata,
@@ -310,17 +376,20 @@ function createObservableUnaryMethod(ctx: Context): Code {
             }
           },
         });
+        ${useAbortSignal ? 'observer.add(() => client.close());' : ''}
       }).pipe(${take}(1));
     }
   `;
 }
 
 function createInvokeMethod(ctx: Context) {
+  const { useAbortSignal } = ctx.options;
   return code`
     invoke<T extends UnaryMethodDefinitionish>(
       methodDesc: T,
       _request: any,
-      metadata: grpc.Metadata | undefined
+      metadata: grpc.Metadata | undefined,
+      ${useAbortSignal ? 'abortSignal?: AbortSignal,' : ''}
     ): ${observableType(ctx)}<any> {
       const upStreamCodes = this.options.upStreamRetryCodes || [];
       const DEFAULT_TIMEOUT_TIME: number = 3_000;
@@ -330,6 +399,7 @@ function createInvokeMethod(ctx: Context) {
         ? new ${BrowserHeaders}({ ...this.options?.metadata.headersMap, ...metadata?.headersMap })
         : metadata || this.options.metadata;
       return new Observable(observer => {
+        ${useAbortSignal ? abortObserver() : ''}
         const upStream = (() => {
           const client = ${grpc}.invoke(methodDesc, {
             host: this.host,
@@ -359,6 +429,28 @@ function createInvokeMethod(ctx: Context) {
   `;
 }
 
+/**
+ * Ends the observer of an `Rpc` call with a `CANCELLED` error when its `abortSignal` fires, or right away if
+ * it already has. Closing the grpc-web client is left to the observer's teardown.
+ */
+function abortObserver(): Code {
+  return code`
+    if (abortSignal?.aborted) {
+      ${abortedError()}
+      observer.error(err);
+      return;
+    }
+    if (abortSignal) {
+      const onAbort = () => {
+        ${abortedError()}
+        observer.error(err);
+      };
+      abortSignal.addEventListener('abort', onAbort);
+      observer.add(() => abortSignal?.removeEventListener('abort', onAbort));
+    }
+  `;
+}
+
 function createStreamMethod(ctx: Context) {
   return code`
   stream<T extends MethodDefinitionish>(
@@ -0,0 +1,162 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import { DescriptorProto, FieldDescriptorProto, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  detectMapType,
+  getMessageFunction,
+  getMessageLongAsStringType,
+  isLong,
+  isMessage,
+  isOptionalProperty,
+  isRepeated,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  longOption,
+} from './types';
+import { camelCase, maybeSnakeToCamel } from './case';
+import { LongOption } from './options';
+
+/** Whether the message has 64-bit fields that aren't already strings, including within its sub-messages. */
+export function hasLongConversions(ctx: Context, messageDesc: DescriptorProto): boolean {
+  return messageDesc.field.some((field) => needsLongConversion(ctx, field));
+}
+
+function needsLongConversion(ctx: Context, field: FieldDescriptorProto): boolean {
+  const { options, typeMap } = ctx;
+  const seen = new Set<DescriptorProto>();
+  const check = (f: FieldDescriptorProto): boolean => {
+    // The unions of oneof=unions are passed through as they are, and so are the well-known types below
+    if (isWithinOneOfThatShouldBeUnion(options, f)) {
+      return false;
+    } else if (isLong(f)) {
+      return longOption(f, options) !== LongOption.STRING;
+    } else if (!isMessage(f) || f.typeName.startsWith('.google.protobuf.')) {
+      return false;
+    }
+    const desc = typeMap.get(f.typeName)?.[2] as DescriptorProto | undefined;
+    if (!desc || seen.has(desc)) {
+      return false;
+    }
+    seen.add(desc);
+    // Only the values of maps are converted, as their keys are left as they are
+    return desc.options?.mapEntry ? check(desc.field[1]) : desc.field.some(check);
+  };
+  return check(field);
+}
+
+/**
+ * Creates a `FooLongAsString` type of the `Foo` message with its 64-bit fields as strings, and the
+ * `fooLongAsString(message)` and `fooLongFromString(message)` functions that convert between the two, i.e. for
+ * passing messages between modules that were generated with different `forceLong` options.
+ *
+ * Sub-messages, repeated fields and the values of maps are converted as well, while the other fields are
+ * passed through as they are, including the well-known types and the unions of oneof=unions.
+ */
+export function generateLongConversions(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options } = ctx;
+  const types: Code[] = [];
+  const asStrings: Code[] = [];
+  const fromStrings: Code[] = [];
+  const names: string[] = [];
+
+  messageDesc.field
+    .filter((field) => needsLongConversion(ctx, field))
+    .forEach((field) => {
+      const name = maybeSnakeToCamel(field.name, options);
+      const place = `message.${name}`;
+      const isOptional = isOptionalProperty(field, messageDesc.options, options);
+      const mapType = detectMapType(ctx, messageDesc, field);
+      const value = longConversion(ctx, mapType ? mapType.valueField : field);
+
+      let type: Code;
+      let asString: Code;
+      let fromString: Code;
+      if (mapType) {
+        const { keyType, valueType } = mapType;
+        if (options.useMapType) {
+          const entries = (valueType: Code, snippet: Code) =>
+            code`new Map([...${place}].map(([key, value]): [${keyType}, ${valueType}] => [key, ${snippet}]))`;
+          type = code`Map<${keyType}, ${value.type}>`;
+          asString = entries(value.type, value.asString('value'));
+          fromString = entries(valueType, value.fromString('value'));
+        } else {
+          // Object keys are strings either way, and index signatures of strings are assignable to those of numbers
+          const reduce = (valueType: Code, snippet: Code) => code`
+            Object.entries(${place}).reduce<{ [key: string]: ${valueType} }>((acc, [key, value]) => {
+              acc[key] = ${snippet};
+              return acc;
+            }, {})
+          `;
+          type = code`{ [key: ${keyType}]: ${value.type} }`;
+          asString = reduce(value.type, value.asString('value'));
+          fromString = reduce(valueType, value.fromString('value'));
+        }
+      } else if (isRepeated(field)) {
+        type = code`${value.type}[]`;
+        asString = code`${place}.map((e) => ${value.asString('e')})`;
+        fromString = code`${place}.map((e) => ${value.fromString('e')})`;
+      } else {
+        type = isMessage(field) || isWithinOneOf(field) ? code`${value.type} | undefined` : value.type;
+        asString = value.asString(place);
+        fromString = value.fromString(place);
+      }
+
+      // Singular messages, oneof members and optional properties may not be set
+      if (isOptional || (!isRepeated(field) && (isMessage(field) || isWithinOneOf(field)))) {
+        asString = code`${place} !== undefined ? ${asString} : undefined`;
+        fromString = code`${place} !== undefined ? ${fromString} : undefined`;
+      }
+      names.push(name);
+      types.push(code`${name}${isOptional ? '?' : ''}: ${type};`);
+      asStrings.push(code`${name}: ${asString},`);
+      fromStrings.push(code`${name}: ${fromString},`);
+    });
+
+  const functionName = camelCase(fullName);
+  const variant = `${fullName}LongAsString`;
+  return code`
+    export type ${def(variant)} = Omit<${fullName}, ${names.map((name) => `'${name}'`).join(' | ')}> & {
+      ${joinCode(types, { on: '\n' })}
+    };
+
+    export function ${def(`${functionName}LongAsString`)}(message: ${fullName}): ${variant} {
+      return { ...message, ${joinCode(asStrings, { on: '\n' })} };
+    }
+
+    export function ${def(`${functionName}LongFromString`)}(message: ${variant}): ${fullName} {
+      return { ...message, ${joinCode(fromStrings, { on: '\n' })} };
+    }
+  `;
+}
+
+/** The string type of a 64-bit or message `field`, and the snippets that convert a single value to and from it. */
+function longConversion(
+  ctx: Context,
+  field: FieldDescriptorProto
+): { type: Code; asString: (place: string) => Code; fromString: (place: string) => Code } {
+  const { options, utils } = ctx;
+  if (isMessage(field)) {
+    const asString = getMessageFunction(ctx, field.typeName, 'LongAsString');
+    const fromString = getMessageFunction(ctx, field.typeName, 'LongFromString');
+    return {
+      type: getMessageLongAsStringType(ctx, field.typeName),
+      asString: (place) => code`${asString}(${place})`,
+      fromString: (place) => code`${fromString}(${place})`,
+    };
+  }
+  const unsigned =
+    field.type === FieldDescriptorProto_Type.TYPE_UINT64 || field.type === FieldDescriptorProto_Type.TYPE_FIXED64;
+  if (longOption(field, options) === LongOption.LONG) {
+    return {
+      type: code`string`,
+      asString: (place) => code`${place}.toString()`,
+      fromString: (place) => code`${utils.Long}.fromString(${place}, ${unsigned})`,
+    };
+  }
+  // Like decode, refuse strings that are out of the range of numbers, rather than silently rounding them
+  return {
+    type: code`string`,
+    asString: (place) => code`String(${place})`,
+    fromString: (place) => code`${utils.longToNumber}(${utils.Long}.fromString(${place}, ${unsigned}))`,
+  };
+}
@@ -0,0 +1,344 @@
+import { code, Code, joinCode } from 'ts-poet';
+import { DescriptorProto, FieldDescriptorProto, FileDescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  basicTypeName,
+  detectMapType,
+  isMapType,
+  isMessage,
+  isNativeDuration,
+  isObjectId,
+  isOptionalProperty,
+  isRepeated,
+  isStructType,
+  isTimestamp,
+  isValueType,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  messageToTypeName,
+  notDefaultCheck,
+  valueTypeName,
+} from './types';
+import { maybeSnakeToCamel } from './case';
+import { DateOption } from './options';
+
+/**
+ * Creates a `merge(target, source)` function that overlays the set fields of `source` onto a copy of `target`.
+ *
+ * Scalars are copied when non-default, sub-messages are merged recursively, map entries are merged
+ * key-by-key (recursively for message values), `Struct` fields are deep-merged like JSON objects, and oneofs
+ * are replaced wholesale. Repeated fields are replaced too, unless `{ repeated: 'append' }` is given, which
+ * is passed on to the nested merges.
+ * With partial methods, `source` is a `DeepPartial`, so messages can be built up from partials incrementally.
+ */
+export function generateMerge(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const chunks: Code[] = [];
+
+  const hasFields = messageDesc.field.length > 0;
+  const mergeOptions = code`${hasFields ? 'mergeOptions' : '_mergeOptions'}?: ${utils.MergeOptions}`;
+  if (!options.outputPartialMethods) {
+    chunks.push(code`
+      merge(target: ${fullName}, ${hasFields ? 'source' : '_'}: ${fullName}, ${mergeOptions}): ${fullName} {
+    `);
+  } else if (options.useExactTypes) {
+    chunks.push(code`
+      merge<I extends ${utils.Exact}<${utils.DeepPartial}<${fullName}>, I>>(
+        target: ${fullName},
+        ${hasFields ? 'partial' : '_'}: I,
+        ${mergeOptions},
+      ): ${fullName} {
+    `);
+  } else {
+    chunks.push(code`
+      merge(
+        target: ${fullName},
+        ${hasFields ? 'partial' : '_'}: ${utils.DeepPartial}<${fullName}>,
+        ${mergeOptions},
+      ): ${fullName} {
+    `);
+  }
+
+  // With partial methods, `source` is a partial that is read like fromPartial does, i.e. with its
+  // missing fields left at their defaults, which below then means "leave the target's value as is"
+  if (options.outputPartialMethods && hasFields) {
+    chunks.push(code`const source = ${fullName}.fromPartial(partial);`);
+  }
+
+  if (options.usePrototypeForDefaults) {
+    chunks.push(code`const message = Object.assign(Object.create(createBase${fullName}()), target) as ${fullName};`);
+  } else {
+    chunks.push(code`const message = { ...target };`);
+  }
+
+  const processedOneofs = new Set<number>();
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      if (!processedOneofs.has(field.oneofIndex)) {
+        processedOneofs.add(field.oneofIndex);
+        const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+        chunks.push(code`
+          if (source.${oneofName} !== undefined) {
+            message.${oneofName} = source.${oneofName};
+          }
+        `);
+      }
+    } else if (isRepeated(field) && isMapType(ctx, messageDesc, field)) {
+      const { keyType, valueField } = detectMapType(ctx, messageDesc, field)!;
+      if (isMessage(valueField) && isMergeableMessage(ctx, valueField)) {
+        // Message values of the same key are merged, just like message fields
+        const type = basicTypeName(ctx, valueField);
+        const key = keyType.toCodeString() === 'string' ? 'key' : 'Number(key)';
+        const maybeBang = options.emptyRepeated === 'undefined' ? '!' : '';
+        chunks.push(code`
+          message.${fieldName} = { ...target.${fieldName} };
+          Object.entries(source.${fieldName} ?? {}).forEach(([key, value]) => {
+            const existing = target.${fieldName}?.[${key}];
+            message.${fieldName}${maybeBang}[${key}] =
+              existing !== undefined ? ${type}.merge(existing, value, mergeOptions) : value;
+          });
+        `);
+      } else {
+        chunks.push(code`message.${fieldName} = { ...target.${fieldName}, ...source.${fieldName} };`);
+      }
+    } else if (isRepeated(field)) {
+      const isOptional = isOptionalProperty(field, messageDesc.options, options);
+      const maybeNotUndefinedAnd = isOptional ? `source.${fieldName} !== undefined && ` : '';
+      const existing = isOptional ? `(target.${fieldName} ?? [])` : `target.${fieldName}`;
+      chunks.push(code`
+        if (${maybeNotUndefinedAnd}source.${fieldName}.length !== 0) {
+          message.${fieldName} =
+            mergeOptions?.repeated === 'append'
+              ? [...${existing}, ...source.${fieldName}]
+              : [...source.${fieldName}];
+        }
+      `);
+    } else if (isWithinOneOf(field) && !field.proto3Optional) {
+      // Setting one member of a oneof clears the others
+      const siblings = messageDesc.field
+        .filter((f) => f !== field && isWithinOneOf(f) && f.oneofIndex === field.oneofIndex)
+        .map((f) => code`message.${maybeSnakeToCamel(f.name, options)} = undefined;`);
+      chunks.push(code`
+        if (source.${fieldName} !== undefined) {
+          message.${fieldName} = source.${fieldName};
+          ${joinCode(siblings, { on: '\n' })}
+        }
+      `);
+    } else if (isStructType(field)) {
+      chunks.push(code`
+        if (source.${fieldName} !== undefined) {
+          message.${fieldName} = message.${fieldName} !== undefined
+            ? ${utils.mergeStruct}(message.${fieldName}, source.${fieldName})
+            : source.${fieldName};
+        }
+      `);
+    } else if (isMessage(field) && isMergeableMessage(ctx, field)) {
+      const type = basicTypeName(ctx, field);
+      chunks.push(code`
+        if (source.${fieldName} !== undefined) {
+          message.${fieldName} = message.${fieldName} !== undefined
+            ? ${type}.merge(message.${fieldName}, source.${fieldName}, mergeOptions)
+            : source.${fieldName};
+        }
+      `);
+    } else if (isMessage(field) || field.proto3Optional) {
+      chunks.push(code`
+        if (source.${fieldName} !== undefined) {
+          message.${fieldName} = source.${fieldName};
+        }
+      `);
+    } else {
+      chunks.push(code`
+        if (${notDefaultCheck(ctx, field, messageDesc.options, `source.${fieldName}`)}) {
+          message.${fieldName} = source.${fieldName};
+        }
+      `);
+    }
+  });
+
+  chunks.push(code`return message;`);
+  chunks.push(code`}`);
+  return joinCode(chunks, { on: '\n' });
+}
+
+/**
+ * Creates a `patch(message, changes)` function that applies `changes` to a copy of `message`, and returns it as
+ * `next` along with the `inverse` patch that undoes the changes, i.e. for optimistic updates with rollback.
+ *
+ * Unlike merge, a field is changed when its key is in `changes`, even if its value is undefined, so that the
+ * inverse can reset fields to their defaults and sub-messages to undefined. Sub-messages that are set on both
+ * sides are patched recursively and maps key-by-key, with undefined values deleting their keys, while everything
+ * else is replaced wholesale. Applying the inverse with `patch` in turn returns the patch that redoes the changes.
+ */
+export function generatePatch(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const chunks: Code[] = [];
+
+  const hasFields = messageDesc.field.length > 0;
+  const partial = code`${utils.DeepPartial}<${fullName}>`;
+  chunks.push(code`
+    patch(
+      message: ${fullName},
+      ${hasFields ? 'changes' : '_'}: ${partial},
+    ): { next: ${fullName}; inverse: ${partial} } {
+  `);
+
+  // Like merge, the changes are read like fromPartial does, but here only the keys that are present count
+  if (hasFields) {
+    chunks.push(code`const source = ${fullName}.fromPartial(changes);`);
+  }
+  if (options.usePrototypeForDefaults) {
+    chunks.push(code`const next = Object.assign(Object.create(createBase${fullName}()), message) as ${fullName};`);
+  } else {
+    chunks.push(code`const next = { ...message };`);
+  }
+  chunks.push(code`const inverse: ${partial} = {};`);
+
+  const replace = (name: string, then: Code | string = '') => code`
+    if ('${name}' in changes) {
+      next.${name} = source.${name};
+      inverse.${name} = message.${name};
+      ${then}
+    }
+  `;
+
+  const processedOneofs = new Set<number>();
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      if (!processedOneofs.has(field.oneofIndex)) {
+        processedOneofs.add(field.oneofIndex);
+        chunks.push(replace(maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options)));
+      }
+    } else if (isRepeated(field) && isMapType(ctx, messageDesc, field)) {
+      const { keyType } = detectMapType(ctx, messageDesc, field)!;
+      const key = keyType.toCodeString() === 'string' ? 'key' : 'Number(key)';
+      chunks.push(code`
+        if (changes.${fieldName} !== undefined) {
+          const prior: NonNullable<typeof inverse.${fieldName}> = {};
+          next.${fieldName} = { ...message.${fieldName} };
+          for (const key of Object.keys(changes.${fieldName})) {
+            prior[${key}] = message.${fieldName}?.[${key}];
+            const value = source.${fieldName}?.[${key}];
+            if (value !== undefined) {
+              next.${fieldName}[${key}] = value;
+            } else {
+              delete next.${fieldName}[${key}];
+            }
+          }
+          inverse.${fieldName} = prior;
+        }
+      `);
+    } else if (isWithinOneOf(field) && !field.proto3Optional) {
+      // Setting one member of a oneof clears the others, which the inverse then sets back, while unsetting a
+      // member leaves the others alone, so that applying the inverse doesn't clear the member it restores
+      const siblings = messageDesc.field
+        .filter((f) => f !== field && isWithinOneOf(f) && f.oneofIndex === field.oneofIndex)
+        .map((f) => maybeSnakeToCamel(f.name, options))
+        .map(
+          (name) => code`
+            next.${name} = undefined;
+            inverse.${name} = message.${name};
+          `
+        );
+      chunks.push(
+        replace(
+          fieldName,
+          code`
+            if (source.${fieldName} !== undefined) {
+              ${joinCode(siblings, { on: '\n' })}
+            }
+          `
+        )
+      );
+    } else if (!isRepeated(field) && isMessage(field) && isMergeableMessage(ctx, field)) {
+      const type = basicTypeName(ctx, field);
+      chunks.push(code`
+        if ('${fieldName}' in changes) {
+          if (message.${fieldName} !== undefined && changes.${fieldName} !== undefined) {
+            const patched = ${type}.patch(message.${fieldName}, changes.${fieldName});
+            next.${fieldName} = patched.next;
+            inverse.${fieldName} = patched.inverse;
+          } else {
+            next.${fieldName} = source.${fieldName};
+            inverse.${fieldName} = message.${fieldName};
+          }
+        }
+      `);
+    } else {
+      chunks.push(replace(fieldName));
+    }
+  });
+
+  chunks.push(code`return { next, inverse };`);
+  chunks.push(code`}`);
+  return joinCode(chunks, { on: '\n' });
+}
+
+/** Whether the field holds one of our generated messages, vs. a value that is mapped to a native type. */
+export function isMergeableMessage(ctx: Context, field: FieldDescriptorProto): boolean {
+  const { options } = ctx;
+  if (isValueType(ctx, field)) {
+    return false;
+  }
+  if (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) {
+    return false;
+  }
+  if (isNativeDuration(field, options)) {
+    return false;
+  }
+  if (isObjectId(field) && options.useMongoObjectId) {
+    return false;
+  }
+  return true;
+}
+
+/**
+ * Creates `accumulateFoo(stream)` helpers for the responses of server-streaming methods, which fold
+ * each streamed message into a running state with `Foo.merge` and yield the accumulated message.
+ *
+ * Streamed chunks usually carry the next elements of repeated fields, so these are appended by default.
+ */
+export function generateStreamAccumulators(ctx: Context, fileDesc: FileDescriptorProto): Code[] {
+  const { typeMap, utils } = ctx;
+  const chunks: Code[] = [];
+  const seen = new Set<string>();
+
+  fileDesc.service.forEach((serviceDesc) => {
+    serviceDesc.method.forEach((methodDesc) => {
+      if (!methodDesc.serverStreaming || seen.has(methodDesc.outputType)) {
+        return;
+      }
+      const mapping = typeMap.get(methodDesc.outputType);
+      const isNativeType =
+        valueTypeName(ctx, methodDesc.outputType) !== undefined ||
+        methodDesc.outputType === '.google.protobuf.Timestamp';
+      if (!mapping || isNativeType) {
+        return;
+      }
+      seen.add(methodDesc.outputType);
+
+      const type = messageToTypeName(ctx, methodDesc.outputType, { keepValueType: true });
+      chunks.push(code`
+        /** Merges each message of a ${mapping[1]} stream into the previous ones, yielding the accumulated state. */
+        export async function* accumulate${mapping[1]}(
+          stream: AsyncIterable<${type}>,
+          mergeOptions: ${utils.MergeOptions} = { repeated: 'append' },
+        ): AsyncIterable<${type}> {
+          let state: ${type} | undefined;
+          for await (const chunk of stream) {
+            state = state === undefined ? chunk : ${type}.merge(state, chunk, mergeOptions);
+            yield state;
+          }
+        }
+      `);
+    });
+  });
+
+  return chunks;
+}
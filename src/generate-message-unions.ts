@@ -0,0 +1,132 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import {
+  DescriptorProto,
+  EnumDescriptorProto,
+  FieldDescriptorProto,
+  FieldDescriptorProto_Type,
+} from 'ts-proto-descriptors';
+import { Context } from './context';
+import { basicTypeName, getEnumMethod, getMessageMethod, isEnum, messageToTypeName } from './types';
+import { maybeSnakeToCamel } from './case';
+import { getFieldJsonName, getPropertyAccessor } from './utils';
+
+/**
+ * A `messageUnions=Event:type:pkg.Created@CREATED|pkg.Deleted@DELETED` entry, i.e. a `type Event` union of
+ * `pkg.Created` and `pkg.Deleted` that is discriminated by their `type` field being `CREATED` or `DELETED`.
+ */
+interface MessageUnion {
+  name: string;
+  fieldName: string;
+  members: Array<{ message: string; value: string }>;
+}
+
+/** Parses the `messageUnions` option into the unions whose first member is `fullTypeName`. */
+export function messageUnionsFor(ctx: Context, fullTypeName: string): MessageUnion[] {
+  return ctx.options.messageUnions
+    .map((entry) => {
+      const [name, fieldName, members] = entry.split(':');
+      const parsed = (members ?? '').split('|').map((member) => {
+        const [message, value] = member.split('@');
+        return { message, value };
+      });
+      if (!name || !fieldName || parsed.some(({ message, value }) => !message || !value)) {
+        throw new Error(
+          `messageUnions entries must look like 'Event:type:pkg.Created@CREATED|pkg.Deleted@DELETED', got '${entry}'`
+        );
+      }
+      return { name, fieldName, members: parsed };
+    })
+    .filter((union) => union.members[0].message === fullTypeName);
+}
+
+/**
+ * Creates a `type Event = (Created & { type: EventType.CREATED }) | ...` union of the messages in `union`,
+ * and, with JSON methods, a `parseEvent(object)` function that decodes the JSON form of whichever member
+ * the discriminant field says it is.
+ *
+ * The discriminant must be a string or enum field of the same type in every member, with a different
+ * value per member, otherwise we fail the codegen.
+ */
+export function generateMessageUnion(ctx: Context, union: MessageUnion): Code {
+  const { options, typeMap, utils } = ctx;
+  const seen = new Set<string>();
+  let discriminant: FieldDescriptorProto | undefined;
+
+  const members = union.members.map(({ message, value }) => {
+    const mapping = typeMap.get(`.${message}`);
+    if (!mapping || !('field' in mapping[2])) {
+      throw new Error(`messageUnions: could not find message ${message}`);
+    }
+    const field = (mapping[2] as DescriptorProto).field.find((f) => f.name === union.fieldName);
+    if (!field) {
+      throw new Error(`messageUnions: message ${message} has no field ${union.fieldName}`);
+    }
+    if (!isEnum(field) && field.type !== FieldDescriptorProto_Type.TYPE_STRING) {
+      throw new Error(`messageUnions: field ${union.fieldName} of ${message} must be a string or an enum`);
+    }
+    if (discriminant && (field.type !== discriminant.type || field.typeName !== discriminant.typeName)) {
+      throw new Error(
+        `messageUnions: field ${union.fieldName} of ${message} has a different type than in ${union.name}`
+      );
+    }
+    if (seen.has(value)) {
+      throw new Error(`messageUnions: ${union.name} uses the ${union.fieldName} value ${value} more than once`);
+    }
+    seen.add(value);
+    discriminant = field;
+
+    let literalValue: Code;
+    let literalType: Code;
+    if (isEnum(field)) {
+      const enumDesc = typeMap.get(field.typeName)?.[2] as EnumDescriptorProto | undefined;
+      if (!enumDesc?.value.some((v) => v.name === value)) {
+        throw new Error(`messageUnions: ${value} is not a value of ${field.typeName.substring(1)}`);
+      }
+      literalValue = code`${basicTypeName(ctx, field)}.${value}`;
+      literalType = options.enumsAsLiterals ? code`typeof ${literalValue}` : literalValue;
+    } else {
+      literalValue = code`${JSON.stringify(value)}`;
+      literalType = literalValue;
+    }
+    return {
+      type: messageToTypeName(ctx, `.${message}`, { keepValueType: true }),
+      fromJson: getMessageMethod(ctx, `.${message}`, 'fromJSON'),
+      literalValue,
+      literalType,
+    };
+  });
+
+  const field = discriminant!;
+  const propertyName = maybeSnakeToCamel(field.name, options);
+  const chunks: Code[] = [];
+  chunks.push(code`
+    export type ${def(union.name)} = ${joinCode(
+      members.map((m) => code`(${m.type} & { ${propertyName}: ${m.literalType} })`),
+      { on: ' | ' }
+    )};
+  `);
+
+  if (options.outputJsonMethods) {
+    const jsonProperty = getPropertyAccessor('object', getFieldJsonName(field, options), true);
+    const read = isEnum(field)
+      ? code`${getEnumMethod(ctx, field.typeName, 'FromJSON')}(${jsonProperty})`
+      : jsonProperty;
+    const cases = members.map(
+      (m) => code`
+        case ${m.literalValue}:
+          return ${m.fromJson}(object) as ${union.name};
+      `
+    );
+    chunks.push(code`
+      export function ${def(`parse${union.name}`)}(object: any): ${union.name} {
+        switch (${read}) {
+          ${joinCode(cases, { on: '\n' })}
+          default:
+            throw new ${utils.globalThis}.Error('Unknown ${union.fieldName} ' + ${jsonProperty} + ' for ${union.name}');
+        }
+      }
+    `);
+  }
+
+  return joinCode(chunks, { on: '\n\n' });
+}
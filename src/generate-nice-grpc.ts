@@ -4,7 +4,7 @@ import { camelCase } from './case';
 import { Context } from './context';
 import SourceInfo, { Fields } from './sourceInfo';
 import { messageToTypeName } from './types';
-import { assertInstanceOf, FormattedMethodDescriptor, maybeAddComment } from './utils';
+import { assertInstanceOf, FormattedMethodDescriptor, maybeAddComment, maybePrefixPackage } from './utils';
 
 const CallOptions = imp('CallOptions@nice-grpc-common');
 const CallContext = imp('CallContext@nice-grpc-common');
@@ -22,6 +22,9 @@ export function generateNiceGrpcService(
 
   chunks.push(generateServerStub(ctx, sourceInfo, serviceDesc));
   chunks.push(generateClientStub(ctx, sourceInfo, serviceDesc));
+  if (ctx.options.niceGrpcInterceptors) {
+    chunks.push(generateClientWrapper(fileDesc, serviceDesc));
+  }
 
   return joinCode(chunks, { on: '\n\n' });
 }
@@ -151,3 +154,81 @@ function generateClientStub(ctx: Context, sourceInfo: SourceInfo, serviceDesc: S
 
   return joinCode(chunks, { on: '\n' });
 }
+
+/**
+ * Generates a `wrapFooClient(client, interceptors)` function that returns a `FooClient` whose methods
+ * call the given `client`, i.e. one made by nice-grpc's `createClient`, through the interceptors.
+ */
+function generateClientWrapper(fileDesc: FileDescriptorProto, serviceDesc: ServiceDescriptorProto) {
+  const service = maybePrefixPackage(fileDesc, serviceDesc.name);
+  const methods = serviceDesc.method.map((methodDesc) => {
+    assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+    const name = camelCase(methodDesc.name);
+    return code`
+      ${name}: (request, options) =>
+        runNiceGrpcInterceptors(
+          interceptors,
+          {
+            service: "${service}",
+            method: "${methodDesc.name}",
+            requestStream: ${methodDesc.clientStreaming},
+            responseStream: ${methodDesc.serverStreaming},
+            request,
+            options: options ?? {},
+          },
+          (request, options) => client.${name}(request, options as ${CallOptions} & CallOptionsExt),
+        ),
+    `;
+  });
+
+  return code`
+    export function ${def(`wrap${serviceDesc.name}Client`)}<CallOptionsExt = {}>(
+      client: ${serviceDesc.name}Client<CallOptionsExt>,
+      interceptors: NiceGrpcInterceptor[],
+    ): ${serviceDesc.name}Client<CallOptionsExt> {
+      return {
+        ${joinCode(methods, { on: '\n' })}
+      };
+    }
+  `;
+}
+
+/**
+ * Creates the `NiceGrpcInterceptor` type that the `wrapFooClient` functions of `niceGrpcInterceptors=true`
+ * accept, and the `runNiceGrpcInterceptors` function that chains them around each call.
+ *
+ * Like for `clientInterceptors`, the first interceptor is the outermost. The result of a call is whatever
+ * the client method returns, i.e. a `Promise` for unary calls and an `AsyncIterable` for streamed responses,
+ * so interceptors can await the former, or wrap the latter to observe and transform each message.
+ */
+export function generateNiceGrpcInterceptorTypes(): Code {
+  return code`
+    export interface NiceGrpcCall<Req> {
+      service: string;
+      method: string;
+      /** Whether \`request\` is an \`AsyncIterable\` of messages, rather than a single one. */
+      requestStream: boolean;
+      /** Whether the call returns an \`AsyncIterable\` of messages, rather than a \`Promise\` of a single one. */
+      responseStream: boolean;
+      request: Req;
+      options: ${CallOptions};
+    }
+
+    export type NiceGrpcInterceptor = <Req, Res>(
+      call: NiceGrpcCall<Req>,
+      next: (request: Req, options: ${CallOptions}) => Res
+    ) => Res;
+
+    function runNiceGrpcInterceptors<Req, Res>(
+      interceptors: NiceGrpcInterceptor[],
+      call: NiceGrpcCall<Req>,
+      transport: (request: Req, options: ${CallOptions}) => Res
+    ): Res {
+      const chain = interceptors.reduceRight<(request: Req, options: ${CallOptions}) => Res>(
+        (next, interceptor) => (request, options) => interceptor({ ...call, request, options }, next),
+        transport
+      );
+      return chain(call.request, call.options);
+    }
+  `;
+}
@@ -0,0 +1,140 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import { DescriptorProto, FieldDescriptorProto, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  getEnumMethod,
+  getMessageFunction,
+  isAnyValueType,
+  isBytesValueType,
+  isEnum,
+  isFieldMaskType,
+  isListValueType,
+  isMapType,
+  isMessage,
+  isRepeated,
+  isStructType,
+  isTimestamp,
+  isValueType,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  notDefaultCheck,
+  oneofCaseName,
+  oneofValueName,
+} from './types';
+import { camelCase, maybeSnakeToCamel } from './case';
+import { getFieldJsonName } from './utils';
+import { DateOption } from './options';
+
+/**
+ * Creates `fooToQueryParams(message, prefix)` and `fooToQueryString(message)` functions that serialize the
+ * message's scalar, enum and repeated scalar fields as URL query parameters under their JSON names, i.e.
+ * `name=x&tags=a&tags=b&filter.status=ACTIVE`, for calling REST endpoints.
+ *
+ * Fields at their default value are left out, sub-messages are flattened into dotted names, and fields that
+ * don't map onto query parameters, i.e. maps, bytes, repeated messages and `Struct`s, are skipped.
+ */
+export function generateQueryString(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    const key = `prefix + '${getFieldJsonName(field, options)}'`;
+    if (isMapType(ctx, messageDesc, field) || field.type === FieldDescriptorProto_Type.TYPE_BYTES) {
+      return;
+    }
+
+    // Dates and scalar wrappers are written like scalars, while other messages are flattened
+    const isDate = isTimestamp(field) && options.useDate !== DateOption.TIMESTAMP;
+    if (isMessage(field) && !isDate && !isScalarWrapper(ctx, field)) {
+      // Only singular messages can be flattened into dotted names, and well-known types have no params
+      if (
+        isRepeated(field) ||
+        isWithinOneOfThatShouldBeUnion(options, field) ||
+        field.typeName.startsWith('.google.protobuf.')
+      ) {
+        return;
+      }
+      const toParams = getMessageFunction(ctx, field.typeName, 'ToQueryParams');
+      chunks.push(code`
+        if (message.${fieldName} !== undefined) {
+          params.push(...${toParams}(message.${fieldName}, ${key} + '.'));
+        }
+      `);
+      return;
+    }
+
+    const value = queryValueSnippet(ctx, field);
+    if (isRepeated(field)) {
+      chunks.push(code`
+        for (const v of message.${fieldName} ?? []) {
+          params.push([${key}, ${value('v')}]);
+        }
+      `);
+    } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      chunks.push(code`
+        if (message.${oneofName}?.${oneofCaseName(options)} === '${fieldName}') {
+          params.push([${key}, ${value(`message.${oneofName}.${oneofValueName(fieldName, options)}`)}]);
+        }
+      `);
+    } else if (isWithinOneOf(field) || isMessage(field)) {
+      chunks.push(code`
+        if (message.${fieldName} !== undefined) {
+          params.push([${key}, ${value(`message.${fieldName}`)}]);
+        }
+      `);
+    } else {
+      chunks.push(code`
+        if (${notDefaultCheck(ctx, field, messageDesc.options, `message.${fieldName}`)}) {
+          params.push([${key}, ${value(`message.${fieldName}`)}]);
+        }
+      `);
+    }
+  });
+
+  const functionName = camelCase(fullName);
+  return code`
+    export function ${def(`${functionName}ToQueryParams`)}(
+      ${chunks.length > 0 ? 'message' : '_'}: ${fullName},
+      ${chunks.length > 0 ? 'prefix' : '_prefix'}: string = '',
+    ): [string, string][] {
+      const params: [string, string][] = [];
+      ${joinCode(chunks, { on: '\n' })}
+      return params;
+    }
+
+    export function ${def(`${functionName}ToQueryString`)}(message: ${fullName}): string {
+      return ${functionName}ToQueryParams(message)
+        .map(([key, value]) => encodeURIComponent(key) + '=' + encodeURIComponent(value))
+        .join('&');
+    }
+  `;
+}
+
+/** Returns a function that renders a single value of the scalar, enum, Date or wrapper `field` as a string. */
+function queryValueSnippet(ctx: Context, field: FieldDescriptorProto): (place: string) => Code {
+  const { options } = ctx;
+  if (isEnum(field) && options.outputJsonMethods) {
+    const toJson = getEnumMethod(ctx, field.typeName, 'ToJSON');
+    return (place) => code`${toJson}(${place})`;
+  } else if (isTimestamp(field) && options.useDate === DateOption.DATE) {
+    return (place) => code`${place}.toISOString()`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_STRING || isTimestamp(field)) {
+    // Timestamps are already strings with useDate=string
+    return (place) => code`${place}`;
+  }
+  return (place) => code`String(${place})`;
+}
+
+/** Whether `field` is a wrapper type like `StringValue` that is represented as its scalar value. */
+function isScalarWrapper(ctx: Context, field: FieldDescriptorProto): boolean {
+  return (
+    isValueType(ctx, field) &&
+    !isBytesValueType(field) &&
+    !isAnyValueType(field) &&
+    !isListValueType(field) &&
+    !isStructType(field) &&
+    !isFieldMaskType(field)
+  );
+}
@@ -0,0 +1,63 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import {
+  DescriptorProto,
+  EnumDescriptorProto,
+  FieldDescriptorProto,
+  FieldDescriptorProto_Type,
+} from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  detectMapType,
+  getMessageFunction,
+  isEnum,
+  isMessage,
+  isRepeated,
+  isWithinOneOfThatShouldBeUnion,
+} from './types';
+import { camelCase, maybeSnakeToCamel } from './case';
+
+/**
+ * Creates a `fooFieldDescriptors` array of the fields of the `Foo` message, in declaration order, with their
+ * property name, number, type and cardinality, i.e. for driving generic form and table renderers.
+ *
+ * Message fields refer to the descriptors of their message type, and enum fields list the values of their enum.
+ * Map fields are described by their values, with the type of their keys as `mapKey`.
+ */
+export function generateReflection(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, typeMap } = ctx;
+  const fields = messageDesc.field.map((field) => {
+    const mapType = detectMapType(ctx, messageDesc, field);
+    const valueField = mapType ? mapType.valueField : field;
+    const props: Code[] = [
+      code`name: '${maybeSnakeToCamel(field.name, options)}'`,
+      code`number: ${field.number}`,
+      code`type: '${reflectionType(valueField)}'`,
+      code`repeated: ${!mapType && isRepeated(field)}`,
+    ];
+    if (mapType) {
+      props.push(code`mapKey: '${reflectionType(mapType.keyField)}'`);
+    }
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      props.push(code`oneof: '${maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options)}'`);
+    }
+    if (isMessage(valueField)) {
+      props.push(code`messageType: () => ${getMessageFunction(ctx, valueField.typeName, 'FieldDescriptors')}`);
+    } else if (isEnum(valueField)) {
+      const enumDesc = typeMap.get(valueField.typeName)?.[2] as EnumDescriptorProto | undefined;
+      const values = (enumDesc?.value ?? []).map((value) => `{ name: '${value.name}', number: ${value.number} }`);
+      props.push(code`enumValues: [${values.join(', ')}]`);
+    }
+    return code`{ ${joinCode(props, { on: ', ' })} }`;
+  });
+
+  return code`
+    export const ${def(`${camelCase(fullName)}FieldDescriptors`)}: ${ctx.utils.ReflectionField}[] = [
+      ${joinCode(fields, { on: ',\n' })}
+    ];
+  `;
+}
+
+/** The lowercase name of the field's proto type, i.e. `int32` for `TYPE_INT32`. */
+function reflectionType(field: FieldDescriptorProto): string {
+  return FieldDescriptorProto_Type[field.type].replace('TYPE_', '').toLowerCase();
+}
@@ -0,0 +1,36 @@
+import { createHash } from 'crypto';
+import { code, Code } from 'ts-poet';
+import { DescriptorProto } from 'ts-proto-descriptors';
+import { camelToSnake } from './case';
+import { Context } from './context';
+import { isMessage } from './types';
+
+/**
+ * Creates a `FOO_SCHEMA_HASH` constant of the wire shape of a message, i.e. for a server to check that a client
+ * was built against the same version of the schema.
+ *
+ * The hash covers the number, label and type of each field, and the shape of the messages that fields refer to, so
+ * it changes when fields are added, removed, renumbered or change their type or cardinality, even when that is
+ * wire-compatible, like adding a field. Renaming fields, comments, options and the order of the fields in the
+ * `.proto` file don't change it.
+ */
+export function generateSchemaHash(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const constName = `${fullName.split('_').map(camelToSnake).join('_')}_SCHEMA_HASH`;
+  const hash = createHash('sha256').update(schemaShape(ctx, messageDesc, new Set())).digest('hex').substring(0, 16);
+  return code`export const ${constName} = "${hash}";`;
+}
+
+/** Writes the fields of `messageDesc` in field number order, with referenced messages inlined once each. */
+function schemaShape(ctx: Context, messageDesc: DescriptorProto, seen: Set<DescriptorProto>): string {
+  seen.add(messageDesc);
+  const fields = [...messageDesc.field].sort((a, b) => a.number - b.number);
+  const shapes = fields.map((field) => {
+    const desc = ctx.typeMap.get(field.typeName)?.[2];
+    const nested =
+      isMessage(field) && desc && !seen.has(desc as DescriptorProto)
+        ? schemaShape(ctx, desc as DescriptorProto, seen)
+        : field.typeName;
+    return `${field.number} ${field.label} ${field.type} ${nested}`;
+  });
+  return `{${shapes.join(';')}}`;
+}
@@ -0,0 +1,327 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import { DescriptorProto, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { Context } from './context';
+import { OneofOption } from './options';
+import {
+  basicTypeName,
+  brandedTypeName,
+  isMapType,
+  isMessage,
+  isOptionalProperty,
+  isRepeated,
+  isScalar,
+  isWithinOneOfThatShouldBeUnion,
+  messageToTypeName,
+  oneofCaseName,
+  oneofMembers,
+  toTypeName,
+} from './types';
+import { camelCase, capitalize, maybeSnakeToCamel } from './case';
+import { emptyObject } from './utils';
+
+/** Creates a typed `selectFooBar(message)` accessor for each property of the `Foo` interface. */
+export function generateSelectors(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+
+  // When oneof=unions, the whole `oneof` clause is a single property
+  const processedOneofs = new Set<number>();
+
+  messageDesc.field.forEach((field) => {
+    let name: string;
+    let type: Code;
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      if (processedOneofs.has(field.oneofIndex)) {
+        return;
+      }
+      processedOneofs.add(field.oneofIndex);
+      name = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      type = code`${fullName}["${name}"]`;
+    } else {
+      name = maybeSnakeToCamel(field.name, options);
+      type = toTypeName(ctx, messageDesc, field);
+      if (isOptionalProperty(field, messageDesc.options, options)) {
+        type = code`${type} | undefined`;
+      }
+    }
+    chunks.push(code`
+      export function select${fullName}${capitalize(name)}(message: ${fullName}): ${type} {
+        return message.${name};
+      }
+    `);
+  });
+
+  return joinCode(chunks, { on: '\n\n' });
+}
+
+/**
+ * Creates a `FooFieldNames` tuple of the properties of the `Foo` interface that hold its fields, in declaration
+ * order, and the `FooField` union of them, i.e. for iterating over a message's fields with their literal types.
+ */
+export function generateFieldNames(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const names = propertyNames(ctx, messageDesc);
+  return code`
+    export const ${def(`${fullName}FieldNames`)} = [${names.map((name) => `'${name}'`).join(', ')}] as const;
+
+    export type ${def(`${fullName}Field`)} = typeof ${fullName}FieldNames[number];
+  `;
+}
+
+/**
+ * Creates a `fooEntries(message)` function that returns the message's properties as typed `[key, value]` pairs,
+ * in declaration order, and a `fooFromEntries(entries)` function that builds a message back from them, i.e. for
+ * binding generic tables and forms to messages.
+ *
+ * Properties that are missing from the entries keep their default value, and unknown keys are ignored. The values
+ * aren't converted, so sub-messages are passed through as they are.
+ */
+export function generateEntries(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const names = propertyNames(ctx, messageDesc);
+  const entries = names.map((name) => `['${name}', message.${name}]`);
+  const cases = names.map(
+    (name) => code`
+      case '${name}':
+        message.${name} = value as ${fullName}['${name}'];
+        break;
+    `
+  );
+  const name = camelCase(fullName);
+  const entry = code`{ [K in keyof ${fullName}]: [K, ${fullName}[K]] }[keyof ${fullName}]`;
+  return code`
+    export function ${def(`${name}Entries`)}(${names.length > 0 ? 'message' : '_'}: ${fullName}): Array<${entry}> {
+      return [${entries.join(', ')}];
+    }
+
+    export function ${def(`${name}FromEntries`)}(entries: Iterable<[keyof ${fullName}, unknown]>): ${fullName} {
+      const message = createBase${fullName}();
+      for (const [key, value] of entries) {
+        switch (key) {
+          ${joinCode(cases, { on: '\n' })}
+        }
+      }
+      return message;
+    }
+  `;
+}
+
+/** Returns the properties of the message's interface that hold its fields, in declaration order. */
+function propertyNames(ctx: Context, messageDesc: DescriptorProto): string[] {
+  const { options } = ctx;
+  const names: string[] = [];
+  messageDesc.field.forEach((field) => {
+    // When oneof=unions, the whole `oneof` clause is a single property
+    const name = isWithinOneOfThatShouldBeUnion(options, field)
+      ? maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options)
+      : maybeSnakeToCamel(field.name, options);
+    if (!names.includes(name)) {
+      names.push(name);
+    }
+  });
+  return names;
+}
+
+/**
+ * Creates a `clearFooBar(message)` helper for each `oneof bar` of `Foo`, which returns a copy of the message
+ * with the oneof unset, i.e. the union property when oneof=unions, or otherwise all of its member fields.
+ */
+export function generateOneofClears(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code[] {
+  const { options } = ctx;
+  return messageDesc.oneofDecl
+    .map((oneofDecl, oneofIndex) => {
+      // proto3 `optional` fields live in synthetic oneofs, which aren't oneofs as far as users are concerned
+      const members = oneofMembers(messageDesc, oneofIndex);
+      if (members.length === 0) {
+        return undefined;
+      }
+      const oneofName = maybeSnakeToCamel(oneofDecl.name, options);
+      const cleared = isWithinOneOfThatShouldBeUnion(options, members[0])
+        ? [code`${oneofName}: undefined`]
+        : members.map((f) => code`${maybeSnakeToCamel(f.name, options)}: undefined`);
+      return code`
+        export function clear${fullName}${capitalize(oneofName)}(message: ${fullName}): ${fullName} {
+          return { ...message, ${joinCode(cleared, { on: ', ' })} };
+        }
+      `;
+    })
+    .filter((chunk): chunk is Code => chunk !== undefined);
+}
+
+/**
+ * Creates an `assertUnreachableFooBar(x: never): never` helper for each `oneof bar` of `Foo` that is output
+ * as a union, for the `default:` branch of a `switch` on its case, so that TS flags the switch as soon as
+ * the oneof gets a member it doesn't handle.
+ */
+export function generateOneofExhaustiveHelpers(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code[] {
+  const { options, utils } = ctx;
+  const caseName = oneofCaseName(options);
+  return messageDesc.oneofDecl
+    .map((oneofDecl, oneofIndex) => {
+      const members = oneofMembers(messageDesc, oneofIndex);
+      if (members.length === 0 || !isWithinOneOfThatShouldBeUnion(options, members[0])) {
+        return undefined;
+      }
+      const oneofName = maybeSnakeToCamel(oneofDecl.name, options);
+      return code`
+        export function assertUnreachable${fullName}${capitalize(oneofName)}(x: never): never {
+          throw new ${utils.globalThis}.Error('Unhandled ${oneofName} of ${fullName}: ' + (x as any)?.${caseName});
+        }
+      `;
+    })
+    .filter((chunk): chunk is Code => chunk !== undefined);
+}
+
+/**
+ * Creates a `fooBarValue(message)` accessor for each `oneof bar` of `Foo` whose members all have the same scalar
+ * type, which returns the value of whichever member is set, or `undefined` if none is. Oneofs with members of
+ * different (or branded) types are skipped, as there is no single type to return.
+ */
+export function generateOneofValues(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code[] {
+  const { options } = ctx;
+  const caseName = oneofCaseName(options);
+  return messageDesc.oneofDecl
+    .map((oneofDecl, oneofIndex) => {
+      const members = oneofMembers(messageDesc, oneofIndex);
+      const isHomogeneous =
+        members.length > 0 &&
+        members.every((f) => isScalar(f) && f.type === members[0].type && !brandedTypeName(ctx, messageDesc, f));
+      if (!isHomogeneous) {
+        return undefined;
+      }
+      const oneofName = maybeSnakeToCamel(oneofDecl.name, options);
+      const type = basicTypeName(ctx, members[0], { keepValueType: false });
+      let body: Code;
+      if (!isWithinOneOfThatShouldBeUnion(options, members[0])) {
+        const values = members.map((f) => `message.${maybeSnakeToCamel(f.name, options)}`);
+        body = code`return ${values.join(' ?? ')};`;
+      } else if (options.oneof === OneofOption.UNIONS_VALUE) {
+        body = code`return message.${oneofName}?.value;`;
+      } else {
+        const cases = members.map((f) => {
+          const name = maybeSnakeToCamel(f.name, options);
+          return code`case '${name}': return message.${oneofName}.${name};`;
+        });
+        body = code`
+          switch (message.${oneofName}?.${caseName}) {
+            ${joinCode(cases, { on: '\n' })}
+            default: return undefined;
+          }
+        `;
+      }
+      return code`
+        export function ${def(`${camelCase(fullName)}${capitalize(oneofName)}Value`)}(
+          message: ${fullName},
+        ): ${type} | undefined {
+          ${body}
+        }
+      `;
+    })
+    .filter((chunk): chunk is Code => chunk !== undefined);
+}
+
+/**
+ * Creates `addFooBar`, `removeFooBarAt` and `setFooBarAt` helpers for each repeated (non-map) field `bar`
+ * of `Foo`, which return a copy of the message with the updated array.
+ */
+export function generateRepeatedHelpers(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code[] {
+  const { options } = ctx;
+  return messageDesc.field
+    .filter((field) => isRepeated(field) && !isMapType(ctx, messageDesc, field))
+    .map((field) => {
+      const name = maybeSnakeToCamel(field.name, options);
+      const suffix = `${fullName}${capitalize(name)}`;
+      const isOptional = isOptionalProperty(field, messageDesc.options, options);
+      const type = isOptional ? code`NonNullable<${fullName}["${name}"]>[number]` : code`${fullName}["${name}"][number]`;
+      const values = isOptional ? `(message.${name} || [])` : `message.${name}`;
+      return code`
+        export function add${suffix}(message: ${fullName}, value: ${type}): ${fullName} {
+          return { ...message, ${name}: [...${values}, value] };
+        }
+
+        export function remove${suffix}At(message: ${fullName}, index: number): ${fullName} {
+          return { ...message, ${name}: ${values}.filter((_, i) => i !== index) };
+        }
+
+        export function set${suffix}At(message: ${fullName}, index: number, value: ${type}): ${fullName} {
+          return { ...message, ${name}: ${values}.map((v, i) => (i === index ? value : v)) };
+        }
+      `;
+    });
+}
+
+/**
+ * Creates a `hasFooBar(message)` type guard for each field `bar` of `Foo` with explicit presence, i.e. proto3
+ * `optional` fields and singular message fields, which is `true` for any set value, including falsy ones like `0`.
+ */
+export function generatePresenceHelpers(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code[] {
+  const { options } = ctx;
+  return messageDesc.field
+    .filter(
+      (field) =>
+        (field.proto3Optional || (isMessage(field) && !isRepeated(field))) &&
+        !isWithinOneOfThatShouldBeUnion(options, field)
+    )
+    .map((field) => {
+      const name = maybeSnakeToCamel(field.name, options);
+      return code`
+        export function has${fullName}${capitalize(name)}(
+          message: ${fullName},
+        ): message is ${fullName} & { ${name}: NonNullable<${fullName}["${name}"]> } {
+          return message.${name} !== undefined;
+        }
+      `;
+    });
+}
+
+/**
+ * Creates `fooBarToMap(message)` and `fooBarFromMap(map)` helpers for each repeated field `bar` of `Foo` whose
+ * message has just a string `key` and a `value` field, i.e. the `repeated KeyValue` pattern of schemas from
+ * before maps, which convert between the list and an object of the values by their keys.
+ */
+export function generateRepeatedToMapHelpers(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code[] {
+  const { options, typeMap } = ctx;
+  return messageDesc.field
+    .filter((field) => isRepeated(field) && isMessage(field) && !isMapType(ctx, messageDesc, field))
+    .map((field) => {
+      const itemDesc = typeMap.get(field.typeName)?.[2];
+      if (!itemDesc || !('field' in itemDesc) || itemDesc.field.length !== 2) {
+        return undefined;
+      }
+      const keyField = itemDesc.field.find((f) => f.name === options.repeatedToMapKeyField);
+      const valueField = itemDesc.field.find((f) => f.name === options.repeatedToMapValueField);
+      if (
+        !keyField ||
+        !valueField ||
+        keyField === valueField ||
+        keyField.type !== FieldDescriptorProto_Type.TYPE_STRING ||
+        isRepeated(keyField) ||
+        isRepeated(valueField)
+      ) {
+        return undefined;
+      }
+
+      const name = maybeSnakeToCamel(field.name, options);
+      const functionName = `${camelCase(fullName)}${capitalize(name)}`;
+      const itemType = messageToTypeName(ctx, field.typeName);
+      const key = maybeSnakeToCamel(keyField.name, options);
+      const value = maybeSnakeToCamel(valueField.name, options);
+      const mapType = code`{ [key: string]: ${itemType}["${value}"] }`;
+      const isOptional = isOptionalProperty(field, messageDesc.options, options);
+      const items = isOptional ? `(message.${name} ?? [])` : `message.${name}`;
+      const maybeTypeField = options.outputTypeRegistry ? `$type: '${field.typeName.slice(1)}',` : '';
+      return code`
+        export function ${def(`${functionName}ToMap`)}(message: ${fullName}): ${mapType} {
+          const map: ${mapType} = ${emptyObject(options)};
+          // Like for map fields, the last item of a key wins
+          for (const item of ${items}) {
+            map[item.${key}] = item.${value};
+          }
+          return map;
+        }
+
+        export function ${def(`${functionName}FromMap`)}(map: ${mapType}): ${itemType}[] {
+          return Object.entries(map).map(([key, value]) => ({ ${maybeTypeField} ${key}: key, ${value}: value }));
+        }
+      `;
+    })
+    .filter((chunk): chunk is Code => chunk !== undefined);
+}
@@ -3,6 +3,10 @@ import { Code, code, imp, joinCode } from 'ts-poet';
 import {
   BatchMethod,
   detectBatchMethod,
+  detectPaginatedMethod,
+  detectWatchMethod,
+  getMessageMethod,
+  messageToTypeName,
   requestType,
   rawRequestType,
   responsePromiseOrObservable,
@@ -15,11 +19,14 @@ import {
   impFile,
   maybeAddComment,
   maybePrefixPackage,
+  maybePrefixServicePath,
   singular,
 } from './utils';
 import SourceInfo, { Fields } from './sourceInfo';
 import { contextTypeVar } from './main';
+import { camelCase, maybeSnakeToCamel } from './case';
 import { Context } from './context';
+import { grpcWebFetchMetadataType } from './generate-grpc-web-fetch';
 
 const hash = imp('hash*object-hash');
 const dataloader = imp('DataLoader*dataloader');
@@ -59,12 +66,19 @@ export function generateService(
 
     // the grpc-web clients auto-`fromPartial` the input before handing off to grpc-web's
     // serde runtime, so it's okay to accept partial results from the client
-    const partialInput = options.outputClientImpl === 'grpc-web';
+    const partialInput = options.outputClientImpl === 'grpc-web' || options.outputClientImpl === 'grpc-web-fetch';
     const inputType = requestType(ctx, methodDesc, partialInput);
     params.push(code`request: ${inputType}`);
+    // Right after the request, in the same position as in the ClientImpl, so code written against the
+    // interface can pass a retry policy too
+    if (options.clientRetry && options.outputClientImpl === true && isUnaryPromiseMethod(ctx, methodDesc)) {
+      params.push(code`retry?: RetryPolicy`);
+    }
 
     // Use metadata as last argument for interface only configuration
-    if (options.outputClientImpl === 'grpc-web') {
+    if (options.outputClientImpl === 'grpc-web-fetch') {
+      params.push(code`metadata?: ${grpcWebFetchMetadataType}`);
+    } else if (options.outputClientImpl === 'grpc-web') {
       // We have to use grpc.Metadata where grpc will come from @improbable-eng
       if (methodDesc.clientStreaming) {
         params.push(code`options?: {
@@ -72,6 +86,9 @@ export function generateService(
           rpcOptions?: grpc.RpcOptions}`);
       } else {
         params.push(code`metadata?: grpc.Metadata`);
+        if (options.useAbortSignal) {
+          params.push(code`abortSignal?: AbortSignal`);
+        }
       }
     } else if (options.addGrpcMetadata) {
       const Metadata = imp('Metadata@@grpc/grpc-js');
@@ -109,6 +126,132 @@ export function generateService(
   return joinCode(chunks, { on: '\n' });
 }
 
+/**
+ * Generates `paginateFooServiceListFoos(client, request)` helpers for the list methods of `serviceDesc` that
+ * follow the page token convention (see `detectPaginatedMethod`), which keep calling the method with each
+ * response's next page token and yield the items of every page.
+ *
+ * The helpers are top-level functions, so they're prefixed with the service name, as two services of the
+ * same file can have methods of the same name.
+ */
+export function generatePaginationHelpers(ctx: Context, serviceDesc: ServiceDescriptorProto): Code[] {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+  // Helpers are written against the plain `Promise`-returning interface
+  if (options.context || options.returnObservable) {
+    return chunks;
+  }
+
+  const partialInput = options.outputClientImpl === 'grpc-web' || options.outputClientImpl === 'grpc-web-fetch';
+  serviceDesc.method.forEach((methodDesc) => {
+    assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+    const paginatedMethod = detectPaginatedMethod(ctx, methodDesc);
+    if (!paginatedMethod) {
+      return;
+    }
+    const inputType = requestType(ctx, methodDesc, partialInput);
+    const requestToken = maybeSnakeToCamel(paginatedMethod.requestTokenField.name, options);
+    const responseToken = maybeSnakeToCamel(paginatedMethod.responseTokenField.name, options);
+    const items = maybeSnakeToCamel(paginatedMethod.itemsField.name, options);
+    const maybeEmpty = options.useOptionals === 'all' || options.emptyRepeated === 'undefined' ? ' ?? []' : '';
+    chunks.push(code`
+      export async function* paginate${serviceDesc.name}${methodDesc.name}(
+        client: ${serviceDesc.name},
+        request: ${inputType},
+      ): AsyncIterable<${paginatedMethod.itemType}> {
+        let ${requestToken} = request.${requestToken};
+        do {
+          const response = await client.${methodDesc.formattedName}({ ...request, ${requestToken} });
+          yield* response.${items}${maybeEmpty};
+          ${requestToken} = response.${responseToken};
+        } while (${requestToken});
+      }
+    `);
+  });
+  return chunks;
+}
+
+/**
+ * Generates `watchFooServiceGetFoo(client, request, intervalMs)` helpers for the watch methods of `serviceDesc`
+ * that follow the watch token convention (see `detectWatchMethod`), which keep polling the method with each
+ * response's next watch token and yield every response, until the caller stops iterating.
+ */
+export function generateWatchHelpers(ctx: Context, serviceDesc: ServiceDescriptorProto): Code[] {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+  // Helpers are written against the plain `Promise`-returning interface
+  if (options.context || options.returnObservable) {
+    return chunks;
+  }
+
+  const partialInput = options.outputClientImpl === 'grpc-web' || options.outputClientImpl === 'grpc-web-fetch';
+  serviceDesc.method.forEach((methodDesc) => {
+    assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+    const watchMethod = detectWatchMethod(ctx, methodDesc);
+    if (!watchMethod) {
+      return;
+    }
+    const inputType = requestType(ctx, methodDesc, partialInput);
+    const requestToken = maybeSnakeToCamel(watchMethod.requestTokenField.name, options);
+    const responseToken = maybeSnakeToCamel(watchMethod.responseTokenField.name, options);
+    chunks.push(code`
+      export async function* watch${serviceDesc.name}${methodDesc.name}(
+        client: ${serviceDesc.name},
+        request: ${inputType},
+        intervalMs: number = 1_000,
+      ): AsyncIterable<${responseType(ctx, methodDesc)}> {
+        let ${requestToken} = request.${requestToken};
+        while (true) {
+          const response = await client.${methodDesc.formattedName}({ ...request, ${requestToken} });
+          yield response;
+          ${requestToken} = response.${responseToken} || ${requestToken};
+          await new Promise((resolve) => setTimeout(resolve, intervalMs));
+        }
+      }
+    `);
+  });
+  return chunks;
+}
+
+/**
+ * Generates `fooServiceGetFooQueryKey(request)` helpers for the methods of `serviceDesc`, which return a
+ * `['pkg.FooService', 'GetFoo', json]` key for TanStack Query (or similar caches), where `json` is the
+ * request's canonical JSON form, so requests that only differ in how their defaults are set share a key.
+ */
+export function generateQueryKeyHelpers(
+  ctx: Context,
+  fileDesc: FileDescriptorProto,
+  serviceDesc: ServiceDescriptorProto
+): Code[] {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+  // The key is derived from the request's `toJSON`
+  if (!options.outputJsonMethods) {
+    return chunks;
+  }
+
+  const partialInput = options.outputClientImpl === 'grpc-web' || options.outputClientImpl === 'grpc-web-fetch';
+  const serviceName = maybePrefixPackage(fileDesc, serviceDesc.name);
+  serviceDesc.method.forEach((methodDesc) => {
+    assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+    // Streamed requests have no single value to derive a key from
+    if (methodDesc.clientStreaming) {
+      return;
+    }
+    const inputType = requestType(ctx, methodDesc, partialInput);
+    const toJson = getMessageMethod(ctx, methodDesc.inputType, 'toJSON');
+    const json = partialInput
+      ? code`${toJson}(${getMessageMethod(ctx, methodDesc.inputType, 'fromPartial')}(request))`
+      : code`${toJson}(request)`;
+    chunks.push(code`
+      export function ${camelCase(serviceDesc.name)}${methodDesc.name}QueryKey(request: ${inputType}): unknown[] {
+        return ['${serviceName}', '${methodDesc.name}', ${json}];
+      }
+    `);
+  });
+  return chunks;
+}
+
 function generateRegularRpcMethod(
   ctx: Context,
   fileDesc: FileDescriptorProto,
@@ -120,17 +263,17 @@ function generateRegularRpcMethod(
   const Reader = impFile(ctx.options, 'Reader@protobufjs/minimal');
   const rawInputType = rawRequestType(ctx, methodDesc);
   const inputType = requestType(ctx, methodDesc);
-  const outputType = responseType(ctx, methodDesc);
-  const rawOutputType = responseType(ctx, methodDesc, { keepValueType: true });
+  const rawOutputType = messageToTypeName(ctx, methodDesc.outputType, { keepValueType: true });
 
   const params = [...(options.context ? [code`ctx: Context`] : []), code`request: ${inputType}`];
   const maybeCtx = options.context ? 'ctx,' : '';
 
-  let encode = code`${rawInputType}.encode(request).finish()`;
-  let decode = code`data => ${outputType}.decode(new ${Reader}(data))`;
+  const decodeOutput = getMessageMethod(ctx, methodDesc.outputType, 'decode');
+  let encode = code`${getMessageMethod(ctx, methodDesc.inputType, 'encode')}(request).finish()`;
+  let decode = code`data => ${decodeOutput}(new ${Reader}(data))`;
 
   if (options.useDate && rawOutputType.toString().includes('Timestamp')) {
-    decode = code`data => ${utils.fromTimestamp}(${rawOutputType}.decode(new ${Reader}(data)))`;
+    decode = code`data => ${utils.fromTimestamp}(${decodeOutput}(new ${Reader}(data)))`;
   }
   if (methodDesc.clientStreaming) {
     if (options.useAsyncIterable) {
@@ -163,18 +306,77 @@ function generateRegularRpcMethod(
     rpcMethod = 'request';
   }
 
+  const isRetryable = options.clientRetry && isUnaryPromiseMethod(ctx, methodDesc);
+  if (isRetryable) {
+    params.push(code`retry?: RetryPolicy`);
+  }
+  const isReconnectable = options.streamReconnect && methodDesc.serverStreaming && !methodDesc.clientStreaming;
+  if (isReconnectable) {
+    params.push(code`reconnect?: ReconnectPolicy<${inputType}, ${responseType(ctx, methodDesc)}>`);
+  }
+  const serviceName = maybePrefixPackage(fileDesc, serviceDesc.name);
+  const servicePath = `"${maybePrefixServicePath(options, serviceName)}"`;
+  // Interceptors see the unprefixed service name, which only the transport call prefixes
+  const callServicePath = options.servicePathPrefix
+    ? `"${maybePrefixServicePath(options, '')}" + call.service`
+    : 'call.service';
+  const sendRequest = (service: string, method: string) => code`
+    const data = ${encode};
+    const ${returnVariable} = this.rpc.${rpcMethod}(
+      ${maybeCtx}
+      ${service},
+      ${method},
+      data
+    );
+    return ${decode};
+  `;
+
+  if (options.clientInterceptors && isUnaryPromiseMethod(ctx, methodDesc)) {
+    // Run the transport call as the innermost step of the interceptor chain, retrying just the transport call
+    const transport = isRetryable
+      ? code`(request) => withRetry(retry, () => { ${sendRequest(callServicePath, 'call.method')} })`
+      : code`(request) => { ${sendRequest(callServicePath, 'call.method')} }`;
+    return code`
+      ${methodDesc.formattedName}(
+        ${joinCode(params, { on: ',' })}
+      ): ${responsePromiseOrObservable(ctx, methodDesc)} {
+        const call = { service: "${serviceName}", method: "${methodDesc.name}", request };
+        const methodInterceptors = this.methodInterceptors.${methodDesc.formattedName} ?? [];
+        return runInterceptors(this.interceptors, methodInterceptors, call, ${transport});
+      }
+    `;
+  }
+
+  if (isRetryable) {
+    return code`
+      ${methodDesc.formattedName}(
+        ${joinCode(params, { on: ',' })}
+      ): ${responsePromiseOrObservable(ctx, methodDesc)} {
+        return withRetry(retry, () => {
+          ${sendRequest(servicePath, `"${methodDesc.name}"`)}
+        });
+      }
+    `;
+  }
+
+  // Each reconnect sends the request that the policy resumes with, so the transport call gets it as a parameter
+  if (isReconnectable) {
+    return code`
+      ${methodDesc.formattedName}(
+        ${joinCode(params, { on: ',' })}
+      ): ${responsePromiseOrObservable(ctx, methodDesc)} {
+        return withReconnect(reconnect, request, (request) => {
+          ${sendRequest(servicePath, `"${methodDesc.name}"`)}
+        });
+      }
+    `;
+  }
+
   return code`
     ${methodDesc.formattedName}(
       ${joinCode(params, { on: ',' })}
     ): ${responsePromiseOrObservable(ctx, methodDesc)} {
-      const data = ${encode};
-      const ${returnVariable} = this.rpc.${rpcMethod}(
-        ${maybeCtx}
-        "${maybePrefixPackage(fileDesc, serviceDesc.name)}",
-        "${methodDesc.name}",
-        data
-      );
-      return ${decode};
+      ${sendRequest(servicePath, `"${methodDesc.name}"`)}
     }
   `;
 }
@@ -196,7 +398,16 @@ export function generateServiceClientImpl(
   // Create the constructor(rpc: Rpc)
   const rpcType = options.context ? 'Rpc<Context>' : 'Rpc';
   chunks.push(code`private readonly rpc: ${rpcType};`);
-  chunks.push(code`constructor(rpc: ${rpcType}) {`);
+  if (options.clientInterceptors) {
+    chunks.push(code`private readonly interceptors: UnaryInterceptor[];`);
+    chunks.push(code`private readonly methodInterceptors: ${name}Interceptors;`);
+    const interceptorParams = code`interceptors: UnaryInterceptor[] = [], methodInterceptors: ${name}Interceptors = {}`;
+    chunks.push(code`constructor(rpc: ${rpcType}, ${interceptorParams}) {`);
+    chunks.push(code`this.interceptors = interceptors;`);
+    chunks.push(code`this.methodInterceptors = methodInterceptors;`);
+  } else {
+    chunks.push(code`constructor(rpc: ${rpcType}) {`);
+  }
   chunks.push(code`this.rpc = rpc;`);
   // Bind each FooService method to the FooServiceImpl class
   for (const methodDesc of serviceDesc.method) {
@@ -288,15 +499,14 @@ function generateCachingRpcMethod(
   const inputType = requestType(ctx, methodDesc);
   const outputType = responseType(ctx, methodDesc);
   const uniqueIdentifier = `${maybePrefixPackage(fileDesc, serviceDesc.name)}.${methodDesc.name}`;
+  const servicePath = maybePrefixServicePath(ctx.options, maybePrefixPackage(fileDesc, serviceDesc.name));
   const Reader = impFile(ctx.options, 'Reader@protobufjs/minimal');
   const lambda = code`
     (requests) => {
       const responses = requests.map(async request => {
-        const data = ${inputType}.encode(request).finish()
-        const response = await this.rpc.request(ctx, "${maybePrefixPackage(fileDesc, serviceDesc.name)}", "${
-    methodDesc.name
-  }", data);
-        return ${outputType}.decode(new ${Reader}(response));
+        const data = ${getMessageMethod(ctx, methodDesc.inputType, 'encode')}(request).finish()
+        const response = await this.rpc.request(ctx, "${servicePath}", "${methodDesc.name}", data);
+        return ${getMessageMethod(ctx, methodDesc.outputType, 'decode')}(new ${Reader}(response));
       });
       return Promise.all(responses);
     }
@@ -360,6 +570,208 @@ export function generateRpcType(ctx: Context, hasStreamingMethods: boolean): Cod
   return joinCode(chunks, { on: '\n' });
 }
 
+/** Whether `methodDesc` is a plain request/`Promise` response call, i.e. one that interceptors can wrap. */
+function isUnaryPromiseMethod(ctx: Context, methodDesc: MethodDescriptorProto): boolean {
+  return !methodDesc.clientStreaming && !methodDesc.serverStreaming && !ctx.options.returnObservable;
+}
+
+/**
+ * Creates the `FooInterceptors` type of the per-method interceptors that the `FooClientImpl` of
+ * `clientInterceptors=true` accepts next to its `UnaryInterceptor[]`, which maps the name of each unary
+ * method to the interceptors of just that method, typed with its request and response.
+ */
+export function generateServiceInterceptors(ctx: Context, serviceDesc: ServiceDescriptorProto): Code {
+  const members = serviceDesc.method
+    .filter((methodDesc) => isUnaryPromiseMethod(ctx, methodDesc))
+    .map((methodDesc) => {
+      assertInstanceOf(methodDesc, FormattedMethodDescriptor);
+      const types = code`${requestType(ctx, methodDesc)}, ${responseType(ctx, methodDesc)}`;
+      return code`${methodDesc.formattedName}?: MethodInterceptor<${types}>[];`;
+    });
+  return code`
+    export interface ${serviceDesc.name}Interceptors {
+      ${joinCode(members, { on: '\n' })}
+    }
+  `;
+}
+
+/**
+ * Creates the `UnaryInterceptor` type that `clientInterceptors=true` clients accept, the `MethodInterceptor`
+ * type of their per-method interceptors, and the `runInterceptors` function that chains them around each
+ * unary call.
+ *
+ * Interceptors run in the order they're given, i.e. the first interceptor is the outermost, and the
+ * per-method interceptors run inside the ones of every method.
+ */
+export function generateInterceptorTypes(): Code {
+  return code`
+    export interface UnaryCall<Req> {
+      service: string;
+      method: string;
+      request: Req;
+    }
+
+    export type UnaryInterceptor = <Req, Res>(
+      call: UnaryCall<Req>,
+      next: (request: Req) => Promise<Res>
+    ) => Promise<Res>;
+
+    export type MethodInterceptor<Req, Res> = (
+      call: UnaryCall<Req>,
+      next: (request: Req) => Promise<Res>
+    ) => Promise<Res>;
+
+    function runInterceptors<Req, Res>(
+      interceptors: UnaryInterceptor[],
+      methodInterceptors: MethodInterceptor<Req, Res>[],
+      call: UnaryCall<Req>,
+      transport: (request: Req) => Promise<Res>
+    ): Promise<Res> {
+      const all: MethodInterceptor<Req, Res>[] = [...interceptors, ...methodInterceptors];
+      const chain = all.reduceRight<(request: Req) => Promise<Res>>(
+        (next, interceptor) => (request) => interceptor({ ...call, request }, next),
+        transport
+      );
+      return chain(call.request);
+    }
+  `;
+}
+
+/**
+ * Creates the `RetryPolicy` type that the unary methods of `clientRetry=true` clients accept, and the
+ * `withRetry` function that retries failed calls with exponential backoff.
+ *
+ * Errors are matched against `retryableCodes` by their `code` property, as set by most gRPC transports.
+ */
+export function generateRetryTypes(): Code {
+  return code`
+    export interface RetryPolicy {
+      /** The maximum number of attempts, including the first one. */
+      maxAttempts: number;
+      /** The status codes of the errors to retry. */
+      retryableCodes: Array<number | string>;
+      /** The delay before the first retry, which doubles with each further retry. */
+      backoffMs: number;
+    }
+
+    async function withRetry<T>(retry: RetryPolicy | undefined, attempt: () => Promise<T>): Promise<T> {
+      for (let i = 1; ; i++) {
+        try {
+          return await attempt();
+        } catch (e) {
+          if (!retry || i >= retry.maxAttempts || retry.retryableCodes.indexOf((e as any)?.code) === -1) {
+            throw e;
+          }
+          await new Promise((resolve) => setTimeout(resolve, retry.backoffMs * Math.pow(2, i - 1)));
+        }
+      }
+    }
+  `;
+}
+
+/**
+ * Creates the `ReconnectPolicy` type that the server-streaming methods of `streamReconnect=true` clients accept,
+ * and the `withReconnect` function that reopens streams that failed with exponential backoff.
+ *
+ * Like for `withRetry`, errors are matched against `retryableCodes` by their `code` property. Only the app knows
+ * how to pick a stream back up, so the request that a stream is reopened with comes from the policy's `resume`.
+ */
+export function generateReconnectTypes(ctx: Context): Code {
+  const policy = code`
+    export interface ReconnectPolicy<Req, Res> {
+      /** The maximum number of reconnects in a row, i.e. without receiving a message in between. */
+      maxReconnects: number;
+      /** The status codes of the errors to reconnect on. */
+      retryableCodes: Array<number | string>;
+      /** The delay before the first reconnect, which doubles with each further reconnect in a row. */
+      backoffMs: number;
+      /** Returns the request to reopen the stream with, i.e. with a resume token of the last message received. */
+      resume: (request: Req, last: Res | undefined) => Req;
+    }
+  `;
+
+  if (ctx.options.useAsyncIterable) {
+    return code`
+      ${policy}
+
+      async function* withReconnect<Req, Res>(
+        reconnect: ReconnectPolicy<Req, Res> | undefined,
+        request: Req,
+        open: (request: Req) => AsyncIterable<Res>,
+      ): AsyncIterable<Res> {
+        let last: Res | undefined;
+        for (let failures = 0; ; ) {
+          try {
+            for await (const message of open(request)) {
+              last = message;
+              failures = 0;
+              yield message;
+            }
+            return;
+          } catch (e) {
+            failures++;
+            if (
+              !reconnect ||
+              failures > reconnect.maxReconnects ||
+              reconnect.retryableCodes.indexOf((e as any)?.code) === -1
+            ) {
+              throw e;
+            }
+            await new Promise((resolve) => setTimeout(resolve, reconnect.backoffMs * Math.pow(2, failures - 1)));
+            request = reconnect.resume(request, last);
+          }
+        }
+      }
+    `;
+  }
+
+  const Observable = imp('Observable@rxjs');
+  const Subscription = imp('Subscription@rxjs');
+  return code`
+    ${policy}
+
+    function withReconnect<Req, Res>(
+      reconnect: ReconnectPolicy<Req, Res> | undefined,
+      request: Req,
+      open: (request: Req) => ${Observable}<Res>,
+    ): ${Observable}<Res> {
+      if (!reconnect) {
+        return open(request);
+      }
+      return new ${Observable}<Res>((subscriber) => {
+        let last: Res | undefined;
+        let failures = 0;
+        let subscription: ${Subscription} | undefined;
+        let timer: ReturnType<typeof setTimeout> | undefined;
+        const connect = (request: Req) => {
+          subscription = open(request).subscribe({
+            next: (message) => {
+              last = message;
+              failures = 0;
+              subscriber.next(message);
+            },
+            error: (e) => {
+              failures++;
+              if (failures > reconnect.maxReconnects || reconnect.retryableCodes.indexOf(e?.code) === -1) {
+                subscriber.error(e);
+                return;
+              }
+              const delay = reconnect.backoffMs * Math.pow(2, failures - 1);
+              timer = setTimeout(() => connect(reconnect.resume(request, last)), delay);
+            },
+            complete: () => subscriber.complete(),
+          });
+        };
+        connect(request);
+        return () => {
+          clearTimeout(timer);
+          subscription?.unsubscribe();
+        };
+      });
+    }
+  `;
+}
+
 export function generateDataLoadersType(): Code {
   // TODO Maybe should be a generic `Context.get<T>(id, () => T): T` method
   return code`
@@ -0,0 +1,94 @@
+import { code, Code, joinCode } from 'ts-poet';
+import {
+  DescriptorProto,
+  EnumDescriptorProto,
+  FieldDescriptorProto,
+  FieldDescriptorProto_Type,
+} from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  brandedValue,
+  isBufferBytes,
+  isEnum,
+  isLong,
+  isRepeated,
+  isScalar,
+  isWithinOneOf,
+  longOption,
+  messageToTypeName,
+} from './types';
+import { maybeSnakeToCamel } from './case';
+import { BytesOption, LongOption } from './options';
+import { messageMethod } from './utils';
+
+/**
+ * Creates a `makeFoo(overrides)` test factory that fills the message's singular scalar and enum fields with
+ * non-default placeholder values, and then applies `overrides` on top via `Foo.fromPartial`.
+ *
+ * Sub-messages, repeated fields, maps, oneofs and `optional` fields are left unset, so that recursive
+ * messages don't recurse forever and tests opt in to them explicitly.
+ */
+export function generateTestFactory(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const placeholders: Code[] = [];
+
+  messageDesc.field.forEach((field) => {
+    if (isRepeated(field) || isWithinOneOf(field)) {
+      return;
+    }
+    const placeholder = placeholderValue(ctx, field);
+    if (placeholder) {
+      const value = brandedValue(ctx, messageDesc, field, placeholder);
+      placeholders.push(code`${maybeSnakeToCamel(field.name, options)}: ${value},`);
+    }
+  });
+
+  return code`
+    export function make${fullName}(overrides: ${utils.DeepPartial}<${fullName}> = {}): ${fullName} {
+      return ${messageMethod(options, fullName, 'fromPartial')}({
+        ${joinCode(placeholders, { on: '\n' })}
+        ...overrides,
+      });
+    }
+  `;
+}
+
+/**
+ * Creates a `__roundTripTestFoo(message)` self-test that encodes the message, decodes the bytes, and returns
+ * whether the decoded copy deep-equals the original, i.e. for consumers to check in CI that their schemas
+ * round-trip through the generated code.
+ */
+export function generateRoundTripTest(ctx: Context, fullName: string): Code {
+  const { options, utils } = ctx;
+  const encode = messageMethod(options, fullName, 'encode');
+  const decode = messageMethod(options, fullName, 'decode');
+  return code`
+    export function __roundTripTest${fullName}(message: ${fullName}): boolean {
+      return ${utils.roundTripEquals}(message, ${decode}(${encode}(message).finish()));
+    }
+  `;
+}
+
+function placeholderValue(ctx: Context, field: FieldDescriptorProto): Code | undefined {
+  const { options, typeMap } = ctx;
+  if (isEnum(field)) {
+    // Prefer the first non-zero value, as zero is usually the `UNSPECIFIED` one
+    const enumDesc = typeMap.get(field.typeName)![2] as EnumDescriptorProto;
+    const value = enumDesc.value.find((v) => v.number !== 0) || enumDesc.value[0];
+    return code`${messageToTypeName(ctx, field.typeName)}.${value.name}`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_STRING) {
+    return code`'string'`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BYTES) {
+    if (options.bytesAs === BytesOption.BASE64_STRING) {
+      return code`'AQ=='`;
+    }
+    return isBufferBytes(options) ? code`Buffer.from([1])` : code`new Uint8Array([1])`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BOOL) {
+    return code`true`;
+  } else if (isLong(field) && longOption(field, options) === LongOption.STRING) {
+    return code`'1'`;
+  } else if (isScalar(field)) {
+    return code`1`;
+  }
+  return undefined;
+}
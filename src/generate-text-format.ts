@@ -0,0 +1,271 @@
+import { code, Code, joinCode } from 'ts-poet';
+import { DescriptorProto, FieldDescriptorProto, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  basicTypeName,
+  brandedValue,
+  getEnumMethod,
+  isAnyValueType,
+  isEnum,
+  isFieldMaskType,
+  isListValueType,
+  isLong,
+  isMapType,
+  isMessage,
+  isNativeDuration,
+  isObjectId,
+  isRepeated,
+  isScalar,
+  isStructType,
+  isTimestamp,
+  isValueType,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  longOption,
+  notDefaultCheck,
+  oneofCaseName,
+  oneofValueName,
+} from './types';
+import { maybeSnakeToCamel } from './case';
+import { BytesOption, DateOption, LongOption } from './options';
+import { emptyMapValue, mapSetSnippet } from './utils';
+
+/**
+ * Creates a `toTextFormat(message)` function that renders the message in the protobuf text format,
+ * i.e. one `name: value` line per set field, in field number order, with sub-messages in nested braces.
+ *
+ * Presence follows `encode`, so fields that would not be written to the wire are left out here too.
+ */
+export function generateToTextFormat(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, typeMap } = ctx;
+  const chunks: Code[] = [];
+
+  if (messageDesc.field.length === 0) {
+    return code`
+      toTextFormat(_: ${fullName}, _indent: string = ''): string {
+        return '';
+      }
+    `;
+  }
+
+  chunks.push(code`
+    toTextFormat(message: ${fullName}, indent: string = ''): string {
+      const lines: string[] = [];
+  `);
+
+  const fields = [...messageDesc.field].sort((a, b) => a.number - b.number);
+  fields.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    const lineSnippet = textFormatLineSnippet(ctx, field);
+
+    if (isRepeated(field) && isMapType(ctx, messageDesc, field)) {
+      // Map fields are written as repeated entry messages, just like on the wire
+      const valueType = (typeMap.get(field.typeName)![2] as DescriptorProto).field[1];
+      const maybeTypeField = options.outputTypeRegistry ? `$type: '${field.typeName.slice(1)}',` : '';
+      const entryLine = code`lines.push(${lineSnippet(`{ ${maybeTypeField} key: key as any, value }`)});`;
+      const entries = options.useMapType
+        ? `[...(message.${fieldName} || new Map()).entries()]`
+        : `Object.entries(message.${fieldName} || {})`;
+      chunks.push(code`
+        ${entries}.forEach(([key, value]) => {
+          ${isValueType(ctx, valueType) ? code`if (value !== undefined) { ${entryLine} }` : entryLine}
+        });
+      `);
+    } else if (isRepeated(field)) {
+      chunks.push(code`
+        for (const v of message.${fieldName} || []) {
+          lines.push(${lineSnippet('v!')});
+        }
+      `);
+    } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      chunks.push(code`
+        if (message.${oneofName}?.${oneofCaseName(options)} === '${fieldName}') {
+          lines.push(${lineSnippet(`message.${oneofName}.${oneofValueName(fieldName, options)}`)});
+        }
+      `);
+    } else if (isWithinOneOf(field) || isMessage(field)) {
+      chunks.push(code`
+        if (message.${fieldName} !== undefined) {
+          lines.push(${lineSnippet(`message.${fieldName}`)});
+        }
+      `);
+    } else {
+      chunks.push(code`
+        if (${notDefaultCheck(ctx, field, messageDesc.options, `message.${fieldName}`)}) {
+          lines.push(${lineSnippet(`message.${fieldName}`)});
+        }
+      `);
+    }
+  });
+
+  chunks.push(code`return lines.join('\\n');`);
+  chunks.push(code`}`);
+  return joinCode(chunks, { on: '\n' });
+}
+
+/** Returns a function that renders the `name: value`, or `name { ... }`, line for a single value of `field`. */
+function textFormatLineSnippet(ctx: Context, field: FieldDescriptorProto): (place: string) => Code {
+  const { options, utils } = ctx;
+  const name = field.name;
+
+  if (isEnum(field)) {
+    const toJson = getEnumMethod(ctx, field.typeName, 'ToJSON');
+    return (place) => code`indent + '${name}: ' + ${toJson}(${place})`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_STRING) {
+    return (place) => code`indent + '${name}: ' + ${utils.textFormatString}(${place})`;
+  } else if (
+    field.type === FieldDescriptorProto_Type.TYPE_DOUBLE ||
+    field.type === FieldDescriptorProto_Type.TYPE_FLOAT
+  ) {
+    // The text format spells the non-finite values as `nan`, `inf` and `-inf`
+    return (place) => code`indent + '${name}: ' + ${utils.textFormatFloat}(${place})`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BYTES && options.bytesAs === BytesOption.BASE64_STRING) {
+    return (place) => code`indent + '${name}: ' + ${utils.textFormatBytes}(${utils.bytesFromBase64}(${place}))`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BYTES) {
+    return (place) => code`indent + '${name}: ' + ${utils.textFormatBytes}(${place})`;
+  } else if (isScalar(field)) {
+    return (place) => code`indent + '${name}: ' + ${place}`;
+  }
+
+  // Everything else is a message, so convert any native representation back to the message first
+  const type = basicTypeName(ctx, field, { keepValueType: true });
+  let toMessage: (place: string) => Code;
+  if (isObjectId(field) && options.useMongoObjectId) {
+    toMessage = (place) => code`${utils.toProtoObjectId}(${place})`;
+  } else if (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) {
+    toMessage = (place) => code`${utils.toTimestamp}(${place})`;
+  } else if (isNativeDuration(field, options)) {
+    toMessage = (place) => code`${utils.toDuration}(${place})`;
+  } else if (isAnyValueType(field) || isListValueType(field) || isStructType(field) || isFieldMaskType(field)) {
+    toMessage = (place) => code`${type}.wrap(${place})`;
+  } else if (isValueType(ctx, field)) {
+    const maybeTypeField = options.outputTypeRegistry ? `$type: '${field.typeName.slice(1)}',` : '';
+    toMessage = (place) => code`{${maybeTypeField} value: ${place}!}`;
+  } else {
+    toMessage = (place) => code`${place}`;
+  }
+  return (place) =>
+    code`${utils.textFormatMessage}(indent, '${name}', ${type}.toTextFormat(${toMessage(place)}, indent + '  '))`;
+}
+
+/**
+ * Creates a `fromTextFormat(text)` function that parses the protobuf text format back into a message.
+ *
+ * Unknown field names are ignored, and for non-repeated fields that appear more than once the last value wins.
+ */
+export function generateFromTextFormat(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const chunks: Code[] = [];
+
+  chunks.push(code`
+    fromTextFormat(${messageDesc.field.length > 0 ? 'input' : '_'}: string | ${utils.TextFormatNode}): ${fullName} {
+      const message = createBase${fullName}();
+  `);
+  if (messageDesc.field.length > 0) {
+    chunks.push(code`const node = typeof input === 'string' ? ${utils.parseTextFormat}(input) : input;`);
+  }
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    const values = `node['${field.name}']`;
+    const read = textFormatReadSnippet(ctx, field);
+    const readSnippet = (place: string) => brandedValue(ctx, messageDesc, field, read(place));
+
+    if (isRepeated(field) && isMapType(ctx, messageDesc, field)) {
+      const entryType = basicTypeName(ctx, field, { keepValueType: true });
+      const maybeNonNullAssertion = options.emptyRepeated === 'undefined' ? '!' : '';
+      chunks.push(code`
+        if (${values} !== undefined) {
+          ${options.emptyRepeated === 'undefined' ? code`message.${fieldName} = ${emptyMapValue(options)};` : ''}
+          for (const v of ${values}) {
+            const entry = ${entryType}.fromTextFormat(v as ${utils.TextFormatNode});
+            if (entry.value !== undefined) {
+              ${mapSetSnippet(options, `message.${fieldName}${maybeNonNullAssertion}`, 'entry.key', 'entry.value')};
+            }
+          }
+        }
+      `);
+    } else if (isRepeated(field)) {
+      chunks.push(code`
+        if (${values} !== undefined) {
+          message.${fieldName} = ${values}.map((v) => ${readSnippet('v')});
+        }
+      `);
+    } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      const caseName = oneofCaseName(options);
+      const valueName = oneofValueName(fieldName, options);
+      chunks.push(code`
+        for (const v of ${values} || []) {
+          message.${oneofName} = { ${caseName}: '${fieldName}', ${valueName}: ${readSnippet('v')} };
+        }
+      `);
+    } else {
+      chunks.push(code`
+        for (const v of ${values} || []) {
+          message.${fieldName} = ${readSnippet('v')};
+        }
+      `);
+    }
+  });
+
+  chunks.push(code`return message;`);
+  chunks.push(code`}`);
+  return joinCode(chunks, { on: '\n' });
+}
+
+/** Returns a function that converts a single parsed text format value of `field` into its TS representation. */
+function textFormatReadSnippet(ctx: Context, field: FieldDescriptorProto): (place: string) => Code {
+  const { options, utils } = ctx;
+
+  if (isEnum(field)) {
+    const fromJson = getEnumMethod(ctx, field.typeName, 'FromJSON');
+    // Enums can be given by name or by number
+    return (place) => code`${fromJson}(/^-?\\d+$/.test(${place} as string) ? Number(${place}) : ${place})`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_STRING) {
+    return (place) => code`${utils.stringFromTextFormat}(${place} as string)`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BYTES && options.bytesAs === BytesOption.BASE64_STRING) {
+    return (place) => code`${utils.base64FromBytes}(${utils.bytesFromTextFormat}(${place} as string))`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BYTES && options.bytesAs === BytesOption.BUFFER) {
+    return (place) => code`Buffer.from(${utils.bytesFromTextFormat}(${place} as string))`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BYTES) {
+    return (place) => code`${utils.bytesFromTextFormat}(${place} as string)`;
+  } else if (field.type === FieldDescriptorProto_Type.TYPE_BOOL) {
+    return (place) => code`${utils.textFormatBool}(${place} as string)`;
+  } else if (
+    field.type === FieldDescriptorProto_Type.TYPE_DOUBLE ||
+    field.type === FieldDescriptorProto_Type.TYPE_FLOAT
+  ) {
+    return (place) => code`${utils.textFormatNumber}(${place} as string)`;
+  } else if (isLong(field)) {
+    const unsigned =
+      field.type === FieldDescriptorProto_Type.TYPE_UINT64 || field.type === FieldDescriptorProto_Type.TYPE_FIXED64;
+    const long = (place: string) => code`${utils.Long}.fromString(${place} as string, ${unsigned ? 'true' : 'false'})`;
+    if (longOption(field, options) === LongOption.LONG) {
+      return long;
+    } else if (longOption(field, options) === LongOption.STRING) {
+      return (place) => code`${utils.longToString}(${long(place)})`;
+    } else {
+      return (place) => code`${utils.longToNumber}(${long(place)})`;
+    }
+  } else if (isScalar(field)) {
+    return (place) => code`Number(${place})`;
+  }
+
+  // Everything else is a message, so convert it to any native representation after parsing
+  const type = basicTypeName(ctx, field, { keepValueType: true });
+  const parsed = (place: string) => code`${type}.fromTextFormat(${place} as ${utils.TextFormatNode})`;
+  if (isObjectId(field) && options.useMongoObjectId) {
+    return (place) => code`${utils.fromProtoObjectId}(${parsed(place)})`;
+  } else if (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) {
+    return (place) => code`${utils.fromTimestamp}(${parsed(place)})`;
+  } else if (isNativeDuration(field, options)) {
+    return (place) => code`${utils.fromDuration}(${parsed(place)})`;
+  } else if (isAnyValueType(field) || isListValueType(field) || isStructType(field) || isFieldMaskType(field)) {
+    return (place) => code`${type}.unwrap(${parsed(place)})`;
+  } else if (isValueType(ctx, field)) {
+    return (place) => code`${parsed(place)}.value`;
+  }
+  return parsed;
+}
@@ -0,0 +1,83 @@
+import { code, Code } from 'ts-poet';
+import { DescriptorProto, FileDescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import { isTimestamp } from './types';
+import { DateOption, LongOption } from './options';
+import { impProtoType } from './utils';
+
+/** Returns whether any message of the file, including nested ones, has a `google.protobuf.Timestamp` field. */
+export function usesTimestamp(fileDesc: FileDescriptorProto): boolean {
+  const hasTimestamp = (message: DescriptorProto): boolean =>
+    message.field.some(isTimestamp) || message.nestedType.some(hasTimestamp);
+  return fileDesc.messageType.some(hasTimestamp);
+}
+
+/**
+ * Creates `compareTimestamp(a, b)` and `timestampEquals(a, b)` helpers for the representation of timestamps that
+ * useDate chose, i.e. for sorting messages by a timestamp field.
+ *
+ * Timestamps are compared by their seconds and then their nanos, so that, unlike with a `Date`, nanos that are
+ * within the same millisecond still compare as different. A `Date` only has milliseconds to begin with, and for
+ * strings the fraction of the seconds is parsed separately from the rest.
+ */
+export function generateTimestampHelpers(ctx: Context): Code {
+  const { options } = ctx;
+
+  if (options.useDate === DateOption.DATE) {
+    return code`
+      export function compareTimestamp(a: Date, b: Date): -1 | 0 | 1 {
+        const diff = a.getTime() - b.getTime();
+        return diff < 0 ? -1 : diff > 0 ? 1 : 0;
+      }
+
+      export function timestampEquals(a: Date, b: Date): boolean {
+        return a.getTime() === b.getTime();
+      }
+    `;
+  }
+
+  let type: Code | string;
+  let parts: Code;
+  if (options.useDate === DateOption.STRING) {
+    type = 'string';
+    parts = code`
+      // A Date only keeps the milliseconds, so the fraction of the seconds is parsed by itself
+      const match = /^(.*?)(?:\\.(\\d+))?(Z|[+-]\\d\\d:\\d\\d)$/i.exec(t);
+      if (match === null) {
+        const millis = Date.parse(t);
+        const seconds = Math.floor(millis / 1_000);
+        return [seconds, (millis - seconds * 1_000) * 1_000_000];
+      }
+      return [Date.parse(match[1] + match[3]) / 1_000, Number((match[2] ?? "").padEnd(9, "0").slice(0, 9))];
+    `;
+  } else {
+    type = impProtoType(ctx, 'google/protobuf/timestamp', 'google.protobuf', 'Timestamp');
+    // Timestamps are within ±2^38 seconds, so even Long and string seconds are exact as numbers
+    const seconds =
+      options.forceLong === LongOption.LONG
+        ? 't.seconds.toNumber()'
+        : options.forceLong === LongOption.STRING
+        ? 'Number(t.seconds)'
+        : 't.seconds';
+    parts = code`return [${seconds}, t.nanos];`;
+  }
+
+  return code`
+    function timestampParts(t: ${type}): [number, number] {
+      ${parts}
+    }
+
+    export function compareTimestamp(a: ${type}, b: ${type}): -1 | 0 | 1 {
+      const [aSeconds, aNanos] = timestampParts(a);
+      const [bSeconds, bNanos] = timestampParts(b);
+      if (aSeconds !== bSeconds) {
+        return aSeconds < bSeconds ? -1 : 1;
+      }
+      return aNanos < bNanos ? -1 : aNanos > bNanos ? 1 : 0;
+    }
+
+    export function timestampEquals(a: ${type}, b: ${type}): boolean {
+      return compareTimestamp(a, b) === 0;
+    }
+  `;
+}
@@ -0,0 +1,120 @@
+import { code, Code, def, joinCode } from 'ts-poet';
+import { DescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  getEnumMethod,
+  getMessageFunction,
+  isEnum,
+  isMapType,
+  isMessage,
+  isRepeated,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  notDefaultCheck,
+  oneofCaseName,
+  oneofValueName,
+} from './types';
+import { camelCase, maybeSnakeToCamel } from './case';
+
+/** Strings longer than this are cut off by `fooDebugString`, to keep log lines short. */
+const debugStringMaxLength = 64;
+
+/**
+ * Creates a `fooToString(message)` function that summarizes the message on a single line for logging,
+ * i.e. `Foo{id=1, name="x"}`, with unset fields left out, bytes shown by length, and long arrays truncated.
+ */
+export function generateToString(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const parts: Code[] = [];
+  const processedOneofs = new Set<number>();
+
+  messageDesc.field.forEach((field) => {
+    let name = maybeSnakeToCamel(field.name, options);
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      if (processedOneofs.has(field.oneofIndex)) {
+        return;
+      }
+      processedOneofs.add(field.oneofIndex);
+      name = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+    }
+
+    // Show enums by name rather than by number, which needs the enum's `toJSON` function
+    const showEnumName =
+      options.outputJsonMethods && isEnum(field) && !isRepeated(field) && !isWithinOneOfThatShouldBeUnion(options, field);
+    const value = showEnumName
+      ? code`${getEnumMethod(ctx, field.typeName, 'ToJSON')}(message.${name})`
+      : code`${utils.debugString}(message.${name})`;
+    parts.push(code`
+      if (message.${name} !== undefined) {
+        parts.push('${name}=' + ${value});
+      }
+    `);
+  });
+
+  return code`
+    export function ${camelCase(fullName)}ToString(${parts.length > 0 ? 'message' : '_'}: ${fullName}): string {
+      const parts: string[] = [];
+      ${joinCode(parts, { on: '\n' })}
+      return '${fullName}{' + parts.join(', ') + '}';
+    }
+  `;
+}
+
+/**
+ * Creates a `fooDebugString(message)` function that prints just the fields that `encode` would write, i.e. those
+ * that aren't at their default value, as `key=value` pairs on a single line like `id=1 name="x" author={name="y"}`.
+ *
+ * Bytes are shown by length, and strings and repeated fields are truncated, which makes this lighter than
+ * `toTextFormat`, and geared toward log lines. Sub-messages are shown by their own `DebugString` function.
+ */
+export function generateDebugString(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+  const { options, utils } = ctx;
+  const parts: Code[] = [];
+
+  messageDesc.field.forEach((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    const isUnionMember = isWithinOneOfThatShouldBeUnion(options, field);
+
+    // Show singular sub-messages with their own defaults left out, and enums by name like toString does
+    const value = (place: string): Code => {
+      if (isMessage(field) && !isRepeated(field) && !field.typeName.startsWith('.google.protobuf.')) {
+        return code`'{' + ${getMessageFunction(ctx, field.typeName, 'DebugString')}(${place}) + '}'`;
+      } else if (options.outputJsonMethods && isEnum(field) && !isRepeated(field)) {
+        return code`${getEnumMethod(ctx, field.typeName, 'ToJSON')}(${place})`;
+      }
+      return code`${utils.debugString}(${place}, ${debugStringMaxLength})`;
+    };
+
+    let isSet: Code;
+    let place = `message.${fieldName}`;
+    if (isUnionMember) {
+      const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+      isSet = code`message.${oneofName}?.${oneofCaseName(options)} === '${fieldName}'`;
+      place = `message.${oneofName}.${oneofValueName(fieldName, options)}`;
+    } else if (isMapType(ctx, messageDesc, field)) {
+      const size = options.useMapType ? `${place}.size` : `Object.keys(${place}).length`;
+      isSet = code`${place} !== undefined && ${size} !== 0`;
+    } else if (isRepeated(field)) {
+      isSet = code`${place} !== undefined && ${place}.length !== 0`;
+    } else if (isWithinOneOf(field) || isMessage(field)) {
+      isSet = code`${place} !== undefined`;
+    } else {
+      isSet = notDefaultCheck(ctx, field, messageDesc.options, place);
+    }
+    parts.push(code`
+      if (${isSet}) {
+        parts.push('${fieldName}=' + ${value(place)});
+      }
+    `);
+  });
+
+  return code`
+    export function ${def(`${camelCase(fullName)}DebugString`)}(
+      ${parts.length > 0 ? 'message' : '_'}: ${fullName},
+    ): string {
+      const parts: string[] = [];
+      ${joinCode(parts, { on: '\n' })}
+      return parts.join(' ');
+    }
+  `;
+}
@@ -15,7 +15,11 @@ export function generateTypeRegistry(ctx: Context): Code {
     export const messageTypeRegistry = new Map<string, MessageType>();
   `);
 
-  chunks.push(code` ${ctx.utils.Builtin.ifUsed} ${ctx.utils.DeepPartial.ifUsed}`);
+  if (ctx.options.outputAnyDispatcher) {
+    chunks.push(generateDecodeAny(ctx));
+  }
+
+  chunks.push(code` ${ctx.utils.Builtin.ifUsed} ${ctx.utils.DeepPartial.ifUsed} ${ctx.utils.globalThis.ifUsed}`);
 
   return joinCode(chunks, { on: '\n\n' });
 }
@@ -48,3 +52,23 @@ function generateMessageType(ctx: Context): Code {
 
   return joinCode(chunks, { on: '\n' });
 }
+
+/**
+ * Creates a `decodeAny(typeUrl, bytes)` function that decodes the `value` of an `Any` with the message of its
+ * `typeUrl`, as found in the registry.
+ *
+ * Messages are only registered once their module has been imported, so type URLs of messages whose modules
+ * haven't been loaded yet, or that weren't generated at all, throw just like unknown ones.
+ */
+function generateDecodeAny(ctx: Context): Code {
+  return code`
+    export function decodeAny(typeUrl: string, bytes: Uint8Array): UnknownMessage {
+      // Only the part after the last slash names the type, i.e. 'type.googleapis.com/foo.Bar'
+      const type = messageTypeRegistry.get(typeUrl.slice(typeUrl.lastIndexOf('/') + 1));
+      if (type === undefined) {
+        throw new ${ctx.utils.globalThis}.Error(\`Unknown type URL \${typeUrl}\`);
+      }
+      return type.decode(bytes);
+    }
+  `;
+}
@@ -0,0 +1,37 @@
+import { code, Code, joinCode } from 'ts-poet';
+import { DescriptorProto } from 'ts-proto-descriptors';
+import { Context } from './context';
+
+/**
+ * Creates a `KnownTypeUrl` string enum of the `Any` type URLs of all messages in the request, i.e.
+ * `Foo = 'type.googleapis.com/pkg.Foo'`, and an `isKnownTypeUrl` guard, for switching over `Any.typeUrl`
+ * exhaustively.
+ *
+ * Members are named like the messages' types, or by their fully-qualified proto name, i.e. `pkg_v1_Foo`,
+ * when several packages have a message of the same name.
+ */
+export function generateTypeUrlEnum(ctx: Context): Code {
+  const messages = [...ctx.typeMap.entries()]
+    .filter(([, [, , desc]]) => 'field' in desc && !(desc as DescriptorProto).options?.mapEntry)
+    .map(([protoFullName, [, tsFullName]]) => ({ protoFullName: protoFullName.substring(1), tsFullName }));
+
+  const counts = new Map<string, number>();
+  messages.forEach(({ tsFullName }) => counts.set(tsFullName, (counts.get(tsFullName) ?? 0) + 1));
+
+  const members = messages.map(({ protoFullName, tsFullName }) => {
+    const name = counts.get(tsFullName) === 1 ? tsFullName : protoFullName.replace(/\./g, '_');
+    return code`${name} = 'type.googleapis.com/${protoFullName}',`;
+  });
+
+  return code`
+    export enum KnownTypeUrl {
+      ${joinCode(members, { on: '\n' })}
+    }
+
+    const knownTypeUrls = new Set<string>(Object.values(KnownTypeUrl));
+
+    export function isKnownTypeUrl(typeUrl: string): typeUrl is KnownTypeUrl {
+      return knownTypeUrls.has(typeUrl);
+    }
+  `;
+}
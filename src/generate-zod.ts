@@ -0,0 +1,239 @@
+import { code, Code, def, imp, joinCode } from 'ts-poet';
+import { DescriptorProto, FieldDescriptorProto, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { Context } from './context';
+import {
+  basicTypeName,
+  brandedTypeName,
+  detectMapType,
+  getMessageSchema,
+  hasRawTimestamp,
+  isBufferBytes,
+  isEnum,
+  isLong,
+  isMapType,
+  isMessage,
+  isNativeDuration,
+  isObjectId,
+  isOptionalProperty,
+  isRepeated,
+  isTimestamp,
+  isValueType,
+  isWithinOneOf,
+  isWithinOneOfThatShouldBeUnion,
+  longOption,
+  messageToTypeName,
+  oneofCaseName,
+  oneofMembers,
+  oneofValueName,
+} from './types';
+import { maybeSnakeToCamel } from './case';
+import { BytesOption, DateOption, DurationOption, LongOption, OneofOption } from './options';
+
+const z = imp('z@zod');
+const mongodb = imp('mongodb*mongodb');
+
+/**
+ * Creates a `FooSchema` zod schema of the `Foo` interface, for validating untrusted data at runtime.
+ *
+ * Each property's schema is declared as a `z.ZodType<Foo["bar"]>`, so the generated code only compiles while
+ * the schema and the interface agree, and the schema itself is a `z.ZodType<Foo>`, i.e. `z.infer` gives `Foo`.
+ * Schemas are `z.lazy`, as messages can refer to each other, or themselves, regardless of declaration order.
+ */
+export function generateZodSchema(
+  ctx: Context,
+  fullName: string,
+  messageDesc: DescriptorProto,
+  fullTypeName: string
+): Code {
+  const { options } = ctx;
+  const properties: Code[] = [];
+
+  if (options.outputTypeRegistry) {
+    properties.push(code`$type: ${z}.literal('${fullTypeName}'),`);
+  }
+
+  // When oneof=unions, the whole `oneof` clause is a single property
+  const processedOneofs = new Set<number>();
+
+  messageDesc.field.forEach((field) => {
+    if (isWithinOneOfThatShouldBeUnion(options, field)) {
+      if (!processedOneofs.has(field.oneofIndex)) {
+        processedOneofs.add(field.oneofIndex);
+        properties.push(generateOneofSchema(ctx, fullName, messageDesc, field.oneofIndex));
+      }
+      return;
+    }
+
+    const name = maybeSnakeToCamel(field.name, options);
+    let schema = fieldSchema(ctx, messageDesc, field);
+    if (isOptionalProperty(field, messageDesc.options, options) || typeIncludesUndefined(ctx, field)) {
+      schema = code`${schema}.optional()`;
+    }
+    properties.push(code`${name}: ${schema},`);
+    if (hasRawTimestamp(field, options)) {
+      properties.push(code`${name}Raw: ${getMessageSchema(ctx, field.typeName)}.optional(),`);
+    }
+  });
+
+  return code`
+    export const ${def(`${fullName}Schema`)}: ${z}.ZodType<${fullName}> = ${z}.lazy(() => {
+      const shape: { [K in keyof Required<${fullName}>]: ${z}.ZodType<${fullName}[K]> } = {
+        ${joinCode(properties, { on: '\n' })}
+      };
+      // zod makes every property that accepts undefined optional, which the typed shape already accounts for
+      return ${z}.object(shape) as unknown as ${z}.ZodType<${fullName}>;
+    });
+  `;
+}
+
+/** Creates the schema of a oneof=unions property, i.e. a `z.discriminatedUnion` on its `$case`. */
+function generateOneofSchema(ctx: Context, fullName: string, messageDesc: DescriptorProto, oneofIndex: number): Code {
+  const { options } = ctx;
+  const caseName = oneofCaseName(options);
+  const members = oneofMembers(messageDesc, oneofIndex);
+  const branches = members.map((field) => {
+    const fieldName = maybeSnakeToCamel(field.name, options);
+    let schema = fieldSchema(ctx, messageDesc, field);
+    if (typeIncludesUndefined(ctx, field)) {
+      schema = code`${schema}.optional()`;
+    }
+    const valueName = oneofValueName(fieldName, options);
+    return code`${z}.object({ ${caseName}: ${z}.literal('${fieldName}'), ${valueName}: ${schema} })`;
+  });
+
+  const name = maybeSnakeToCamel(messageDesc.oneofDecl[oneofIndex].name, options);
+  const union = code`${z}.discriminatedUnion('${caseName}', [${joinCode(branches, { on: ', ' })}]).optional()`;
+  // Like for the message itself, zod would make the wrapper values of the branches optional properties
+  if (members.some((field) => typeIncludesUndefined(ctx, field))) {
+    return code`${name}: ${union} as unknown as ${z}.ZodType<${fullName}['${name}']>,`;
+  }
+  return code`${name}: ${union},`;
+}
+
+/** Whether the TS type of `field`, regardless of its property being optional, is unioned with `undefined`. */
+function typeIncludesUndefined(ctx: Context, field: FieldDescriptorProto): boolean {
+  const { options } = ctx;
+  if (isRepeated(field)) {
+    return false;
+  }
+  const optionalMessages =
+    options.useOptionals === true || options.useOptionals === 'messages' || options.useOptionals === 'all';
+  if (isValueType(ctx, field)) {
+    return !optionalMessages;
+  }
+  return (
+    (!isWithinOneOf(field) && isMessage(field) && !optionalMessages) ||
+    (isWithinOneOf(field) && options.oneof === OneofOption.PROPERTIES) ||
+    (isWithinOneOf(field) && field.proto3Optional)
+  );
+}
+
+/** Returns the schema of the property of `field`, i.e. a `z.array` or `z.record` for repeated fields and maps. */
+function fieldSchema(ctx: Context, messageDesc: DescriptorProto, field: FieldDescriptorProto): Code {
+  if (isMapType(ctx, messageDesc, field)) {
+    const { keyField, valueField } = detectMapType(ctx, messageDesc, field)!;
+    let valueSchema = valueSchemaOf(ctx, valueField);
+    if (isValueType(ctx, valueField) && typeIncludesUndefined(ctx, valueField)) {
+      valueSchema = code`${valueSchema}.optional()`;
+    }
+    if (ctx.options.useMapType) {
+      return code`${z}.map(${valueSchemaOf(ctx, keyField)}, ${valueSchema})`;
+    }
+    return code`${z}.record(${z}.string(), ${valueSchema})`;
+  }
+  let schema = valueSchemaOf(ctx, field);
+  const brand = brandedTypeName(ctx, messageDesc, field);
+  if (brand) {
+    // Branded values are validated as their primitive, which zod can't brand in a way that matches our type
+    schema = code`${z}.custom<${brand}>((v) => ${schema}.safeParse(v).success)`;
+  }
+  return isRepeated(field) ? code`${z}.array(${schema})` : schema;
+}
+
+/** Returns the schema of a single value of `field`. */
+function valueSchemaOf(ctx: Context, field: FieldDescriptorProto): Code {
+  const { options } = ctx;
+  if (isEnum(field)) {
+    const enumType = messageToTypeName(ctx, field.typeName);
+    if (options.constEnums) {
+      // const enums don't exist at runtime, so only the type of their values can be checked
+      return code`${z}.custom<${enumType}>((v) => typeof v === '${options.stringEnums ? 'string' : 'number'}')`;
+    }
+    return code`${z}.nativeEnum(${enumType})`;
+  } else if (isLong(field)) {
+    return longSchema(ctx, longOption(field, options));
+  } else if (isValueType(ctx, field)) {
+    return valueTypeSchema(ctx, field.typeName);
+  } else if (isTimestamp(field) && options.useDate === DateOption.DATE) {
+    return code`${z}.date()`;
+  } else if (isTimestamp(field) && options.useDate === DateOption.STRING) {
+    return code`${z}.string()`;
+  } else if (isNativeDuration(field, options)) {
+    return options.useDuration === DurationOption.NUMBER ? code`${z}.number()` : code`${z}.string()`;
+  } else if (isObjectId(field) && options.useMongoObjectId) {
+    return code`${z}.custom<${basicTypeName(ctx, field)}>((v) => v instanceof ${mongodb}.ObjectId)`;
+  } else if (isMessage(field)) {
+    return getMessageSchema(ctx, field.typeName);
+  }
+
+  switch (field.type) {
+    case FieldDescriptorProto_Type.TYPE_STRING:
+      return code`${z}.string()`;
+    case FieldDescriptorProto_Type.TYPE_BOOL:
+      return code`${z}.boolean()`;
+    case FieldDescriptorProto_Type.TYPE_BYTES:
+      return bytesSchema(ctx);
+    default:
+      // Everything else is a 32-bit integer or a floating point number
+      return code`${z}.number()`;
+  }
+}
+
+/** The wrapper types and other well-known value types are validated as the values they're unwrapped to. */
+function valueTypeSchema(ctx: Context, typeName: string): Code {
+  const { options } = ctx;
+  switch (typeName) {
+    case '.google.protobuf.StringValue':
+      return code`${z}.string()`;
+    case '.google.protobuf.BoolValue':
+      return code`${z}.boolean()`;
+    case '.google.protobuf.Int64Value':
+    case '.google.protobuf.UInt64Value':
+      return longSchema(ctx, options.forceLong);
+    case '.google.protobuf.BytesValue':
+      return options.useJsonWireFormat && !isBufferBytes(options) ? code`${z}.string()` : bytesSchema(ctx);
+    case '.google.protobuf.ListValue':
+      return code`${z}.array(${z}.any())`;
+    case '.google.protobuf.Value':
+      return code`${z}.any()`;
+    case '.google.protobuf.Struct':
+      return code`${z}.record(${z}.string(), ${z}.any())`;
+    case '.google.protobuf.FieldMask':
+      return options.useJsonWireFormat ? code`${z}.string()` : code`${z}.array(${z}.string())`;
+    case '.google.protobuf.Duration':
+    case '.google.protobuf.Timestamp':
+      // These are only value types with useJsonWireFormat, as their JSON strings
+      return code`${z}.string()`;
+    default:
+      // Int32Value, UInt32Value, DoubleValue and FloatValue
+      return code`${z}.number()`;
+  }
+}
+
+function longSchema(ctx: Context, longOption: LongOption): Code {
+  const { utils } = ctx;
+  if (longOption === LongOption.STRING) {
+    return code`${z}.string()`;
+  } else if (longOption === LongOption.LONG) {
+    return code`${z}.custom<${utils.Long}>((v) => ${utils.Long}.isLong(v))`;
+  }
+  return code`${z}.number()`;
+}
+
+function bytesSchema(ctx: Context): Code {
+  const { options } = ctx;
+  if (options.bytesAs === BytesOption.BASE64_STRING) {
+    return code`${z}.string()`;
+  }
+  return code`${z}.instanceof(${isBufferBytes(options) ? 'Buffer' : 'Uint8Array'})`;
+}
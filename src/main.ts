@@ -1,15 +1,26 @@
-import { code, Code, conditionalOutput, def, imp, joinCode } from 'ts-poet';
-import { DescriptorProto, FieldDescriptorProto, FileDescriptorProto } from 'ts-proto-descriptors';
+import { code, Code, conditionalOutput, def, imp, Import, joinCode } from 'ts-poet';
+import {
+  DescriptorProto,
+  EnumDescriptorProto,
+  FieldDescriptorProto,
+  FieldDescriptorProto_Type,
+  FileDescriptorProto,
+} from 'ts-proto-descriptors';
 import {
   basicLongWireType,
   basicTypeName,
   basicWireType,
+  brandedTypeName,
+  brandedValue,
   defaultValue,
   detectMapType,
   getEnumMethod,
+  getMessageMethod,
+  hasRawTimestamp,
   isAnyValueType,
   isAnyValueTypeName,
   isBytes,
+  isBufferBytes,
   isBytesValueType,
   isEnum,
   isFieldMaskType,
@@ -20,11 +31,15 @@ import {
   isLongValueType,
   isMapType,
   isMessage,
+  isNativeDuration,
   isObjectId,
+  isOneofUnions,
   isOptionalProperty,
   isPrimitive,
   isRepeated,
   isScalar,
+  isScalarWrapperType,
+  isSensitiveField,
   isStructType,
   isStructTypeName,
   isTimestamp,
@@ -32,8 +47,15 @@ import {
   isWholeNumber,
   isWithinOneOf,
   isWithinOneOfThatShouldBeUnion,
+  longOption,
+  messageToTypeName,
   notDefaultCheck,
+  oneofMembers,
+  oneofCaseName,
+  oneofValueName,
   packedType,
+  splitNestedRoot,
+  splitNestedRoots,
   toReaderCall,
   toTypeName,
   valueTypeName,
@@ -43,11 +65,20 @@ import {
   assertInstanceOf,
   getFieldJsonName,
   FormattedMethodDescriptor,
-  impProto,
+  impProtoMethod,
+  impProtoType,
   maybeAddComment,
   maybePrefixPackage,
+  maybeNullPrototype,
+  maybeWarningHookCall,
+  messageMethod,
+  messageMethodHead,
   getPropertyAccessor,
   impFile,
+  protoFileModuleName,
+  emptyMapValue,
+  emptyObject,
+  mapSetSnippet,
 } from './utils';
 import { camelToSnake, capitalize, maybeSnakeToCamel } from './case';
 import {
@@ -58,9 +89,16 @@ import {
 import {
   generateDataLoaderOptionsType,
   generateDataLoadersType,
+  generateInterceptorTypes,
+  generatePaginationHelpers,
+  generateQueryKeyHelpers,
+  generateRetryTypes,
+  generateReconnectTypes,
   generateRpcType,
   generateService,
   generateServiceClientImpl,
+  generateServiceInterceptors,
+  generateWatchHelpers,
 } from './generate-services';
 import {
   addGrpcWebMisc,
@@ -68,18 +106,96 @@ import {
   generateGrpcMethodDesc,
   generateGrpcServiceDesc,
 } from './generate-grpc-web';
-import { generateEncodeTransform, generateDecodeTransform } from './generate-async-iterable';
+import { addGrpcWebFetchMisc, generateGrpcWebFetchClientImpl } from './generate-grpc-web-fetch';
+import { generateEncodeTransform, generateDecodeTransform, generateDecodeStream } from './generate-async-iterable';
 import { generateEnum } from './enums';
 import { visit, visitServices } from './visit';
-import { DateOption, EnvOption, LongOption, OneofOption, Options, ServiceOption } from './options';
+import {
+  BytesOption,
+  DateOption,
+  DurationOption,
+  EnvOption,
+  LongOption,
+  OneofOption,
+  Options,
+  ServiceOption,
+} from './options';
 import { Context } from './context';
 import { generateSchema } from './schema';
 import { ConditionalOutput } from 'ts-poet/build/ConditionalOutput';
-import { generateGrpcJsService } from './generate-grpc-js';
+import {
+  generateGrpcJsBackpressureReader,
+  generateGrpcJsDefaultMetadataInterceptor,
+  generateGrpcJsService,
+  generateGrpcJsServiceRegistrar,
+} from './generate-grpc-js';
 import { generateGenericServiceDefinition } from './generate-generic-service-definition';
-import { generateNiceGrpcService } from './generate-nice-grpc';
+import { generateConnectService } from './generate-connect';
+import { generateNiceGrpcInterceptorTypes, generateNiceGrpcService } from './generate-nice-grpc';
+import { generateMerge, generatePatch, generateStreamAccumulators, isMergeableMessage } from './generate-merge';
+import {
+  generateOneofClears,
+  generateOneofExhaustiveHelpers,
+  generateOneofValues,
+  generatePresenceHelpers,
+  generateRepeatedHelpers,
+  generateRepeatedToMapHelpers,
+  generateEntries,
+  generateFieldNames,
+  generateSelectors,
+} from './generate-selectors';
+import {
+  conversionsTo,
+  enumConversionsTo,
+  generateConversion,
+  generateEnumConversion,
+} from './generate-conversions';
+import { generateMessageUnion, messageUnionsFor } from './generate-message-unions';
+import { generateGrpcJsBidiObservables, generateGrpcWebBidiObservables } from './generate-bidi-observable';
+import { generateFromTextFormat, generateToTextFormat } from './generate-text-format';
+import { generateApplyUpdate, generateAssertMasked } from './generate-field-mask';
+import { generateRoundTripTest, generateTestFactory } from './generate-test-factories';
+import { generateDebugString, generateToString } from './generate-to-string';
+import { generateReflection } from './generate-reflection';
+import { generateEventBus } from './generate-event-bus';
+import { generateComparator, hasSortKeys } from './generate-comparators';
+import { generateZodSchema } from './generate-zod';
+import { generateQueryString } from './generate-query-string';
+import { generateFormData } from './generate-form-data';
+import { generateTimestampHelpers, usesTimestamp } from './generate-timestamp-helpers';
+import { generateDurationHelpers, usesDuration } from './generate-duration-helpers';
+import { generateSchemaHash } from './generate-schema-hash';
+import { generateLongConversions, hasLongConversions } from './generate-long-conversions';
+
+/**
+ * Generates the modules of `fileDesc`, i.e. just its own, or with splitNestedTypes=true also one per type that
+ * is nested splitNestedTypesDepth levels deep, holding it and its own nested types, which the file's module
+ * re-exports, so that consumers can keep importing everything from the file's module.
+ */
+export function generateFiles(ctx: Context, fileDesc: FileDescriptorProto): Array<[string, Code]> {
+  const file = generateFile(ctx, fileDesc);
+  const roots = splitNestedRoots(fileDesc, ctx.options);
+  if (roots.length === 0) {
+    return [file];
+  }
 
-export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [string, Code] {
+  // Two modules that `export *` the same name would make it ambiguous, so only the file's module exports these
+  const options = { ...ctx.options, exportCommonSymbols: false };
+  const partCtx = { ...ctx, options, utils: makeUtils(options) };
+  const parts = roots.map((root) => generateFile(partCtx, { ...fileDesc, service: [] }, root));
+
+  const baseName = protoFileModuleName(fileDesc, options).split('/').pop();
+  const reExports = roots.map(
+    (root) => code`export * from './${baseName}.${root}${options.fileSuffix}${options.importSuffix}';`
+  );
+  return [[file[0], joinCode([file[1], ...reExports], { on: '\n\n' })], ...parts];
+}
+
+/**
+ * Generates the module of `fileDesc`, or with splitNestedTypes=true, the module of the types nested in its
+ * type `root`, see `splitNestedRoot`.
+ */
+export function generateFile(ctx: Context, fileDesc: FileDescriptorProto, root?: string): [string, Code] {
   const { options, utils } = ctx;
 
   if (options.useOptionals === false) {
@@ -104,21 +220,41 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
   // company/bar.proto --> company/bar.ts
   //
   // We'll also assume that the fileDesc.name is already the `company/foo.proto` path, with
-  // the package already implicitly in it, so we won't re-append/strip/etc. it out/back in.
+  // the package already implicitly in it, so we won't re-append/strip/etc. it out/back in,
+  // unless outputLayout=package asks us to place files by package instead.
   const suffix = `${options.fileSuffix}.ts`;
-  const moduleName = fileDesc.name.replace('.proto', suffix);
+  const module = root ? `${protoFileModuleName(fileDesc, options)}.${root}` : protoFileModuleName(fileDesc, options);
+  const moduleName = `${module}${suffix}`;
   const chunks: Code[] = [];
+  ctx = { ...ctx, currentModule: module };
+  // With splitNestedTypes, only the types of this module's part of the file
+  const inModule = (protoFullName: string, desc: DescriptorProto | EnumDescriptorProto) =>
+    splitNestedRoot(options, protoFullName, desc) === root;
 
   // Indicate this file's source protobuf package for reflective use with google.protobuf.Any
   if (options.exportCommonSymbols) {
     chunks.push(code`export const protobufPackage = '${fileDesc.package}';`);
   }
 
+  // The effective options, i.e. after their defaults and implications, for tooling to check what produced the file
+  if (options.embedOptions && !root) {
+    const sorted = Object.keys(options)
+      .sort()
+      .reduce<{ [key: string]: unknown }>((acc, key) => {
+        acc[key] = (options as any)[key];
+        return acc;
+      }, {});
+    chunks.push(code`export const __tsProtoOptions = ${JSON.stringify(sorted)} as const;`);
+  }
+
   // Syntax, unlike most fields, is not repeated and thus does not use an index
   const sourceInfo = SourceInfo.fromDescriptor(fileDesc);
   const headerComment = sourceInfo.lookup(Fields.file.syntax, undefined);
   maybeAddComment(headerComment, chunks, fileDesc.options?.deprecated);
 
+  // With wrapInNamespace=true, everything from here on up to the utils goes into the package's namespace
+  const namespaceStart = chunks.length;
+
   // Apply formatting to methods here, so they propagate globally
   for (let svc of fileDesc.service) {
     for (let i = 0; i < svc.method.length; i++) {
@@ -127,22 +263,88 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
   }
 
   // first make all the type declarations
+  const brandedTypes = new Map<string, string>();
   visit(
     fileDesc,
     sourceInfo,
     (fullName, message, sInfo, fullProtoTypeName) => {
+      if (!inModule(fullProtoTypeName, message)) {
+        return;
+      }
+      chunks.push(...generateBrandedTypes(ctx, message, brandedTypes));
       chunks.push(
         generateInterfaceDeclaration(ctx, fullName, message, sInfo, maybePrefixPackage(fileDesc, fullProtoTypeName))
       );
+      if (options.outputSelectors && message.field.length > 0 && !message.options?.mapEntry) {
+        chunks.push(generateSelectors(ctx, fullName, message));
+      }
+      if (options.outputFieldNames && !message.options?.mapEntry) {
+        chunks.push(generateFieldNames(ctx, fullName, message));
+      }
+      if (options.outputSchemaHash && !message.options?.mapEntry) {
+        chunks.push(generateSchemaHash(ctx, fullName, message));
+      }
+      if (options.outputReflection && !message.options?.mapEntry) {
+        chunks.push(generateReflection(ctx, fullName, message));
+      }
+      if (options.outputComparators && hasSortKeys(ctx, message)) {
+        chunks.push(generateComparator(ctx, fullName, message));
+      }
+      // fromEntries starts from the base instance, which is only output along with the methods
+      const hasBaseInstance = options.outputEncodeMethods || options.outputJsonMethods || options.outputTypeRegistry;
+      if (options.outputEntries && hasBaseInstance && !message.options?.mapEntry) {
+        chunks.push(generateEntries(ctx, fullName, message));
+      }
+      if (options.outputOneofClear) {
+        chunks.push(...generateOneofClears(ctx, fullName, message));
+      }
+      if (options.outputOneofExhaustive) {
+        chunks.push(...generateOneofExhaustiveHelpers(ctx, fullName, message));
+      }
+      if (options.outputOneofValue) {
+        chunks.push(...generateOneofValues(ctx, fullName, message));
+      }
+      if (options.outputRepeatedHelpers) {
+        chunks.push(...generateRepeatedHelpers(ctx, fullName, message));
+      }
+      if (options.outputRepeatedToMap) {
+        chunks.push(...generateRepeatedToMapHelpers(ctx, fullName, message));
+      }
+      if (options.outputPresenceHelpers && !message.options?.mapEntry) {
+        chunks.push(...generatePresenceHelpers(ctx, fullName, message));
+      }
+      if (options.outputToString && !message.options?.mapEntry) {
+        chunks.push(generateToString(ctx, fullName, message));
+      }
+      if (options.outputDebugString && !message.options?.mapEntry) {
+        chunks.push(generateDebugString(ctx, fullName, message));
+      }
+      if (options.outputLongConversions && !message.options?.mapEntry && hasLongConversions(ctx, message)) {
+        chunks.push(generateLongConversions(ctx, fullName, message));
+      }
+      if (options.outputQueryString && !message.options?.mapEntry) {
+        chunks.push(generateQueryString(ctx, fullName, message));
+      }
+      if (options.outputFormData && options.outputJsonMethods && !message.options?.mapEntry) {
+        chunks.push(generateFormData(ctx, fullName, message));
+      }
+      if (options.outputZodSchemas && !message.options?.mapEntry) {
+        chunks.push(generateZodSchema(ctx, fullName, message, maybePrefixPackage(fileDesc, fullProtoTypeName)));
+      }
     },
     options,
-    (fullName, enumDesc, sInfo) => {
-      chunks.push(generateEnum(ctx, fullName, enumDesc, sInfo));
+    (fullName, enumDesc, sInfo, fullProtoTypeName) => {
+      if (inModule(fullProtoTypeName, enumDesc)) {
+        chunks.push(generateEnum(ctx, fullName, enumDesc, sInfo));
+        for (const conversion of enumConversionsTo(ctx, maybePrefixPackage(fileDesc, fullProtoTypeName))) {
+          chunks.push(generateEnumConversion(ctx, fullName, enumDesc, conversion));
+        }
+      }
     }
   );
 
   // If nestJs=true export [package]_PACKAGE_NAME and [service]_SERVICE_NAME const
-  if (options.nestJs) {
+  if (options.nestJs && !root) {
     const prefix = camelToSnake(fileDesc.package.replace(/\./g, '_'));
     chunks.push(code`export const ${prefix}_PACKAGE_NAME = '${fileDesc.package}';`);
   }
@@ -153,6 +355,9 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
       fileDesc,
       sourceInfo,
       (fullName, message, sInfo, fullProtoTypeName) => {
+        if (!inModule(fullProtoTypeName, message)) {
+          return;
+        }
         const fullTypeName = maybePrefixPackage(fileDesc, fullProtoTypeName);
 
         chunks.push(generateBaseInstanceFactory(ctx, fullName, message, fullTypeName));
@@ -167,17 +372,58 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
           staticMembers.push(generateEncode(ctx, fullName, message));
           staticMembers.push(generateDecode(ctx, fullName, message));
         }
+        if (options.outputEncodeMethods && options.outputLowLevelWriters) {
+          staticMembers.push(...generateFieldWriters(ctx, message));
+        }
+        if (options.outputEncodeMethods && options.outputSelectiveDecode) {
+          staticMembers.push(...generateFieldDecoders(ctx, message));
+        }
+        if (options.outputEncodeMethods && options.outputEncodeInto) {
+          staticMembers.push(generateEncodeInto(ctx, fullName));
+        }
+        if (options.outputEncodeMethods && options.outputTryDecode) {
+          staticMembers.push(generateTryDecode(ctx, fullName));
+        }
+        if (options.outputEncodeMethods && options.outputEncodeExcept) {
+          staticMembers.push(generateEncode(ctx, fullName, message, 'encodeExcept'));
+        }
+        if (options.outputEncodeMethods && options.outputEncodeWithMask) {
+          staticMembers.push(generateEncode(ctx, fullName, message, 'encodeWithMask'));
+        }
         if (options.useAsyncIterable) {
           staticMembers.push(generateEncodeTransform(fullName));
-          staticMembers.push(generateDecodeTransform(fullName));
+          staticMembers.push(generateDecodeTransform(ctx, fullName));
+        }
+        if (options.outputEncodeMethods && options.outputStreamHelpers) {
+          staticMembers.push(generateDecodeStream(ctx, fullName));
         }
         if (options.outputJsonMethods) {
           staticMembers.push(generateFromJson(ctx, fullName, fullTypeName, message));
           staticMembers.push(generateToJson(ctx, fullName, fullTypeName, message));
+          if (options.sensitiveFieldOption !== undefined) {
+            staticMembers.push(generateToJson(ctx, fullName, fullTypeName, message, 'toJSONFull'));
+          }
         }
         if (options.outputPartialMethods) {
           staticMembers.push(generateFromPartial(ctx, fullName, message));
         }
+        if (options.outputPartialMethods && options.outputPartialArray) {
+          staticMembers.push(generateFromPartialArray(ctx, fullName));
+        }
+        if (options.outputMergeMethods) {
+          staticMembers.push(generateMerge(ctx, fullName, message));
+        }
+        if (options.outputPatch && options.outputPartialMethods) {
+          staticMembers.push(generatePatch(ctx, fullName, message));
+        }
+        if (options.outputFieldMaskMethods) {
+          staticMembers.push(generateApplyUpdate(ctx, fullName, message));
+          staticMembers.push(generateAssertMasked(ctx, fullName, message));
+        }
+        if (options.outputTextFormat) {
+          staticMembers.push(generateToTextFormat(ctx, fullName, message));
+          staticMembers.push(generateFromTextFormat(ctx, fullName, message));
+        }
 
         const structFieldNames = {
           nullValue: maybeSnakeToCamel('null_value', ctx.options),
@@ -187,14 +433,43 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
           structValue: maybeSnakeToCamel('struct_value', ctx.options),
           listValue: maybeSnakeToCamel('list_value', ctx.options),
         };
-        staticMembers.push(...generateWrap(ctx, fullTypeName, structFieldNames));
-        staticMembers.push(...generateUnwrap(ctx, fullTypeName, structFieldNames));
+        staticMembers.push(...generateWrap(ctx, fullName, fullTypeName, structFieldNames));
+        staticMembers.push(...generateUnwrap(ctx, fullName, fullTypeName, structFieldNames));
+
+        // The wrap/unwrap helpers of Struct & co aren't part of the Codec interface, so leave those unannotated
+        const hasWrap = generateWrap(ctx, fullName, fullTypeName, structFieldNames).length > 0;
+        const maybeCodec = options.outputCodecInterface && !hasWrap ? code`: ${utils.Codec}<${fullName}>` : '';
+        // Like the interface, tag the methods of deprecated messages, so that e.g. `Foo.decode` call sites show it
+        const maybeDeprecated = message.options?.deprecated ? '/** @deprecated */\n' : '';
+        if (options.outputStyle === 'functions') {
+          chunks.push(joinCode(staticMembers.map((member) => code`${maybeDeprecated}${member}`), { on: '\n\n' }));
+        } else {
+          chunks.push(code`
+            ${maybeDeprecated}export const ${def(fullName)}${maybeCodec} = {
+              ${joinCode(staticMembers, { on: ',\n\n' })}
+            };
+          `);
+        }
 
-        chunks.push(code`
-          export const ${def(fullName)} = {
-            ${joinCode(staticMembers, { on: ',\n\n' })}
-          };
-        `);
+        for (const conversion of conversionsTo(ctx, fullTypeName)) {
+          chunks.push(generateConversion(ctx, fullName, message, conversion));
+        }
+
+        for (const union of messageUnionsFor(ctx, fullTypeName)) {
+          chunks.push(generateMessageUnion(ctx, union));
+        }
+
+        if (options.outputTestFactories && options.outputPartialMethods && !message.options?.mapEntry) {
+          chunks.push(generateTestFactory(ctx, fullName, message));
+        }
+
+        if (options.outputRoundTripTests && options.outputEncodeMethods && !message.options?.mapEntry) {
+          chunks.push(generateRoundTripTest(ctx, fullName));
+        }
+
+        if (options.outputEventBus && !message.options?.mapEntry) {
+          chunks.push(generateEventBus(ctx, fullName, fullTypeName));
+        }
 
         if (options.outputTypeRegistry) {
           const messageTypeRegistry = impFile(options, 'messageTypeRegistry@./typeRegistry');
@@ -208,6 +483,16 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
     );
   }
 
+  // Each file with Timestamp fields gets its own copy of the helpers, for the representation that useDate chose
+  if (options.outputTimestampHelpers && usesTimestamp(fileDesc)) {
+    chunks.push(generateTimestampHelpers(ctx));
+  }
+
+  // Likewise for Duration fields, for the representation that useDuration chose
+  if (options.outputDurationHelpers && usesDuration(fileDesc)) {
+    chunks.push(generateDurationHelpers(ctx));
+  }
+
   let hasStreamingMethods = false;
 
   visitServices(fileDesc, sourceInfo, (serviceDesc, sInfo) => {
@@ -232,23 +517,46 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
       uniqueServices.forEach((outputService) => {
         if (outputService === ServiceOption.GRPC) {
           chunks.push(generateGrpcJsService(ctx, fileDesc, sInfo, serviceDesc));
+          if (options.bidiObservable && options.outputClientImpl) {
+            chunks.push(...generateGrpcJsBidiObservables(ctx, serviceDesc));
+          }
         } else if (outputService === ServiceOption.NICE_GRPC) {
           chunks.push(generateNiceGrpcService(ctx, fileDesc, sInfo, serviceDesc));
         } else if (outputService === ServiceOption.GENERIC) {
           chunks.push(generateGenericServiceDefinition(ctx, fileDesc, sInfo, serviceDesc));
+        } else if (outputService === ServiceOption.CONNECT) {
+          chunks.push(generateConnectService(ctx, fileDesc, sInfo, serviceDesc));
         } else if (outputService === ServiceOption.DEFAULT) {
           // This service could be Twirp or grpc-web or JSON (maybe). So far all of their
           // interfaces are fairly similar so we share the same service interface.
           chunks.push(generateService(ctx, fileDesc, sInfo, serviceDesc));
 
+          if (options.outputPagination) {
+            chunks.push(...generatePaginationHelpers(ctx, serviceDesc));
+          }
+          if (options.outputWatch) {
+            chunks.push(...generateWatchHelpers(ctx, serviceDesc));
+          }
+          if (options.outputQueryKeys) {
+            chunks.push(...generateQueryKeyHelpers(ctx, fileDesc, serviceDesc));
+          }
+
           if (options.outputClientImpl === true) {
+            if (options.clientInterceptors) {
+              chunks.push(generateServiceInterceptors(ctx, serviceDesc));
+            }
             chunks.push(generateServiceClientImpl(ctx, fileDesc, serviceDesc));
           } else if (options.outputClientImpl === 'grpc-web') {
             chunks.push(generateGrpcClientImpl(ctx, fileDesc, serviceDesc));
-            chunks.push(generateGrpcServiceDesc(fileDesc, serviceDesc));
+            chunks.push(generateGrpcServiceDesc(ctx, fileDesc, serviceDesc));
             serviceDesc.method.forEach((method) => {
               chunks.push(generateGrpcMethodDesc(ctx, serviceDesc, method));
             });
+            if (options.bidiObservable && !options.useAsyncIterable) {
+              chunks.push(...generateGrpcWebBidiObservables(ctx, serviceDesc));
+            }
+          } else if (options.outputClientImpl === 'grpc-web-fetch') {
+            chunks.push(generateGrpcWebFetchClientImpl(ctx, fileDesc, serviceDesc));
           }
         }
       });
@@ -267,20 +575,84 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
   ) {
     if (options.outputClientImpl === true) {
       chunks.push(generateRpcType(ctx, hasStreamingMethods));
+      if (options.clientInterceptors) {
+        chunks.push(generateInterceptorTypes());
+      }
+      if (options.clientRetry) {
+        chunks.push(generateRetryTypes());
+      }
+      const hasServerStreamingMethods = fileDesc.service.some((serviceDesc) =>
+        serviceDesc.method.some((methodDesc) => methodDesc.serverStreaming && !methodDesc.clientStreaming)
+      );
+      if (options.streamReconnect && hasServerStreamingMethods) {
+        chunks.push(generateReconnectTypes(ctx));
+      }
     } else if (options.outputClientImpl === 'grpc-web') {
       chunks.push(addGrpcWebMisc(ctx, hasStreamingMethods));
+    } else if (options.outputClientImpl === 'grpc-web-fetch') {
+      chunks.push(addGrpcWebFetchMisc(ctx, hasStreamingMethods));
     }
   }
 
+  if (
+    options.niceGrpcInterceptors &&
+    options.outputServices.includes(ServiceOption.NICE_GRPC) &&
+    fileDesc.service.length > 0
+  ) {
+    chunks.push(generateNiceGrpcInterceptorTypes());
+  }
+
+  if (
+    options.outputServiceRegistrar &&
+    !options.nestJs &&
+    options.outputServices.includes(ServiceOption.GRPC) &&
+    fileDesc.service.length > 0
+  ) {
+    chunks.push(generateGrpcJsServiceRegistrar(fileDesc));
+  }
+
+  if (
+    options.defaultMetadata &&
+    options.outputClientImpl &&
+    !options.nestJs &&
+    options.outputServices.includes(ServiceOption.GRPC) &&
+    fileDesc.service.length > 0
+  ) {
+    chunks.push(generateGrpcJsDefaultMetadataInterceptor());
+  }
+
+  if (
+    options.streamBackpressure &&
+    options.outputClientImpl &&
+    !options.nestJs &&
+    options.outputServices.includes(ServiceOption.GRPC) &&
+    fileDesc.service.some((serviceDesc) => serviceDesc.method.some((methodDesc) => methodDesc.serverStreaming))
+  ) {
+    chunks.push(generateGrpcJsBackpressureReader());
+  }
+
+  if (options.outputStreamAccumulators) {
+    chunks.push(...generateStreamAccumulators(ctx, fileDesc));
+  }
+
   if (options.context) {
     chunks.push(generateDataLoaderOptionsType());
     chunks.push(generateDataLoadersType());
   }
 
-  if (options.outputSchema) {
+  if (options.outputSchema && !root) {
     chunks.push(...generateSchema(ctx, fileDesc, sourceInfo));
   }
 
+  if (options.wrapInNamespace && fileDesc.package) {
+    const body = chunks.splice(namespaceStart);
+    chunks.push(code`
+      export namespace ${fileDesc.package} {
+        ${joinCode(body, { on: '\n\n' })}
+      }
+    `);
+  }
+
   chunks.push(
     ...Object.values(utils).map((v) => {
       if (v instanceof ConditionalOutput) {
@@ -307,22 +679,53 @@ export function generateFile(ctx: Context, fileDesc: FileDescriptorProto): [stri
 export type Utils = ReturnType<typeof makeDeepPartial> &
   ReturnType<typeof makeObjectIdMethods> &
   ReturnType<typeof makeTimestampMethods> &
+  ReturnType<typeof makeDurationMethods> &
   ReturnType<typeof makeByteUtils> &
   ReturnType<typeof makeLongUtils> &
   ReturnType<typeof makeComparisonUtils> &
+  ReturnType<typeof makeDecodeLimitUtils> &
+  ReturnType<typeof makeTruncationUtils> &
+  ReturnType<typeof makeEncodeOptionUtils> &
+  ReturnType<typeof makeMergeOptionUtils> &
+  ReturnType<typeof makeCodecUtils> &
+  ReturnType<typeof makeTextFormatUtils> &
+  ReturnType<typeof makeDebugStringUtils> &
+  ReturnType<typeof makeReflectionUtils> &
+  ReturnType<typeof makeEventBusUtils> &
+  ReturnType<typeof makeComparatorUtils> &
+  ReturnType<typeof makeStreamUtils> &
+  ReturnType<typeof makeFormDataUtils> &
   ReturnType<typeof makeNiceGrpcServerStreamingMethodResult>;
 
 /** These are runtime utility methods used by the generated code. */
 export function makeUtils(options: Options): Utils {
-  const bytes = makeByteUtils();
+  const bytes = makeByteUtils(options);
   const longs = makeLongUtils(options, bytes);
+  const deepPartial = makeDeepPartial(options, longs);
+  const decodeLimits = makeDecodeLimitUtils(options, bytes);
+  const encodeOptions = makeEncodeOptionUtils(options);
+  const mergeOptions = makeMergeOptionUtils(options);
+  const textFormat = makeTextFormatUtils(options);
   return {
     ...bytes,
-    ...makeDeepPartial(options, longs),
+    ...deepPartial,
     ...makeObjectIdMethods(options),
     ...makeTimestampMethods(options, longs),
+    ...makeDurationMethods(options, bytes, longs),
     ...longs,
     ...makeComparisonUtils(),
+    ...decodeLimits,
+    ...makeTruncationUtils(options, bytes),
+    ...encodeOptions,
+    ...mergeOptions,
+    ...textFormat,
+    ...makeDebugStringUtils(),
+    ...makeReflectionUtils(options),
+    ...makeEventBusUtils(options),
+    ...makeComparatorUtils(),
+    ...makeStreamUtils(bytes),
+    ...makeFormDataUtils(),
+    ...makeCodecUtils(options, deepPartial, decodeLimits, encodeOptions, mergeOptions, textFormat),
     ...makeNiceGrpcServerStreamingMethodResult(),
   };
 }
@@ -399,7 +802,7 @@ function makeLongUtils(options: Options, bytes: ReturnType<typeof makeByteUtils>
   return { numberToLong, longToNumber, longToString, Long };
 }
 
-function makeByteUtils() {
+function makeByteUtils(options: Options) {
   const globalThis = conditionalOutput(
     'globalThis',
     code`
@@ -416,9 +819,17 @@ function makeByteUtils() {
     `
   );
 
+  // With bytesAs=buffer, the bytes are Buffers anyway, so let Buffer do the transcoding
+  const isBuffer = options.bytesAs === BytesOption.BUFFER;
   const bytesFromBase64 = conditionalOutput(
     'bytesFromBase64',
-    code`
+    isBuffer
+      ? code`
+      function bytesFromBase64(b64: string): Buffer {
+        return Buffer.from(b64, 'base64');
+      }
+    `
+      : code`
       const atob: (b64: string) => string = ${globalThis}.atob || ((b64) => ${globalThis}.Buffer.from(b64, 'base64').toString('binary'));
       function bytesFromBase64(b64: string): Uint8Array {
         const bin = atob(b64);
@@ -432,7 +843,13 @@ function makeByteUtils() {
   );
   const base64FromBytes = conditionalOutput(
     'base64FromBytes',
-    code`
+    isBuffer
+      ? code`
+      function base64FromBytes(arr: Buffer): string {
+        return arr.toString('base64');
+      }
+    `
+      : code`
       const btoa : (bin: string) => string = ${globalThis}.btoa || ((bin) => ${globalThis}.Buffer.from(bin, 'binary').toString('base64'));
       function base64FromBytes(arr: Uint8Array): string {
         const bin: string[] = [];
@@ -443,12 +860,35 @@ function makeByteUtils() {
       }
     `
   );
-  return { globalThis, bytesFromBase64, base64FromBytes };
+
+  // With bytesJsonEncoding=base64url, JSON uses the URL-safe alphabet without padding, while accepting it when
+  // parsing, so wrap the base64 helpers rather than change them, as they also serve bytesAs=base64-string
+  const bytesType = isBuffer ? 'Buffer' : 'Uint8Array';
+  const bytesFromBase64Url = conditionalOutput(
+    'bytesFromBase64Url',
+    code`
+      function bytesFromBase64Url(b64: string): ${bytesType} {
+        const padding = '==='.slice((b64.length + 3) % 4);
+        return ${bytesFromBase64}(b64.replace(/-/g, '+').replace(/_/g, '/') + padding);
+      }
+    `
+  );
+  const base64UrlFromBytes = conditionalOutput(
+    'base64UrlFromBytes',
+    code`
+      function base64UrlFromBytes(arr: ${bytesType}): string {
+        return ${base64FromBytes}(arr).replace(/\\+/g, '-').replace(/\\//g, '_').replace(/=+$/, '');
+      }
+    `
+  );
+  return { globalThis, bytesFromBase64, base64FromBytes, bytesFromBase64Url, base64UrlFromBytes };
 }
 
 function makeDeepPartial(options: Options, longs: ReturnType<typeof makeLongUtils>) {
   let oneofCase = '';
-  if (options.oneof === OneofOption.UNIONS) {
+  // Keep `$case` required in partials, but not `kind`, as that's also a common field name and a message
+  // with a `kind` string would otherwise be mistaken for a oneof branch
+  if (options.oneof === OneofOption.UNIONS || options.oneof === OneofOption.UNIONS_VALUE) {
     oneofCase = `
       : T extends { $case: string }
       ? { [K in keyof Omit<T, '$case'>]?: DeepPartial<T[K]> } & { $case: T['$case'] }
@@ -460,6 +900,11 @@ function makeDeepPartial(options: Options, longs: ReturnType<typeof makeLongUtil
   const maybeLong =
     options.forceLong === LongOption.LONG ? code` : T extends ${longs.Long} ? string | number | Long ` : '';
 
+  // With useMapType, map fields can be given as either a Map or, like their JSON, a plain object
+  const maybeMap = options.useMapType
+    ? code` : T extends Map<infer K, infer V> ? Map<K, DeepPartial<V>> | { [key: string]: DeepPartial<V> } `
+    : '';
+
   const Builtin = conditionalOutput(
     'Builtin',
     code`type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;`
@@ -486,6 +931,7 @@ function makeDeepPartial(options: Options, longs: ReturnType<typeof makeLongUtil
       ${maybeExport} type DeepPartial<T> =  T extends ${Builtin}
         ? T
         ${maybeLong}
+        ${maybeMap}
         : T extends Array<infer U>
         ? Array<DeepPartial<U>>
         : T extends ReadonlyArray<infer U>
@@ -496,7 +942,27 @@ function makeDeepPartial(options: Options, longs: ReturnType<typeof makeLongUtil
     `
   );
 
-  return { Builtin, DeepPartial, Exact };
+  // With useReadonlyTypes=output, the type of decoded messages, whose arrays, maps and sub-messages are readonly too
+  const maybeReadonlyLong = options.forceLong === LongOption.LONG ? code` : T extends ${longs.Long} ? T ` : '';
+  const maybeReadonlyMap = options.useMapType
+    ? code` : T extends ReadonlyMap<infer K, infer V> ? ReadonlyMap<K, DeepReadonly<V>> `
+    : '';
+  const DeepReadonly = conditionalOutput(
+    'DeepReadonly',
+    code`
+      ${maybeExport} type DeepReadonly<T> = T extends ${Builtin}
+        ? T
+        ${maybeReadonlyLong}
+        ${maybeReadonlyMap}
+        : T extends ReadonlyArray<infer U>
+        ? ReadonlyArray<DeepReadonly<U>>
+        : T extends {}
+        ? { readonly [K in keyof T]: DeepReadonly<T[K]> }
+        : Readonly<T>;
+    `
+  );
+
+  return { Builtin, DeepPartial, DeepReadonly, Exact };
 }
 
 function makeObjectIdMethods(options: Options) {
@@ -540,20 +1006,31 @@ function makeObjectIdMethods(options: Options) {
 }
 
 function makeTimestampMethods(options: Options, longs: ReturnType<typeof makeLongUtils>) {
-  const Timestamp = impProto(options, 'google/protobuf/timestamp', 'Timestamp');
+  // The utils are shared by all files, so this always refers to Timestamp as if from another file
+  const Timestamp = impProtoType({ options }, 'google/protobuf/timestamp', 'google.protobuf', 'Timestamp');
+  const timestampFromJson = impProtoMethod(
+    { options },
+    'google/protobuf/timestamp',
+    'google.protobuf',
+    'Timestamp',
+    'fromJSON'
+  );
 
-  let seconds: string | Code = 'date.getTime() / 1_000';
+  // Discard the fraction of the seconds, which the nanos already hold. Rounding down rather than towards
+  // zero keeps the nanos non-negative for dates before the epoch, i.e. -1.5s is -2 seconds plus 0.5s of nanos
+  let seconds: string | Code = 'Math.floor(date.getTime() / 1_000)';
   let toNumberCode = 't.seconds';
   if (options.forceLong === LongOption.LONG) {
     toNumberCode = 't.seconds.toNumber()';
-    seconds = code`${longs.numberToLong}(date.getTime() / 1_000)`;
+    seconds = code`${longs.numberToLong}(Math.floor(date.getTime() / 1_000))`;
   } else if (options.forceLong === LongOption.STRING) {
     toNumberCode = 'Number(t.seconds)';
     // Must discard the fractional piece here
     // Otherwise the fraction ends up on the seconds when parsed as a Long
     // (note this only occurs when the string is > 8 characters)
-    seconds = 'Math.trunc(date.getTime() / 1_000).toString()';
+    seconds = 'Math.floor(date.getTime() / 1_000).toString()';
   }
+  const nanos = '(((date.getTime() % 1_000) + 1_000) % 1_000) * 1_000_000';
 
   const maybeTypeField = options.outputTypeRegistry ? `$type: 'google.protobuf.Timestamp',` : '';
 
@@ -564,14 +1041,14 @@ function makeTimestampMethods(options: Options, longs: ReturnType<typeof makeLon
           function toTimestamp(dateStr: string): ${Timestamp} {
             const date = new Date(dateStr);
             const seconds = ${seconds};
-            const nanos = (date.getTime() % 1_000) * 1_000_000;
+            const nanos = ${nanos};
             return { ${maybeTypeField} seconds, nanos };
           }
         `
       : code`
           function toTimestamp(date: Date): ${Timestamp} {
             const seconds = ${seconds};
-            const nanos = (date.getTime() % 1_000) * 1_000_000;
+            const nanos = ${nanos};
             return { ${maybeTypeField} seconds, nanos };
           }
         `
@@ -606,7 +1083,7 @@ function makeTimestampMethods(options: Options, longs: ReturnType<typeof makeLon
           } else if (typeof o === "string") {
             return new Date(o);
           } else {
-            return ${fromTimestamp}(Timestamp.fromJSON(o));
+            return ${fromTimestamp}(${timestampFromJson}(o));
           }
         }
       `
@@ -617,7 +1094,7 @@ function makeTimestampMethods(options: Options, longs: ReturnType<typeof makeLon
           } else if (typeof o === "string") {
             return ${toTimestamp}(new Date(o));
           } else {
-            return Timestamp.fromJSON(o);
+            return ${timestampFromJson}(o);
           }
         }
       `
@@ -626,6 +1103,125 @@ function makeTimestampMethods(options: Options, longs: ReturnType<typeof makeLon
   return { toTimestamp, fromTimestamp, fromJsonTimestamp };
 }
 
+function makeDurationMethods(
+  options: Options,
+  bytes: ReturnType<typeof makeByteUtils>,
+  longs: ReturnType<typeof makeLongUtils>
+) {
+  // The utils are shared by all files, so this always refers to Duration as if from another file
+  const Duration = impProtoType({ options }, 'google/protobuf/duration', 'google.protobuf', 'Duration');
+  const durationFromJson = impProtoMethod(
+    { options },
+    'google/protobuf/duration',
+    'google.protobuf',
+    'Duration',
+    'fromJSON'
+  );
+
+  // Durations are capped at 10,000 years, which is well within Number.MAX_SAFE_INTEGER
+  let toSeconds = (place: string): string | Code => place;
+  let fromSeconds = 'd.seconds';
+  if (options.forceLong === LongOption.LONG) {
+    toSeconds = (place) => code`${longs.numberToLong}(${place})`;
+    fromSeconds = 'd.seconds.toNumber()';
+  } else if (options.forceLong === LongOption.STRING) {
+    toSeconds = (place) => `${place}.toString()`;
+    fromSeconds = 'Number(d.seconds)';
+  }
+
+  const maybeTypeField = options.outputTypeRegistry ? `$type: 'google.protobuf.Duration',` : '';
+
+  // Parses the canonical JSON form like `-1.5s`, where nanos carry the same sign as the seconds
+  const parse = (name: string) => code`
+    function ${name}(text: string): ${Duration} {
+      const match = /^(-)?(\\d+)(?:\\.(\\d{1,9}))?s$/.exec(text);
+      if (!match || Number(match[2]) > 315_576_000_000) {
+        throw new ${bytes.globalThis}.Error("Invalid duration " + text);
+      }
+      const sign = match[1] ? -1 : 1;
+      const seconds = sign * Number(match[2]) || 0;
+      const nanos = sign * Number((match[3] ?? "").padEnd(9, "0")) || 0;
+      return { ${maybeTypeField} seconds: ${toSeconds('seconds')}, nanos };
+    }
+  `;
+
+  // Formats the canonical JSON form, with 0, 3, 6 or 9 fractional digits like the reference implementations
+  const format = (name: string) => code`
+    function ${name}(d: ${Duration}): string {
+      const seconds = ${fromSeconds};
+      const sign = seconds < 0 || d.nanos < 0 ? "-" : "";
+      let fraction = "";
+      if (d.nanos !== 0) {
+        fraction = "." + Math.abs(d.nanos).toString().padStart(9, "0");
+        fraction = fraction.replace(/000000$/, "").replace(/000$/, "");
+      }
+      return sign + Math.abs(seconds) + fraction + "s";
+    }
+  `;
+
+  const parseDuration = conditionalOutput('parseDuration', parse('parseDuration'));
+  const formatDuration = conditionalOutput('formatDuration', format('formatDuration'));
+
+  const toDuration = conditionalOutput(
+    'toDuration',
+    options.useDuration === DurationOption.STRING
+      ? parse('toDuration')
+      : code`
+          function toDuration(value: number): ${Duration} {
+            if (!(Math.abs(value) <= 315_576_000_000)) {
+              throw new ${bytes.globalThis}.Error("Invalid duration " + value);
+            }
+            let seconds = Math.trunc(value) || 0;
+            let nanos = Math.round((value - seconds) * 1_000_000_000) || 0;
+            // Rounding can carry the fraction over into a whole second
+            if (Math.abs(nanos) === 1_000_000_000) {
+              seconds += Math.sign(nanos);
+              nanos = 0;
+            }
+            return { ${maybeTypeField} seconds: ${toSeconds('seconds')}, nanos };
+          }
+        `
+  );
+
+  const fromDuration = conditionalOutput(
+    'fromDuration',
+    options.useDuration === DurationOption.STRING
+      ? format('fromDuration')
+      : code`
+          function fromDuration(d: ${Duration}): number {
+            return ${fromSeconds} + d.nanos / 1_000_000_000;
+          }
+        `
+  );
+
+  const fromJsonDuration = conditionalOutput(
+    'fromJsonDuration',
+    options.useDuration === DurationOption.STRING
+      ? code`
+          function fromJsonDuration(o: any): string {
+            if (typeof o === "string") {
+              return ${fromDuration}(${toDuration}(o));
+            } else {
+              return ${fromDuration}(${durationFromJson}(o));
+            }
+          }
+        `
+      : code`
+          function fromJsonDuration(o: any): number {
+            if (typeof o === "number") {
+              return o;
+            } else if (typeof o === "string") {
+              return ${fromDuration}(${parseDuration}(o));
+            } else {
+              return ${fromDuration}(${durationFromJson}(o));
+            }
+          }
+        `
+  );
+
+  return { toDuration, fromDuration, fromJsonDuration, formatDuration };
+}
+
 function makeComparisonUtils() {
   const isObject = conditionalOutput(
     'isObject',
@@ -643,85 +1239,871 @@ function makeComparisonUtils() {
     }`
   );
 
-  return { isObject, isSet };
-}
+  // With wrapperJsonLenient=true, wrapper fields also accept the `{ "value": 42 }` form of non-conformant producers
+  const unwrapJsonWrapper = conditionalOutput(
+    'unwrapJsonWrapper',
+    code`
+    function unwrapJsonWrapper(value: any): any {
+      return ${isObject}(value) && 'value' in value ? value.value : value;
+    }`
+  );
 
-function makeNiceGrpcServerStreamingMethodResult() {
-  const NiceGrpcServerStreamingMethodResult = conditionalOutput(
-    'ServerStreamingMethodResult',
+  // With deterministicEncode=true, string map keys are sorted by their UTF-8 bytes like libprotobuf does,
+  // which is their code point order (and not the code unit order of `<`, which puts surrogates too early)
+  const compareUtf8 = conditionalOutput(
+    'compareUtf8',
     code`
-      export type ServerStreamingMethodResult<Response> = {
-        [Symbol.asyncIterator](): AsyncIterator<Response, void>;
-      };
-    `
+    function compareUtf8(a: string, b: string): number {
+      const order = (c: number) => (c >= 0xd800 ? (c < 0xe000 ? c + 0x2000 : c - 0x800) : c);
+      for (let i = 0; i < a.length && i < b.length; i++) {
+        if (a.charCodeAt(i) !== b.charCodeAt(i)) {
+          return order(a.charCodeAt(i)) - order(b.charCodeAt(i));
+        }
+      }
+      return a.length - b.length;
+    }`
   );
 
-  return { NiceGrpcServerStreamingMethodResult };
-}
+  // For the round-trip self-tests, bytes are equal by their contents and Longs by their bits, while properties that
+  // are undefined are the same as missing ones, i.e. for unset oneof members.
+  const roundTripEquals = conditionalOutput(
+    'roundTripEquals',
+    code`
+    function roundTripEquals(a: any, b: any): boolean {
+      if (a === b || (a !== a && b !== b)) {
+        return true;
+      } else if (typeof a !== 'object' || typeof b !== 'object' || a === null || b === null) {
+        return false;
+      } else if (ArrayBuffer.isView(a) && ArrayBuffer.isView(b)) {
+        const x = new Uint8Array(a.buffer, a.byteOffset, a.byteLength);
+        const y = new Uint8Array(b.buffer, b.byteOffset, b.byteLength);
+        return x.length === y.length && x.every((v, i) => v === y[i]);
+      } else if (a instanceof Date && b instanceof Date) {
+        return a.getTime() === b.getTime();
+      } else if (typeof a.low === 'number' && typeof a.high === 'number') {
+        return a.low === b.low && a.high === b.high;
+      } else if (a instanceof Map && b instanceof Map) {
+        // Keys that are Longs aren't found by \`has\`, so the entries are matched by their values
+        const entries = [...b];
+        const has = ([k, v]: [any, any]) =>
+          entries.some(([k2, v2]) => roundTripEquals(k, k2) && roundTripEquals(v, v2));
+        return a.size === b.size && [...a].every(has);
+      } else if (Array.isArray(a) !== Array.isArray(b)) {
+        return false;
+      }
+      const keys = new Set([...Object.keys(a), ...Object.keys(b)]);
+      return [...keys].every((key) => key === '_unknownFields' || roundTripEquals(a[key], b[key]));
+    }`
+  );
 
-// Create the interface with properties
-function generateInterfaceDeclaration(
-  ctx: Context,
-  fullName: string,
-  messageDesc: DescriptorProto,
-  sourceInfo: SourceInfo,
-  fullTypeName: string
-): Code {
-  const { options } = ctx;
-  const chunks: Code[] = [];
+  return { isObject, isSet, unwrapJsonWrapper, compareUtf8, roundTripEquals };
+}
 
-  maybeAddComment(sourceInfo, chunks, messageDesc.options?.deprecated);
-  // interface name should be defined to avoid import collisions
-  chunks.push(code`export interface ${def(fullName)} {`);
+function makeDebugStringUtils() {
+  const debugString = conditionalOutput(
+    'debugString',
+    code`
+    function debugString(value: any, maxLength?: number): string {
+      if (typeof value === 'string') {
+        const truncated = maxLength !== undefined && value.length > maxLength;
+        return JSON.stringify(truncated ? value.slice(0, maxLength) : value) + (truncated ? '...' : '');
+      } else if (value instanceof Uint8Array) {
+        return \`bytes[\${value.length}]\`;
+      } else if (value instanceof Date) {
+        return value.toISOString();
+      } else if (Array.isArray(value)) {
+        const shown = value.slice(0, 10).map((v) => debugString(v, maxLength));
+        if (value.length > 10) {
+          shown.push(\`... \${value.length - 10} more\`);
+        }
+        return '[' + shown.join(', ') + ']';
+      } else if (typeof value === 'object' && value !== null && value.toString === Object.prototype.toString) {
+        const parts = Object.entries(value)
+          .filter(([_, v]) => v !== undefined)
+          .map(([k, v]) => k + '=' + debugString(v, maxLength));
+        return '{' + parts.join(', ') + '}';
+      }
+      // Numbers, booleans and anything with its own toString, i.e. Long and ObjectId
+      return String(value);
+    }`
+  );
 
-  if (ctx.options.outputTypeRegistry) {
-    chunks.push(code`$type: '${fullTypeName}',`);
-  }
+  return { debugString };
+}
 
-  // When oneof=unions, we generate a single property with an ADT per `oneof` clause.
-  const processedOneofs = new Set<number>();
+function makeReflectionUtils(options: Options) {
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
 
-  messageDesc.field.forEach((fieldDesc, index) => {
-    if (isWithinOneOfThatShouldBeUnion(options, fieldDesc)) {
-      const { oneofIndex } = fieldDesc;
-      if (!processedOneofs.has(oneofIndex)) {
-        processedOneofs.add(oneofIndex);
-        chunks.push(generateOneofProperty(ctx, messageDesc, oneofIndex, sourceInfo));
+  const ReflectionField = conditionalOutput(
+    'ReflectionField',
+    code`
+      ${maybeExport} interface ReflectionField {
+        /** The property of the field in the message's interface. */
+        name: string;
+        number: number;
+        type:
+          | 'double' | 'float' | 'int64' | 'uint64' | 'int32' | 'fixed64' | 'fixed32' | 'bool' | 'string'
+          | 'message' | 'bytes' | 'uint32' | 'enum' | 'sfixed32' | 'sfixed64' | 'sint32' | 'sint64';
+        repeated: boolean;
+        /** The key type of a map field, whose other properties describe its values. */
+        mapKey?: ReflectionField['type'];
+        /** The property of the oneof=unions union that the field is a member of. */
+        oneof?: string;
+        /** The fields of the message type, which are looked up lazily as messages may refer to each other. */
+        messageType?: () => ReflectionField[];
+        enumValues?: Array<{ name: string; number: number }>;
       }
-      return;
-    }
+    `
+  );
 
-    const info = sourceInfo.lookup(Fields.message.field, index);
-    maybeAddComment(info, chunks, fieldDesc.options?.deprecated);
+  return { ReflectionField };
+}
 
-    const name = maybeSnakeToCamel(fieldDesc.name, options);
-    const type = toTypeName(ctx, messageDesc, fieldDesc);
-    const q = isOptionalProperty(fieldDesc, messageDesc.options, options) ? '?' : '';
-    chunks.push(code`${name}${q}: ${type}, `);
-  });
+function makeComparatorUtils() {
+  const compareSortKey = conditionalOutput(
+    'compareSortKey',
+    code`
+      function compareSortKey<T>(
+        a: T | null | undefined,
+        b: T | null | undefined,
+        compare: (a: T, b: T) => number,
+      ): number {
+        // Unset keys sort before set ones
+        if (a === undefined || a === null || b === undefined || b === null) {
+          return Number(a !== undefined && a !== null) - Number(b !== undefined && b !== null);
+        }
+        return compare(a, b);
+      }
+    `
+  );
 
-  chunks.push(code`}`);
-  return joinCode(chunks, { on: '\n' });
+  return { compareSortKey };
 }
 
-function generateOneofProperty(
-  ctx: Context,
-  messageDesc: DescriptorProto,
-  oneofIndex: number,
-  sourceInfo: SourceInfo
-): Code {
+function makeEventBusUtils(options: Options) {
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
+
+  const Bus = conditionalOutput(
+    'Bus',
+    code`
+      ${maybeExport} interface Bus {
+        /** Sends the bytes of a message to the subscribers of the topic. */
+        publish(topic: string, data: Uint8Array): void | Promise<void>;
+        /** Calls the handler with the bytes of each message on the topic, until the returned function is called. */
+        subscribe(topic: string, handler: (data: Uint8Array) => void): () => void;
+      }
+    `
+  );
+
+  return { Bus };
+}
+
+function makeFormDataUtils() {
+  // The JSON of a field as a form value, i.e. strings (including enum names and 64-bit numbers) as they are
+  const formDataValue = conditionalOutput(
+    'formDataValue',
+    code`
+    function formDataValue(value: unknown): string {
+      return typeof value === 'string' ? value : JSON.stringify(value);
+    }`
+  );
+
+  return { formDataValue };
+}
+
+function makeStreamUtils(bytes: ReturnType<typeof makeByteUtils>) {
+  // Splits a stream of arbitrarily chunked bytes into the messages of its varint length-prefixed frames
+  const readDelimited = conditionalOutput(
+    'readDelimited',
+    code`
+    async function* readDelimited(source: AsyncIterable<Uint8Array>): AsyncIterable<Uint8Array> {
+      let buffer = new Uint8Array(0);
+      for await (const chunk of source) {
+        if (buffer.length === 0) {
+          buffer = chunk;
+        } else {
+          const joined = new Uint8Array(buffer.length + chunk.length);
+          joined.set(buffer);
+          joined.set(chunk, buffer.length);
+          buffer = joined;
+        }
+        let pos = 0;
+        while (pos < buffer.length) {
+          // The length prefix itself may be split across chunks, in which case we wait for the next one
+          let length = 0;
+          let end = pos;
+          let complete = false;
+          while (end < buffer.length && !complete) {
+            const b = buffer[end];
+            if (end - pos === 5) {
+              throw new ${bytes.globalThis}.Error("Invalid length prefix, it's longer than 5 bytes");
+            }
+            length += (b & 0x7f) * 2 ** (7 * (end - pos));
+            complete = (b & 0x80) === 0;
+            end++;
+          }
+          if (!complete || end + length > buffer.length) {
+            break;
+          }
+          yield buffer.subarray(end, end + length);
+          pos = end + length;
+        }
+        buffer = buffer.subarray(pos);
+      }
+      if (buffer.length > 0) {
+        throw new ${bytes.globalThis}.Error(
+          "Stream ended within a length-delimited message, with " + buffer.length + " bytes of it left"
+        );
+      }
+    }`
+  );
+
+  return { readDelimited };
+}
+
+function makeDecodeLimitUtils(options: Options, bytes: ReturnType<typeof makeByteUtils>) {
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
+
+  const DecodeLimits = conditionalOutput(
+    'DecodeLimits',
+    code`
+      ${maybeExport} interface DecodeLimits {
+        /** The maximum number of nested messages, where the top-level message is at depth 0. */
+        maxDepth?: number;
+        /** The maximum number of bytes that the top-level message may span. */
+        maxBytes?: number;
+      }
+    `
+  );
+
+  const checkDecodeLimits = conditionalOutput(
+    'checkDecodeLimits',
+    code`
+      function checkDecodeLimits(length: number, limits: ${DecodeLimits} | undefined, depth: number): void {
+        if (limits?.maxDepth !== undefined && depth > limits.maxDepth) {
+          throw new ${bytes.globalThis}.Error("Message exceeds the maximum nesting depth of " + limits.maxDepth);
+        }
+        // Nested messages are within the top-level message's bytes, so it's enough to check it once
+        if (depth === 0 && limits?.maxBytes !== undefined && length > limits.maxBytes) {
+          throw new ${bytes.globalThis}.Error("Message exceeds the maximum size of " + limits.maxBytes + " bytes");
+        }
+      }
+    `
+  );
+
+  return { DecodeLimits, checkDecodeLimits };
+}
+
+function makeTruncationUtils(options: Options, bytes: ReturnType<typeof makeByteUtils>) {
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
+
+  const TruncatedError = conditionalOutput(
+    'TruncatedError',
+    code`
+      /** Thrown by \`decode\` when the input ends within the message, i.e. when more bytes may complete it. */
+      ${maybeExport} class TruncatedError extends ${bytes.globalThis}.Error {
+        constructor(message: string) {
+          super(message);
+          this.name = "TruncatedError";
+        }
+      }
+    `
+  );
+
+  // protobufjs throws a RangeError whenever a read would go past the end of the buffer, while corrupt input,
+  // like an invalid varint or wire type, fails with a plain Error, which is passed through.
+  const toTruncatedError = conditionalOutput(
+    'toTruncatedError',
+    code`
+      function toTruncatedError(e: unknown): unknown {
+        if (e instanceof RangeError && e.message.startsWith("index out of range")) {
+          return new ${TruncatedError}(e.message);
+        }
+        return e;
+      }
+    `
+  );
+
+  return { TruncatedError, toTruncatedError };
+}
+
+function makeEncodeOptionUtils(options: Options) {
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
+
+  const EncodeOptions = conditionalOutput(
+    'EncodeOptions',
+    code`
+      ${maybeExport} interface EncodeOptions {
+        /** Whether to leave out the fields that have their default value, which is the default, like in protobuf. */
+        skipDefaults?: boolean;
+      }
+    `
+  );
+
+  // The paths below `field` of an `encodeWithMask` mask, i.e. `['name']` for `author.name`, or an empty array
+  // when the whole field is named, and undefined when it isn't masked at all
+  const nestedMaskPaths = conditionalOutput(
+    'nestedMaskPaths',
+    code`
+      function nestedMaskPaths(paths: string[], field: string): string[] | undefined {
+        let nested: string[] | undefined;
+        for (const path of paths) {
+          if (path === field) {
+            return [];
+          } else if (path.startsWith(field + ".")) {
+            nested = [...(nested ?? []), path.slice(field.length + 1)];
+          }
+        }
+        return nested;
+      }
+    `
+  );
+
+  return { EncodeOptions, nestedMaskPaths };
+}
+
+function makeMergeOptionUtils(options: Options) {
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
+
+  const MergeOptions = conditionalOutput(
+    'MergeOptions',
+    code`
+      ${maybeExport} interface MergeOptions {
+        /** Whether repeated fields of the source replace those of the target, which is the default, or are appended. */
+        repeated?: 'append' | 'replace';
+      }
+    `
+  );
+
+  // Struct fields hold plain JSON objects, whose nested objects are merged key by key, while arrays and other
+  // values of the source replace those of the target, like a JSON merge patch where null is set rather than deleted
+  const mergeStruct = conditionalOutput(
+    'mergeStruct',
+    code`
+      function mergeStruct(target: { [key: string]: any }, source: { [key: string]: any }): { [key: string]: any } {
+        const isJsonObject = (value: any) => typeof value === "object" && value !== null && !Array.isArray(value);
+        const result = Object.assign(${emptyObject(options)}, target);
+        for (const [key, value] of Object.entries(source)) {
+          result[key] = isJsonObject(result[key]) && isJsonObject(value) ? mergeStruct(result[key], value) : value;
+        }
+        return result;
+      }
+    `
+  );
+
+  return { MergeOptions, mergeStruct };
+}
+
+function makeCodecUtils(
+  options: Options,
+  deepPartial: ReturnType<typeof makeDeepPartial>,
+  decodeLimits: ReturnType<typeof makeDecodeLimitUtils>,
+  encodeOptions: ReturnType<typeof makeEncodeOptionUtils>,
+  mergeOptions: ReturnType<typeof makeMergeOptionUtils>,
+  textFormat: ReturnType<typeof makeTextFormatUtils>
+) {
+  const Reader = impFile(options, 'Reader@protobufjs/minimal');
+  const Writer = impFile(options, 'Writer@protobufjs/minimal');
+  const { DeepPartial, DeepReadonly, Exact } = deepPartial;
+  const output = options.useReadonlyTypes === 'output' ? code`${DeepReadonly}<T>` : 'T';
+
+  // Mirror the static members we output for each message, so annotating them with `Codec<Foo>` doesn't lose any
+  const members: Code[] = [];
+  if (options.outputTypeRegistry) {
+    members.push(code`$type: string;`);
+  }
+  if (options.outputEncodeMethods) {
+    const message =
+      options.encodeAcceptsPartial && options.outputPartialMethods ? code`${output} | ${DeepPartial}<T>` : output;
+    if (options.outputEncodeOptions) {
+      const { EncodeOptions } = encodeOptions;
+      members.push(
+        code`encode(message: ${message}, writer?: ${Writer} | ${EncodeOptions}, encodeOptions?: ${EncodeOptions}): ${Writer};`
+      );
+    } else {
+      members.push(code`encode(message: ${message}, writer?: ${Writer}): ${Writer};`);
+    }
+    if (options.outputDecodeLimits) {
+      const { DecodeLimits } = decodeLimits;
+      members.push(
+        code`decode(input: ${Reader} | Uint8Array, length?: number | ${DecodeLimits}, limits?: ${DecodeLimits}, depth?: number): ${output};`
+      );
+    } else {
+      members.push(code`decode(input: ${Reader} | Uint8Array, length?: number): ${output};`);
+    }
+    if (options.outputEncodeInto) {
+      members.push(code`encodeInto(message: T, target: Uint8Array, offset?: number): number;`);
+    }
+    if (options.outputTryDecode) {
+      const limits = options.outputDecodeLimits ? code` | ${decodeLimits.DecodeLimits}` : '';
+      members.push(
+        code`tryDecode(input: ${Reader} | Uint8Array, length?: number${limits}): { ok: true; value: ${output} } | { ok: false; error: Error };`
+      );
+    }
+    if (options.outputEncodeExcept) {
+      members.push(code`encodeExcept(message: ${output}, fields: (keyof T)[], writer?: ${Writer}): ${Writer};`);
+    }
+    if (options.outputEncodeWithMask) {
+      members.push(code`encodeWithMask(message: ${output}, paths: string[], writer?: ${Writer}): ${Writer};`);
+    }
+  }
+  if (options.useAsyncIterable) {
+    members.push(code`encodeTransform(source: AsyncIterable<T | T[]> | Iterable<T | T[]>): AsyncIterable<Uint8Array>;`);
+    members.push(
+      code`decodeTransform(source: AsyncIterable<Uint8Array | Uint8Array[]> | Iterable<Uint8Array | Uint8Array[]>): AsyncIterable<${output}>;`
+    );
+  }
+  if (options.outputEncodeMethods && options.outputStreamHelpers) {
+    members.push(code`decodeStream(source: AsyncIterable<Uint8Array>): AsyncIterable<${output}>;`);
+  }
+  if (options.outputJsonMethods) {
+    members.push(code`fromJSON(object: any): T;`);
+    members.push(code`toJSON(message: T): unknown;`);
+    if (options.sensitiveFieldOption !== undefined) {
+      members.push(code`toJSONFull(message: T): unknown;`);
+    }
+  }
+  if (options.outputPartialMethods) {
+    if (options.useExactTypes) {
+      members.push(code`fromPartial<I extends ${Exact}<${DeepPartial}<T>, I>>(object: I): T;`);
+    } else {
+      members.push(code`fromPartial(object: ${DeepPartial}<T>): T;`);
+    }
+  }
+  if (options.outputPartialMethods && options.outputPartialArray) {
+    if (options.useExactTypes) {
+      members.push(code`fromPartialArray<I extends ${Exact}<${DeepPartial}<T>, I>>(items: I[]): T[];`);
+    } else {
+      members.push(code`fromPartialArray(items: ${DeepPartial}<T>[]): T[];`);
+    }
+  }
+  const { MergeOptions } = mergeOptions;
+  if (options.outputMergeMethods && !options.outputPartialMethods) {
+    members.push(code`merge(target: T, source: T, mergeOptions?: ${MergeOptions}): T;`);
+  } else if (options.outputMergeMethods && options.useExactTypes) {
+    members.push(
+      code`merge<I extends ${Exact}<${DeepPartial}<T>, I>>(target: T, partial: I, mergeOptions?: ${MergeOptions}): T;`
+    );
+  } else if (options.outputMergeMethods) {
+    members.push(code`merge(target: T, partial: ${DeepPartial}<T>, mergeOptions?: ${MergeOptions}): T;`);
+  }
+  if (options.outputPatch && options.outputPartialMethods) {
+    members.push(code`patch(message: T, changes: ${DeepPartial}<T>): { next: T; inverse: ${DeepPartial}<T> };`);
+  }
+  if (options.outputFieldMaskMethods) {
+    members.push(code`applyUpdate(existing: T | undefined, update: T | undefined, mask: string[]): T;`);
+    members.push(code`assertMasked(message: T, mask: readonly string[]): Partial<T>;`);
+  }
+  if (options.outputTextFormat) {
+    members.push(code`toTextFormat(message: T, indent?: string): string;`);
+    members.push(code`fromTextFormat(input: string | ${textFormat.TextFormatNode}): T;`);
+  }
+
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
+  const Codec = conditionalOutput(
+    'Codec',
+    code`
+      ${maybeExport} interface Codec<T> {
+        ${joinCode(members, { on: '\n' })}
+      }
+    `
+  );
+
+  return { Codec };
+}
+
+function makeTextFormatUtils(options: Options) {
+  const util = impFile(options, 'util@protobufjs/minimal');
+
+  const textFormatMessage = conditionalOutput(
+    'textFormatMessage',
+    code`
+      function textFormatMessage(indent: string, name: string, body: string): string {
+        return body === '' ? indent + name + ' {}' : indent + name + ' {\\n' + body + '\\n' + indent + '}';
+      }
+    `
+  );
+
+  // Printable ASCII is written as-is, everything else as C-style or octal escapes, like protoc does
+  const textFormatBytes = conditionalOutput(
+    'textFormatBytes',
+    code`
+      function textFormatBytes(b: Uint8Array): string {
+        const escapes: { [byte: number]: string } = {
+          9: '\\\\t', 10: '\\\\n', 13: '\\\\r', 34: '\\\\"', 39: "\\\\'", 92: '\\\\\\\\',
+        };
+        let result = '"';
+        for (let i = 0; i < b.length; i++) {
+          const byte = b[i];
+          if (escapes[byte] !== undefined) {
+            result += escapes[byte];
+          } else if (byte >= 0x20 && byte < 0x7f) {
+            result += String.fromCharCode(byte);
+          } else {
+            result += '\\\\' + ('00' + byte.toString(8)).slice(-3);
+          }
+        }
+        return result + '"';
+      }
+    `
+  );
+
+  // Strings are written as the escaped bytes of their UTF-8 encoding, so the output is always ASCII
+  const textFormatString = conditionalOutput(
+    'textFormatString',
+    code`
+      function textFormatString(s: string): string {
+        const bytes = new Uint8Array(${util}.utf8.length(s));
+        ${util}.utf8.write(s, bytes, 0);
+        return ${textFormatBytes}(bytes);
+      }
+    `
+  );
+
+  const textFormatFloat = conditionalOutput(
+    'textFormatFloat',
+    code`
+      function textFormatFloat(n: number): string {
+        return Number.isNaN(n) ? 'nan' : n === Infinity ? 'inf' : n === -Infinity ? '-inf' : String(n);
+      }
+    `
+  );
+
+  const maybeExport = options.exportCommonSymbols ? 'export' : '';
+  const TextFormatNode = conditionalOutput(
+    'TextFormatNode',
+    code`
+      /** The fields of a parsed text format message, where scalars are kept as their token and strings as bytes. */
+      ${maybeExport} type TextFormatNode = { [name: string]: Array<string | TextFormatNode> };
+    `
+  );
+
+  // A small recursive-descent parser; extensions (`[foo.bar]: ...`) and expanded `Any`s aren't supported
+  const parseTextFormat = conditionalOutput(
+    'parseTextFormat',
+    code`
+      function parseTextFormat(text: string): ${TextFormatNode} {
+        let pos = 0;
+
+        function fail(expected: string): never {
+          throw new Error('Expected ' + expected + ' at position ' + pos + ' of text format input');
+        }
+
+        function skip(): void {
+          while (pos < text.length) {
+            const c = text[pos];
+            if (c === '#') {
+              while (pos < text.length && text[pos] !== '\\n') pos++;
+            } else if (c === ' ' || c === '\\t' || c === '\\r' || c === '\\n' || c === ',' || c === ';') {
+              pos++;
+            } else {
+              break;
+            }
+          }
+        }
+
+        function token(): string {
+          const start = pos;
+          while (pos < text.length && /[\\w.+-]/.test(text[pos])) pos++;
+          return start === pos ? fail('a field name or value') : text.slice(start, pos);
+        }
+
+        function utf8(c: string): string {
+          const bytes = new Uint8Array(${util}.utf8.length(c));
+          ${util}.utf8.write(c, bytes, 0);
+          return String.fromCharCode(...bytes);
+        }
+
+        // Octal and hex escapes are bytes, i.e. of a multi-byte UTF-8 character, so strings are kept as bytes
+        // too, one char per byte, and decoded as UTF-8 once all of their escapes are in place
+        function string(): string {
+          const quote = text[pos++];
+          let result = '';
+          while (text[pos] !== quote) {
+            if (pos >= text.length) fail(quote);
+            const c = String.fromCodePoint(text.codePointAt(pos)!);
+            pos += c.length;
+            if (c !== '\\\\') {
+              result += utf8(c);
+              continue;
+            }
+            const e = text[pos++];
+            if (e >= '0' && e <= '7') {
+              let octal = e;
+              while (octal.length < 3 && text[pos] >= '0' && text[pos] <= '7') octal += text[pos++];
+              result += String.fromCharCode(parseInt(octal, 8));
+            } else if (e === 'x') {
+              result += String.fromCharCode(parseInt(text.slice(pos, pos + 2), 16));
+              pos += 2;
+            } else if (e === 'u' || e === 'U') {
+              // Unicode escapes are characters rather than bytes
+              const digits = e === 'u' ? 4 : 8;
+              result += utf8(String.fromCodePoint(parseInt(text.slice(pos, pos + digits), 16)));
+              pos += digits;
+            } else {
+              const escapes: { [e: string]: string } = { n: '\\n', r: '\\r', t: '\\t', b: '\\b', f: '\\f', v: '\\v', a: '\\x07' };
+              result += escapes[e] || e;
+            }
+          }
+          pos++;
+          return result;
+        }
+
+        function value(): string | ${TextFormatNode} {
+          const c = text[pos];
+          if (c === '{' || c === '<') {
+            pos++;
+            const close = c === '{' ? '}' : '>';
+            const node = message(close);
+            if (text[pos++] !== close) fail(close);
+            return node;
+          } else if (c === '"' || c === "'") {
+            // Adjacent string literals are concatenated
+            let result = string();
+            for (skip(); text[pos] === '"' || text[pos] === "'"; skip()) result += string();
+            return result;
+          }
+          return token();
+        }
+
+        function message(close: string): ${TextFormatNode} {
+          const node: ${TextFormatNode} = {};
+          for (skip(); pos < text.length && text[pos] !== close; skip()) {
+            const name = token();
+            const values = node[name] || (node[name] = []);
+            skip();
+            if (text[pos] === ':') {
+              pos++;
+              skip();
+            }
+            if (text[pos] === '[') {
+              // The short form of repeated fields, i.e. \`name: [1, 2, 3]\`
+              for (pos++, skip(); text[pos] !== ']'; skip()) {
+                if (pos >= text.length) fail(']');
+                values.push(value());
+              }
+              pos++;
+            } else {
+              values.push(value());
+            }
+          }
+          return node;
+        }
+
+        return message('');
+      }
+    `
+  );
+
+  const textFormatNumber = conditionalOutput(
+    'textFormatNumber',
+    code`
+      function textFormatNumber(token: string): number {
+        const t = token.toLowerCase();
+        if (t === 'inf' || t === 'infinity' || t === '+inf' || t === '+infinity') return Infinity;
+        if (t === '-inf' || t === '-infinity') return -Infinity;
+        if (t === 'nan') return NaN;
+        // Floats may have an \`f\` suffix, e.g. \`1.5f\`
+        return Number(/^[+-]?0x/.test(t) ? t : t.replace(/f$/, ''));
+      }
+    `
+  );
+
+  const textFormatBool = conditionalOutput(
+    'textFormatBool',
+    code`
+      function textFormatBool(token: string): boolean {
+        return token === 'true' || token === 'True' || token === 't' || token === '1';
+      }
+    `
+  );
+
+  const bytesFromTextFormat = conditionalOutput(
+    'bytesFromTextFormat',
+    code`
+      function bytesFromTextFormat(s: string): Uint8Array {
+        const bytes = new Uint8Array(s.length);
+        for (let i = 0; i < s.length; i++) {
+          bytes[i] = s.charCodeAt(i);
+        }
+        return bytes;
+      }
+    `
+  );
+
+  const stringFromTextFormat = conditionalOutput(
+    'stringFromTextFormat',
+    code`
+      function stringFromTextFormat(s: string): string {
+        const bytes = ${bytesFromTextFormat}(s);
+        return ${util}.utf8.read(bytes, 0, bytes.length);
+      }
+    `
+  );
+
+  return {
+    textFormatMessage,
+    textFormatBytes,
+    textFormatString,
+    textFormatFloat,
+    TextFormatNode,
+    parseTextFormat,
+    textFormatNumber,
+    textFormatBool,
+    bytesFromTextFormat,
+    stringFromTextFormat,
+  };
+}
+
+function makeNiceGrpcServerStreamingMethodResult() {
+  const NiceGrpcServerStreamingMethodResult = conditionalOutput(
+    'ServerStreamingMethodResult',
+    code`
+      export type ServerStreamingMethodResult<Response> = {
+        [Symbol.asyncIterator](): AsyncIterator<Response, void>;
+      };
+    `
+  );
+
+  return { NiceGrpcServerStreamingMethodResult };
+}
+
+/**
+ * Creates the `type UserId = string & { readonly __brand: 'UserId' }` declarations of the `brandedTypes` of
+ * `messageDesc`'s fields, skipping the ones in `declared`, i.e. already declared for other messages of the file.
+ */
+function generateBrandedTypes(ctx: Context, messageDesc: DescriptorProto, declared: Map<string, string>): Code[] {
+  const chunks: Code[] = [];
+  for (const field of messageDesc.field) {
+    const brand = brandedTypeName(ctx, messageDesc, field);
+    if (!brand) {
+      continue;
+    }
+    const type = basicTypeName(ctx, field);
+    const existing = declared.get(brand);
+    if (existing !== undefined && existing !== type.toCodeString()) {
+      throw new Error(`brandedTypes: ${brand} is used for both ${existing} and ${type.toCodeString()} fields`);
+    } else if (existing === undefined) {
+      declared.set(brand, type.toCodeString());
+      chunks.push(code`export type ${def(brand)} = ${type} & { readonly __brand: '${brand}' };`);
+    }
+  }
+  return chunks;
+}
+
+// Create the interface with properties
+function generateInterfaceDeclaration(
+  ctx: Context,
+  fullName: string,
+  messageDesc: DescriptorProto,
+  sourceInfo: SourceInfo,
+  fullTypeName: string
+): Code {
+  const { options } = ctx;
+  const chunks: Code[] = [];
+
+  maybeAddComment(sourceInfo, chunks, messageDesc.options?.deprecated);
+  // interface name should be defined to avoid import collisions
+  chunks.push(code`export interface ${def(fullName)} {`);
+
+  if (ctx.options.outputTypeRegistry) {
+    chunks.push(code`$type: '${fullTypeName}',`);
+  }
+
+  // When oneof=unions, we generate a single property with an ADT per `oneof` clause.
+  const processedOneofs = new Set<number>();
+
+  messageDesc.field.forEach((fieldDesc, index) => {
+    if (isWithinOneOfThatShouldBeUnion(options, fieldDesc)) {
+      const { oneofIndex } = fieldDesc;
+      if (!processedOneofs.has(oneofIndex)) {
+        processedOneofs.add(oneofIndex);
+        chunks.push(generateOneofProperty(ctx, fullName, messageDesc, oneofIndex, sourceInfo));
+      }
+      return;
+    }
+
+    const info = sourceInfo.lookup(Fields.message.field, index);
+    maybeAddComment(info, chunks, fieldDesc.options?.deprecated);
+
+    const name = maybeSnakeToCamel(fieldDesc.name, options);
+    const type = toTypeName(ctx, messageDesc, fieldDesc);
+    const q = isOptionalProperty(fieldDesc, messageDesc.options, options) ? '?' : '';
+    chunks.push(code`${name}${q}: ${type}, `);
+    if (hasRawTimestamp(fieldDesc, options)) {
+      const rawType = basicTypeName(ctx, fieldDesc, { keepValueType: true });
+      chunks.push(code`${name}Raw?: ${rawType} | undefined, `);
+    }
+  });
+
+  chunks.push(code`}`);
+
+  // With oneof=named-unions, each branch of each oneof union gets its own interface after the message's
+  if (options.oneof === OneofOption.NAMED_UNIONS) {
+    processedOneofs.forEach((oneofIndex) => {
+      chunks.push(generateNamedOneofUnion(ctx, fullName, messageDesc, oneofIndex));
+    });
+  }
+  return joinCode(chunks, { on: '\n' });
+}
+
+/**
+ * Creates the `FooPayload` union of a oneof `payload` of `Foo` for oneof=named-unions, i.e. one
+ * `FooPayloadBar` interface for each member `bar`, with a `kind: 'bar'` discriminant.
+ */
+function generateNamedOneofUnion(
+  ctx: Context,
+  fullName: string,
+  messageDesc: DescriptorProto,
+  oneofIndex: number
+): Code {
   const { options } = ctx;
-  const fields = messageDesc.field.filter((field) => isWithinOneOf(field) && field.oneofIndex === oneofIndex);
+  const unionName = `${fullName}${capitalize(maybeSnakeToCamel(messageDesc.oneofDecl[oneofIndex].name, options))}`;
+  const branches = oneofMembers(messageDesc, oneofIndex).map((f) => {
+    const fieldName = maybeSnakeToCamel(f.name, options);
+    if (fieldName === 'kind') {
+      throw new Error(
+        `oneof=named-unions cannot generate ${fullName}.${fieldName}, as it clashes with the kind discriminant`
+      );
+    }
+    return { name: `${unionName}${capitalize(fieldName)}`, fieldName, type: toTypeName(ctx, messageDesc, f) };
+  });
+
+  const chunks: Code[] = [];
+  chunks.push(code`export type ${def(unionName)} = ${branches.map((b) => b.name).join(' | ')};`);
+  branches.forEach(({ name, fieldName, type }) => {
+    chunks.push(code`
+      export interface ${def(name)} {
+        kind: '${fieldName}';
+        ${fieldName}: ${type};
+      }
+    `);
+  });
+  return joinCode(chunks, { on: '\n\n' });
+}
+
+function generateOneofProperty(
+  ctx: Context,
+  fullName: string,
+  messageDesc: DescriptorProto,
+  oneofIndex: number,
+  sourceInfo: SourceInfo
+): Code {
+  const { options } = ctx;
+  const fields = oneofMembers(messageDesc, oneofIndex);
+  const name = maybeSnakeToCamel(messageDesc.oneofDecl[oneofIndex].name, options);
+  if (options.oneof === OneofOption.NAMED_UNIONS) {
+    return code`${name}?: ${fullName}${capitalize(name)},`;
+  }
+
   const unionType = joinCode(
     fields.map((f) => {
       let fieldName = maybeSnakeToCamel(f.name, options);
       let typeName = toTypeName(ctx, messageDesc, f);
-      return code`{ $case: '${fieldName}', ${fieldName}: ${typeName} }`;
+      return code`{ $case: '${fieldName}', ${oneofValueName(fieldName, options)}: ${typeName} }`;
     }),
     { on: ' | ' }
   );
-
-  const name = maybeSnakeToCamel(messageDesc.oneofDecl[oneofIndex].name, options);
   return code`${name}?: ${unionType},`;
 
   /*
@@ -771,15 +2153,20 @@ function generateBaseInstanceFactory(
     }
 
     const name = maybeSnakeToCamel(field.name, ctx.options);
-    const val = isWithinOneOf(field)
-      ? 'undefined'
-      : isMapType(ctx, messageDesc, field)
-      ? '{}'
-      : isRepeated(field)
-      ? '[]'
-      : defaultValue(ctx, field);
+    const isUnsetRepeated = isRepeated(field) && ctx.options.emptyRepeated === 'undefined';
+    const val =
+      isWithinOneOf(field) || isUnsetRepeated
+        ? 'undefined'
+        : isMapType(ctx, messageDesc, field)
+        ? emptyMapValue(ctx.options)
+        : isRepeated(field)
+        ? '[]'
+        : brandedValue(ctx, messageDesc, field, defaultValue(ctx, field));
 
     fields.push(code`${name}: ${val}`);
+    if (hasRawTimestamp(field, ctx.options)) {
+      fields.push(code`${name}Raw: undefined`);
+    }
   }
 
   if (ctx.options.outputTypeRegistry) {
@@ -788,11 +2175,86 @@ function generateBaseInstanceFactory(
 
   return code`
     function createBase${fullName}(): ${fullName} {
-      return { ${joinCode(fields, { on: ',' })} };
+      return ${maybeNullPrototype(ctx.options, code`{ ${joinCode(fields, { on: ',' })} }`)};
     }
   `;
 }
 
+/**
+ * Returns the code that reads a single value of `field` from `reader`, i.e. a `reader.doSomething` call
+ * that is specific to the basic type, or the `decode` of the field's message.
+ */
+function generateReadSnippet(
+  ctx: Context,
+  messageDesc: DescriptorProto,
+  field: FieldDescriptorProto,
+  nestedDecodeArgs: string
+): Code {
+  const { options, utils } = ctx;
+  let readSnippet: Code;
+  if (isPrimitive(field)) {
+    readSnippet = code`reader.${toReaderCall(field)}()`;
+    if (isBytes(field)) {
+      if (options.bytesAs === BytesOption.BASE64_STRING) {
+        readSnippet = code`${utils.base64FromBytes}(${readSnippet})`;
+      } else if (options.env === EnvOption.NODE && isBufferBytes(options)) {
+        readSnippet = code`${readSnippet} as Buffer`;
+      } else if (isBufferBytes(options)) {
+        // protobufjs only reads Buffers out of Buffer inputs
+        readSnippet = code`Buffer.from(${readSnippet})`;
+      }
+    } else if (basicLongWireType(field.type) !== undefined) {
+      if (longOption(field, options) === LongOption.LONG) {
+        readSnippet = code`${readSnippet} as Long`;
+      } else if (longOption(field, options) === LongOption.STRING) {
+        readSnippet = code`${utils.longToString}(${readSnippet} as Long)`;
+      } else {
+        readSnippet = code`${utils.longToNumber}(${readSnippet} as Long)`;
+      }
+    } else if (isEnum(field)) {
+      // With outputEnumExhaustive, values that aren't in the enum are decoded as UNRECOGNIZED, like in fromJSON
+      if (options.stringEnums || options.outputEnumExhaustive) {
+        const fromJson = getEnumMethod(ctx, field.typeName, 'FromJSON');
+        readSnippet = code`${fromJson}(${readSnippet})`;
+      } else {
+        readSnippet = code`${readSnippet} as any`;
+      }
+    }
+    readSnippet = brandedValue(ctx, messageDesc, field, readSnippet);
+  } else if (isValueType(ctx, field)) {
+    const unwrap = (decodedValue: any): Code => {
+      if (isListValueType(field) || isStructType(field) || isAnyValueType(field) || isFieldMaskType(field)) {
+        return code`${getMessageMethod(ctx, field.typeName, 'unwrap')}(${decodedValue})`;
+      }
+      return code`${decodedValue}.value`;
+    };
+    readSnippet = code`${unwrap(nestedDecode(ctx, field.typeName, nestedDecodeArgs))}`;
+  } else if (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) {
+    readSnippet = code`${utils.fromTimestamp}(${nestedDecode(ctx, field.typeName, nestedDecodeArgs)})`;
+  } else if (isNativeDuration(field, options)) {
+    readSnippet = code`${utils.fromDuration}(${nestedDecode(ctx, field.typeName, nestedDecodeArgs)})`;
+  } else if (isObjectId(field) && options.useMongoObjectId) {
+    readSnippet = code`${utils.fromProtoObjectId}(${nestedDecode(ctx, field.typeName, nestedDecodeArgs)})`;
+  } else if (isMessage(field)) {
+    readSnippet = nestedDecode(ctx, field.typeName, nestedDecodeArgs);
+  } else {
+    throw new Error(`Unhandled field ${field}`);
+  }
+  return readSnippet;
+}
+
+/**
+ * Returns the `decode` call of a sub-message of type `typeName`, which with useReadonlyTypes=output is cast from
+ * its readonly return type back to the mutable type, as the outer message is still being built up.
+ */
+function nestedDecode(ctx: Context, typeName: string, nestedDecodeArgs: string): Code {
+  const decode = code`${getMessageMethod(ctx, typeName, 'decode')}(${nestedDecodeArgs})`;
+  if (ctx.options.useReadonlyTypes === 'output') {
+    return code`(${decode} as ${messageToTypeName(ctx, typeName, { keepValueType: true })})`;
+  }
+  return decode;
+}
+
 /** Creates a function to decode a message by loop overing the tags. */
 function generateDecode(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
   const { options, utils, typeMap } = ctx;
@@ -804,92 +2266,102 @@ function generateDecode(ctx: Context, fullName: string, messageDesc: DescriptorP
   }
 
   const Reader = impFile(ctx.options, 'Reader@protobufjs/minimal');
+  const output = options.useReadonlyTypes === 'output' ? code`${utils.DeepReadonly}<${fullName}>` : fullName;
 
   // create the basic function declaration
-  chunks.push(code`
-    decode(
-      input: ${Reader} | Uint8Array,
-      length?: number,
-    ): ${fullName} {
-      const reader = input instanceof ${Reader} ? input : new ${Reader}(input);
-      let end = length === undefined ? reader.len : reader.pos + length;
-      const message = ${createBase};
-  `);
+  if (options.outputDecodeLimits) {
+    // Top-level callers can pass the limits in place of the length, i.e. `Foo.decode(bytes, { maxDepth: 32 })`,
+    // while nested messages get the length, the limits, and their depth.
+    chunks.push(code`
+      ${messageMethodHead(options, fullName, 'decode')}(
+        input: ${Reader} | Uint8Array,
+        length?: number | ${utils.DecodeLimits},
+        limits?: ${utils.DecodeLimits},
+        depth: number = 0,
+      ): ${output} {
+        if (typeof length === "object") {
+          limits = length;
+          length = undefined;
+        }
+        const reader = input instanceof ${Reader} ? input : new ${Reader}(input);
+        let end = length === undefined ? reader.len : reader.pos + length;
+        ${utils.checkDecodeLimits}(end - reader.pos, limits, depth);
+        const message = ${createBase};
+    `);
+  } else {
+    chunks.push(code`
+      ${messageMethodHead(options, fullName, 'decode')}(
+        input: ${Reader} | Uint8Array,
+        length?: number,
+      ): ${output} {
+        const reader = input instanceof ${Reader} ? input : new ${Reader}(input);
+        let end = length === undefined ? reader.len : reader.pos + length;
+        const message = ${createBase};
+    `);
+  }
 
   if (options.unknownFields) {
     chunks.push(code`(message as any)._unknownFields = {}`);
   }
 
-  // start the tag loop
+  // start the tag loop, within a try when reads past the end of the buffer are rethrown as TruncatedErrors
+  if (options.outputTruncationDetection) {
+    chunks.push(code`try {`);
+  }
   chunks.push(code`
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
   `);
 
+  // Nested messages are decoded with the same limits, one level deeper
+  const nestedDecodeArgs = options.outputDecodeLimits
+    ? 'reader, reader.uint32(), limits, depth + 1'
+    : 'reader, reader.uint32()';
+
   // add a case for each incoming field
   messageDesc.field.forEach((field) => {
     const fieldName = maybeSnakeToCamel(field.name, options);
-    chunks.push(code`case ${field.number}:`);
-
-    // get a generic 'reader.doSomething' bit that is specific to the basic type
-    let readSnippet: Code;
-    if (isPrimitive(field)) {
-      readSnippet = code`reader.${toReaderCall(field)}()`;
-      if (isBytes(field)) {
-        if (options.env === EnvOption.NODE) {
-          readSnippet = code`${readSnippet} as Buffer`;
-        }
-      } else if (basicLongWireType(field.type) !== undefined) {
-        if (options.forceLong === LongOption.LONG) {
-          readSnippet = code`${readSnippet} as Long`;
-        } else if (options.forceLong === LongOption.STRING) {
-          readSnippet = code`${utils.longToString}(${readSnippet} as Long)`;
-        } else {
-          readSnippet = code`${utils.longToNumber}(${readSnippet} as Long)`;
-        }
-      } else if (isEnum(field)) {
-        if (options.stringEnums) {
-          const fromJson = getEnumMethod(ctx, field.typeName, 'FromJSON');
-          readSnippet = code`${fromJson}(${readSnippet})`;
-        } else {
-          readSnippet = code`${readSnippet} as any`;
-        }
-      }
-    } else if (isValueType(ctx, field)) {
-      const type = basicTypeName(ctx, field, { keepValueType: true });
-      const unwrap = (decodedValue: any): Code => {
-        if (isListValueType(field) || isStructType(field) || isAnyValueType(field) || isFieldMaskType(field)) {
-          return code`${type}.unwrap(${decodedValue})`;
+    chunks.push(code`case ${field.number}:`);
+
+    if (options.decodeStrictWireType) {
+      // Repeated scalars can also arrive packed, i.e. length-delimited
+      const wireType = isPrimitive(field) ? basicWireType(field.type) : 2;
+      const isPackable = isRepeated(field) && packedType(field.type) !== undefined;
+      const mismatch = isPackable ? `(tag & 7) !== ${wireType} && (tag & 7) !== 2` : `(tag & 7) !== ${wireType}`;
+      chunks.push(code`
+        if (${mismatch}) {
+          throw new Error(\`Invalid wire type \${tag & 7} for field ${field.name} of ${fullName}\`);
         }
-        return code`${decodedValue}.value`;
-      };
-      const decoder = code`${type}.decode(reader, reader.uint32())`;
-      readSnippet = code`${unwrap(decoder)}`;
-    } else if (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) {
-      const type = basicTypeName(ctx, field, { keepValueType: true });
-      readSnippet = code`${utils.fromTimestamp}(${type}.decode(reader, reader.uint32()))`;
-    } else if (isObjectId(field) && options.useMongoObjectId) {
-      const type = basicTypeName(ctx, field, { keepValueType: true });
-      readSnippet = code`${utils.fromProtoObjectId}(${type}.decode(reader, reader.uint32()))`;
-    } else if (isMessage(field)) {
-      const type = basicTypeName(ctx, field);
-      readSnippet = code`${type}.decode(reader, reader.uint32())`;
-    } else {
-      throw new Error(`Unhandled field ${field}`);
+      `);
     }
 
+    // get a generic 'reader.doSomething' bit that is specific to the basic type
+    const readSnippet = generateReadSnippet(ctx, messageDesc, field, nestedDecodeArgs);
+
     // and then use the snippet to handle repeated fields if necessary
     if (isRepeated(field)) {
-      const maybeNonNullAssertion = ctx.options.useOptionals === 'all' ? '!' : '';
+      const maybeNonNullAssertion =
+        ctx.options.useOptionals === 'all' || ctx.options.emptyRepeated === 'undefined' ? '!' : '';
+
+      if (options.emptyRepeated === 'undefined') {
+        // Unset repeated fields start out as undefined, so create the collection on the first value
+        const empty = isMapType(ctx, messageDesc, field) ? emptyMapValue(options) : '[]';
+        chunks.push(code`
+          if (message.${fieldName} === undefined) {
+            message.${fieldName} = ${empty};
+          }
+        `);
+      }
 
       if (isMapType(ctx, messageDesc, field)) {
         // We need a unique const within the `cast` statement
         const varName = `entry${field.number}`;
+        const map = `message.${fieldName}${maybeNonNullAssertion}`;
         chunks.push(code`
           const ${varName} = ${readSnippet};
           if (${varName}.value !== undefined) {
-            message.${fieldName}${maybeNonNullAssertion}[${varName}.key] = ${varName}.value;
+            ${mapSetSnippet(options, map, `${varName}.key`, `${varName}.value`)};
           }
         `);
       } else if (packedType(field.type) === undefined) {
@@ -908,7 +2380,14 @@ function generateDecode(ctx: Context, fullName: string, messageDesc: DescriptorP
       }
     } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
       let oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
-      chunks.push(code`message.${oneofName} = { $case: '${fieldName}', ${fieldName}: ${readSnippet} };`);
+      const valueName = oneofValueName(fieldName, options);
+      const caseName = oneofCaseName(options);
+      chunks.push(code`message.${oneofName} = { ${caseName}: '${fieldName}', ${valueName}: ${readSnippet} };`);
+    } else if (hasRawTimestamp(field, options)) {
+      chunks.push(code`
+        message.${fieldName}Raw = ${nestedDecode(ctx, field.typeName, nestedDecodeArgs)};
+        message.${fieldName} = ${utils.fromTimestamp}(message.${fieldName}Raw);
+      `);
     } else {
       chunks.push(code`message.${fieldName} = ${readSnippet};`);
     }
@@ -924,8 +2403,10 @@ function generateDecode(ctx: Context, fullName: string, messageDesc: DescriptorP
         break;
     `);
   } else {
+    const warning = `{ kind: "unknownField", type: "${fullName}", fieldNumber: tag >>> 3, wireType: tag & 7 }`;
     chunks.push(code`
       default:
+        ${maybeWarningHookCall(options, warning)}
         reader.skipType(tag & 7);
         break;
     `);
@@ -934,70 +2415,276 @@ function generateDecode(ctx: Context, fullName: string, messageDesc: DescriptorP
   // and then wrap up the switch/while/return
   chunks.push(code`}`);
   chunks.push(code`}`);
+  if (options.outputTruncationDetection) {
+    chunks.push(code`
+      } catch (e) {
+        throw ${utils.toTruncatedError}(e);
+      }
+    `);
+  }
   chunks.push(code`return message;`);
 
   chunks.push(code`}`);
   return joinCode(chunks, { on: '\n' });
 }
 
+/** Creates a function that writes a single value of `field`, including its tag, to `writer`. */
+function generateWriteSnippet(
+  ctx: Context,
+  field: FieldDescriptorProto,
+  encodeOptions: boolean = false
+): (place: string) => Code {
+  const { options, utils } = ctx;
+  if (isEnum(field) && options.stringEnums) {
+    const tag = ((field.number << 3) | basicWireType(field.type)) >>> 0;
+    const toNumber = getEnumMethod(ctx, field.typeName, 'ToNumber');
+    return (place) => code`writer.uint32(${tag}).${toReaderCall(field)}(${toNumber}(${place}))`;
+  } else if (isBytes(field) && options.bytesAs === BytesOption.BASE64_STRING) {
+    const tag = ((field.number << 3) | basicWireType(field.type)) >>> 0;
+    return (place) => code`writer.uint32(${tag}).bytes(${utils.bytesFromBase64}(${place}))`;
+  } else if (isScalar(field) || isEnum(field)) {
+    const tag = ((field.number << 3) | basicWireType(field.type)) >>> 0;
+    return (place) => code`writer.uint32(${tag}).${toReaderCall(field)}(${place})`;
+  } else if (isObjectId(field) && options.useMongoObjectId) {
+    const tag = ((field.number << 3) | 2) >>> 0;
+    const encode = getMessageMethod(ctx, field.typeName, 'encode');
+    return (place) => code`${encode}(${utils.toProtoObjectId}(${place}), writer.uint32(${tag}).fork()).ldelim()`;
+  } else if (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) {
+    const tag = ((field.number << 3) | 2) >>> 0;
+    const encode = getMessageMethod(ctx, field.typeName, 'encode');
+    return (place) => code`${encode}(${utils.toTimestamp}(${place}), writer.uint32(${tag}).fork()).ldelim()`;
+  } else if (isNativeDuration(field, options)) {
+    const tag = ((field.number << 3) | 2) >>> 0;
+    const encode = getMessageMethod(ctx, field.typeName, 'encode');
+    return (place) => code`${encode}(${utils.toDuration}(${place}), writer.uint32(${tag}).fork()).ldelim()`;
+  } else if (isValueType(ctx, field)) {
+    const maybeTypeField = options.outputTypeRegistry ? `$type: '${field.typeName.slice(1)}',` : '';
+
+    const encode = getMessageMethod(ctx, field.typeName, 'encode');
+    const wrappedValue = (place: string): Code => {
+      if (isAnyValueType(field) || isListValueType(field) || isStructType(field) || isFieldMaskType(field)) {
+        return code`${getMessageMethod(ctx, field.typeName, 'wrap')}(${place})`;
+      }
+      return code`{${maybeTypeField} value: ${place}!}`;
+    };
+
+    const tag = ((field.number << 3) | 2) >>> 0;
+    return (place) => code`${encode}(${wrappedValue(place)}, writer.uint32(${tag}).fork()).ldelim()`;
+  } else if (isMessage(field)) {
+    const tag = ((field.number << 3) | 2) >>> 0;
+    const encode = getMessageMethod(ctx, field.typeName, 'encode');
+    const maybeOptions = encodeOptions ? ', encodeOptions' : '';
+    return (place) => code`${encode}(${place}, writer.uint32(${tag}).fork()${maybeOptions}).ldelim()`;
+  } else {
+    throw new Error(`Unhandled field ${field}`);
+  }
+}
+
+/**
+ * Creates a `writeFieldFoo(writer, value)` function for each field, which appends a single value of `foo`,
+ * including its tag, to `writer`. Repeated fields are written one element (or map entry) per call, unpacked.
+ */
+function generateFieldWriters(ctx: Context, messageDesc: DescriptorProto): Code[] {
+  const { options } = ctx;
+  const Writer = impFile(options, 'Writer@protobufjs/minimal');
+  return messageDesc.field.map((field) => {
+    const name = `writeField${capitalize(maybeSnakeToCamel(field.name, options))}`;
+    const writeSnippet = generateWriteSnippet(ctx, field);
+    const mapType = detectMapType(ctx, messageDesc, field);
+    if (mapType) {
+      const maybeTypeField = options.outputTypeRegistry ? `$type: '${field.typeName.slice(1)}',` : '';
+      return code`
+        ${name}(writer: ${Writer}, key: ${mapType.keyType}, value: ${mapType.valueType}): ${Writer} {
+          return ${writeSnippet(`{ ${maybeTypeField} key: key as any, value }`)};
+        }
+      `;
+    }
+    return code`
+      ${name}(writer: ${Writer}, value: ${basicTypeName(ctx, field)}): ${Writer} {
+        return ${writeSnippet('value')};
+      }
+    `;
+  });
+}
+
+/**
+ * Creates a `decodeFieldFoo(input)` function for each field, which scans the encoded message for the tags of
+ * `foo` and only decodes those, skipping every other field. Non-repeated fields return their last occurrence,
+ * repeated fields and maps collect all of them, and fields that don't occur at all return `undefined`.
+ */
+function generateFieldDecoders(ctx: Context, messageDesc: DescriptorProto): Code[] {
+  const { options } = ctx;
+  const Reader = impFile(options, 'Reader@protobufjs/minimal');
+  return messageDesc.field.map((field) => {
+    const name = `decodeField${capitalize(maybeSnakeToCamel(field.name, options))}`;
+    const readSnippet = generateReadSnippet(ctx, messageDesc, field, 'reader, reader.uint32()');
+    const mapType = detectMapType(ctx, messageDesc, field);
+    const brand = brandedTypeName(ctx, messageDesc, field);
+    const valueType = brand ? code`${brand}` : basicTypeName(ctx, field);
+
+    let type: Code;
+    let read: Code;
+    if (mapType) {
+      type = toTypeName(ctx, messageDesc, field);
+      read = code`
+        const entry = ${readSnippet};
+        if (entry.value !== undefined) {
+          if (value === undefined) {
+            value = ${emptyMapValue(options)};
+          }
+          ${mapSetSnippet(options, 'value', 'entry.key', 'entry.value')};
+        }
+      `;
+    } else if (isRepeated(field)) {
+      type = code`${valueType}[]`;
+      const readPacked =
+        packedType(field.type) === undefined
+          ? code``
+          : code`
+            if ((tag & 7) === 2) {
+              const end = reader.uint32() + reader.pos;
+              while (reader.pos < end) {
+                value.push(${readSnippet});
+              }
+              continue;
+            }
+          `;
+      read = code`
+        if (value === undefined) {
+          value = [];
+        }
+        ${readPacked}
+        value.push(${readSnippet});
+      `;
+    } else {
+      type = valueType;
+      read = code`value = ${readSnippet};`;
+    }
+
+    return code`
+      ${name}(input: ${Reader} | Uint8Array): ${type} | undefined {
+        const reader = input instanceof ${Reader} ? input : new ${Reader}(input);
+        let value: ${type} | undefined;
+        while (reader.pos < reader.len) {
+          const tag = reader.uint32();
+          if (tag >>> 3 !== ${field.number}) {
+            reader.skipType(tag & 7);
+            continue;
+          }
+          ${read}
+        }
+        return value;
+      }
+    `;
+  });
+}
+
 /** Creates a function to encode a message by loop overing the tags. */
-function generateEncode(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
+/**
+ * Creates a function to encode a message, or, for `encodeExcept`, one that takes the properties to leave out,
+ * i.e. for forwarding a message without some of its fields, which we check before writing each field.
+ *
+ * For `encodeWithMask`, it takes the `FieldMask`-like paths of the fields to write instead, where a dotted
+ * path like `author.name` writes just that field of the sub-message, by passing the rest of the path on.
+ */
+function generateEncode(
+  ctx: Context,
+  fullName: string,
+  messageDesc: DescriptorProto,
+  methodName: 'encode' | 'encodeExcept' | 'encodeWithMask' = 'encode'
+): Code {
   const { options, utils, typeMap } = ctx;
   const chunks: Code[] = [];
+  const except = methodName === 'encodeExcept';
+  const masked = methodName === 'encodeWithMask';
 
   const Writer = impFile(ctx.options, 'Writer@protobufjs/minimal');
 
   // create the basic function declaration
-  chunks.push(code`
-    encode(
-      ${messageDesc.field.length > 0 || options.unknownFields ? 'message' : '_'}: ${fullName},
-      writer: ${Writer} = ${Writer}.create(),
-    ): ${Writer} {
-  `);
+  const hasMessageParam = messageDesc.field.length > 0 || options.unknownFields;
+  // With useReadonlyTypes=output, decoded messages can be re-encoded as is, without a copy
+  const readonlyInput = options.useReadonlyTypes === 'output';
+  const input = readonlyInput ? code`${utils.DeepReadonly}<${fullName}>` : fullName;
+  // With encode options, callers can pass the options in place of the writer, i.e.
+  // `Foo.encode(message, { skipDefaults: false })`, while nested messages get the writer, and the same options.
+  const encodeOptions = options.outputEncodeOptions && !except && !masked;
+  const writerParam = encodeOptions
+    ? code`writer: ${Writer} | ${utils.EncodeOptions} = ${Writer}.create(), encodeOptions?: ${utils.EncodeOptions},`
+    : code`writer: ${Writer} = ${Writer}.create(),`;
+  const writerOrOptions = encodeOptions
+    ? code`
+        if (!(writer instanceof ${Writer})) {
+          encodeOptions = writer;
+          writer = ${Writer}.create();
+        }
+      `
+    : '';
+  if (except) {
+    chunks.push(code`
+      ${messageMethodHead(options, fullName, methodName)}(
+        ${!hasMessageParam ? '_' : readonlyInput ? 'input' : 'message'}: ${input},
+        ${messageDesc.field.length > 0 ? 'fields' : '_fields'}: (keyof ${fullName})[],
+        writer: ${Writer} = ${Writer}.create(),
+      ): ${Writer} {
+        ${hasMessageParam && readonlyInput ? code`const message = input as ${fullName};` : ''}
+        ${messageDesc.field.length > 0 ? code`const skip = new Set<keyof ${fullName}>(fields);` : ''}
+    `);
+  } else if (masked) {
+    const hasFields = messageDesc.field.length > 0;
+    chunks.push(code`
+      ${messageMethodHead(options, fullName, methodName)}(
+        ${!hasFields ? '_' : readonlyInput ? 'input' : 'message'}: ${input},
+        ${hasFields ? 'paths' : '_paths'}: string[],
+        writer: ${Writer} = ${Writer}.create(),
+      ): ${Writer} {
+        ${hasFields && readonlyInput ? code`const message = input as ${fullName};` : ''}
+    `);
+  } else if (options.encodeAcceptsPartial && options.outputPartialMethods) {
+    // Fill in the defaults of partial messages first, but keep the unknown fields of decoded messages
+    const fromPartial = messageMethod(options, fullName, 'fromPartial');
+    const toMessage = options.unknownFields
+      ? code`'_unknownFields' in input ? (input as ${fullName}) : ${fromPartial}(input as ${utils.DeepPartial}<${fullName}>)`
+      : code`${fromPartial}(input as ${utils.DeepPartial}<${fullName}>)`;
+    chunks.push(code`
+      ${messageMethodHead(options, fullName, 'encode')}(
+        ${hasMessageParam ? 'input' : '_'}: ${input} | ${utils.DeepPartial}<${fullName}>,
+        ${writerParam}
+      ): ${Writer} {
+        ${writerOrOptions}
+        ${hasMessageParam ? code`const message = ${toMessage};` : ''}
+    `);
+  } else if (readonlyInput) {
+    // Encoding only reads the message, so it's safe to treat it as the mutable type
+    chunks.push(code`
+      ${messageMethodHead(options, fullName, 'encode')}(
+        ${hasMessageParam ? 'input' : '_'}: ${input},
+        ${writerParam}
+      ): ${Writer} {
+        ${writerOrOptions}
+        ${hasMessageParam ? code`const message = input as ${fullName};` : ''}
+    `);
+  } else {
+    chunks.push(code`
+      ${messageMethodHead(options, fullName, 'encode')}(
+        ${hasMessageParam ? 'message' : '_'}: ${fullName},
+        ${writerParam}
+      ): ${Writer} {
+        ${writerOrOptions}
+    `);
+  }
 
-  // then add a case for each field
-  messageDesc.field.forEach((field) => {
+  // then add a case for each field, in field number order for deterministicEncode, like libprotobuf does,
+  // or when asked for with encodeFieldOrder=number
+  const fields =
+    options.deterministicEncode || options.encodeFieldOrder === 'number'
+      ? [...messageDesc.field].sort((a, b) => a.number - b.number)
+      : messageDesc.field;
+  fields.forEach((field) => {
     const fieldName = maybeSnakeToCamel(field.name, options);
+    const start = chunks.length;
 
     // get a generic writer.doSomething based on the basic type
-    let writeSnippet: (place: string) => Code;
-    if (isEnum(field) && options.stringEnums) {
-      const tag = ((field.number << 3) | basicWireType(field.type)) >>> 0;
-      const toNumber = getEnumMethod(ctx, field.typeName, 'ToNumber');
-      writeSnippet = (place) => code`writer.uint32(${tag}).${toReaderCall(field)}(${toNumber}(${place}))`;
-    } else if (isScalar(field) || isEnum(field)) {
-      const tag = ((field.number << 3) | basicWireType(field.type)) >>> 0;
-      writeSnippet = (place) => code`writer.uint32(${tag}).${toReaderCall(field)}(${place})`;
-    } else if (isObjectId(field) && options.useMongoObjectId) {
-      const tag = ((field.number << 3) | 2) >>> 0;
-      const type = basicTypeName(ctx, field, { keepValueType: true });
-      writeSnippet = (place) =>
-        code`${type}.encode(${utils.toProtoObjectId}(${place}), writer.uint32(${tag}).fork()).ldelim()`;
-    } else if (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) {
-      const tag = ((field.number << 3) | 2) >>> 0;
-      const type = basicTypeName(ctx, field, { keepValueType: true });
-      writeSnippet = (place) =>
-        code`${type}.encode(${utils.toTimestamp}(${place}), writer.uint32(${tag}).fork()).ldelim()`;
-    } else if (isValueType(ctx, field)) {
-      const maybeTypeField = options.outputTypeRegistry ? `$type: '${field.typeName.slice(1)}',` : '';
-
-      const type = basicTypeName(ctx, field, { keepValueType: true });
-      const wrappedValue = (place: string): Code => {
-        if (isAnyValueType(field) || isListValueType(field) || isStructType(field) || isFieldMaskType(field)) {
-          return code`${type}.wrap(${place})`;
-        }
-        return code`{${maybeTypeField} value: ${place}!}`;
-      };
-
-      const tag = ((field.number << 3) | 2) >>> 0;
-      writeSnippet = (place) => code`${type}.encode(${wrappedValue(place)}, writer.uint32(${tag}).fork()).ldelim()`;
-    } else if (isMessage(field)) {
-      const tag = ((field.number << 3) | 2) >>> 0;
-      const type = basicTypeName(ctx, field);
-      writeSnippet = (place) => code`${type}.encode(${place}, writer.uint32(${tag}).fork()).ldelim()`;
-    } else {
-      throw new Error(`Unhandled field ${field}`);
-    }
+    const writeSnippet = generateWriteSnippet(ctx, field, encodeOptions);
 
     const isOptional = isOptionalProperty(field, messageDesc.options, options);
     if (isRepeated(field)) {
@@ -1011,9 +2698,15 @@ function generateEncode(ctx: Context, fullName: string, messageDesc: DescriptorP
               }
             `
           : writeSnippet(`{ ${maybeTypeField} key: key as any, value }`);
-        const optionalAlternative = isOptional ? ' || {}' : '';
+        const optionalAlternative = isOptional ? ` || ${emptyMapValue(options)}` : '';
+        const entries = options.useMapType
+          ? `[...(message.${fieldName}${optionalAlternative}).entries()]`
+          : `Object.entries(message.${fieldName}${optionalAlternative})`;
+        const maybeSort = options.deterministicEncode
+          ? code`.sort(([a], [b]) => ${compareMapKeys(ctx, messageDesc, field)})`
+          : '';
         chunks.push(code`
-          Object.entries(message.${fieldName}${optionalAlternative}).forEach(([key, value]) => {
+          ${entries}${maybeSort}.forEach(([key, value]) => {
             ${entryWriteSnippet}
           });
         `);
@@ -1078,8 +2771,8 @@ function generateEncode(ctx: Context, fullName: string, messageDesc: DescriptorP
     } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
       let oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
       chunks.push(code`
-        if (message.${oneofName}?.$case === '${fieldName}') {
-          ${writeSnippet(`message.${oneofName}.${fieldName}`)};
+        if (message.${oneofName}?.${oneofCaseName(options)} === '${fieldName}') {
+          ${writeSnippet(`message.${oneofName}.${oneofValueName(fieldName, options)}`)};
         }
       `);
     } else if (isWithinOneOf(field)) {
@@ -1089,6 +2782,18 @@ function generateEncode(ctx: Context, fullName: string, messageDesc: DescriptorP
           ${writeSnippet(`message.${fieldName}`)};
         }
       `);
+    } else if (hasRawTimestamp(field, options)) {
+      // Prefer the raw Timestamp for its nanoseconds, unless the Date has been changed since
+      const tag = ((field.number << 3) | 2) >>> 0;
+      const encode = getMessageMethod(ctx, field.typeName, 'encode');
+      const raw = `message.${fieldName}Raw`;
+      chunks.push(code`
+        if (${raw} !== undefined && message.${fieldName}?.getTime() === ${utils.fromTimestamp}(${raw}).getTime()) {
+          ${encode}(${raw}, writer.uint32(${tag}).fork()).ldelim();
+        } else if (message.${fieldName} !== undefined) {
+          ${writeSnippet(`message.${fieldName}`)};
+        }
+      `);
     } else if (isMessage(field)) {
       chunks.push(code`
         if (message.${fieldName} !== undefined) {
@@ -1096,17 +2801,66 @@ function generateEncode(ctx: Context, fullName: string, messageDesc: DescriptorP
         }
       `);
     } else if (isScalar(field) || isEnum(field)) {
+      const notDefault = notDefaultCheck(ctx, field, messageDesc.options, `message.${fieldName}`);
+      // Only properties that always have a value can be written regardless of it
+      const check =
+        encodeOptions && !isOptional ? code`encodeOptions?.skipDefaults === false || ${notDefault}` : notDefault;
       chunks.push(code`
-        if (${notDefaultCheck(ctx, field, messageDesc.options, `message.${fieldName}`)}) {
+        if (${check}) {
           ${writeSnippet(`message.${fieldName}`)};
         }
       `);
     } else {
       chunks.push(code`${writeSnippet(`message.${fieldName}`)};`);
     }
+
+    // The fields of oneof=unions are left out by their union's property
+    if (except) {
+      const key = isWithinOneOfThatShouldBeUnion(options, field)
+        ? maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options)
+        : fieldName;
+      const written = chunks.splice(start);
+      chunks.push(code`
+        if (!skip.has('${key}')) {
+          ${joinCode(written, { on: '\n' })}
+        }
+      `);
+    }
+
+    // Singular sub-messages also take the nested paths, while any other field is written in full when it's named
+    if (masked) {
+      const written = chunks.splice(start);
+      const fieldPaths = `paths${field.number}`;
+      const isNestable =
+        isMessage(field) && isMergeableMessage(ctx, field) && !isRepeated(field) && !isWithinOneOf(field);
+      const tag = ((field.number << 3) | 2) >>> 0;
+      const encodeWithMask = isNestable ? getMessageMethod(ctx, field.typeName, 'encodeWithMask') : '';
+      chunks.push(code`
+        const ${fieldPaths} = ${utils.nestedMaskPaths}(paths, '${field.name}');
+        ${
+          isNestable
+            ? code`
+              if (${fieldPaths} !== undefined && ${fieldPaths}.length > 0) {
+                if (message.${fieldName} !== undefined) {
+                  ${encodeWithMask}(message.${fieldName}, ${fieldPaths}, writer.uint32(${tag}).fork()).ldelim();
+                }
+              } else if (${fieldPaths} !== undefined) {
+                ${joinCode(written, { on: '\n' })}
+              }
+            `
+            : code`
+              if (${fieldPaths} !== undefined) {
+                ${joinCode(written, { on: '\n' })}
+              }
+            `
+        }
+      `);
+    }
   });
 
-  if (options.unknownFields) {
+  // Integer-like keys iterate in ascending order, so unknown fields are re-encoded in tag (i.e. field number) order,
+  // and left out by the mask, which only names known fields
+  if (options.unknownFields && !masked) {
     chunks.push(code`if ('_unknownFields' in message) {
       const msgUnknownFields: any = (message as any)['_unknownFields']
       for (const key of Object.keys(msgUnknownFields)) {
@@ -1128,6 +2882,126 @@ function generateEncode(ctx: Context, fullName: string, messageDesc: DescriptorP
   return joinCode(chunks, { on: '\n' });
 }
 
+/**
+ * Creates a `tryDecode(input, length)` function that returns the error of a failed `decode` as a
+ * `{ ok: false, error }` result, rather than throwing it, i.e. for skipping corrupt records in batches.
+ */
+function generateTryDecode(ctx: Context, fullName: string): Code {
+  const { options, utils } = ctx;
+  const Reader = impFile(options, 'Reader@protobufjs/minimal');
+  const output = options.useReadonlyTypes === 'output' ? code`${utils.DeepReadonly}<${fullName}>` : fullName;
+  // With decode limits, the limits can be passed in place of the length, like for decode itself
+  const length = options.outputDecodeLimits ? code`number | ${utils.DecodeLimits}` : 'number';
+  return code`
+    ${messageMethodHead(options, fullName, 'tryDecode')}(
+      input: ${Reader} | Uint8Array,
+      length?: ${length},
+    ): { ok: true; value: ${output} } | { ok: false; error: Error } {
+      try {
+        return { ok: true, value: ${messageMethod(options, fullName, 'decode')}(input, length) };
+      } catch (e) {
+        // protobufjs throws Errors, but anything else is wrapped so that callers can rely on the type
+        const error = e instanceof ${utils.globalThis}.Error ? e : new ${utils.globalThis}.Error(String(e));
+        return { ok: false, error };
+      }
+    }
+  `;
+}
+
+/**
+ * Returns the comparison of the `a` and `b` keys of a map field, in the order that libprotobuf's deterministic
+ * serialization writes its entries, i.e. numerically for integer keys, and by their UTF-8 bytes for strings.
+ *
+ * The keys come from `Object.entries`, so they are always strings, whatever the type of the map's key, except
+ * with useMapType, where they keep their type.
+ */
+function compareMapKeys(ctx: Context, messageDesc: DescriptorProto, field: FieldDescriptorProto): Code {
+  const { options, utils } = ctx;
+  const { keyField } = detectMapType(ctx, messageDesc, field)!;
+  if (keyField.type === FieldDescriptorProto_Type.TYPE_STRING) {
+    return code`${utils.compareUtf8}(a, b)`;
+  } else if (options.useMapType && isLong(keyField) && longOption(keyField, options) === LongOption.LONG) {
+    return code`a.compare(b)`;
+  } else if (options.useMapType && !(isLong(keyField) && longOption(keyField, options) === LongOption.STRING)) {
+    // Map keys keep their type, so these are numbers, or booleans
+    return code`Number(a) - Number(b)`;
+  } else if (keyField.type === FieldDescriptorProto_Type.TYPE_BOOL) {
+    // `false` comes before `true`, which is also the order of their names
+    return code`(a < b ? -1 : a > b ? 1 : 0)`;
+  } else if (isLong(keyField)) {
+    // 64-bit keys may be out of the range that numbers can represent exactly
+    const unsigned =
+      keyField.type === FieldDescriptorProto_Type.TYPE_UINT64 ||
+      keyField.type === FieldDescriptorProto_Type.TYPE_FIXED64;
+    return code`${utils.Long}.fromString(a, ${unsigned}).compare(${utils.Long}.fromString(b, ${unsigned}))`;
+  }
+  return code`Number(a) - Number(b)`;
+}
+
+/**
+ * Creates an `encodeInto(message, target, offset)` function that encodes into a caller-provided buffer,
+ * i.e. a preallocated or pooled one, and returns the offset just past the encoded bytes.
+ *
+ * The message is first encoded into a plain `Writer`, whose `len` is the exact size, and then its queued
+ * ops are replayed onto `target` like `Writer.finish()` would do onto a newly allocated buffer.
+ */
+function generateEncodeInto(ctx: Context, fullName: string): Code {
+  const { utils } = ctx;
+  const Writer = impFile(ctx.options, 'Writer@protobufjs/minimal');
+  return code`
+    encodeInto(message: ${fullName}, target: Uint8Array, offset: number = 0): number {
+      // Writer.create() picks a BufferWriter on Node, whose ops only work on Buffers
+      const writer = ${fullName}.encode(message, new ${Writer}());
+      const end = offset + writer.len;
+      if (offset < 0 || end > target.length) {
+        throw new ${utils.globalThis}.RangeError(
+          'Encoding ${fullName} needs ' + writer.len + ' bytes at offset ' + offset +
+            ', but the target has ' + target.length
+        );
+      }
+      let op = (writer as any).head.next;
+      let pos = offset;
+      while (op) {
+        op.fn(op.val, target, pos);
+        pos += op.len;
+        op = op.next;
+      }
+      return end;
+    }
+  `;
+}
+
+/** Reads the base64 JSON form of a `bytes` value, whose string is `b64`, into the `bytesAs` representation. */
+function bytesFromJson(ctx: Context, from: Code | string, b64: Code | string = from): Code {
+  const { options, utils } = ctx;
+  const isBase64Url = options.bytesJsonEncoding === 'base64url';
+  const fromBase64 = isBase64Url ? utils.bytesFromBase64Url : utils.bytesFromBase64;
+  if (options.bytesAs === BytesOption.BASE64_STRING) {
+    // The strings are standard base64 outside of JSON
+    return isBase64Url ? code`${utils.base64FromBytes}(${fromBase64}(String(${from})))` : code`String(${from})`;
+  }
+  // Pass already-decoded bytes through, so that calling fromJSON on a message doesn't re-decode them
+  if (options.bytesAs === BytesOption.BUFFER) {
+    return code`(${from} instanceof Uint8Array ? Buffer.from(${from}) : ${fromBase64}(${b64}))`;
+  }
+  const bytes = code`(${from} instanceof Uint8Array ? ${from} : ${fromBase64}(${b64}))`;
+  if (options.env === EnvOption.NODE) {
+    return code`Buffer.from${bytes}`;
+  } else {
+    return bytes;
+  }
+}
+
+/** Writes a `bytes` value in the `bytesAs` representation as its base64 JSON form. */
+function bytesToJson(ctx: Context, from: Code | string): Code {
+  const { options, utils } = ctx;
+  if (options.bytesJsonEncoding !== 'base64url') {
+    return options.bytesAs === BytesOption.BASE64_STRING ? code`${from}` : code`${utils.base64FromBytes}(${from})`;
+  }
+  const bytes = options.bytesAs === BytesOption.BASE64_STRING ? code`${utils.bytesFromBase64}(${from})` : code`${from}`;
+  return code`${utils.base64UrlFromBytes}(${bytes})`;
+}
+
 /**
  * Creates a function to decode a message from JSON.
  *
@@ -1138,19 +3012,30 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
   const { options, utils, typeMap } = ctx;
   const chunks: Code[] = [];
 
+  // Like toJSON, a Value is read from any JSON value, which wrap turns into the matching kind
+  if (isAnyValueTypeName(fullTypeName)) {
+    return code`
+      ${messageMethodHead(options, fullName, 'fromJSON')}(object: any): ${fullName} {
+        return ${messageMethod(options, fullName, 'wrap')}(object);
+      }
+    `;
+  }
+
   // create the basic function declaration
+  const paramName = messageDesc.field.length > 0 ? 'object' : '_';
   chunks.push(code`
-    fromJSON(${messageDesc.field.length > 0 ? 'object' : '_'}: any): ${fullName} {
-      return {
+    ${messageMethodHead(options, fullName, 'fromJSON')}(${paramName}: any): ${fullName} {
+      return ${options.nullPrototype ? 'Object.assign(Object.create(null), {' : '{'}
   `);
 
   if (ctx.options.outputTypeRegistry) {
     chunks.push(code`$type: ${fullName}.$type,`);
   }
 
-  const oneofFieldsCases = messageDesc.oneofDecl.map((oneof, oneofIndex) =>
-    messageDesc.field.filter(isWithinOneOf).filter((field) => field.oneofIndex === oneofIndex)
-  );
+  const oneofFieldsCases = messageDesc.oneofDecl.map((oneof, oneofIndex) => oneofMembers(messageDesc, oneofIndex));
+
+  const emptyArray = options.emptyRepeated === 'undefined' ? 'undefined' : '[]';
+  const emptyMap = options.emptyRepeated === 'undefined' ? 'undefined' : emptyMapValue(options);
 
   const canonicalFromJson: { [key: string]: { [field: string]: (from: string) => Code } } = {
     ['google.protobuf.FieldMask']: {
@@ -1166,8 +3051,13 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
   messageDesc.field.forEach((field) => {
     const fieldName = maybeSnakeToCamel(field.name, options);
     const jsonName = getFieldJsonName(field, options);
-    const jsonProperty = getPropertyAccessor('object', jsonName);
-    const jsonPropertyOptional = getPropertyAccessor('object', jsonName, true);
+    let jsonProperty = getPropertyAccessor('object', jsonName);
+    let jsonPropertyOptional = getPropertyAccessor('object', jsonName, true);
+    if (options.fromJsonAcceptOriginalNames && field.name !== jsonName) {
+      // Fall back to the proto field name, for producers that don't use the JSON name
+      jsonProperty = `(${jsonProperty} ?? ${getPropertyAccessor('object', field.name)})`;
+      jsonPropertyOptional = `(${jsonPropertyOptional} ?? ${getPropertyAccessor('object', field.name, true)})`;
+    }
 
     // get code that extracts value from incoming object
     const readSnippet = (from: string): Code => {
@@ -1177,20 +3067,18 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
       } else if (isPrimitive(field)) {
         // Convert primitives using the String(value)/Number(value)/bytesFromBase64(value)
         if (isBytes(field)) {
-          if (options.env === EnvOption.NODE) {
-            return code`Buffer.from(${utils.bytesFromBase64}(${from}))`;
-          } else {
-            return code`${utils.bytesFromBase64}(${from})`;
-          }
-        } else if (isLong(field) && options.forceLong === LongOption.LONG) {
+          return bytesFromJson(ctx, from);
+        } else if (isLong(field) && longOption(field, options) === LongOption.LONG) {
           const cstr = capitalize(basicTypeName(ctx, field, { keepValueType: true }).toCodeString());
-          return code`${cstr}.fromValue(${from})`;
+          return brandedValue(ctx, messageDesc, field, code`${cstr}.fromValue(${from})`);
         } else {
           const cstr = capitalize(basicTypeName(ctx, field, { keepValueType: true }).toCodeString());
-          return code`${cstr}(${from})`;
+          return brandedValue(ctx, messageDesc, field, code`${cstr}(${from})`);
         }
       } else if (isObjectId(field) && options.useMongoObjectId) {
         return code`${utils.fromJsonObjectId}(${from})`;
+      } else if (isTimestamp(field) && options.timestampCodecImport) {
+        return code`${timestampCodec(options).fromJson}(${from})`;
       } else if (isTimestamp(field) && options.useDate === DateOption.STRING) {
         return code`String(${from})`;
       } else if (
@@ -1198,44 +3086,49 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
         (options.useDate === DateOption.DATE || options.useDate === DateOption.TIMESTAMP)
       ) {
         return code`${utils.fromJsonTimestamp}(${from})`;
+      } else if (isNativeDuration(field, options)) {
+        return code`${utils.fromJsonDuration}(${from})`;
       } else if (isAnyValueType(field) || isStructType(field)) {
         return code`${from}`;
       } else if (isFieldMaskType(field)) {
-        const type = basicTypeName(ctx, field, { keepValueType: true });
-        return code`${type}.unwrap(${type}.fromJSON(${from}))`;
+        const unwrap = getMessageMethod(ctx, field.typeName, 'unwrap');
+        return code`${unwrap}(${getMessageMethod(ctx, field.typeName, 'fromJSON')}(${from}))`;
       } else if (isListValueType(field)) {
         return code`[...${from}]`;
       } else if (isValueType(ctx, field)) {
         const valueType = valueTypeName(ctx, field.typeName)!;
+        const value =
+          options.wrapperJsonLenient && isScalarWrapperType(field)
+            ? code`${utils.unwrapJsonWrapper}(${from})`
+            : code`${from}`;
         if (isLongValueType(field) && options.forceLong === LongOption.LONG) {
-          return code`${capitalize(valueType.toCodeString())}.fromValue(${from})`;
+          return code`${capitalize(valueType.toCodeString())}.fromValue(${value})`;
         } else if (isBytesValueType(field)) {
-          return code`new ${capitalize(valueType.toCodeString())}(${from})`;
+          // BytesValue is a base64 string in JSON, just like bytes
+          return bytesFromJson(ctx, value);
         } else {
-          return code`${capitalize(valueType.toCodeString())}(${from})`;
+          return code`${capitalize(valueType.toCodeString())}(${value})`;
         }
       } else if (isMessage(field)) {
         if (isRepeated(field) && isMapType(ctx, messageDesc, field)) {
           const { valueField, valueType } = detectMapType(ctx, messageDesc, field)!;
           if (isPrimitive(valueField)) {
-            // TODO Can we not copy/paste this from ^?
             if (isBytes(valueField)) {
-              if (options.env === EnvOption.NODE) {
-                return code`Buffer.from(${utils.bytesFromBase64}(${from} as string))`;
-              } else {
-                return code`${utils.bytesFromBase64}(${from} as string)`;
-              }
+              return bytesFromJson(ctx, from, code`${from} as string`);
             } else if (isLong(valueField) && options.forceLong === LongOption.LONG) {
               return code`Long.fromValue(${from} as Long | string)`;
             } else if (isEnum(valueField)) {
+              // Missing (null) values fall back to the enum's default, just like an unset enum field
               const fromJson = getEnumMethod(ctx, valueField.typeName, 'FromJSON');
-              return code`${fromJson}(${from})`;
+              return code`${utils.isSet}(${from}) ? ${fromJson}(${from}) : ${defaultValue(ctx, valueField)}`;
             } else {
               const cstr = capitalize(valueType.toCodeString());
               return code`${cstr}(${from})`;
             }
           } else if (isObjectId(valueField) && options.useMongoObjectId) {
             return code`${utils.fromJsonObjectId}(${from})`;
+          } else if (isTimestamp(valueField) && options.timestampCodecImport) {
+            return code`${timestampCodec(options).fromJson}(${from})`;
           } else if (isTimestamp(valueField) && options.useDate === DateOption.STRING) {
             return code`String(${from})`;
           } else if (
@@ -1243,16 +3136,21 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
             (options.useDate === DateOption.DATE || options.useDate === DateOption.TIMESTAMP)
           ) {
             return code`${utils.fromJsonTimestamp}(${from})`;
+          } else if (isNativeDuration(valueField, options)) {
+            return code`${utils.fromJsonDuration}(${from})`;
+          } else if (isBytesValueType(valueField)) {
+            return code`${utils.isSet}(${from}) ? ${bytesFromJson(ctx, from, code`${from} as string`)} : undefined`;
+          } else if (options.wrapperJsonLenient && isScalarWrapperType(valueField)) {
+            return code`${utils.unwrapJsonWrapper}(${from}) as ${valueType}`;
           } else if (isValueType(ctx, valueField)) {
             return code`${from} as ${valueType}`;
           } else if (isAnyValueType(valueField)) {
             return code`${from}`;
           } else {
-            return code`${valueType}.fromJSON(${from})`;
+            return code`${getMessageMethod(ctx, valueField.typeName, 'fromJSON')}(${from})`;
           }
         } else {
-          const type = basicTypeName(ctx, field);
-          return code`${type}.fromJSON(${from})`;
+          return code`${getMessageMethod(ctx, field.typeName, 'fromJSON')}(${from})`;
         }
       } else {
         throw new Error(`Unhandled field ${field}`);
@@ -1263,7 +3161,20 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
     if (canonicalFromJson[fullTypeName]?.[fieldName]) {
       chunks.push(code`${fieldName}: ${canonicalFromJson[fullTypeName][fieldName]('object')},`);
     } else if (isRepeated(field)) {
-      if (isMapType(ctx, messageDesc, field)) {
+      if (isMapType(ctx, messageDesc, field) && options.useMapType) {
+        const { keyType, valueType } = detectMapType(ctx, messageDesc, field)!;
+        const key = mapKeyFromString(ctx, messageDesc, field, 'key');
+        chunks.push(code`
+          ${fieldName}: ${ctx.utils.isObject}(${jsonProperty})
+            ? new Map(
+                Object.entries(${jsonProperty}).map(([key, value]): [${keyType}, ${valueType}] => [
+                  ${key},
+                  ${readSnippet('value')},
+                ])
+              )
+            : ${emptyMap},
+        `);
+      } else if (isMapType(ctx, messageDesc, field)) {
         const fieldType = toTypeName(ctx, messageDesc, field);
         const i = maybeCastToNumber(ctx, messageDesc, field, 'key');
         chunks.push(code`
@@ -1271,17 +3182,19 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
             ? Object.entries(${jsonProperty}).reduce<${fieldType}>((acc, [key, value]) => {
                 acc[${i}] = ${readSnippet('value')};
                 return acc;
-              }, {})
-            : {},
+              }, ${emptyObject(options)})
+            : ${emptyMap},
         `);
       } else {
         const readValueSnippet = readSnippet('e');
         if (readValueSnippet.toString() === code`e`.toString()) {
-          chunks.push(code`${fieldName}: Array.isArray(${jsonPropertyOptional}) ? [...${jsonProperty}] : [],`);
+          chunks.push(
+            code`${fieldName}: Array.isArray(${jsonPropertyOptional}) ? [...${jsonProperty}] : ${emptyArray},`
+          );
         } else {
           // Explicit `any` type required to make TS with noImplicitAny happy. `object` is also `any` here.
           chunks.push(code`
-            ${fieldName}: Array.isArray(${jsonPropertyOptional}) ? ${jsonProperty}.map((e: any) => ${readValueSnippet}): [],
+            ${fieldName}: Array.isArray(${jsonPropertyOptional}) ? ${jsonProperty}.map((e: any) => ${readValueSnippet}): ${emptyArray},
           `);
         }
       }
@@ -1296,7 +3209,9 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
       }
 
       const ternaryIf = code`${ctx.utils.isSet}(${jsonProperty})`;
-      const ternaryThen = code`{ $case: '${fieldName}', ${fieldName}: ${readSnippet(`${jsonProperty}`)}`;
+      const valueName = oneofValueName(fieldName, options);
+      const caseName = oneofCaseName(options);
+      const ternaryThen = code`{ ${caseName}: '${fieldName}', ${valueName}: ${readSnippet(`${jsonProperty}`)}`;
       chunks.push(code`${ternaryIf} ? ${ternaryThen}} : `);
 
       if (field === lastCase) {
@@ -1320,7 +3235,9 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
           : undefined,
       `);
     } else {
-      const fallback = isWithinOneOf(field) ? 'undefined' : defaultValue(ctx, field);
+      const fallback = isWithinOneOf(field)
+        ? 'undefined'
+        : brandedValue(ctx, messageDesc, field, defaultValue(ctx, field));
       chunks.push(code`
         ${fieldName}: ${ctx.utils.isSet}(${jsonProperty})
           ? ${readSnippet(`${jsonProperty}`)}
@@ -1329,19 +3246,28 @@ function generateFromJson(ctx: Context, fullName: string, fullTypeName: string,
     }
   });
   // and then wrap up the switch/while/return
-  chunks.push(code`};`);
+  chunks.push(code`${options.nullPrototype ? '});' : '};'}`);
   chunks.push(code`}`);
   return joinCode(chunks, { on: '\n' });
 }
 
-function generateCanonicalToJson(fullName: string, fullProtobufTypeName: string): Code | undefined {
+function generateCanonicalToJson(ctx: Context, fullName: string, fullProtobufTypeName: string): Code | undefined {
   if (isFieldMaskTypeName(fullProtobufTypeName)) {
+    const paths = ctx.options.emptyRepeated === 'undefined' ? '(message.paths ?? [])' : 'message.paths';
     return code`
-    toJSON(message: ${fullName}): string {
-      return message.paths.join(',');
+    ${messageMethodHead(ctx.options, fullName, 'toJSON')}(message: ${fullName}): string {
+      return ${paths}.join(',');
     }
   `;
   }
+  // A Value is just its JSON value, i.e. `1` or `{ "a": [true, null] }` rather than `{ "numberValue": 1 }`
+  if (isAnyValueTypeName(fullProtobufTypeName)) {
+    return code`
+      ${messageMethodHead(ctx.options, fullName, 'toJSON')}(message: ${fullName}): unknown {
+        return ${messageMethod(ctx.options, fullName, 'unwrap')}(message);
+      }
+    `;
+  }
   return undefined;
 }
 
@@ -1349,20 +3275,32 @@ function generateToJson(
   ctx: Context,
   fullName: string,
   fullProtobufTypeName: string,
-  messageDesc: DescriptorProto
+  messageDesc: DescriptorProto,
+  method: 'toJSON' | 'toJSONFull' = 'toJSON'
 ): Code {
   const { options, utils, typeMap } = ctx;
   const chunks: Code[] = [];
+  // bytesAs=base64-string already keeps bytes in their JSON form, unless JSON uses base64url
+  const transcodesBytes = options.bytesAs !== BytesOption.BASE64_STRING || options.bytesJsonEncoding === 'base64url';
 
-  const canonicalToJson = generateCanonicalToJson(fullName, fullProtobufTypeName);
+  const canonicalToJson = generateCanonicalToJson(ctx, fullName, fullProtobufTypeName);
   if (canonicalToJson) {
+    if (method === 'toJSONFull') {
+      // Well-known types have no sensitive fields of their own
+      return code`
+        toJSONFull(message: ${fullName}): unknown {
+          return ${fullName}.toJSON(message);
+        }
+      `;
+    }
     chunks.push(canonicalToJson);
     return joinCode(chunks, { on: '\n' });
   }
 
   // create the basic function declaration
+  const paramName = messageDesc.field.length > 0 ? 'message' : '_';
   chunks.push(code`
-    toJSON(${messageDesc.field.length > 0 ? 'message' : '_'}: ${fullName}): unknown {
+    ${messageMethodHead(options, fullName, method)}(${paramName}: ${fullName}): unknown {
       const obj: any = {};
   `);
 
@@ -1372,6 +3310,29 @@ function generateToJson(
     const jsonName = getFieldJsonName(field, options);
     const jsonProperty = getPropertyAccessor('obj', jsonName);
 
+    if (method === 'toJSON' && isSensitiveField(field, options)) {
+      if (options.sensitiveFieldMode === 'redact') {
+        // Scalars at their default value are left out, so the placeholder doesn't mark an unset field as set
+        let isSet: Code;
+        if (isWithinOneOfThatShouldBeUnion(options, field)) {
+          const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
+          isSet = code`message.${oneofName}?.${oneofCaseName(options)} === '${fieldName}'`;
+        } else if (isRepeated(field) && isMapType(ctx, messageDesc, field)) {
+          isSet = options.useMapType
+            ? code`(message.${fieldName}?.size ?? 0) > 0`
+            : code`Object.keys(message.${fieldName} ?? {}).length > 0`;
+        } else if (isRepeated(field)) {
+          isSet = code`(message.${fieldName}?.length ?? 0) > 0`;
+        } else if (isWithinOneOf(field) || isMessage(field)) {
+          isSet = code`message.${fieldName} !== undefined`;
+        } else {
+          isSet = notDefaultCheck(ctx, field, messageDesc.options, `message.${fieldName}`);
+        }
+        chunks.push(code`${isSet} && (${jsonProperty} = '[redacted]');`);
+      }
+      return;
+    }
+
     const readSnippet = (from: string): Code => {
       if (isEnum(field)) {
         const toJson = getEnumMethod(ctx, field.typeName, 'ToJSON');
@@ -1380,12 +3341,16 @@ function generateToJson(
           : code`${toJson}(${from})`;
       } else if (isObjectId(field) && options.useMongoObjectId) {
         return code`${from}.toString()`;
+      } else if (isTimestamp(field) && options.timestampCodecImport) {
+        return code`${timestampCodec(options).toJson}(${from})`;
       } else if (isTimestamp(field) && options.useDate === DateOption.DATE) {
         return code`${from}.toISOString()`;
       } else if (isTimestamp(field) && options.useDate === DateOption.STRING) {
         return code`${from}`;
       } else if (isTimestamp(field) && options.useDate === DateOption.TIMESTAMP) {
         return code`${utils.fromTimestamp}(${from}).toISOString()`;
+      } else if (isNativeDuration(field, options)) {
+        return durationToJson(ctx, from);
       } else if (isMapType(ctx, messageDesc, field)) {
         // For map types, drill-in and then admittedly re-hard-code our per-value-type logic
         const valueType = (typeMap.get(field.typeName)![2] as DescriptorProto).field[1];
@@ -1393,55 +3358,84 @@ function generateToJson(
           const toJson = getEnumMethod(ctx, valueType.typeName, 'ToJSON');
           return code`${toJson}(${from})`;
         } else if (isBytes(valueType)) {
-          return code`${utils.base64FromBytes}(${from})`;
+          return bytesToJson(ctx, from);
         } else if (isObjectId(valueType) && options.useMongoObjectId) {
           return code`${from}.toString()`;
+        } else if (isTimestamp(valueType) && options.timestampCodecImport) {
+          return code`${timestampCodec(options).toJson}(${from})`;
         } else if (isTimestamp(valueType) && options.useDate === DateOption.DATE) {
           return code`${from}.toISOString()`;
         } else if (isTimestamp(valueType) && options.useDate === DateOption.STRING) {
           return code`${from}`;
         } else if (isTimestamp(valueType) && options.useDate === DateOption.TIMESTAMP) {
           return code`${utils.fromTimestamp}(${from}).toISOString()`;
+        } else if (isNativeDuration(valueType, options)) {
+          return durationToJson(ctx, from);
         } else if (isLong(valueType) && options.forceLong === LongOption.LONG) {
           return code`${from}.toString()`;
         } else if (isWholeNumber(valueType) && !(isLong(valueType) && options.forceLong === LongOption.STRING)) {
           return code`Math.round(${from})`;
+        } else if (isLongValueType(valueType) && options.forceLong === LongOption.LONG) {
+          return code`${from}?.toString()`;
+        } else if (isBytesValueType(valueType) && transcodesBytes) {
+          return code`${from} !== undefined ? ${bytesToJson(ctx, from)} : undefined`;
         } else if (isScalar(valueType) || isValueType(ctx, valueType)) {
           return code`${from}`;
         } else if (isAnyValueType(valueType)) {
           return code`${from}`;
         } else {
-          const type = basicTypeName(ctx, valueType);
-          return code`${type}.toJSON(${from})`;
+          return code`${getMessageMethod(ctx, valueType.typeName, method)}(${from})`;
         }
       } else if (isAnyValueType(field)) {
         return code`${from}`;
       } else if (isFieldMaskType(field)) {
-        const type = basicTypeName(ctx, field, { keepValueType: true });
-        return code`${type}.toJSON(${type}.wrap(${from}))`;
+        const toJson = getMessageMethod(ctx, field.typeName, 'toJSON');
+        return code`${toJson}(${getMessageMethod(ctx, field.typeName, 'wrap')}(${from}))`;
       } else if (isMessage(field) && !isValueType(ctx, field) && !isMapType(ctx, messageDesc, field)) {
-        const type = basicTypeName(ctx, field, { keepValueType: true });
-        return code`${from} ? ${type}.toJSON(${from}) : ${defaultValue(ctx, field)}`;
+        const toJson = getMessageMethod(ctx, field.typeName, method);
+        return code`${from} ? ${toJson}(${from}) : ${defaultValue(ctx, field)}`;
+      } else if (isBytes(field) && !transcodesBytes) {
+        // The bytes are already in their JSON form
+        return isWithinOneOf(field) ? code`${from}` : code`${from} !== undefined ? ${from} : ""`;
       } else if (isBytes(field)) {
         if (isWithinOneOf(field)) {
-          return code`${from} !== undefined ? ${utils.base64FromBytes}(${from}) : undefined`;
+          return code`${from} !== undefined ? ${bytesToJson(ctx, from)} : undefined`;
         } else {
-          return code`${utils.base64FromBytes}(${from} !== undefined ? ${from} : ${defaultValue(ctx, field)})`;
+          return bytesToJson(ctx, code`${from} !== undefined ? ${from} : ${defaultValue(ctx, field)}`);
         }
-      } else if (isLong(field) && options.forceLong === LongOption.LONG) {
+      } else if (isLong(field) && longOption(field, options) === LongOption.LONG) {
         const v = isWithinOneOf(field) ? 'undefined' : defaultValue(ctx, field);
         return code`(${from} || ${v}).toString()`;
-      } else if (isWholeNumber(field) && !(isLong(field) && options.forceLong === LongOption.STRING)) {
+      } else if (isLongValueType(field) && options.forceLong === LongOption.LONG) {
+        // proto3 JSON wants 64-bit wrappers as strings too, not as Long instances
+        return code`${from}?.toString()`;
+      } else if (isBytesValueType(field) && transcodesBytes) {
+        // Like bytes, BytesValue is a base64 string in JSON, rather than the unwrapped bytes themselves
+        return isWithinOneOf(field)
+          ? code`${from} !== undefined ? ${bytesToJson(ctx, from)} : undefined`
+          : bytesToJson(ctx, from);
+      } else if (isWholeNumber(field) && !(isLong(field) && longOption(field, options) === LongOption.STRING)) {
         return code`Math.round(${from})`;
       } else {
         return code`${from}`;
       }
     };
 
-    if (isMapType(ctx, messageDesc, field)) {
-      // Maps might need their values transformed, i.e. bytes --> base64
+    if (isMapType(ctx, messageDesc, field) && options.useMapType) {
+      // JSON has no maps, so the keys are stringified, i.e. `true` or a Long's decimal digits
+      chunks.push(code`
+        ${jsonProperty} = ${emptyObject(options)};
+        if (message.${fieldName}) {
+          message.${fieldName}.forEach((v, k) => {
+            ${jsonProperty}[String(k)] = ${readSnippet('v')};
+          });
+        }
+      `);
+    } else if (isMapType(ctx, messageDesc, field)) {
+      // Maps might need their values transformed, i.e. bytes --> base64. Object.entries keeps the insertion
+      // order of string keys, but JS objects always list integer-like keys first, in ascending order
       chunks.push(code`
-        ${jsonProperty} = {};
+        ${jsonProperty} = ${emptyObject(options)};
         if (message.${fieldName}) {
           Object.entries(message.${fieldName}).forEach(([k, v]) => {
             ${jsonProperty}[k] = ${readSnippet('v')};
@@ -1460,8 +3454,9 @@ function generateToJson(
     } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
       // oneofs in a union are only output as `oneof name = ...`
       const oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
-      const v = readSnippet(`message.${oneofName}?.${fieldName}`);
-      chunks.push(code`message.${oneofName}?.$case === '${fieldName}' && (${jsonProperty} = ${v});`);
+      const v = readSnippet(`message.${oneofName}?.${oneofValueName(fieldName, options)}`);
+      const caseName = oneofCaseName(options);
+      chunks.push(code`message.${oneofName}?.${caseName} === '${fieldName}' && (${jsonProperty} = ${v});`);
     } else {
       const v = readSnippet(`message.${fieldName}`);
       chunks.push(code`message.${fieldName} !== undefined && (${jsonProperty} = ${v});`);
@@ -1472,20 +3467,53 @@ function generateToJson(
   return joinCode(chunks, { on: '\n' });
 }
 
+/** Native durations are written in the canonical `1.5s` form, which `useDuration=string` already uses. */
+function durationToJson(ctx: Context, from: string): Code {
+  const { options, utils } = ctx;
+  return options.useDuration === DurationOption.STRING
+    ? code`${from}`
+    : code`${utils.formatDuration}(${utils.toDuration}(${from}))`;
+}
+
+/** The user-supplied `timestampToJson`/`timestampFromJson` functions of `timestampCodecImport=./my-codec`. */
+function timestampCodec(options: Options): { toJson: Import; fromJson: Import } {
+  return {
+    toJson: imp(`timestampToJson@${options.timestampCodecImport}`),
+    fromJson: imp(`timestampFromJson@${options.timestampCodecImport}`),
+  };
+}
+
+/** Creates `Foo.fromPartialArray(items)`, which maps each of many partials through `Foo.fromPartial`. */
+function generateFromPartialArray(ctx: Context, fullName: string): Code {
+  const { options, utils } = ctx;
+  const head = messageMethodHead(options, fullName, 'fromPartialArray');
+  const fromPartial = messageMethod(options, fullName, 'fromPartial');
+  // Like fromPartial, with useExactTypes each item is checked for properties that the message doesn't have
+  const signature = options.useExactTypes
+    ? code`${head}<I extends ${utils.Exact}<${utils.DeepPartial}<${fullName}>, I>>(items: I[]): ${fullName}[]`
+    : code`${head}(items: ${utils.DeepPartial}<${fullName}>[]): ${fullName}[]`;
+  return code`
+    ${signature} {
+      return items.map((item) => ${fromPartial}(item));
+    }
+  `;
+}
+
 function generateFromPartial(ctx: Context, fullName: string, messageDesc: DescriptorProto): Code {
   const { options, utils, typeMap } = ctx;
   const chunks: Code[] = [];
 
   // create the basic function declaration
-  const paramName = messageDesc.field.length > 0 ? 'object' : '_';
+  const paramName = messageDesc.field.length > 0 || options.unknownFields ? 'object' : '_';
+  const head = messageMethodHead(options, fullName, 'fromPartial');
 
   if (ctx.options.useExactTypes) {
     chunks.push(code`
-      fromPartial<I extends ${utils.Exact}<${utils.DeepPartial}<${fullName}>, I>>(${paramName}: I): ${fullName} {
+      ${head}<I extends ${utils.Exact}<${utils.DeepPartial}<${fullName}>, I>>(${paramName}: I): ${fullName} {
     `);
   } else {
     chunks.push(code`
-      fromPartial(${paramName}: ${utils.DeepPartial}<${fullName}>): ${fullName} {
+      ${head}(${paramName}: ${utils.DeepPartial}<${fullName}>): ${fullName} {
     `);
   }
 
@@ -1496,18 +3524,25 @@ function generateFromPartial(ctx: Context, fullName: string, messageDesc: Descri
 
   chunks.push(code`const message = ${createBase};`);
 
+  // With oneofPartialStrict, each union's case is checked once, before its branches are looked at
+  const checkedOneofs = new Set<number>();
+
   // add a check for each incoming field
   messageDesc.field.forEach((field) => {
     const fieldName = maybeSnakeToCamel(field.name, options);
 
     const readSnippet = (from: string): Code => {
-      if ((isLong(field) || isLongValueType(field)) && options.forceLong === LongOption.LONG) {
+      if (
+        (isLong(field) && longOption(field, options) === LongOption.LONG) ||
+        (isLongValueType(field) && options.forceLong === LongOption.LONG)
+      ) {
         return code`Long.fromValue(${from})`;
       } else if (isObjectId(field) && options.useMongoObjectId) {
         return code`${from} as mongodb.ObjectId`;
       } else if (
         isPrimitive(field) ||
         (isTimestamp(field) && (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) ||
+        isNativeDuration(field, options) ||
         isValueType(ctx, field)
       ) {
         return code`${from}`;
@@ -1530,21 +3565,20 @@ function generateFromPartial(ctx: Context, fullName: string, messageDesc: Descri
           } else if (isObjectId(valueField) && options.useMongoObjectId) {
             return code`${from} as mongodb.ObjectId`;
           } else if (
-            isTimestamp(valueField) &&
-            (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)
+            (isTimestamp(valueField) &&
+              (options.useDate === DateOption.DATE || options.useDate === DateOption.STRING)) ||
+            isNativeDuration(valueField, options)
           ) {
             return code`${from}`;
           } else if (isValueType(ctx, valueField)) {
             return code`${from}`;
           } else {
-            const type = basicTypeName(ctx, valueField);
-            return code`${type}.fromPartial(${from})`;
+            return code`${getMessageMethod(ctx, valueField.typeName, 'fromPartial')}(${from})`;
           }
         } else if (isAnyValueType(field)) {
           return code`${from}`;
         } else {
-          const type = basicTypeName(ctx, field);
-          return code`${type}.fromPartial(${from})`;
+          return code`${getMessageMethod(ctx, field.typeName, 'fromPartial')}(${from})`;
         }
       } else {
         throw new Error(`Unhandled field ${field}`);
@@ -1553,16 +3587,52 @@ function generateFromPartial(ctx: Context, fullName: string, messageDesc: Descri
 
     // and then use the snippet to handle repeated fields if necessary
     if (isRepeated(field)) {
-      if (isMapType(ctx, messageDesc, field)) {
+      if (isMapType(ctx, messageDesc, field) && options.useMapType) {
+        // Partials can be given as a Map, or as a plain object like the JSON of a map, whose keys are strings
         const fieldType = toTypeName(ctx, messageDesc, field);
-        const i = maybeCastToNumber(ctx, messageDesc, field, 'key');
+        const map = `map${field.number}`;
+        const key = mapKeyFromString(ctx, messageDesc, field, 'key');
+        const unset = `object.${fieldName} === undefined || object.${fieldName} === null`;
         chunks.push(code`
-          message.${fieldName} = Object.entries(object.${fieldName} ?? {}).reduce<${fieldType}>((acc, [key, value]) => {
+          const ${map}: ${fieldType} = new Map();
+          if (object.${fieldName} instanceof Map) {
+            object.${fieldName}.forEach((value, key) => {
+              if (value !== undefined) {
+                ${map}.set(key, ${readSnippet('value')});
+              }
+            });
+          } else {
+            Object.entries(object.${fieldName} ?? {}).forEach(([key, value]) => {
+              if (value !== undefined) {
+                ${map}.set(${key}, ${readSnippet('value')});
+              }
+            });
+          }
+          message.${fieldName} = ${options.emptyRepeated === 'undefined' ? `${unset} ? undefined : ${map}` : map};
+        `);
+      } else if (isMapType(ctx, messageDesc, field)) {
+        const fieldType = toTypeName(ctx, messageDesc, field);
+        const i = maybeCastToNumber(ctx, messageDesc, field, 'key');
+        const entries = code`
+          Object.entries(object.${fieldName} ?? {}).reduce<${fieldType}>((acc, [key, value]) => {
             if (value !== undefined) {
               acc[${i}] = ${readSnippet('value')};
             }
             return acc;
-          }, {});
+          }, ${emptyObject(options)})
+        `;
+        if (options.emptyRepeated === 'undefined') {
+          chunks.push(code`
+            message.${fieldName} = object.${fieldName} === undefined || object.${fieldName} === null
+              ? undefined
+              : ${entries};
+          `);
+        } else {
+          chunks.push(code`message.${fieldName} = ${entries};`);
+        }
+      } else if (options.emptyRepeated === 'undefined') {
+        chunks.push(code`
+          message.${fieldName} = object.${fieldName}?.map((e) => ${readSnippet('e')});
         `);
       } else {
         chunks.push(code`
@@ -1571,30 +3641,65 @@ function generateFromPartial(ctx: Context, fullName: string, messageDesc: Descri
       }
     } else if (isWithinOneOfThatShouldBeUnion(options, field)) {
       let oneofName = maybeSnakeToCamel(messageDesc.oneofDecl[field.oneofIndex].name, options);
-      const v = readSnippet(`object.${oneofName}.${fieldName}`);
+      const valueName = oneofValueName(fieldName, options);
+      const caseName = oneofCaseName(options);
+      const v = readSnippet(`object.${oneofName}.${valueName}`);
+      if (options.oneofPartialStrict && !checkedOneofs.has(field.oneofIndex)) {
+        checkedOneofs.add(field.oneofIndex);
+        const cases = oneofMembers(messageDesc, field.oneofIndex).map(
+          (member) => `'${maybeSnakeToCamel(member.name, options)}'`
+        );
+        const actual = `object.${oneofName}.${caseName}`;
+        chunks.push(code`
+          if (object.${oneofName}?.${caseName} !== undefined && ![${cases.join(', ')}].includes(${actual})) {
+            throw new ${utils.globalThis}.Error("Unknown ${caseName} " + ${actual} + " for oneof ${oneofName} of ${fullName}");
+          }
+        `);
+      }
       chunks.push(code`
         if (
-          object.${oneofName}?.$case === '${fieldName}'
-          && object.${oneofName}?.${fieldName} !== undefined
-          && object.${oneofName}?.${fieldName} !== null
+          object.${oneofName}?.${caseName} === '${fieldName}'
+          && object.${oneofName}?.${valueName} !== undefined
+          && object.${oneofName}?.${valueName} !== null
         ) {
-          message.${oneofName} = { $case: '${fieldName}', ${fieldName}: ${v} };
+          message.${oneofName} = { ${caseName}: '${fieldName}', ${valueName}: ${v} };
         }
       `);
     } else if (readSnippet(`x`).toCodeString() == 'x') {
       // An optimized case of the else below that works when `readSnippet` returns the plain input
-      const fallback = isWithinOneOf(field) ? 'undefined' : defaultValue(ctx, field);
+      const fallback = isWithinOneOf(field)
+        ? 'undefined'
+        : brandedValue(ctx, messageDesc, field, defaultValue(ctx, field));
       chunks.push(code`message.${fieldName} = object.${fieldName} ?? ${fallback};`);
     } else {
-      const fallback = isWithinOneOf(field) ? 'undefined' : defaultValue(ctx, field);
+      const fallback = isWithinOneOf(field)
+        ? 'undefined'
+        : brandedValue(ctx, messageDesc, field, defaultValue(ctx, field));
       chunks.push(code`
         message.${fieldName} = (object.${fieldName} !== undefined && object.${fieldName} !== null)
           ? ${readSnippet(`object.${fieldName}`)}
           : ${fallback};
       `);
     }
+
+    if (hasRawTimestamp(field, options)) {
+      const fromPartial = getMessageMethod(ctx, field.typeName, 'fromPartial');
+      const raw = `object.${fieldName}Raw`;
+      chunks.push(code`
+        message.${fieldName}Raw = (${raw} !== undefined && ${raw} !== null) ? ${fromPartial}(${raw}) : undefined;
+      `);
+    }
   });
 
+  // Keep the unknown fields of a decoded message, so that copying it via fromPartial doesn't drop them on re-encode
+  if (options.unknownFields) {
+    chunks.push(code`
+      if ((object as any)._unknownFields !== undefined) {
+        (message as any)._unknownFields = { ...(object as any)._unknownFields };
+      }
+    `);
+  }
+
   // and then wrap up the switch/while/return
   chunks.push(code`return message;`);
   chunks.push(code`}`);
@@ -1610,14 +3715,21 @@ type StructFieldNames = {
   listValue: string;
 };
 
-function generateWrap(ctx: Context, fullProtoTypeName: string, fieldNames: StructFieldNames): Code[] {
+function generateWrap(
+  ctx: Context,
+  fullName: string,
+  fullProtoTypeName: string,
+  fieldNames: StructFieldNames
+): Code[] {
   const chunks: Code[] = [];
+  const wrap = messageMethodHead(ctx.options, fullName, 'wrap');
   if (isStructTypeName(fullProtoTypeName)) {
-    chunks.push(code`wrap(object: {[key: string]: any} | undefined): Struct {
+    chunks.push(code`${wrap}(object: {[key: string]: any} | undefined): Struct {
       const struct = createBaseStruct();
       if (object !== undefined) {
+        ${ctx.options.emptyRepeated === 'undefined' ? `struct.fields = ${emptyObject(ctx.options)};` : ''}
         Object.keys(object).forEach(key => {
-          struct.fields[key] = object[key];
+          struct.fields${ctx.options.emptyRepeated === 'undefined' ? '!' : ''}[key] = object[key];
         });
       }
       return struct;
@@ -1625,22 +3737,23 @@ function generateWrap(ctx: Context, fullProtoTypeName: string, fieldNames: Struc
   }
 
   if (isAnyValueTypeName(fullProtoTypeName)) {
-    if (ctx.options.oneof === OneofOption.UNIONS) {
-      chunks.push(code`wrap(value: any): Value {
+    if (isOneofUnions(ctx.options)) {
+      const caseName = oneofCaseName(ctx.options);
+      chunks.push(code`${wrap}(value: any): Value {
         const result = createBaseValue();
 
         if (value === null) {
-          result.kind = {$case: '${fieldNames.nullValue}', ${fieldNames.nullValue}: NullValue.NULL_VALUE};
+          result.kind = {${caseName}: '${fieldNames.nullValue}', ${oneofValueName(fieldNames.nullValue, ctx.options)}: NullValue.NULL_VALUE};
         } else if (typeof value === 'boolean') {
-          result.kind = {$case: '${fieldNames.boolValue}', ${fieldNames.boolValue}: value};
+          result.kind = {${caseName}: '${fieldNames.boolValue}', ${oneofValueName(fieldNames.boolValue, ctx.options)}: value};
         } else if (typeof value === 'number') {
-          result.kind = {$case: '${fieldNames.numberValue}', ${fieldNames.numberValue}: value};
+          result.kind = {${caseName}: '${fieldNames.numberValue}', ${oneofValueName(fieldNames.numberValue, ctx.options)}: value};
         } else if (typeof value === 'string') {
-          result.kind = {$case: '${fieldNames.stringValue}', ${fieldNames.stringValue}: value};
+          result.kind = {${caseName}: '${fieldNames.stringValue}', ${oneofValueName(fieldNames.stringValue, ctx.options)}: value};
         } else if (Array.isArray(value)) {
-          result.kind = {$case: '${fieldNames.listValue}', ${fieldNames.listValue}: value};
+          result.kind = {${caseName}: '${fieldNames.listValue}', ${oneofValueName(fieldNames.listValue, ctx.options)}: value};
         } else if (typeof value === 'object') {
-          result.kind = {$case: '${fieldNames.structValue}', ${fieldNames.structValue}: value};
+          result.kind = {${caseName}: '${fieldNames.structValue}', ${oneofValueName(fieldNames.structValue, ctx.options)}: value};
         } else if (typeof value !== 'undefined') {
           throw new Error('Unsupported any value type: ' + typeof value);
         }
@@ -1648,7 +3761,7 @@ function generateWrap(ctx: Context, fullProtoTypeName: string, fieldNames: Struc
         return result;
     }`);
     } else {
-      chunks.push(code`wrap(value: any): Value {
+      chunks.push(code`${wrap}(value: any): Value {
         const result = createBaseValue();
 
         if (value === null) {
@@ -1673,7 +3786,7 @@ function generateWrap(ctx: Context, fullProtoTypeName: string, fieldNames: Struc
   }
 
   if (isListValueTypeName(fullProtoTypeName)) {
-    chunks.push(code`wrap(value: Array<any> | undefined): ListValue {
+    chunks.push(code`${wrap}(value: Array<any> | undefined): ListValue {
       const result = createBaseListValue();
 
       result.values = value ?? [];
@@ -1683,7 +3796,7 @@ function generateWrap(ctx: Context, fullProtoTypeName: string, fieldNames: Struc
   }
 
   if (isFieldMaskTypeName(fullProtoTypeName)) {
-    chunks.push(code`wrap(paths: string[]): FieldMask {
+    chunks.push(code`${wrap}(paths: string[]): FieldMask {
       const result = createBaseFieldMask();
 
       result.paths = paths;
@@ -1695,39 +3808,47 @@ function generateWrap(ctx: Context, fullProtoTypeName: string, fieldNames: Struc
   return chunks;
 }
 
-function generateUnwrap(ctx: Context, fullProtoTypeName: string, fieldNames: StructFieldNames): Code[] {
+function generateUnwrap(
+  ctx: Context,
+  fullName: string,
+  fullProtoTypeName: string,
+  fieldNames: StructFieldNames
+): Code[] {
   const chunks: Code[] = [];
+  const unwrap = messageMethodHead(ctx.options, fullName, 'unwrap');
   if (isStructTypeName(fullProtoTypeName)) {
-    chunks.push(code`unwrap(message: Struct): {[key: string]: any} {
-      const object: { [key: string]: any } = {};
-      Object.keys(message.fields).forEach(key => {
-        object[key] = message.fields[key];
+    chunks.push(code`${unwrap}(message: Struct): {[key: string]: any} {
+      const object: { [key: string]: any } = ${emptyObject(ctx.options)};
+      const fields = message.fields${ctx.options.emptyRepeated === 'undefined' ? ' ?? {}' : ''};
+      Object.keys(fields).forEach(key => {
+        object[key] = fields[key];
       });
       return object;
     }`);
   }
 
   if (isAnyValueTypeName(fullProtoTypeName)) {
-    if (ctx.options.oneof === OneofOption.UNIONS) {
-      chunks.push(code`unwrap(message: Value): string | number | boolean | Object | null | Array<any> | undefined {
-        if (message.kind?.$case === '${fieldNames.nullValue}') {
+    if (isOneofUnions(ctx.options)) {
+      const caseName = oneofCaseName(ctx.options);
+      chunks.push(code`${unwrap}(message: Value): string | number | boolean | Object | null | Array<any> | undefined {
+        if (message.kind?.${caseName} === '${fieldNames.nullValue}') {
           return null;
-        } else if (message.kind?.$case === '${fieldNames.numberValue}') {
-          return message.kind?.${fieldNames.numberValue};
-        } else if (message.kind?.$case === '${fieldNames.stringValue}') {
-          return message.kind?.${fieldNames.stringValue};
-        } else if (message.kind?.$case === '${fieldNames.boolValue}') {
-          return message.kind?.${fieldNames.boolValue};
-        } else if (message.kind?.$case === '${fieldNames.structValue}') {
-          return message.kind?.${fieldNames.structValue};
-        } else if (message.kind?.$case === '${fieldNames.listValue}') {
-          return message.kind?.${fieldNames.listValue};
+        } else if (message.kind?.${caseName} === '${fieldNames.numberValue}') {
+          return message.kind?.${oneofValueName(fieldNames.numberValue, ctx.options)};
+        } else if (message.kind?.${caseName} === '${fieldNames.stringValue}') {
+          return message.kind?.${oneofValueName(fieldNames.stringValue, ctx.options)};
+        } else if (message.kind?.${caseName} === '${fieldNames.boolValue}') {
+          return message.kind?.${oneofValueName(fieldNames.boolValue, ctx.options)};
+        } else if (message.kind?.${caseName} === '${fieldNames.structValue}') {
+          return message.kind?.${oneofValueName(fieldNames.structValue, ctx.options)};
+        } else if (message.kind?.${caseName} === '${fieldNames.listValue}') {
+          return message.kind?.${oneofValueName(fieldNames.listValue, ctx.options)};
         } else {
           return undefined;
         }
     }`);
     } else {
-      chunks.push(code`unwrap(message: Value): string | number | boolean | Object | null | Array<any> | undefined {
+      chunks.push(code`${unwrap}(message: Value): string | number | boolean | Object | null | Array<any> | undefined {
       if (message?.${fieldNames.stringValue} !== undefined) {
         return message.${fieldNames.stringValue};
       } else if (message?.${fieldNames.numberValue} !== undefined) {
@@ -1747,14 +3868,14 @@ function generateUnwrap(ctx: Context, fullProtoTypeName: string, fieldNames: Str
   }
 
   if (isListValueTypeName(fullProtoTypeName)) {
-    chunks.push(code`unwrap(message: ListValue): Array<any> {
-      return message.values;
+    chunks.push(code`${unwrap}(message: ListValue): Array<any> {
+      return message.values${ctx.options.emptyRepeated === 'undefined' ? ' ?? []' : ''};
     }`);
   }
 
   if (isFieldMaskTypeName(fullProtoTypeName)) {
-    chunks.push(code`unwrap(message: FieldMask): string[] {
-      return message.paths;
+    chunks.push(code`${unwrap}(message: FieldMask): string[] {
+      return message.paths${ctx.options.emptyRepeated === 'undefined' ? ' ?? []' : ''};
     }`);
   }
 
@@ -1763,6 +3884,33 @@ function generateUnwrap(ctx: Context, fullProtoTypeName: string, fieldNames: Str
 
 export const contextTypeVar = 'Context extends DataLoaders';
 
+/**
+ * Converts the `variableName` string, i.e. a key of the JSON object of a map, to the type of the map's key,
+ * which the `Map` of useMapType keeps, rather than the strings that the keys of plain objects always are.
+ */
+function mapKeyFromString(
+  ctx: Context,
+  messageDesc: DescriptorProto,
+  field: FieldDescriptorProto,
+  variableName: string
+): Code {
+  const { options, utils } = ctx;
+  const { keyField } = detectMapType(ctx, messageDesc, field)!;
+  if (keyField.type === FieldDescriptorProto_Type.TYPE_STRING) {
+    return code`${variableName}`;
+  } else if (keyField.type === FieldDescriptorProto_Type.TYPE_BOOL) {
+    return code`${variableName} === 'true'`;
+  } else if (isLong(keyField) && longOption(keyField, options) === LongOption.LONG) {
+    const unsigned =
+      keyField.type === FieldDescriptorProto_Type.TYPE_UINT64 ||
+      keyField.type === FieldDescriptorProto_Type.TYPE_FIXED64;
+    return code`${utils.Long}.fromString(${variableName}, ${unsigned})`;
+  } else if (isLong(keyField) && longOption(keyField, options) === LongOption.STRING) {
+    return code`${variableName}`;
+  }
+  return code`Number(${variableName})`;
+}
+
 function maybeCastToNumber(
   ctx: Context,
   messageDesc: DescriptorProto,
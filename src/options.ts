@@ -10,21 +10,36 @@ export enum DateOption {
   TIMESTAMP = 'timestamp',
 }
 
+export enum DurationOption {
+  DURATION = 'duration',
+  NUMBER = 'number',
+  STRING = 'string',
+}
+
 export enum EnvOption {
   NODE = 'node',
   BROWSER = 'browser',
   BOTH = 'both',
 }
 
+export enum BytesOption {
+  UINT8ARRAY = 'uint8array',
+  BUFFER = 'buffer',
+  BASE64_STRING = 'base64-string',
+}
+
 export enum OneofOption {
   PROPERTIES = 'properties',
   UNIONS = 'unions',
+  UNIONS_VALUE = 'unions-value',
+  NAMED_UNIONS = 'named-unions',
 }
 
 export enum ServiceOption {
   GRPC = 'grpc-js',
   NICE_GRPC = 'nice-grpc',
   GENERIC = 'generic-definitions',
+  CONNECT = 'connect',
   DEFAULT = 'default',
   NONE = 'none',
 }
@@ -47,7 +62,7 @@ export type Options = {
   stringEnums: boolean;
   constEnums: boolean;
   enumsAsLiterals: boolean;
-  outputClientImpl: boolean | 'grpc-web';
+  outputClientImpl: boolean | 'grpc-web' | 'grpc-web-fetch';
   outputServices: ServiceOption[];
   addGrpcMetadata: boolean;
   metadataType: string | undefined;
@@ -67,6 +82,110 @@ export type Options = {
   usePrototypeForDefaults: boolean;
   useJsonWireFormat: boolean;
   useNumericEnumForJson: boolean;
+  outputMergeMethods: boolean;
+  outputStreamAccumulators: boolean;
+  outputPagination: boolean;
+  paginationRequestField: string;
+  paginationResponseField: string;
+  outputSelectors: boolean;
+  outputEnumHelpers: boolean;
+  quoteStyle: 'double' | 'single';
+  indent: number;
+  clientInterceptors: boolean;
+  emptyRepeated: 'array' | 'undefined';
+  outputServiceRegistrar: boolean;
+  defaultMetadata: boolean;
+  outputLayout: 'source' | 'package';
+  outputDecodeLimits: boolean;
+  messageConversions: string[];
+  outputCodecInterface: boolean;
+  bidiObservable: boolean;
+  bidiObservableImport: string;
+  outputTextFormat: boolean;
+  outputFieldMaskMethods: boolean;
+  bytesJsonEncoding: 'base64' | 'base64url';
+  decodeStrictWireType: boolean;
+  outputTestFactories: boolean;
+  outputOneofClear: boolean;
+  outputRepeatedHelpers: boolean;
+  timestampCodecImport: string | undefined;
+  clientRetry: boolean;
+  enumStyle: 'enum' | 'const-enum';
+  isolatedModules: boolean;
+  outputLowLevelWriters: boolean;
+  encodeAcceptsPartial: boolean;
+  sensitiveFieldOption: number | undefined;
+  sensitiveFieldMode: 'redact' | 'omit';
+  outputToString: boolean;
+  immerCompat: boolean;
+  fromJsonAcceptOriginalNames: boolean;
+  timestampKeepRaw: boolean;
+  outputQueryString: boolean;
+  wrapInNamespace: boolean;
+  outputWatch: boolean;
+  watchRequestField: string;
+  watchResponseField: string;
+  useDuration: DurationOption;
+  outputPresenceHelpers: boolean;
+  outputEncodeInto: boolean;
+  useAbortSignal: boolean;
+  messageUnions: string[];
+  streamBackpressure: boolean;
+  outputStyle: 'object' | 'functions';
+  outputUnknownFields: boolean;
+  bytesAs: BytesOption;
+  outputOneofExhaustive: boolean;
+  outputZodSchemas: boolean;
+  wrapperJsonLenient: boolean;
+  brandedTypes: string[];
+  embedOptions: boolean;
+  outputSelectiveDecode: boolean;
+  useReadonlyTypes: false | 'output';
+  outputQueryKeys: boolean;
+  outputEncodeExcept: boolean;
+  outputStreamHelpers: boolean;
+  deterministicEncode: boolean;
+  outputFieldNames: boolean;
+  outputEnumExhaustive: boolean;
+  outputEnumLabels: boolean;
+  enumLabelOption: number | undefined;
+  niceGrpcInterceptors: boolean;
+  outputTryDecode: boolean;
+  oneofPartialStrict: boolean;
+  useMapType: boolean;
+  splitNestedTypes: boolean;
+  splitNestedTypesDepth: number;
+  outputFormData: boolean;
+  servicePathPrefix: string;
+  outputEncodeOptions: boolean;
+  nullPrototype: boolean;
+  outputTypeUrlEnum: boolean;
+  outputRepeatedToMap: boolean;
+  repeatedToMapKeyField: string;
+  repeatedToMapValueField: string;
+  outputTruncationDetection: boolean;
+  outputEntries: boolean;
+  outputTimestampHelpers: boolean;
+  outputRoundTripTests: boolean;
+  warningHookImport: string | undefined;
+  outputEncodeWithMask: boolean;
+  outputSchemaHash: boolean;
+  outputOneofValue: boolean;
+  outputDebugString: boolean;
+  outputLongConversions: boolean;
+  outputAnyDispatcher: boolean;
+  outputReflection: boolean;
+  outputDurationHelpers: boolean;
+  outputPatch: boolean;
+  encodeFieldOrder: 'descriptor' | 'number';
+  streamReconnect: boolean;
+  outputEventBus: boolean;
+  busImport: string | undefined;
+  outputPartialArray: boolean;
+  outputComparators: boolean;
+  sortKeyOption: number | undefined;
+  enumConversions: string[];
+  enumConversionMatch: 'name' | 'number';
 };
 
 export function defaultOptions(): Options {
@@ -108,6 +227,110 @@ export function defaultOptions(): Options {
     usePrototypeForDefaults: false,
     useJsonWireFormat: false,
     useNumericEnumForJson: false,
+    outputMergeMethods: false,
+    outputStreamAccumulators: false,
+    outputPagination: false,
+    paginationRequestField: 'page_token',
+    paginationResponseField: 'next_page_token',
+    outputSelectors: false,
+    outputEnumHelpers: false,
+    quoteStyle: 'double',
+    indent: 2,
+    clientInterceptors: false,
+    emptyRepeated: 'array',
+    outputServiceRegistrar: false,
+    defaultMetadata: false,
+    outputLayout: 'source',
+    outputDecodeLimits: false,
+    messageConversions: [],
+    outputCodecInterface: false,
+    bidiObservable: false,
+    bidiObservableImport: 'rxjs',
+    outputTextFormat: false,
+    outputFieldMaskMethods: false,
+    bytesJsonEncoding: 'base64',
+    decodeStrictWireType: false,
+    outputTestFactories: false,
+    outputOneofClear: false,
+    outputRepeatedHelpers: false,
+    timestampCodecImport: undefined,
+    clientRetry: false,
+    enumStyle: 'enum',
+    isolatedModules: false,
+    outputLowLevelWriters: false,
+    encodeAcceptsPartial: false,
+    sensitiveFieldOption: undefined,
+    sensitiveFieldMode: 'redact',
+    outputToString: false,
+    immerCompat: false,
+    fromJsonAcceptOriginalNames: false,
+    timestampKeepRaw: false,
+    outputQueryString: false,
+    wrapInNamespace: false,
+    outputWatch: false,
+    watchRequestField: 'watch_token',
+    watchResponseField: 'next_watch_token',
+    useDuration: DurationOption.DURATION,
+    outputPresenceHelpers: false,
+    outputEncodeInto: false,
+    useAbortSignal: false,
+    messageUnions: [],
+    streamBackpressure: false,
+    outputStyle: 'object',
+    outputUnknownFields: false,
+    bytesAs: BytesOption.UINT8ARRAY,
+    outputOneofExhaustive: false,
+    outputZodSchemas: false,
+    wrapperJsonLenient: false,
+    brandedTypes: [],
+    embedOptions: false,
+    outputSelectiveDecode: false,
+    useReadonlyTypes: false,
+    outputQueryKeys: false,
+    outputEncodeExcept: false,
+    outputStreamHelpers: false,
+    deterministicEncode: false,
+    outputFieldNames: false,
+    outputEnumExhaustive: false,
+    outputEnumLabels: false,
+    enumLabelOption: undefined,
+    niceGrpcInterceptors: false,
+    outputTryDecode: false,
+    oneofPartialStrict: false,
+    useMapType: false,
+    splitNestedTypes: false,
+    splitNestedTypesDepth: 2,
+    outputFormData: false,
+    servicePathPrefix: '',
+    outputEncodeOptions: false,
+    nullPrototype: false,
+    outputTypeUrlEnum: false,
+    outputRepeatedToMap: false,
+    repeatedToMapKeyField: 'key',
+    repeatedToMapValueField: 'value',
+    outputTruncationDetection: false,
+    outputEntries: false,
+    outputTimestampHelpers: false,
+    outputRoundTripTests: false,
+    warningHookImport: undefined,
+    outputEncodeWithMask: false,
+    outputSchemaHash: false,
+    outputOneofValue: false,
+    outputDebugString: false,
+    outputLongConversions: false,
+    outputAnyDispatcher: false,
+    outputReflection: false,
+    outputDurationHelpers: false,
+    outputPatch: false,
+    encodeFieldOrder: 'descriptor',
+    streamReconnect: false,
+    outputEventBus: false,
+    busImport: undefined,
+    outputPartialArray: false,
+    outputComparators: false,
+    sortKeyOption: undefined,
+    enumConversions: [],
+    enumConversionMatch: 'name',
   };
 }
 
@@ -149,6 +372,17 @@ export function optionsFromParameter(parameter: string | undefined): Options {
     options.forceLong = LongOption.LONG;
   }
 
+  if (!Object.values(BytesOption).includes(options.bytesAs)) {
+    throw new Error(
+      `ts-proto: unsupported bytesAs=${options.bytesAs}, expected one of ${Object.values(BytesOption).join(', ')}`
+    );
+  }
+
+  // Only the decode output is readonly, as a fully readonly interface couldn't be built up by our own methods
+  if (options.useReadonlyTypes !== false && options.useReadonlyTypes !== 'output') {
+    throw new Error(`ts-proto: unsupported useReadonlyTypes=${options.useReadonlyTypes}, expected output`);
+  }
+
   // Treat outputServices=false as NONE
   if ((options.outputServices as any) === false) {
     options.outputServices = [ServiceOption.NONE];
@@ -163,6 +397,19 @@ export function optionsFromParameter(parameter: string | undefined): Options {
     options.outputServices = [ServiceOption.DEFAULT];
   }
 
+  if (typeof options.messageConversions === 'string') {
+    options.messageConversions = [options.messageConversions];
+  }
+  if (typeof options.enumConversions === 'string') {
+    options.enumConversions = [options.enumConversions];
+  }
+  if (typeof options.messageUnions === 'string') {
+    options.messageUnions = [options.messageUnions];
+  }
+  if (typeof options.brandedTypes === 'string') {
+    options.brandedTypes = [options.brandedTypes];
+  }
+
   if ((options.useDate as any) === true) {
     // Treat useDate=true as DATE
     options.useDate = DateOption.DATE;
@@ -190,6 +437,141 @@ export function optionsFromParameter(parameter: string | undefined): Options {
     }
   }
 
+  // parseParameter leaves numbers as strings, and i.e. indent=abc would otherwise reach prettier as a NaN tabWidth
+  const indent = typeof options.indent === 'boolean' ? NaN : Number(options.indent);
+  if (!Number.isInteger(indent) || indent < 1) {
+    throw new Error(`ts-proto: indent=${options.indent} must be a positive integer`);
+  }
+  options.indent = indent;
+  if (typeof options.splitNestedTypesDepth === 'string') {
+    options.splitNestedTypesDepth = Number(options.splitNestedTypesDepth);
+  }
+  if (typeof options.sensitiveFieldOption === 'string') {
+    options.sensitiveFieldOption = Number(options.sensitiveFieldOption);
+  }
+  if (typeof options.enumLabelOption === 'string') {
+    options.enumLabelOption = Number(options.enumLabelOption);
+  }
+  if (typeof options.sortKeyOption === 'string') {
+    options.sortKeyOption = Number(options.sortKeyOption);
+  }
+
+  // outputUnknownFields=true is another way of asking for unknownFields=true
+  if (options.outputUnknownFields) {
+    options.unknownFields = true;
+  }
+
+  // enumStyle=const-enum is another way of asking for constEnums=true
+  if (options.enumStyle === 'const-enum') {
+    options.constEnums = true;
+  }
+
+  // const enums are erased at compile time, which single-file transpilers can't do across files
+  if (options.constEnums && options.isolatedModules) {
+    throw new Error(
+      'ts-proto: const enums (constEnums=true or enumStyle=const-enum) cannot be used with isolatedModules'
+    );
+  }
+
+  // Immer only drafts plain objects, and usePrototypeForDefaults makes messages inherit from their defaults
+  if (options.immerCompat && options.usePrototypeForDefaults) {
+    throw new Error(
+      'ts-proto: usePrototypeForDefaults cannot be used with immerCompat, as Immer only drafts plain objects'
+    );
+  }
+
+  // Exhaustive switches need a member for the values that were added to the enum after we were generated
+  if (options.outputEnumExhaustive && !options.unrecognizedEnum) {
+    throw new Error('ts-proto: outputEnumExhaustive cannot be used with unrecognizedEnum=false');
+  }
+
+  if (options.quoteStyle !== 'double' && options.quoteStyle !== 'single') {
+    throw new Error(`ts-proto: unsupported quoteStyle=${options.quoteStyle}, expected double or single`);
+  }
+
+  // Top-level types always stay in their proto's file
+  if (options.splitNestedTypes && !(options.splitNestedTypesDepth >= 1)) {
+    throw new Error('ts-proto: splitNestedTypesDepth must be at least 1');
+  }
+
+  // The JSON wire format of a map is a plain object, which is what the interface describes with useJsonWireFormat
+  if (options.useMapType && options.useJsonWireFormat) {
+    throw new Error('ts-proto: useMapType cannot be used with useJsonWireFormat');
+  }
+
+  // Merging or patching partials would have to handle the Map or plain object form of maps, which isn't done yet
+  if (options.useMapType && (options.outputMergeMethods || options.outputStreamAccumulators || options.outputPatch)) {
+    throw new Error(
+      'ts-proto: useMapType cannot be used with outputMergeMethods, outputStreamAccumulators or outputPatch'
+    );
+  }
+
+  // Messages can't both have no prototype and inherit their default values from one
+  if (options.nullPrototype && options.usePrototypeForDefaults) {
+    throw new Error('ts-proto: nullPrototype cannot be used with usePrototypeForDefaults');
+  }
+
+  // The hook is imported by name from its module, i.e. `./my-hook#onProtoWarning`
+  if (options.warningHookImport !== undefined && !/^[^#]+#[A-Za-z_$][\w$]*$/.test(options.warningHookImport)) {
+    throw new Error(`ts-proto: warningHookImport=${options.warningHookImport} must be of the form ./module#function`);
+  }
+
+  // The dispatcher decodes with the messages that registered themselves in typeRegistry.ts
+  if (options.outputAnyDispatcher && !(options.outputTypeRegistry && options.outputEncodeMethods)) {
+    throw new Error('ts-proto: outputAnyDispatcher requires outputTypeRegistry=true and outputEncodeMethods=true');
+  }
+
+  // The sort keys are marked by a custom option, which can only be looked up by its number
+  if (options.outputComparators && options.sortKeyOption === undefined) {
+    throw new Error('ts-proto: outputComparators requires sortKeyOption, i.e. sortKeyOption=50001');
+  }
+
+  // The adapters send messages as the bytes of their encode methods
+  if (options.outputEventBus && !options.outputEncodeMethods) {
+    throw new Error('ts-proto: outputEventBus requires outputEncodeMethods=true');
+  }
+
+  // Codec<T> is shared by all messages, so it can't list the members that are named after each message's fields
+  if (options.outputCodecInterface && (options.outputLowLevelWriters || options.outputSelectiveDecode)) {
+    const what = options.outputLowLevelWriters ? 'outputLowLevelWriters' : 'outputSelectiveDecode';
+    throw new Error(`ts-proto: outputCodecInterface cannot be used with ${what}`);
+  }
+
+  // The standalone functions are only referenced by the generated code that has been taught about them
+  if (options.outputStyle === 'functions') {
+    const unsupported = Object.entries({
+      outputTypeRegistry: options.outputTypeRegistry,
+      outputCodecInterface: options.outputCodecInterface,
+      outputSchema: options.outputSchema,
+      useAsyncIterable: options.useAsyncIterable,
+      outputStreamHelpers: options.outputStreamHelpers,
+      useMongoObjectId: options.useMongoObjectId,
+      outputLowLevelWriters: options.outputLowLevelWriters,
+      outputSelectiveDecode: options.outputSelectiveDecode,
+      outputEncodeInto: options.outputEncodeInto,
+      outputMergeMethods: options.outputMergeMethods || options.outputStreamAccumulators,
+      outputPatch: options.outputPatch,
+      outputFieldMaskMethods: options.outputFieldMaskMethods,
+      outputTextFormat: options.outputTextFormat,
+      sensitiveFieldOption: options.sensitiveFieldOption !== undefined,
+      nestJs: options.nestJs,
+    })
+      .filter(([, enabled]) => enabled)
+      .map(([name]) => name);
+    const services = options.outputServices.filter(
+      (service) => service !== ServiceOption.DEFAULT && service !== ServiceOption.GRPC && service !== ServiceOption.NONE
+    );
+    if (unsupported.length > 0 || services.length > 0) {
+      const what = [...unsupported, ...services.map((service) => `outputServices=${service}`)].join(', ');
+      throw new Error(`ts-proto: outputStyle=functions cannot be used with ${what}`);
+    }
+  }
+
+  // outputStreamAccumulators folds stream chunks together with the generated merge methods
+  if (options.outputStreamAccumulators) {
+    options.outputMergeMethods = true;
+  }
+
   return options;
 }
 
@@ -208,7 +590,15 @@ function parseParameter(parameter: string): Options {
   return options;
 }
 
-export function getTsPoetOpts(_options: Options): { forceModuleImport?: string[]; forceDefaultImport?: string[] } {
+export function getTsPoetOpts(_options: Options): {
+  forceModuleImport?: string[];
+  forceDefaultImport?: string[];
+  prettierOverrides?: { singleQuote?: boolean; tabWidth?: number };
+} {
   const imports = ['protobufjs/minimal' + _options.importSuffix];
-  return _options.esModuleInterop ? { forceDefaultImport: imports } : { forceModuleImport: imports };
+  const importOpts = _options.esModuleInterop ? { forceDefaultImport: imports } : { forceModuleImport: imports };
+  // ts-poet's prettier otherwise picks up whatever prettier config it finds, i.e. the one of the current project,
+  // so always pass both, for quoteStyle and indent (including their defaults) to decide the formatting
+  const prettierOverrides = { singleQuote: _options.quoteStyle === 'single', tabWidth: _options.indent };
+  return { ...importOpts, prettierOverrides };
 }
@@ -4,13 +4,15 @@ import {
   CodeGeneratorResponse_Feature,
   FileDescriptorProto,
 } from 'ts-proto-descriptors';
+import { Code } from 'ts-poet';
 import { promisify } from 'util';
 import { prefixDisableLinter, protoFilesToGenerate, readToBuffer } from './utils';
-import { generateFile, makeUtils } from './main';
+import { generateFiles, makeUtils } from './main';
 import { createTypeMap } from './types';
 import { Context } from './context';
 import { getTsPoetOpts, optionsFromParameter } from './options';
 import { generateTypeRegistry } from './generate-type-registry';
+import { generateTypeUrlEnum } from './generate-type-url-enum';
 
 // this would be the plugin called by the protoc compiler
 async function main() {
@@ -25,9 +27,13 @@ async function main() {
   const ctx: Context = { typeMap, options, utils };
 
   const filesToGenerate = options.emitImportedFiles ? request.protoFile : protoFilesToGenerate(request);
+  // With splitNestedTypes, a proto file can be output as several modules
+  const modules = filesToGenerate.reduce<Array<[string, Code]>>(
+    (acc, file) => [...acc, ...generateFiles(ctx, file)],
+    []
+  );
   const files = await Promise.all(
-    filesToGenerate.map(async (file) => {
-      const [path, code] = generateFile(ctx, file);
+    modules.map(async ([path, code]) => {
       const spec = await code.toStringWithImports({ ...getTsPoetOpts(options), path });
       return { name: path, content: prefixDisableLinter(spec) };
     })
@@ -44,6 +50,17 @@ async function main() {
     files.push({ name: path, content: prefixDisableLinter(spec) });
   }
 
+  if (options.outputTypeUrlEnum) {
+    const utils = makeUtils(options);
+    const ctx: Context = { options, typeMap, utils };
+
+    const path = 'knownTypeUrls.ts';
+    const code = generateTypeUrlEnum(ctx);
+
+    const spec = await code.toStringWithImports({ ...getTsPoetOpts(options), path });
+    files.push({ name: path, content: prefixDisableLinter(spec) });
+  }
+
   const response = CodeGeneratorResponse.fromPartial({
     file: files,
     supportedFeatures: CodeGeneratorResponse_Feature.FEATURE_PROTO3_OPTIONAL,
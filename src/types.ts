@@ -5,15 +5,23 @@ import {
   FieldDescriptorProto,
   FieldDescriptorProto_Label,
   FieldDescriptorProto_Type,
+  FieldOptions_JSType,
   FileDescriptorProto,
   MessageOptions,
   MethodDescriptorProto,
   ServiceDescriptorProto,
 } from 'ts-proto-descriptors';
-import { code, Code, imp, Import } from 'ts-poet';
-import { DateOption, EnvOption, LongOption, OneofOption, Options } from './options';
+import { code, Code, imp } from 'ts-poet';
+import { BytesOption, DateOption, DurationOption, EnvOption, LongOption, OneofOption, Options } from './options';
 import { visit } from './visit';
-import { fail, FormattedMethodDescriptor, impProto, maybePrefixPackage } from './utils';
+import {
+  fail,
+  FormattedMethodDescriptor,
+  impProtoMethod,
+  impProtoType,
+  maybePrefixPackage,
+  protoFileModuleName,
+} from './utils';
 import SourceInfo from './sourceInfo';
 import { camelCase } from './case';
 import { Context } from './context';
@@ -86,13 +94,15 @@ export function basicTypeName(
     case FieldDescriptorProto_Type.TYPE_FIXED64:
     case FieldDescriptorProto_Type.TYPE_SFIXED64:
       // this handles 2^53, Long is only needed for 2^64; this is effectively pbjs's forceNumber
-      return longTypeName(ctx);
+      return longTypeName(ctx, longOption(field, options));
     case FieldDescriptorProto_Type.TYPE_BOOL:
       return code`boolean`;
     case FieldDescriptorProto_Type.TYPE_STRING:
       return code`string`;
     case FieldDescriptorProto_Type.TYPE_BYTES:
-      if (options.env === EnvOption.NODE) {
+      if (options.bytesAs === BytesOption.BASE64_STRING) {
+        return code`string`;
+      } else if (isBufferBytes(options)) {
         return code`Buffer`;
       } else {
         return code`Uint8Array`;
@@ -198,9 +208,9 @@ export function defaultValue(ctx: Context, field: FieldDescriptorProto): any {
       }
     case FieldDescriptorProto_Type.TYPE_UINT64:
     case FieldDescriptorProto_Type.TYPE_FIXED64:
-      if (options.forceLong === LongOption.LONG) {
+      if (longOption(field, options) === LongOption.LONG) {
         return code`${utils.Long}.UZERO`;
-      } else if (options.forceLong === LongOption.STRING) {
+      } else if (longOption(field, options) === LongOption.STRING) {
         return '"0"';
       } else {
         return 0;
@@ -208,9 +218,9 @@ export function defaultValue(ctx: Context, field: FieldDescriptorProto): any {
     case FieldDescriptorProto_Type.TYPE_INT64:
     case FieldDescriptorProto_Type.TYPE_SINT64:
     case FieldDescriptorProto_Type.TYPE_SFIXED64:
-      if (options.forceLong === LongOption.LONG) {
+      if (longOption(field, options) === LongOption.LONG) {
         return code`${utils.Long}.ZERO`;
-      } else if (options.forceLong === LongOption.STRING) {
+      } else if (longOption(field, options) === LongOption.STRING) {
         return '"0"';
       } else {
         return 0;
@@ -220,11 +230,7 @@ export function defaultValue(ctx: Context, field: FieldDescriptorProto): any {
     case FieldDescriptorProto_Type.TYPE_STRING:
       return '""';
     case FieldDescriptorProto_Type.TYPE_BYTES:
-      if (options.env === EnvOption.NODE) {
-        return 'Buffer.alloc(0)';
-      } else {
-        return 'new Uint8Array()';
-      }
+      return defaultBytes(options);
     case FieldDescriptorProto_Type.TYPE_MESSAGE:
     default:
       return 'undefined';
@@ -268,9 +274,9 @@ export function notDefaultCheck(
     case FieldDescriptorProto_Type.TYPE_INT64:
     case FieldDescriptorProto_Type.TYPE_SINT64:
     case FieldDescriptorProto_Type.TYPE_SFIXED64:
-      if (options.forceLong === LongOption.LONG) {
+      if (longOption(field, options) === LongOption.LONG) {
         return code`${maybeNotUndefinedAnd} !${place}.isZero()`;
-      } else if (options.forceLong === LongOption.STRING) {
+      } else if (longOption(field, options) === LongOption.STRING) {
         return code`${maybeNotUndefinedAnd} ${place} !== "0"`;
       } else {
         return code`${maybeNotUndefinedAnd} ${place} !== 0`;
@@ -287,14 +293,14 @@ export function notDefaultCheck(
 }
 
 /** A map of proto type name, e.g. `foo.Message.Inner`, to module/class name, e.g. `foo`, `Message_Inner`. */
-export type TypeMap = Map<string, [string, string, DescriptorProto | EnumDescriptorProto]>;
+export type TypeMap = Map<string, [string, string, DescriptorProto | EnumDescriptorProto, string]>;
 
-/** Scans all of the proto files in `request` and builds a map of proto typeName -> TS module/name. */
+/** Scans all of the proto files in `request` and builds a map of proto typeName -> TS module/name/package. */
 export function createTypeMap(request: CodeGeneratorRequest, options: Options): TypeMap {
   const typeMap: TypeMap = new Map();
   for (const file of request.protoFile) {
     // We assume a file.name of google/protobuf/wrappers.proto --> a module path of google/protobuf/wrapper.ts
-    const moduleName = file.name.replace('.proto', '');
+    const moduleName = protoFileModuleName(file, options);
     // So given a fullName like FooMessage_InnerMessage, proto will see that as package.name.FooMessage.InnerMessage
     function saveMapping(
       tsFullName: string,
@@ -304,13 +310,49 @@ export function createTypeMap(request: CodeGeneratorRequest, options: Options):
     ): void {
       // package is optional, but make sure we have a dot-prefixed type name either way
       const prefix = file.package.length === 0 ? '' : `.${file.package}`;
-      typeMap.set(`${prefix}.${protoFullName}`, [moduleName, tsFullName, desc]);
+      const root = splitNestedRoot(options, protoFullName, desc);
+      const module = root ? `${moduleName}.${root}` : moduleName;
+      typeMap.set(`${prefix}.${protoFullName}`, [module, tsFullName, desc, file.package]);
     }
     visit(file, SourceInfo.empty(), saveMapping, options, saveMapping);
   }
   return typeMap;
 }
 
+/**
+ * With splitNestedTypes=true, returns the type that `protoFullName` is output with in its own module, i.e.
+ * `Outer.Middle.Inner` for `Outer.Middle.Inner.Deep` with splitNestedTypesDepth=2, or `undefined` if the type
+ * stays in the module of its proto file. Map entries always stay with the message of their map field.
+ */
+export function splitNestedRoot(
+  options: Options,
+  protoFullName: string,
+  desc: DescriptorProto | EnumDescriptorProto
+): string | undefined {
+  const names = protoFullName.split('.');
+  const depth = options.splitNestedTypesDepth;
+  if (!options.splitNestedTypes || names.length <= depth) {
+    return undefined;
+  }
+  if (names.length === depth + 1 && 'field' in desc && desc.options?.mapEntry) {
+    return undefined;
+  }
+  return names.slice(0, depth + 1).join('.');
+}
+
+/** Returns the types of `file` that are output in their own module with splitNestedTypes, see `splitNestedRoot`. */
+export function splitNestedRoots(file: FileDescriptorProto, options: Options): string[] {
+  const roots = new Set<string>();
+  const collect = (_: string, desc: DescriptorProto | EnumDescriptorProto, __: SourceInfo, protoFullName: string) => {
+    const root = splitNestedRoot(options, protoFullName, desc);
+    if (root) {
+      roots.add(root);
+    }
+  };
+  visit(file, SourceInfo.empty(), collect, options, collect);
+  return [...roots];
+}
+
 /** A "Scalar Value Type" as defined in https://developers.google.com/protocol-buffers/docs/proto3#scalar */
 export function isScalar(field: FieldDescriptorProto): boolean {
   const scalarTypes = [
@@ -333,6 +375,43 @@ export function isScalar(field: FieldDescriptorProto): boolean {
   return scalarTypes.includes(field.type);
 }
 
+/**
+ * Whether `field` is marked by the bool `FieldOptions` extension numbered `sensitiveFieldOption`, i.e.
+ * `(ts_proto.sensitive) = true`. protoc only hands us extensions as unknown fields, keyed by their tag.
+ */
+export function isSensitiveField(field: FieldDescriptorProto, options: Options): boolean {
+  return hasBoolFieldOption(field, options.sensitiveFieldOption);
+}
+
+/** Whether `field` is marked by the bool `FieldOptions` extension numbered `sortKeyOption`. */
+export function isSortKeyField(field: FieldDescriptorProto, options: Options): boolean {
+  return hasBoolFieldOption(field, options.sortKeyOption);
+}
+
+function hasBoolFieldOption(field: FieldDescriptorProto, extensionNumber: number | undefined): boolean {
+  if (extensionNumber === undefined) {
+    return false;
+  }
+  const unknownFields: { [tag: number]: Uint8Array[] } = (field.options as any)?._unknownFields ?? {};
+  const values = Object.entries(unknownFields).find(([tag]) => Number(tag) >>> 3 === extensionNumber);
+  // The last value wins, and a varint bool is `true` if it's non-zero
+  return !!values && values[1][values[1].length - 1].some((byte) => byte !== 0);
+}
+
+/**
+ * Whether `field` is a `Date` that also keeps the `Timestamp` it was decoded from in a parallel `fooRaw`
+ * property, per `timestampKeepRaw`. Repeated, map and oneof timestamps only get the `Date`.
+ */
+export function hasRawTimestamp(field: FieldDescriptorProto, options: Options): boolean {
+  return (
+    options.timestampKeepRaw &&
+    options.useDate === DateOption.DATE &&
+    isTimestamp(field) &&
+    !isRepeated(field) &&
+    !(isWithinOneOf(field) && !field.proto3Optional)
+  );
+}
+
 // When useOptionals='messages', non-scalar fields are translated into optional
 // properties. When useOptionals='all', all fields are translated into
 // optional properties, with the exception of map Entry key/values, which must
@@ -348,7 +427,9 @@ export function isOptionalProperty(
   return (
     (optionalMessages && isMessage(field) && !isRepeated(field)) ||
     (optionalAll && !messageOptions?.mapEntry) ||
-    field.proto3Optional
+    (options.emptyRepeated === 'undefined' && isRepeated(field)) ||
+    // Wrapper types already model presence as `| undefined`, so `optional` doesn't make them optional twice
+    (field.proto3Optional && wrapperTypeName(field.typeName) === undefined)
   );
 }
 
@@ -361,6 +442,25 @@ export function isBytes(field: FieldDescriptorProto): boolean {
   return field.type === FieldDescriptorProto_Type.TYPE_BYTES;
 }
 
+/** Whether `bytes` fields are `Buffer`s, i.e. with `bytesAs=buffer`, or with `env=node` and the default `bytesAs`. */
+export function isBufferBytes(options: Options): boolean {
+  return (
+    options.bytesAs === BytesOption.BUFFER ||
+    (options.bytesAs === BytesOption.UINT8ARRAY && options.env === EnvOption.NODE)
+  );
+}
+
+/** The default value of a `bytes` field, in the representation chosen by `bytesAs`. */
+export function defaultBytes(options: Options): string {
+  if (options.bytesAs === BytesOption.BASE64_STRING) {
+    return '""';
+  } else if (isBufferBytes(options)) {
+    return 'Buffer.alloc(0)';
+  } else {
+    return 'new Uint8Array()';
+  }
+}
+
 export function isMessage(field: FieldDescriptorProto): boolean {
   return field.type === FieldDescriptorProto_Type.TYPE_MESSAGE;
 }
@@ -373,8 +473,38 @@ export function isWithinOneOf(field: FieldDescriptorProto): boolean {
   return field.hasOwnProperty('oneofIndex');
 }
 
+/**
+ * Returns the fields of the `oneof` at `oneofIndex`, leaving out proto3 `optional` fields, whose synthetic
+ * single-field oneofs only track presence and must never become part of a user oneof.
+ */
+export function oneofMembers(messageDesc: DescriptorProto, oneofIndex: number): FieldDescriptorProto[] {
+  return messageDesc.field.filter((f) => isWithinOneOf(f) && f.oneofIndex === oneofIndex && !f.proto3Optional);
+}
+
+/** Whether `oneof`s are generated as a single union property instead of one property per member. */
+export function isOneofUnions(options: Options): boolean {
+  return (
+    options.oneof === OneofOption.UNIONS ||
+    options.oneof === OneofOption.UNIONS_VALUE ||
+    options.oneof === OneofOption.NAMED_UNIONS
+  );
+}
+
 export function isWithinOneOfThatShouldBeUnion(options: Options, field: FieldDescriptorProto): boolean {
-  return isWithinOneOf(field) && options.oneof === OneofOption.UNIONS && !field.proto3Optional;
+  return isWithinOneOf(field) && isOneofUnions(options) && !field.proto3Optional;
+}
+
+/** Returns the discriminant property of oneof unions, i.e. `kind` for `oneof=named-unions` and `$case` otherwise. */
+export function oneofCaseName(options: Options): string {
+  return options.oneof === OneofOption.NAMED_UNIONS ? 'kind' : '$case';
+}
+
+/**
+ * Returns the property that holds a oneof member's value within its union, i.e. `{ $case: 'foo', foo: ... }`
+ * for `oneof=unions` and `{ $case: 'foo', value: ... }` for `oneof=unions-value`.
+ */
+export function oneofValueName(fieldName: string, options: Options): string {
+  return options.oneof === OneofOption.UNIONS_VALUE ? 'value' : fieldName;
 }
 
 export function isRepeated(field: FieldDescriptorProto): boolean {
@@ -385,6 +515,21 @@ export function isLong(field: FieldDescriptorProto): boolean {
   return basicLongWireType(field.type) !== undefined;
 }
 
+/**
+ * Returns how a 64-bit `field` is represented, i.e. `forceLong`, unless the field overrides it with protobuf.js'
+ * `[jstype = JS_STRING]` or `[jstype = JS_NUMBER]` option. `JS_NORMAL` keeps the configured `forceLong`.
+ */
+export function longOption(field: FieldDescriptorProto, options: Pick<Options, 'forceLong'>): LongOption {
+  switch (isLong(field) ? field.options?.jstype : undefined) {
+    case FieldOptions_JSType.JS_STRING:
+      return LongOption.STRING;
+    case FieldOptions_JSType.JS_NUMBER:
+      return LongOption.NUMBER;
+    default:
+      return options.forceLong;
+  }
+}
+
 export function isWholeNumber(field: FieldDescriptorProto): boolean {
   return (
     field.type === FieldDescriptorProto_Type.TYPE_INT32 ||
@@ -413,6 +558,15 @@ export function isTimestamp(field: FieldDescriptorProto): boolean {
   return field.typeName === '.google.protobuf.Timestamp';
 }
 
+export function isDuration(field: FieldDescriptorProto): boolean {
+  return field.typeName === '.google.protobuf.Duration';
+}
+
+/** Whether `field` is a `Duration` that `useDuration` maps to a native number of seconds or canonical string. */
+export function isNativeDuration(field: FieldDescriptorProto, options: Options): boolean {
+  return isDuration(field) && options.useDuration !== DurationOption.DURATION;
+}
+
 export function isValueType(ctx: Context, field: FieldDescriptorProto): boolean {
   return valueTypeName(ctx, field.typeName) !== undefined;
 }
@@ -457,6 +611,24 @@ export function isLongValueType(field: FieldDescriptorProto): boolean {
   return field.typeName === '.google.protobuf.Int64Value' || field.typeName === '.google.protobuf.UInt64Value';
 }
 
+/** Whether `field` is a wrapper of a single scalar, e.g. `Int32Value`, but not a `ListValue` or `Value`. */
+export function isScalarWrapperType(field: FieldDescriptorProto): boolean {
+  switch (field.typeName) {
+    case '.google.protobuf.StringValue':
+    case '.google.protobuf.Int32Value':
+    case '.google.protobuf.UInt32Value':
+    case '.google.protobuf.DoubleValue':
+    case '.google.protobuf.FloatValue':
+    case '.google.protobuf.Int64Value':
+    case '.google.protobuf.UInt64Value':
+    case '.google.protobuf.BoolValue':
+    case '.google.protobuf.BytesValue':
+      return true;
+    default:
+      return false;
+  }
+}
+
 export function isEmptyType(typeName: string): boolean {
   return typeName === '.google.protobuf.Empty';
 }
@@ -477,7 +649,9 @@ export function valueTypeName(ctx: Context, typeName: string): Code | undefined
     case '.google.protobuf.BoolValue':
       return code`boolean`;
     case '.google.protobuf.BytesValue':
-      return ctx.options.env === EnvOption.NODE
+      return ctx.options.bytesAs === BytesOption.BASE64_STRING
+        ? code`string`
+        : isBufferBytes(ctx.options)
         ? code`Buffer`
         : ctx.options.useJsonWireFormat
         ? code`string`
@@ -519,11 +693,11 @@ export function wrapperTypeName(typeName: string): string | undefined {
   }
 }
 
-function longTypeName(ctx: Context): Code {
-  const { options, utils } = ctx;
-  if (options.forceLong === LongOption.LONG) {
+function longTypeName(ctx: Context, forceLong: LongOption = ctx.options.forceLong): Code {
+  const { utils } = ctx;
+  if (forceLong === LongOption.LONG) {
     return code`${utils.Long}`;
-  } else if (options.forceLong === LongOption.STRING) {
+  } else if (forceLong === LongOption.STRING) {
     return code`string`;
   } else {
     return code`number`;
@@ -566,31 +740,113 @@ export function messageToTypeName(
     }
   }
 
+  if (!typeOptions.keepValueType && protoType === '.google.protobuf.Duration') {
+    if (options.useDuration === DurationOption.NUMBER) {
+      return code`number`;
+    }
+
+    if (options.useDuration === DurationOption.STRING) {
+      return code`string`;
+    }
+  }
+
   // need to use endsWith instead of === because objectid could be imported from an external proto file
   if (!typeOptions.keepValueType && options.useMongoObjectId && protoType.endsWith('.ObjectId')) {
     return code`mongodb.ObjectId`;
   }
-  const [module, type] = toModuleAndType(typeMap, protoType);
-  return code`${impProto(options, module, type)}`;
+  const [module, type, , pkg] = toModuleAndType(typeMap, protoType);
+  return impProtoType(ctx, module, pkg, type);
 }
 
-/** Breaks `.some_proto_namespace.Some.Message` into `['some_proto_namespace', 'Some_Message', Descriptor]. */
-function toModuleAndType(typeMap: TypeMap, protoType: string): [string, string, DescriptorProto | EnumDescriptorProto] {
+/**
+ * Breaks `.some_proto_namespace.Some.Message` into
+ * `['some_proto_namespace', 'Some_Message', Descriptor, 'some_proto_namespace']`.
+ */
+function toModuleAndType(
+  typeMap: TypeMap,
+  protoType: string
+): [string, string, DescriptorProto | EnumDescriptorProto, string] {
   return typeMap.get(protoType) || fail(`No type found for ${protoType}`);
 }
 
-export function getEnumMethod(ctx: Context, enumProtoType: string, methodSuffix: string): Import {
-  const [module, type] = toModuleAndType(ctx.typeMap, enumProtoType);
-  return impProto(ctx.options, module, `${camelCase(type)}${methodSuffix}`);
+export function getEnumMethod(ctx: Context, enumProtoType: string, methodSuffix: string): Code {
+  const [module, type, , pkg] = toModuleAndType(ctx.typeMap, enumProtoType);
+  return impProtoType(ctx, module, pkg, `${camelCase(type)}${methodSuffix}`);
+}
+
+/** Returns the static `method` of the message `messageProtoType`, i.e. `Foo.decode`, see `impProtoMethod`. */
+export function getMessageMethod(ctx: Context, messageProtoType: string, method: string): Code {
+  const [module, type, , pkg] = toModuleAndType(ctx.typeMap, messageProtoType);
+  return impProtoMethod(ctx, module, pkg, type, method);
+}
+
+/** Returns the `fooSuffix` function generated next to the message `messageProtoType`, i.e. `fooToQueryParams`. */
+export function getMessageFunction(ctx: Context, messageProtoType: string, functionSuffix: string): Code {
+  const [module, type, , pkg] = toModuleAndType(ctx.typeMap, messageProtoType);
+  return impProtoType(ctx, module, pkg, `${camelCase(type)}${functionSuffix}`);
+}
+
+/** Returns the `FooLongAsString` type generated next to the message `messageProtoType`. */
+export function getMessageLongAsStringType(ctx: Context, messageProtoType: string): Code {
+  const [module, type, , pkg] = toModuleAndType(ctx.typeMap, messageProtoType);
+  return impProtoType(ctx, module, pkg, `${type}LongAsString`);
+}
+
+/** Returns the `FooSchema` zod schema generated next to the message `messageProtoType`. */
+export function getMessageSchema(ctx: Context, messageProtoType: string): Code {
+  const [module, type, , pkg] = toModuleAndType(ctx.typeMap, messageProtoType);
+  return impProtoType(ctx, module, pkg, `${type}Schema`);
+}
+
+/**
+ * Returns the branded type name of `field` if it's picked by a `brandedTypes=pkg.User.id:UserId` entry,
+ * i.e. `UserId` for the `id` field of `pkg.User`.
+ */
+export function brandedTypeName(
+  ctx: Context,
+  messageDesc: DescriptorProto,
+  field: FieldDescriptorProto
+): string | undefined {
+  const { options, typeMap } = ctx;
+  for (const entry of options.brandedTypes) {
+    const [path, brand] = entry.split(':');
+    const dot = (path ?? '').lastIndexOf('.');
+    if (!brand || !/^[A-Za-z_$][\w$]*$/.test(brand) || dot <= 0) {
+      throw new Error(`brandedTypes entries must look like 'pkg.User.id:UserId', got '${entry}'`);
+    }
+    if (path.substring(dot + 1) !== field.name || typeMap.get(`.${path.substring(0, dot)}`)?.[2] !== messageDesc) {
+      continue;
+    }
+    if (!isScalar(field) || isBytes(field)) {
+      throw new Error(`brandedTypes: ${path} must be a string, number or bool field`);
+    }
+    return brand;
+  }
+  return undefined;
+}
+
+/** Casts `value`, a value of `field`'s primitive type, to the branded type of `field`, if it has one. */
+export function brandedValue(
+  ctx: Context,
+  messageDesc: DescriptorProto,
+  field: FieldDescriptorProto,
+  value: Code | string
+): Code {
+  const brand = brandedTypeName(ctx, messageDesc, field);
+  return brand ? code`${value} as ${brand}` : code`${value}`;
 }
 
 /** Return the TypeName for any field (primitive/message/etc.) as exposed in the interface. */
 export function toTypeName(ctx: Context, messageDesc: DescriptorProto, field: FieldDescriptorProto): Code {
-  let type = basicTypeName(ctx, field, { keepValueType: false });
+  const brand = brandedTypeName(ctx, messageDesc, field);
+  let type = brand ? code`${brand}` : basicTypeName(ctx, field, { keepValueType: false });
   if (isRepeated(field)) {
     const mapType = detectMapType(ctx, messageDesc, field);
     if (mapType) {
       const { keyType, valueType } = mapType;
+      if (ctx.options.useMapType) {
+        return code`Map<${keyType}, ${valueType}>`;
+      }
       return code`{ [key: ${keyType} ]: ${valueType} }`;
     }
     return code`${type}[]`;
@@ -685,7 +941,12 @@ export function responseType(
   methodDesc: MethodDescriptorProto,
   typeOptions: { keepValueType?: boolean; repeated?: boolean } = {}
 ): Code {
-  return messageToTypeName(ctx, methodDesc.outputType, typeOptions);
+  const type = messageToTypeName(ctx, methodDesc.outputType, typeOptions);
+  // With useReadonlyTypes=output, clients return the readonly type of decoded messages
+  if (ctx.options.useReadonlyTypes === 'output') {
+    return code`${ctx.utils.DeepReadonly}<${type}>`;
+  }
+  return type;
 }
 
 export function responsePromise(ctx: Context, methodDesc: MethodDescriptorProto): Code {
@@ -740,6 +1001,9 @@ export function detectBatchMethod(
       if (mapType) {
         outputType = mapType.valueType;
       }
+      if (ctx.options.useReadonlyTypes === 'output') {
+        outputType = code`${ctx.utils.DeepReadonly}<${outputType}>`;
+      }
       const uniqueIdentifier = `${maybePrefixPackage(fileDesc, serviceDesc.name)}.${methodDesc.name}`;
       return {
         methodDesc: methodDesc,
@@ -756,6 +1020,84 @@ export function detectBatchMethod(
   return undefined;
 }
 
+export interface PaginatedMethod {
+  methodDesc: MethodDescriptorProto;
+  requestTokenField: FieldDescriptorProto;
+  responseTokenField: FieldDescriptorProto;
+  itemsField: FieldDescriptorProto;
+  itemType: Code;
+}
+
+/**
+ * Detects list methods that follow the `page_token`/`next_page_token` convention, i.e. a unary method whose
+ * request has a string `paginationRequestField`, and whose response has a string `paginationResponseField`
+ * plus exactly one repeated (non-map) field holding the page's items.
+ */
+export function detectPaginatedMethod(ctx: Context, methodDesc: MethodDescriptorProto): PaginatedMethod | undefined {
+  const { typeMap, options } = ctx;
+  if (methodDesc.clientStreaming || methodDesc.serverStreaming) {
+    return undefined;
+  }
+  const inputType = typeMap.get(methodDesc.inputType);
+  const outputType = typeMap.get(methodDesc.outputType);
+  if (!inputType || !outputType) {
+    return undefined;
+  }
+  const inputTypeDesc = inputType[2] as DescriptorProto;
+  const outputTypeDesc = outputType[2] as DescriptorProto;
+  const isStringField = (field: FieldDescriptorProto | undefined) =>
+    field !== undefined && field.type === FieldDescriptorProto_Type.TYPE_STRING && !isRepeated(field);
+  const requestTokenField = inputTypeDesc.field?.find((f) => f.name === options.paginationRequestField);
+  const responseTokenField = outputTypeDesc.field?.find((f) => f.name === options.paginationResponseField);
+  const itemsFields = (outputTypeDesc.field || []).filter(
+    (f) => isRepeated(f) && !detectMapType(ctx, outputTypeDesc, f)
+  );
+  if (!isStringField(requestTokenField) || !isStringField(responseTokenField) || itemsFields.length !== 1) {
+    return undefined;
+  }
+  const itemsField = itemsFields[0];
+  return {
+    methodDesc,
+    requestTokenField: requestTokenField!,
+    responseTokenField: responseTokenField!,
+    itemsField,
+    itemType: basicTypeName(ctx, itemsField),
+  };
+}
+
+export interface WatchMethod {
+  methodDesc: MethodDescriptorProto;
+  requestTokenField: FieldDescriptorProto;
+  responseTokenField: FieldDescriptorProto;
+}
+
+/**
+ * Detects watch methods that follow the `watch_token`/`next_watch_token` convention, i.e. a unary method whose
+ * request has a string `watchRequestField`, and whose response, the current state, has a string `watchResponseField`
+ * to poll again with.
+ */
+export function detectWatchMethod(ctx: Context, methodDesc: MethodDescriptorProto): WatchMethod | undefined {
+  const { typeMap, options } = ctx;
+  if (methodDesc.clientStreaming || methodDesc.serverStreaming) {
+    return undefined;
+  }
+  const inputType = typeMap.get(methodDesc.inputType);
+  const outputType = typeMap.get(methodDesc.outputType);
+  if (!inputType || !outputType) {
+    return undefined;
+  }
+  const isStringField = (field: FieldDescriptorProto | undefined) =>
+    field !== undefined && field.type === FieldDescriptorProto_Type.TYPE_STRING && !isRepeated(field);
+  const requestTokenField = (inputType[2] as DescriptorProto).field?.find((f) => f.name === options.watchRequestField);
+  const responseTokenField = (outputType[2] as DescriptorProto).field?.find(
+    (f) => f.name === options.watchResponseField
+  );
+  if (!isStringField(requestTokenField) || !isStringField(responseTokenField)) {
+    return undefined;
+  }
+  return { methodDesc, requestTokenField: requestTokenField!, responseTokenField: responseTokenField! };
+}
+
 function hasSingleRepeatedField(messageDesc: DescriptorProto): boolean {
   return messageDesc.field.length == 1 && messageDesc.field[0].label === FieldDescriptorProto_Label.LABEL_REPEATED;
 }
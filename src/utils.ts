@@ -1,4 +1,4 @@
-import { code, Code, imp, Import } from 'ts-poet';
+import { code, Code, def, imp, Import } from 'ts-poet';
 import {
   CodeGeneratorRequest,
   FieldDescriptorProto,
@@ -8,6 +8,7 @@ import {
 } from 'ts-proto-descriptors';
 import ReadStream = NodeJS.ReadStream;
 import { SourceDescription } from './sourceInfo';
+import { Context } from './context';
 import { Options, ServiceOption } from './options';
 import { camelCase } from './case';
 
@@ -15,6 +16,21 @@ export function protoFilesToGenerate(request: CodeGeneratorRequest): FileDescrip
   return request.protoFile.filter((f) => request.fileToGenerate.includes(f.name));
 }
 
+/**
+ * Returns the module path that `file` is output to, without the `.ts` extension.
+ *
+ * By default this mirrors the `.proto` file path, i.e. `company/foo.proto` --> `company/foo`, but
+ * with `outputLayout=package` the file is placed in its package's directory, i.e. `company.api` --> `company/api/foo`.
+ */
+export function protoFileModuleName(file: FileDescriptorProto, options: Options): string {
+  const sourcePath = file.name.replace('.proto', '');
+  if (options.outputLayout === 'package' && file.package.length > 0) {
+    const baseName = sourcePath.split('/').pop();
+    return `${file.package.split('.').join('/')}/${baseName}`;
+  }
+  return sourcePath;
+}
+
 export function readToBuffer(stream: ReadStream): Promise<Buffer> {
   return new Promise((resolve) => {
     const ret: Array<Buffer | string> = [];
@@ -106,6 +122,27 @@ export function maybePrefixPackage(fileDesc: FileDescriptorProto, rest: string):
   return `${prefix}${rest}`;
 }
 
+/**
+ * Prepends the `servicePathPrefix` option to a `pkg.Svc` service name, i.e. `api/pkg.Svc`, for the runtimes
+ * that request `/${service}/${method}` to end up at `/api/pkg.Svc/Method`.
+ */
+export function maybePrefixServicePath(options: Options, serviceName: string): string {
+  const prefix = options.servicePathPrefix.replace(/^\/+|\/+$/g, '');
+  return prefix === '' ? serviceName : `${prefix}/${serviceName}`;
+}
+
+/**
+ * Returns a call of the user-supplied hook of `warningHookImport=./my-hook#onProtoWarning` with the `warning`
+ * object literal, or nothing when no hook is configured, so that the call sites cost nothing by default.
+ */
+export function maybeWarningHookCall(options: Options, warning: string): Code | string {
+  if (options.warningHookImport === undefined) {
+    return '';
+  }
+  const [module, name] = options.warningHookImport.split('#');
+  return code`${imp(`${name}@${module}`)}(${warning});`;
+}
+
 /**
  * Asserts that an object is an instance of a certain class
  * @param obj The object to check
@@ -210,3 +247,73 @@ export function impProto(options: Options, module: string, type: string): Import
     return imp(importString);
   }
 }
+
+/**
+ * Imports the generated `type` of the proto package `pkg` from `module`.
+ *
+ * With wrapInNamespace=true, files only export their package's namespace, so the types of other files are referenced
+ * through a namespace import of that file instead, i.e. `google_protobuf_timestamp.google.protobuf.Timestamp`.
+ */
+export function impProtoType(
+  ctx: Pick<Context, 'options' | 'currentModule'>,
+  module: string,
+  pkg: string,
+  type: string
+): Code {
+  const { options } = ctx;
+  if (!options.wrapInNamespace || pkg === '' || module === ctx.currentModule) {
+    return code`${impProto(options, module, type)}`;
+  }
+  const alias = module.replace(/\W/g, '_');
+  return code`${imp(`${alias}*./${module}${options.fileSuffix}${options.importSuffix}`)}.${pkg}.${type}`;
+}
+
+/**
+ * Imports the static `method` of the generated message `type`, i.e. `Timestamp.fromJSON`, or with
+ * outputStyle=functions the standalone `fromJSONTimestamp` function that replaces it.
+ */
+export function impProtoMethod(
+  ctx: Pick<Context, 'options' | 'currentModule'>,
+  module: string,
+  pkg: string,
+  type: string,
+  method: string
+): Code {
+  if (ctx.options.outputStyle === 'functions') {
+    return impProtoType(ctx, module, pkg, `${method}${type}`);
+  }
+  return code`${impProtoType(ctx, module, pkg, type)}.${method}`;
+}
+
+/** Refers to the static `method` of the message `fullName` from its own file, i.e. `Foo.encode` or `encodeFoo`. */
+export function messageMethod(options: Options, fullName: string, method: string): string {
+  return options.outputStyle === 'functions' ? `${method}${fullName}` : `${fullName}.${method}`;
+}
+
+/**
+ * Starts the definition of the static `method` of the message `fullName`, i.e. `encode` as a member of
+ * `export const Foo = { ... }`, or `export function encodeFoo` with outputStyle=functions.
+ */
+export function messageMethodHead(options: Options, fullName: string, method: string): Code {
+  return options.outputStyle === 'functions' ? code`export function ${def(`${method}${fullName}`)}` : code`${method}`;
+}
+
+/** The value of an empty map field, i.e. `{}`, or `new Map()` with useMapType=true. */
+export function emptyMapValue(options: Options): string {
+  return options.useMapType ? 'new Map()' : emptyObject(options);
+}
+
+/** Returns a new plain object, which with nullPrototype has no prototype, so `__proto__` is just another key. */
+export function emptyObject(options: Options): string {
+  return options.nullPrototype ? 'Object.create(null)' : '{}';
+}
+
+/** Wraps the `{ ... }` object literal `literal` in a copy without a prototype, with nullPrototype. */
+export function maybeNullPrototype(options: Options, literal: Code): Code {
+  return options.nullPrototype ? code`Object.assign(Object.create(null), ${literal})` : literal;
+}
+
+/** Sets `key` of the map field `map` to `value`, i.e. `map[key] = value`, or `map.set(key, value)` with useMapType. */
+export function mapSetSnippet(options: Options, map: string, key: string, value: string): string {
+  return options.useMapType ? `${map}.set(${key}, ${value})` : `${map}[${key}] = ${value}`;
+}
@@ -0,0 +1,48 @@
+import { joinCode } from 'ts-poet';
+import { defaultOptions, Options } from '../src/options';
+import { generateGrpcJsBidiObservables, generateGrpcWebBidiObservables } from '../src/generate-bidi-observable';
+import { Context } from '../src/context';
+import { Utils } from '../src/main';
+import { FormattedMethodDescriptor } from '../src/utils';
+
+describe('bidi-observable', () => {
+  // package chat; service Chat { rpc Talk(stream ChatMessage) returns (stream ChatMessage); }
+  const typeMap = new Map([['.chat.ChatMessage', ['chat', 'ChatMessage', { name: 'ChatMessage', field: [] }, 'chat']]]);
+  const context = (options: Partial<Options>): Context => ({
+    options: { ...defaultOptions(), ...options },
+    typeMap: typeMap as any,
+    utils: {} as any as Utils,
+    currentModule: 'chat',
+  });
+  const serviceDesc = (ctx: Context) =>
+    ({
+      name: 'Chat',
+      method: [
+        new FormattedMethodDescriptor(
+          {
+            name: 'Talk',
+            inputType: '.chat.ChatMessage',
+            outputType: '.chat.ChatMessage',
+            clientStreaming: true,
+            serverStreaming: true,
+          } as any,
+          ctx.options
+        ),
+      ],
+    } as any);
+
+  it('imports Observable and Subject from rxjs by default', async () => {
+    const ctx = context({});
+    const generated = await joinCode(generateGrpcJsBidiObservables(ctx, serviceDesc(ctx))).toStringWithImports();
+    expect(generated).toMatch(/import { (Observable, Subject|Subject, Observable) } from ['"]rxjs['"]/);
+  });
+
+  it('imports Observable and Subject from bidiObservableImport', async () => {
+    const ctx = context({ bidiObservableImport: './my-rxjs' });
+    for (const generate of [generateGrpcJsBidiObservables, generateGrpcWebBidiObservables]) {
+      const generated = await joinCode(generate(ctx, serviceDesc(ctx))).toStringWithImports();
+      expect(generated).toMatch(/import { (Observable, Subject|Subject, Observable) } from ['"]\.\/my-rxjs['"]/);
+      expect(generated).not.toMatch(/from ['"]rxjs['"]/);
+    }
+  });
+});
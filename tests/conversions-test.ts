@@ -0,0 +1,192 @@
+import { defaultOptions, Options } from '../src/options';
+import { FieldDescriptorProto_Label, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import {
+  conversionsTo,
+  enumConversionsTo,
+  generateConversion,
+  generateEnumConversion,
+} from '../src/generate-conversions';
+import { Context } from '../src/context';
+import { Utils } from '../src/main';
+
+describe('conversions', () => {
+  describe('messageConversions', () => {
+    const { TYPE_ENUM, TYPE_INT32, TYPE_MESSAGE, TYPE_STRING } = FieldDescriptorProto_Type;
+    const { LABEL_OPTIONAL, LABEL_REPEATED } = FieldDescriptorProto_Label;
+    const field = (name: string, number: number, type: number, typeName = '', label = LABEL_OPTIONAL) =>
+      ({ name, number, type, typeName, label } as any);
+    // package pkg.vN; message Foo { string name = 1; Bar bar = 2; repeated Bar bars = 3;
+    //   map<string, Bar> by_name = 4; Color color = 5; repeated string tags = 6; } message Bar { int32 id = 1; }
+    const foo = (pkg: string) => ({
+      name: 'Foo',
+      field: [
+        field('name', 1, TYPE_STRING),
+        field('bar', 2, TYPE_MESSAGE, `.${pkg}.Bar`),
+        field('bars', 3, TYPE_MESSAGE, `.${pkg}.Bar`, LABEL_REPEATED),
+        field('by_name', 4, TYPE_MESSAGE, `.${pkg}.Foo.ByNameEntry`, LABEL_REPEATED),
+        field('color', 5, TYPE_ENUM, `.${pkg}.Color`),
+        field('tags', 6, TYPE_STRING, '', LABEL_REPEATED),
+      ],
+      oneofDecl: [],
+    });
+    const entry = (pkg: string) => ({
+      name: 'ByNameEntry',
+      field: [field('key', 1, TYPE_STRING), field('value', 2, TYPE_MESSAGE, `.${pkg}.Bar`)],
+      options: { mapEntry: true },
+    });
+    const bar = { name: 'Bar', field: [field('id', 1, TYPE_INT32)], oneofDecl: [] };
+    const color = { name: 'Color', value: [{ name: 'RED', number: 0 }] };
+    const typeMap = new Map<string, any>();
+    for (const pkg of ['pkg.v1', 'pkg.v2']) {
+      const module = pkg.split('.')[1];
+      typeMap.set(`.${pkg}.Foo`, [module, 'Foo', foo(pkg), pkg]);
+      typeMap.set(`.${pkg}.Foo.ByNameEntry`, [module, 'Foo_ByNameEntry', entry(pkg), pkg]);
+      typeMap.set(`.${pkg}.Bar`, [module, 'Bar', bar, pkg]);
+      typeMap.set(`.${pkg}.Color`, [module, 'Color', color, pkg]);
+    }
+    // package pkg.v0; message Foo { Baz bar = 2; } message Baz { int32 id = 1; }
+    const fooV0 = { name: 'Foo', field: [field('bar', 2, TYPE_MESSAGE, '.pkg.v0.Baz')], oneofDecl: [] };
+    typeMap.set('.pkg.v0.Foo', ['v0', 'Foo', fooV0, 'pkg.v0']);
+    typeMap.set('.pkg.v0.Baz', ['v0', 'Baz', { ...bar, name: 'Baz' }, 'pkg.v0']);
+    const context = (options: Partial<Options>): Context => ({
+      options: { ...defaultOptions(), ...options },
+      typeMap,
+      utils: { globalThis: 'globalThis' } as any as Utils,
+      currentModule: 'v2',
+    });
+    const generate = (options: Partial<Options>, target = 'pkg.v2.Foo') => {
+      const ctx = context(options);
+      const [conversion] = conversionsTo(ctx, target);
+      const [, name, desc] = typeMap.get(`.${target}`);
+      return generateConversion(ctx, name, desc, conversion).toCodeString();
+    };
+
+    it('converts the fields of messages with the same name in other packages', () => {
+      const conversion = generate({ messageConversions: ['pkg.v1.Foo:pkg.v2.Foo'] });
+      expect(conversion).toContain('function fromV1Foo(source: Foo): Foo');
+      expect(conversion).toContain('message.name = source.name;');
+      expect(conversion).toContain(
+        'message.bar = source.bar !== undefined && source.bar !== null ? fromV1Bar(source.bar) : undefined;'
+      );
+      expect(conversion).toContain('message.color = fromV1Color(source.color);');
+    });
+
+    it('copies repeated fields and maps through the nested conversion', () => {
+      const conversion = generate({ messageConversions: ['pkg.v1.Foo:pkg.v2.Foo'] });
+      expect(conversion).toContain('message.bars = source.bars.map((e) => fromV1Bar(e));');
+      expect(conversion).toContain('message.tags = source.tags.map((e) => e);');
+      expect(conversion).toContain('Object.entries(source.byName).reduce');
+      expect(conversion).toContain('acc[key] = fromV1Bar(value);');
+    });
+
+    it('copies maps into Maps with useMapType', () => {
+      const conversion = generate({ messageConversions: ['pkg.v1.Foo:pkg.v2.Foo'], useMapType: true });
+      expect(conversion).toContain(
+        'new Map(Array.from(source.byName, ([key, value]): [string, Bar] => [key, fromV1Bar(value)]))'
+      );
+    });
+
+    it('generates the nested conversions as well', () => {
+      const ctx = context({ messageConversions: ['pkg.v1.Foo:pkg.v2.Foo'] });
+      expect(conversionsTo(ctx, 'pkg.v2.Bar')).toEqual([{ source: 'pkg.v1.Bar', target: 'pkg.v2.Bar' }]);
+      expect(enumConversionsTo(ctx, 'pkg.v2.Color')).toEqual([
+        { source: 'pkg.v1.Color', target: 'pkg.v2.Color', fallback: undefined },
+      ]);
+      const conversion = generate({ messageConversions: ['pkg.v1.Foo:pkg.v2.Foo'] }, 'pkg.v2.Bar');
+      expect(conversion).toContain('function fromV1Bar(source: Bar): Bar');
+      expect(conversion).toContain('message.id = source.id;');
+    });
+
+    it('leaves the nested conversions of identical types out', () => {
+      const ctx = context({ messageConversions: ['pkg.v2.Foo:pkg.v2.Foo'] });
+      expect(conversionsTo(ctx, 'pkg.v2.Bar')).toEqual([]);
+      expect(generate({ messageConversions: ['pkg.v2.Foo:pkg.v2.Foo'] })).toContain('message.bar = source.bar;');
+    });
+
+    it('fails on shared fields of different types', () => {
+      expect(() => generate({ messageConversions: ['pkg.v0.Foo:pkg.v2.Foo'] })).toThrow(
+        'field bar of pkg.v0.Foo and pkg.v2.Foo have incompatible types'
+      );
+    });
+  });
+
+  describe('enumConversions', () => {
+    // package pkg; enum ColorV1 { V1_UNSPECIFIED = 0; RED = 1; GREEN = 2; TEAL = 3; }
+    const sourceDesc = {
+      name: 'ColorV1',
+      value: [
+        { name: 'V1_UNSPECIFIED', number: 0 },
+        { name: 'RED', number: 1 },
+        { name: 'GREEN', number: 2 },
+        { name: 'TEAL', number: 3 },
+      ],
+    } as any;
+    // package pkg; enum ColorV2 { V2_UNSPECIFIED = 0; GREEN = 1; RED = 2; }
+    const enumDesc = {
+      name: 'ColorV2',
+      value: [
+        { name: 'V2_UNSPECIFIED', number: 0 },
+        { name: 'GREEN', number: 1 },
+        { name: 'RED', number: 2 },
+      ],
+    } as any;
+    const context = (options: Partial<Options>): Context => ({
+      options: { ...defaultOptions(), ...options },
+      typeMap: new Map([['.pkg.ColorV1', ['colors', 'ColorV1', sourceDesc, 'pkg']]]) as any,
+      utils: { globalThis: 'globalThis' } as any as Utils,
+    });
+    const generate = (options: Partial<Options>) => {
+      const ctx = context(options);
+      const [conversion] = enumConversionsTo(ctx, 'pkg.ColorV2');
+      return generateEnumConversion(ctx, 'ColorV2', enumDesc, conversion).toCodeString();
+    };
+
+    beforeEach(() => {
+      jest.spyOn(console, 'warn').mockImplementation(() => {});
+    });
+
+    afterEach(() => {
+      jest.restoreAllMocks();
+    });
+
+    it('maps the values with the same name', () => {
+      const conversion = generate({ enumConversions: ['pkg.ColorV1:pkg.ColorV2'] });
+      expect(conversion).toContain('function fromPkgColorV1(value: ColorV1): ColorV2');
+      expect(conversion).toContain('case ColorV1.RED: return ColorV2.RED;');
+      expect(conversion).toContain('case ColorV1.GREEN: return ColorV2.GREEN;');
+      expect(conversion).not.toContain('case ColorV1.TEAL');
+      expect(conversion).toContain('return ColorV2.UNRECOGNIZED;');
+    });
+
+    it('maps the values with the same number with enumConversionMatch=number', () => {
+      const conversion = generate({ enumConversions: ['pkg.ColorV1:pkg.ColorV2'], enumConversionMatch: 'number' });
+      expect(conversion).toContain('case ColorV1.V1_UNSPECIFIED: return ColorV2.V2_UNSPECIFIED;');
+      expect(conversion).toContain('case ColorV1.RED: return ColorV2.GREEN;');
+      expect(conversion).toContain('case ColorV1.GREEN: return ColorV2.RED;');
+    });
+
+    it('converts unmapped values to the fallback, and warns about them', () => {
+      const conversion = generate({ enumConversions: ['pkg.ColorV1:pkg.ColorV2:V2_UNSPECIFIED'] });
+      expect(conversion).toContain('return ColorV2.V2_UNSPECIFIED;');
+      expect(console.warn).toHaveBeenCalledWith(expect.stringContaining('V1_UNSPECIFIED, TEAL of pkg.ColorV1'));
+    });
+
+    it('throws on unmapped values without a fallback or UNRECOGNIZED', () => {
+      const conversion = generate({ enumConversions: ['pkg.ColorV1:pkg.ColorV2'], unrecognizedEnum: false });
+      expect(conversion).toContain('throw new globalThis.Error("Cannot convert " + value + " of ColorV1 to ColorV2")');
+    });
+
+    it('fails on fallbacks that are not values of the target', () => {
+      expect(() => generate({ enumConversions: ['pkg.ColorV1:pkg.ColorV2:TEAL'] })).toThrow(
+        'TEAL is not a value of pkg.ColorV2'
+      );
+    });
+
+    it('fails on malformed entries and unknown enums', () => {
+      expect(() => generate({ enumConversions: ['pkg.ColorV1'] })).toThrow(/must look like/);
+      expect(() => generate({ enumConversions: ['pkg.ColorV0:pkg.ColorV2'] })).toThrow(
+        'could not find enum pkg.ColorV0'
+      );
+    });
+  });
+});
@@ -0,0 +1,75 @@
+import { defaultOptions } from '../src/options';
+import { generateEnumAliases, generateEnumFromJson, generateEnumLabel, generateEnumToJson } from '../src/enums';
+import { Context } from '../src/context';
+import { Utils } from '../src/main';
+
+describe('enums', () => {
+  describe('allow_alias', () => {
+    // enum Status { option allow_alias = true; STARTED = 1; RUNNING = 1; IN_PROGRESS = 1; }
+    const enumDesc = {
+      name: 'Status',
+      value: [
+        { name: 'STARTED', number: 1 },
+        { name: 'RUNNING', number: 1 },
+        { name: 'IN_PROGRESS', number: 1 },
+      ],
+      options: { allowAlias: true },
+    } as any;
+    const ctx: Context = { options: defaultOptions(), typeMap: new Map(), utils: undefined as any as Utils };
+
+    it('maps every alias name to its value', () => {
+      const aliases = generateEnumAliases(ctx, 'Status', enumDesc).toCodeString();
+      expect(aliases).toContain('STARTED: Status.STARTED');
+      expect(aliases).toContain('RUNNING: Status.RUNNING');
+      expect(aliases).toContain('IN_PROGRESS: Status.IN_PROGRESS');
+    });
+
+    it('accepts all alias names in fromJSON', () => {
+      const fromJson = generateEnumFromJson(ctx, 'Status', enumDesc).toCodeString();
+      expect(fromJson).toContain('case "RUNNING":');
+      expect(fromJson).toContain('case "IN_PROGRESS":');
+      expect(fromJson.match(/case 1:/g)).toHaveLength(1);
+    });
+
+    it('uses the canonical name in toJSON', () => {
+      const toJson = generateEnumToJson(ctx, 'Status', enumDesc).toCodeString();
+      expect(toJson).toContain('return "STARTED"');
+      expect(toJson).not.toContain('return "RUNNING"');
+    });
+  });
+
+  describe('labels', () => {
+    // extend google.protobuf.EnumValueOptions { string label = 50001; }
+    // enum Status { STATUS_UNSPECIFIED = 0; IN_PROGRESS = 1; DONE = 2 [(label) = "Finished ✓"]; }
+    const label = new TextEncoder().encode('Finished ✓');
+    const enumDesc = {
+      name: 'Status',
+      value: [
+        { name: 'STATUS_UNSPECIFIED', number: 0 },
+        { name: 'IN_PROGRESS', number: 1 },
+        {
+          name: 'DONE',
+          number: 2,
+          options: { _unknownFields: { [(50001 << 3) | 2]: [Uint8Array.of(label.length, ...label)] } },
+        },
+      ],
+    } as any;
+    const ctx: Context = {
+      options: { ...defaultOptions(), enumLabelOption: 50001 },
+      typeMap: new Map(),
+      utils: undefined as any as Utils,
+    };
+
+    it('title-cases the value names', () => {
+      const labels = generateEnumLabel(ctx, 'Status', enumDesc).toCodeString();
+      expect(labels).toContain('case Status.STATUS_UNSPECIFIED: return "Status Unspecified"');
+      expect(labels).toContain('case Status.IN_PROGRESS: return "In Progress"');
+      expect(labels).toContain('return "Unrecognized"');
+    });
+
+    it('uses the label option when it is set', () => {
+      const labels = generateEnumLabel(ctx, 'Status', enumDesc).toCodeString();
+      expect(labels).toContain('case Status.DONE: return "Finished ✓"');
+    });
+  });
+});
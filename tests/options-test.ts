@@ -1,4 +1,5 @@
-import { DateOption, optionsFromParameter, ServiceOption } from '../src/options';
+import { code } from 'ts-poet';
+import { DateOption, getTsPoetOpts, optionsFromParameter, ServiceOption } from '../src/options';
 
 describe('options', () => {
   it('can set outputJsonMethods with nestJs=true', () => {
@@ -6,46 +7,149 @@ describe('options', () => {
       Object {
         "addGrpcMetadata": false,
         "addNestjsRestParameter": false,
+        "bidiObservable": false,
+        "brandedTypes": Array [],
+        "busImport": undefined,
+        "bytesAs": "uint8array",
+        "bytesJsonEncoding": "base64",
+        "clientInterceptors": false,
+        "clientRetry": false,
         "constEnums": false,
         "context": false,
+        "decodeStrictWireType": false,
+        "defaultMetadata": false,
+        "deterministicEncode": false,
+        "embedOptions": false,
         "emitImportedFiles": true,
+        "emptyRepeated": "array",
+        "encodeAcceptsPartial": false,
+        "encodeFieldOrder": "descriptor",
+        "enumConversionMatch": "name",
+        "enumConversions": Array [],
+        "enumLabelOption": undefined,
+        "enumStyle": "enum",
         "enumsAsLiterals": false,
         "env": "both",
         "esModuleInterop": false,
         "exportCommonSymbols": true,
         "fileSuffix": "",
         "forceLong": "number",
+        "fromJsonAcceptOriginalNames": false,
+        "immerCompat": false,
         "importSuffix": "",
+        "indent": 2,
+        "isolatedModules": false,
         "lowerCaseServiceMethods": true,
+        "messageConversions": Array [],
+        "messageUnions": Array [],
         "metadataType": undefined,
         "nestJs": true,
+        "niceGrpcInterceptors": false,
+        "nullPrototype": false,
         "oneof": "properties",
+        "oneofPartialStrict": false,
         "onlyTypes": false,
+        "outputAnyDispatcher": false,
         "outputClientImpl": false,
+        "outputCodecInterface": false,
+        "outputComparators": false,
+        "outputDebugString": false,
+        "outputDecodeLimits": false,
+        "outputDurationHelpers": false,
+        "outputEncodeExcept": false,
+        "outputEncodeInto": false,
         "outputEncodeMethods": false,
+        "outputEncodeOptions": false,
+        "outputEncodeWithMask": false,
+        "outputEntries": false,
+        "outputEnumExhaustive": false,
+        "outputEnumHelpers": false,
+        "outputEnumLabels": false,
+        "outputEventBus": false,
+        "outputFieldMaskMethods": false,
+        "outputFieldNames": false,
+        "outputFormData": false,
         "outputJsonMethods": true,
+        "outputLayout": "source",
+        "outputLongConversions": false,
+        "outputLowLevelWriters": false,
+        "outputMergeMethods": false,
+        "outputOneofClear": false,
+        "outputOneofExhaustive": false,
+        "outputOneofValue": false,
+        "outputPagination": false,
+        "outputPartialArray": false,
         "outputPartialMethods": false,
+        "outputPatch": false,
+        "outputPresenceHelpers": false,
+        "outputQueryKeys": false,
+        "outputQueryString": false,
+        "outputReflection": false,
+        "outputRepeatedHelpers": false,
+        "outputRepeatedToMap": false,
+        "outputRoundTripTests": false,
         "outputSchema": false,
+        "outputSchemaHash": false,
+        "outputSelectiveDecode": false,
+        "outputSelectors": false,
+        "outputServiceRegistrar": false,
         "outputServices": Array [
           "default",
         ],
+        "outputStreamAccumulators": false,
+        "outputStreamHelpers": false,
+        "outputStyle": "object",
+        "outputTestFactories": false,
+        "outputTextFormat": false,
+        "outputTimestampHelpers": false,
+        "outputToString": false,
+        "outputTruncationDetection": false,
+        "outputTryDecode": false,
         "outputTypeRegistry": false,
+        "outputTypeUrlEnum": false,
+        "outputUnknownFields": false,
+        "outputWatch": false,
+        "outputZodSchemas": false,
+        "paginationRequestField": "page_token",
+        "paginationResponseField": "next_page_token",
+        "quoteStyle": "double",
+        "repeatedToMapKeyField": "key",
+        "repeatedToMapValueField": "value",
         "returnObservable": false,
+        "sensitiveFieldMode": "redact",
+        "sensitiveFieldOption": undefined,
+        "servicePathPrefix": "",
         "snakeToCamel": Array [
           "json",
           "keys",
         ],
+        "sortKeyOption": undefined,
+        "splitNestedTypes": false,
+        "splitNestedTypesDepth": 2,
+        "streamBackpressure": false,
+        "streamReconnect": false,
         "stringEnums": false,
+        "timestampCodecImport": undefined,
+        "timestampKeepRaw": false,
         "unknownFields": false,
         "unrecognizedEnum": true,
+        "useAbortSignal": false,
         "useAsyncIterable": false,
         "useDate": "timestamp",
+        "useDuration": "duration",
         "useExactTypes": true,
         "useJsonWireFormat": false,
+        "useMapType": false,
         "useMongoObjectId": false,
         "useNumericEnumForJson": false,
         "useOptionals": "none",
         "usePrototypeForDefaults": false,
+        "useReadonlyTypes": false,
+        "warningHookImport": undefined,
+        "watchRequestField": "watch_token",
+        "watchResponseField": "next_watch_token",
+        "wrapInNamespace": false,
+        "wrapperJsonLenient": false,
       }
     `);
   });
@@ -79,6 +183,13 @@ describe('options', () => {
     });
   });
 
+  it('can set outputServices to connect', () => {
+    const options = optionsFromParameter('outputServices=connect');
+    expect(options).toMatchObject({
+      outputServices: [ServiceOption.CONNECT],
+    });
+  });
+
   it('can set useOptionals to boolean', () => {
     const options = optionsFromParameter('useOptionals=true');
     expect(options).toMatchObject({
@@ -136,4 +247,151 @@ describe('options', () => {
       useDate: DateOption.STRING,
     });
   });
+
+  it('outputStreamAccumulators implies outputMergeMethods', () => {
+    const options = optionsFromParameter('outputStreamAccumulators=true');
+    expect(options).toMatchObject({
+      outputStreamAccumulators: true,
+      outputMergeMethods: true,
+    });
+  });
+
+  it('passes quoteStyle and indent through to the formatter', () => {
+    expect(getTsPoetOpts(optionsFromParameter('quoteStyle=single,indent=4'))).toMatchObject({
+      prettierOverrides: { singleQuote: true, tabWidth: 4 },
+    });
+    // The defaults are passed explicitly too, so that a project's own prettier config can't override them
+    expect(getTsPoetOpts(optionsFromParameter(''))).toMatchObject({
+      prettierOverrides: { singleQuote: false, tabWidth: 2 },
+    });
+    expect(getTsPoetOpts(optionsFromParameter('quoteStyle=double'))).toMatchObject({
+      prettierOverrides: { singleQuote: false },
+    });
+  });
+
+  it('formats the output with quoteStyle and indent', async () => {
+    const output = code`export function greet() { return "hi"; }`;
+    const opts = getTsPoetOpts(optionsFromParameter('quoteStyle=single,indent=4'));
+    expect(await output.toStringWithImports(opts)).toContain("export function greet() {\n    return 'hi';\n}");
+    // This repo's own .prettierrc has singleQuote=true, which the defaults still override
+    const defaults = getTsPoetOpts(optionsFromParameter(''));
+    expect(await output.toStringWithImports(defaults)).toContain('export function greet() {\n  return "hi";\n}');
+    const single = code`export function greet() { return 'hi'; }`;
+    const double = getTsPoetOpts(optionsFromParameter('quoteStyle=double,indent=3'));
+    expect(await single.toStringWithImports(double)).toContain('export function greet() {\n   return "hi";\n}');
+  });
+
+  it('rejects indents that are not positive integers', () => {
+    expect(() => optionsFromParameter('indent=abc')).toThrow('indent=abc must be a positive integer');
+    expect(() => optionsFromParameter('indent=0')).toThrow(/indent=0/);
+    expect(() => optionsFromParameter('indent=2.5')).toThrow(/indent=2.5/);
+  });
+
+  it('rejects unsupported quoteStyle values', () => {
+    expect(() => optionsFromParameter('quoteStyle=backtick')).toThrow(/quoteStyle=backtick/);
+  });
+
+  it('outputUnknownFields=true implies unknownFields', () => {
+    expect(optionsFromParameter('outputUnknownFields=true')).toMatchObject({ unknownFields: true });
+  });
+
+  it('enumStyle=const-enum implies constEnums', () => {
+    expect(optionsFromParameter('enumStyle=const-enum')).toMatchObject({ constEnums: true });
+  });
+
+  it('rejects const enums with isolatedModules', () => {
+    expect(() => optionsFromParameter('enumStyle=const-enum,isolatedModules=true')).toThrow(/isolatedModules/);
+    expect(() => optionsFromParameter('constEnums=true,isolatedModules=true')).toThrow(/isolatedModules/);
+  });
+
+  it('rejects unsupported bytesAs values', () => {
+    expect(() => optionsFromParameter('bytesAs=arraybuffer')).toThrow(/bytesAs=arraybuffer/);
+  });
+
+  it('rejects unsupported useReadonlyTypes values', () => {
+    expect(() => optionsFromParameter('useReadonlyTypes=true')).toThrow(/useReadonlyTypes=true/);
+  });
+
+  it('rejects exhaustive enums without the UNRECOGNIZED member', () => {
+    expect(() => optionsFromParameter('outputEnumExhaustive=true,unrecognizedEnum=false')).toThrow(
+      /unrecognizedEnum=false/
+    );
+  });
+
+  it('rejects Map types with the JSON wire format', () => {
+    expect(() => optionsFromParameter('useMapType=true,useJsonWireFormat=true,onlyTypes=true')).toThrow(
+      /useJsonWireFormat/
+    );
+    expect(() => optionsFromParameter('useMapType=true,outputMergeMethods=true')).toThrow(/outputMergeMethods/);
+    expect(() => optionsFromParameter('useMapType=true,outputPatch=true')).toThrow(/outputPatch/);
+  });
+
+  it('parses splitNestedTypesDepth as a number', () => {
+    const options = optionsFromParameter('splitNestedTypes=true,splitNestedTypesDepth=1');
+    expect(options).toMatchObject({ splitNestedTypes: true, splitNestedTypesDepth: 1 });
+    expect(() => optionsFromParameter('splitNestedTypes=true,splitNestedTypesDepth=0')).toThrow(/at least 1/);
+  });
+
+  it('rejects prototype-based defaults with immerCompat', () => {
+    expect(() => optionsFromParameter('usePrototypeForDefaults=true,immerCompat=true')).toThrow(/immerCompat/);
+    expect(optionsFromParameter('immerCompat=true')).toMatchObject({ usePrototypeForDefaults: false });
+  });
+
+  it('rejects prototype-based defaults with nullPrototype', () => {
+    const parameter = 'nullPrototype=true,usePrototypeForDefaults=true';
+    expect(() => optionsFromParameter(parameter)).toThrow(/usePrototypeForDefaults/);
+  });
+
+  it('requires a module and a function name for warningHookImport', () => {
+    expect(optionsFromParameter('warningHookImport=./hook#onProtoWarning')).toMatchObject({
+      warningHookImport: './hook#onProtoWarning',
+    });
+    expect(() => optionsFromParameter('warningHookImport=./hook')).toThrow(/warningHookImport=.\/hook/);
+  });
+
+  it('rejects outputStyle=functions with options that need the message objects', () => {
+    expect(() => optionsFromParameter('outputStyle=functions,outputTypeRegistry=true')).toThrow(/outputTypeRegistry/);
+    expect(() => optionsFromParameter('outputStyle=functions,outputServices=nice-grpc')).toThrow(/nice-grpc/);
+    expect(optionsFromParameter('outputStyle=functions')).toMatchObject({ outputStyle: 'functions' });
+  });
+
+  it('requires the type registry and encode methods for outputAnyDispatcher', () => {
+    expect(() => optionsFromParameter('outputAnyDispatcher=true')).toThrow(/outputTypeRegistry=true/);
+    expect(() => optionsFromParameter('outputAnyDispatcher=true,outputTypeRegistry=true,onlyTypes=true')).toThrow(
+      /outputEncodeMethods=true/
+    );
+    expect(optionsFromParameter('outputAnyDispatcher=true,outputTypeRegistry=true')).toMatchObject({
+      outputAnyDispatcher: true,
+    });
+  });
+
+  it('requires the sort key option for outputComparators', () => {
+    expect(() => optionsFromParameter('outputComparators=true')).toThrow(/sortKeyOption/);
+    expect(optionsFromParameter('outputComparators=true,sortKeyOption=50001')).toMatchObject({
+      outputComparators: true,
+      sortKeyOption: 50001,
+    });
+  });
+
+  it('requires encode methods for outputEventBus', () => {
+    expect(() => optionsFromParameter('outputEventBus=true,onlyTypes=true')).toThrow(/outputEncodeMethods=true/);
+    expect(optionsFromParameter('outputEventBus=true,busImport=./my-bus')).toMatchObject({
+      outputEventBus: true,
+      busImport: './my-bus',
+    });
+  });
+
+  it('rejects the per-field members of outputLowLevelWriters with outputCodecInterface', () => {
+    expect(() => optionsFromParameter('outputCodecInterface=true,outputLowLevelWriters=true')).toThrow(
+      /outputLowLevelWriters/
+    );
+    expect(optionsFromParameter('outputLowLevelWriters=true')).toMatchObject({ outputLowLevelWriters: true });
+  });
+
+  it('rejects the per-field members of outputSelectiveDecode with outputCodecInterface', () => {
+    expect(() => optionsFromParameter('outputCodecInterface=true,outputSelectiveDecode=true')).toThrow(
+      /outputSelectiveDecode/
+    );
+    expect(optionsFromParameter('outputSelectiveDecode=true')).toMatchObject({ outputSelectiveDecode: true });
+  });
 });
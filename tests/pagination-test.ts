@@ -0,0 +1,80 @@
+import { joinCode } from 'ts-poet';
+import { FieldDescriptorProto_Label, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { defaultOptions } from '../src/options';
+import { generatePaginationHelpers } from '../src/generate-services';
+import { Context } from '../src/context';
+import { Utils } from '../src/main';
+import { FormattedMethodDescriptor } from '../src/utils';
+
+describe('pagination', () => {
+  // package library; message ListBooksRequest { string page_token = 1; }
+  // message ListBooksResponse { repeated Book books = 1; string next_page_token = 2; } message Book {}
+  const string = (name: string, number: number) => ({
+    name,
+    number,
+    type: FieldDescriptorProto_Type.TYPE_STRING,
+    label: FieldDescriptorProto_Label.LABEL_OPTIONAL,
+  });
+  const books = {
+    name: 'books',
+    number: 1,
+    type: FieldDescriptorProto_Type.TYPE_MESSAGE,
+    label: FieldDescriptorProto_Label.LABEL_REPEATED,
+    typeName: '.library.Book',
+  };
+  const typeMap = new Map([
+    ['.library.Book', ['library', 'Book', { name: 'Book', field: [] }, 'library']],
+    [
+      '.library.ListBooksRequest',
+      ['library', 'ListBooksRequest', { name: 'ListBooksRequest', field: [string('page_token', 1)] }, 'library'],
+    ],
+    [
+      '.library.ListBooksResponse',
+      [
+        'library',
+        'ListBooksResponse',
+        { name: 'ListBooksResponse', field: [books, string('next_page_token', 2)] },
+        'library',
+      ],
+    ],
+  ]);
+  const ctx: Context = {
+    options: { ...defaultOptions(), outputPagination: true },
+    typeMap: typeMap as any,
+    utils: {} as any as Utils,
+    currentModule: 'library',
+  };
+  const serviceDesc = (name: string) =>
+    ({
+      name,
+      method: [
+        new FormattedMethodDescriptor(
+          {
+            name: 'ListBooks',
+            inputType: '.library.ListBooksRequest',
+            outputType: '.library.ListBooksResponse',
+            clientStreaming: false,
+            serverStreaming: false,
+          } as any,
+          ctx.options
+        ),
+      ],
+    } as any);
+
+  it('prefixes the helpers with the service name', async () => {
+    const generated = await joinCode(generatePaginationHelpers(ctx, serviceDesc('Library'))).toStringWithImports();
+    expect(generated).toMatch(/export async function\* paginateLibraryListBooks\(\s*client: Library,/);
+  });
+
+  it('outputs distinct helpers for services of the same file with the same list method', async () => {
+    const helpers = [
+      ...generatePaginationHelpers(ctx, serviceDesc('Library')),
+      ...generatePaginationHelpers(ctx, serviceDesc('Archive')),
+    ];
+    const generated = await joinCode(helpers, { on: '\n' }).toStringWithImports();
+    expect(generated.match(/function\* \w+/g)).toEqual([
+      'function* paginateLibraryListBooks',
+      'function* paginateArchiveListBooks',
+    ]);
+  });
+});
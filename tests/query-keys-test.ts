@@ -0,0 +1,76 @@
+import { joinCode } from 'ts-poet';
+import { FieldDescriptorProto_Label, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { defaultOptions } from '../src/options';
+import { generateQueryKeyHelpers } from '../src/generate-services';
+import { Context } from '../src/context';
+import { Utils } from '../src/main';
+import { FormattedMethodDescriptor } from '../src/utils';
+
+describe('query keys', () => {
+  // package library; message GetBookRequest { string name = 1; } message Book {}
+  const typeMap = new Map([
+    ['.library.Book', ['library', 'Book', { name: 'Book', field: [] }, 'library']],
+    [
+      '.library.GetBookRequest',
+      [
+        'library',
+        'GetBookRequest',
+        {
+          name: 'GetBookRequest',
+          field: [
+            {
+              name: 'name',
+              number: 1,
+              type: FieldDescriptorProto_Type.TYPE_STRING,
+              label: FieldDescriptorProto_Label.LABEL_OPTIONAL,
+            },
+          ],
+        },
+        'library',
+      ],
+    ],
+  ]);
+  const ctx: Context = {
+    options: { ...defaultOptions(), outputQueryKeys: true },
+    typeMap: typeMap as any,
+    utils: {} as any as Utils,
+    currentModule: 'library',
+  };
+  const fileDesc = { package: 'library' } as any;
+  const serviceDesc = (name: string) =>
+    ({
+      name,
+      method: [
+        new FormattedMethodDescriptor(
+          {
+            name: 'GetBook',
+            inputType: '.library.GetBookRequest',
+            outputType: '.library.Book',
+            clientStreaming: false,
+            serverStreaming: false,
+          } as any,
+          ctx.options
+        ),
+      ],
+    } as any);
+
+  it('prefixes the helpers and their keys with the service name', async () => {
+    const helpers = generateQueryKeyHelpers(ctx, fileDesc, serviceDesc('Library'));
+    const generated = await joinCode(helpers).toStringWithImports();
+    expect(generated).toContain('export function libraryGetBookQueryKey(request: GetBookRequest): unknown[] {');
+    expect(generated).toContain("return ['library.Library', 'GetBook', GetBookRequest.toJSON(request)];");
+  });
+
+  it('outputs distinct helpers and keys for services of the same file with the same method', async () => {
+    const helpers = [
+      ...generateQueryKeyHelpers(ctx, fileDesc, serviceDesc('Library')),
+      ...generateQueryKeyHelpers(ctx, fileDesc, serviceDesc('Archive')),
+    ];
+    const generated = await joinCode(helpers, { on: '\n' }).toStringWithImports();
+    expect(generated.match(/function \w+/g)).toEqual([
+      'function libraryGetBookQueryKey',
+      'function archiveGetBookQueryKey',
+    ]);
+    expect(generated.match(/return \['[\w.]+'/g)).toEqual(["return ['library.Library'", "return ['library.Archive'"]);
+  });
+});
@@ -1,6 +1,20 @@
-import { Options, defaultOptions } from '../src/options';
-import { messageToTypeName, TypeMap } from '../src/types';
+import { DurationOption, LongOption, OneofOption, Options, defaultOptions } from '../src/options';
+import {
+  detectPaginatedMethod,
+  detectWatchMethod,
+  isOptionalProperty,
+  isSortKeyField,
+  isWithinOneOfThatShouldBeUnion,
+  longOption,
+  messageToTypeName,
+  oneofCaseName,
+  oneofMembers,
+  splitNestedRoot,
+  toTypeName,
+  TypeMap,
+} from '../src/types';
 import { Code, code, imp } from 'ts-poet';
+import { FieldDescriptorProto_Label, FieldDescriptorProto_Type, FieldOptions_JSType } from 'ts-proto-descriptors';
 import { Utils } from '../src/main';
 
 const fakeProto = undefined as any;
@@ -12,28 +26,44 @@ describe('types', () => {
       typeMap: TypeMap;
       protoType: string;
       options?: Options;
+      currentModule?: string;
       expected: Code;
     };
     const testCases: Array<TestCase> = [
       {
         descr: 'top-level messages',
-        typeMap: new Map([['.namespace.Message', ['namespace', 'Message', fakeProto]]]),
+        typeMap: new Map([['.namespace.Message', ['namespace', 'Message', fakeProto, 'namespace']]]),
         protoType: '.namespace.Message',
         expected: code`${imp('Message@./namespace')}`,
       },
       {
         descr: 'nested messages',
-        typeMap: new Map([['.namespace.Message.Inner', ['namespace', 'Message_Inner', fakeProto]]]),
+        typeMap: new Map([['.namespace.Message.Inner', ['namespace', 'Message_Inner', fakeProto, 'namespace']]]),
         protoType: '.namespace.Message.Inner',
         expected: code`${imp('Message_Inner@./namespace')}`,
       },
       {
         descr: 'nested messages: .js import suffix',
-        typeMap: new Map([['.namespace.Message.Inner', ['namespace', 'Message_Inner', fakeProto]]]),
+        typeMap: new Map([['.namespace.Message.Inner', ['namespace', 'Message_Inner', fakeProto, 'namespace']]]),
         protoType: '.namespace.Message.Inner',
         options: { ...defaultOptions(), importSuffix: '.js' },
         expected: code`${imp('Message_Inner@./namespace.js')}`,
       },
+      {
+        descr: 'messages of other files (wrapInNamespace=true)',
+        typeMap: new Map([['.foo.bar.Message', ['foo/bar', 'Message', fakeProto, 'foo.bar']]]),
+        protoType: '.foo.bar.Message',
+        options: { ...defaultOptions(), wrapInNamespace: true },
+        expected: code`${imp('foo_bar*./foo/bar')}.foo.bar.Message`,
+      },
+      {
+        descr: 'messages of the same file (wrapInNamespace=true)',
+        typeMap: new Map([['.foo.bar.Message', ['foo/bar', 'Message', fakeProto, 'foo.bar']]]),
+        protoType: '.foo.bar.Message',
+        options: { ...defaultOptions(), wrapInNamespace: true },
+        currentModule: 'foo/bar',
+        expected: code`${imp('Message@./foo/bar')}`,
+      },
       {
         descr: 'value types',
         typeMap: new Map(),
@@ -54,6 +84,20 @@ describe('types', () => {
         options: { ...defaultOptions(), useOptionals: 'all' },
         expected: code`string`,
       },
+      {
+        descr: 'durations (useDuration=number)',
+        typeMap: new Map(),
+        protoType: '.google.protobuf.Duration',
+        options: { ...defaultOptions(), useDuration: DurationOption.NUMBER },
+        expected: code`number`,
+      },
+      {
+        descr: 'durations (useDuration=string)',
+        typeMap: new Map(),
+        protoType: '.google.protobuf.Duration',
+        options: { ...defaultOptions(), useDuration: DurationOption.STRING },
+        expected: code`string`,
+      },
     ];
     testCases.forEach((t) =>
       it(t.descr, async () => {
@@ -63,4 +107,263 @@ describe('types', () => {
       })
     );
   });
+
+  describe('detectPaginatedMethod', () => {
+    const string = (name: string, label = FieldDescriptorProto_Label.LABEL_OPTIONAL) =>
+      ({ name, type: FieldDescriptorProto_Type.TYPE_STRING, label, typeName: '' } as any);
+    const typeMap: TypeMap = new Map([
+      ['.ListRequest', ['list', 'ListRequest', { field: [string('page_token')] } as any, '']],
+      [
+        '.ListResponse',
+        [
+          'list',
+          'ListResponse',
+          { field: [string('names', FieldDescriptorProto_Label.LABEL_REPEATED), string('next_page_token')] } as any,
+          '',
+        ],
+      ],
+      ['.Other', ['list', 'Other', { field: [string('cursor')] } as any, '']],
+    ]);
+    const ctx = { options: defaultOptions(), typeMap, utils: undefined as any as Utils };
+    const method = (inputType: string, outputType: string) =>
+      ({ name: 'List', inputType, outputType, clientStreaming: false, serverStreaming: false } as any);
+
+    it('detects page token request/response pairs', () => {
+      const detected = detectPaginatedMethod(ctx, method('.ListRequest', '.ListResponse'));
+      expect(detected?.itemsField.name).toEqual('names');
+      expect(detected?.itemType.toCodeString()).toEqual('string');
+    });
+
+    it('ignores requests without a page token', () => {
+      expect(detectPaginatedMethod(ctx, method('.Other', '.ListResponse'))).toBeUndefined();
+    });
+
+    it('respects configured token field names', () => {
+      const options = { ...defaultOptions(), paginationRequestField: 'cursor' };
+      expect(detectPaginatedMethod({ ...ctx, options }, method('.Other', '.ListResponse'))).toBeDefined();
+    });
+  });
+
+  describe('detectWatchMethod', () => {
+    const string = (name: string) =>
+      ({ name, type: FieldDescriptorProto_Type.TYPE_STRING, label: FieldDescriptorProto_Label.LABEL_OPTIONAL } as any);
+    const typeMap: TypeMap = new Map([
+      ['.WatchRequest', ['watch', 'WatchRequest', { field: [string('name'), string('watch_token')] } as any, '']],
+      [
+        '.WatchResponse',
+        ['watch', 'WatchResponse', { field: [string('state'), string('next_watch_token')] } as any, ''],
+      ],
+      ['.Other', ['watch', 'Other', { field: [string('etag')] } as any, '']],
+    ]);
+    const ctx = { options: defaultOptions(), typeMap, utils: undefined as any as Utils };
+    const method = (inputType: string, outputType: string, serverStreaming = false) =>
+      ({ name: 'Watch', inputType, outputType, clientStreaming: false, serverStreaming } as any);
+
+    it('detects watch token request/response pairs', () => {
+      const detected = detectWatchMethod(ctx, method('.WatchRequest', '.WatchResponse'));
+      expect(detected?.requestTokenField.name).toEqual('watch_token');
+      expect(detected?.responseTokenField.name).toEqual('next_watch_token');
+    });
+
+    it('ignores requests without a watch token', () => {
+      expect(detectWatchMethod(ctx, method('.Other', '.WatchResponse'))).toBeUndefined();
+    });
+
+    it('ignores streaming methods', () => {
+      expect(detectWatchMethod(ctx, method('.WatchRequest', '.WatchResponse', true))).toBeUndefined();
+    });
+
+    it('respects configured token field names', () => {
+      const options = { ...defaultOptions(), watchRequestField: 'etag' };
+      expect(detectWatchMethod({ ...ctx, options }, method('.Other', '.WatchResponse'))).toBeDefined();
+    });
+  });
+
+  describe('isOptionalProperty', () => {
+    const repeated = {
+      name: 'tags',
+      type: FieldDescriptorProto_Type.TYPE_STRING,
+      label: FieldDescriptorProto_Label.LABEL_REPEATED,
+    } as any;
+
+    it('keeps repeated fields required with emptyRepeated=array', () => {
+      expect(isOptionalProperty(repeated, undefined, { ...defaultOptions(), emptyRepeated: 'array' })).toBe(false);
+    });
+
+    it('makes repeated fields optional with emptyRepeated=undefined', () => {
+      expect(isOptionalProperty(repeated, undefined, { ...defaultOptions(), emptyRepeated: 'undefined' })).toBe(true);
+    });
+
+    it('makes proto3 optional fields optional', () => {
+      const optionalInt = { name: 'count', type: FieldDescriptorProto_Type.TYPE_INT32, proto3Optional: true } as any;
+      expect(isOptionalProperty(optionalInt, undefined, defaultOptions())).toBe(true);
+    });
+
+    it('keeps proto3 optional wrapper fields required, as they already include undefined', () => {
+      // optional google.protobuf.Int32Value count = 1;
+      const optionalWrapper = {
+        name: 'count',
+        type: FieldDescriptorProto_Type.TYPE_MESSAGE,
+        typeName: '.google.protobuf.Int32Value',
+        proto3Optional: true,
+      } as any;
+      expect(isOptionalProperty(optionalWrapper, undefined, defaultOptions())).toBe(false);
+    });
+  });
+
+  describe('oneofMembers', () => {
+    // message Mixed {
+    //   oneof choice { string a = 1; int32 b = 2; }
+    //   optional string name = 3;
+    //   optional int32 age = 4;
+    // }
+    const a = { name: 'a', number: 1, type: FieldDescriptorProto_Type.TYPE_STRING, oneofIndex: 0 } as any;
+    const b = { name: 'b', number: 2, type: FieldDescriptorProto_Type.TYPE_INT32, oneofIndex: 0 } as any;
+    const name = {
+      name: 'name',
+      number: 3,
+      type: FieldDescriptorProto_Type.TYPE_STRING,
+      oneofIndex: 1,
+      proto3Optional: true,
+    } as any;
+    const age = {
+      name: 'age',
+      number: 4,
+      type: FieldDescriptorProto_Type.TYPE_INT32,
+      oneofIndex: 2,
+      proto3Optional: true,
+    } as any;
+    const mixed = {
+      name: 'Mixed',
+      field: [a, b, name, age],
+      oneofDecl: [{ name: 'choice' }, { name: '_name' }, { name: '_age' }],
+    } as any;
+    const options = { ...defaultOptions(), oneof: OneofOption.UNIONS };
+
+    it('only includes the fields of the real oneof', () => {
+      expect(oneofMembers(mixed, 0)).toEqual([a, b]);
+    });
+
+    it('never includes optional fields, even if they share an index with a real oneof', () => {
+      expect(oneofMembers(mixed, 1)).toEqual([]);
+      expect(oneofMembers({ ...mixed, field: [a, b, { ...name, oneofIndex: 0 }] }, 0)).toEqual([a, b]);
+    });
+
+    it('treats optional fields as presence rather than as union members', () => {
+      expect(isWithinOneOfThatShouldBeUnion(options, a)).toBe(true);
+      expect(isWithinOneOfThatShouldBeUnion(options, name)).toBe(false);
+      expect(isOptionalProperty(name, undefined, options)).toBe(true);
+    });
+
+    it('uses a kind discriminant for named unions', () => {
+      const namedUnions = { ...defaultOptions(), oneof: OneofOption.NAMED_UNIONS };
+      expect(isWithinOneOfThatShouldBeUnion(namedUnions, a)).toBe(true);
+      expect(oneofCaseName(namedUnions)).toEqual('kind');
+      expect(oneofCaseName(options)).toEqual('$case');
+    });
+  });
+
+  describe('toTypeName', () => {
+    // message Flags { map<bool, string> labels = 1; }
+    const entry = {
+      name: 'LabelsEntry',
+      field: [
+        { name: 'key', number: 1, type: FieldDescriptorProto_Type.TYPE_BOOL },
+        { name: 'value', number: 2, type: FieldDescriptorProto_Type.TYPE_STRING },
+      ],
+      options: { mapEntry: true },
+    } as any;
+    const labels = {
+      name: 'labels',
+      number: 1,
+      label: FieldDescriptorProto_Label.LABEL_REPEATED,
+      type: FieldDescriptorProto_Type.TYPE_MESSAGE,
+      typeName: '.Flags.LabelsEntry',
+    } as any;
+    const flags = { name: 'Flags', field: [labels], nestedType: [entry] } as any;
+    const typeMap: TypeMap = new Map([['.Flags.LabelsEntry', ['', 'Flags_LabelsEntry', entry, '']]]);
+    const ctxFor = (options: Options) => ({ options, typeMap, utils: undefined as any as Utils });
+
+    it('uses an index signature for maps by default', () => {
+      expect(toTypeName(ctxFor(defaultOptions()), flags, labels).toCodeString()).toContain('[key: boolean');
+    });
+
+    it('uses a Map that keeps the type of the keys with useMapType', () => {
+      const options = { ...defaultOptions(), useMapType: true };
+      expect(toTypeName(ctxFor(options), flags, labels).toCodeString()).toEqual('Map<boolean, string>');
+    });
+  });
+
+  describe('splitNestedRoot', () => {
+    const options = { ...defaultOptions(), splitNestedTypes: true, splitNestedTypesDepth: 1 };
+    const message = { name: 'Inner', field: [] } as any;
+
+    it('keeps types above the depth in their file', () => {
+      expect(splitNestedRoot(options, 'Outer', message)).toBeUndefined();
+      expect(splitNestedRoot({ ...options, splitNestedTypes: false }, 'Outer.Inner', message)).toBeUndefined();
+    });
+
+    it('splits types at the depth, with their nested types', () => {
+      expect(splitNestedRoot(options, 'Outer.Inner', message)).toEqual('Outer.Inner');
+      expect(splitNestedRoot(options, 'Outer.Inner.Deep', message)).toEqual('Outer.Inner');
+      expect(splitNestedRoot(options, 'Outer.Kind', { name: 'Kind', value: [] } as any)).toEqual('Outer.Kind');
+    });
+
+    it('keeps map entries with their message', () => {
+      const entry = { name: 'TagsEntry', field: [], options: { mapEntry: true } } as any;
+      expect(splitNestedRoot(options, 'Outer.TagsEntry', entry)).toBeUndefined();
+      expect(splitNestedRoot(options, 'Outer.Inner.TagsEntry', entry)).toEqual('Outer.Inner');
+    });
+  });
+
+  describe('longOption', () => {
+    const field = (jstype?: FieldOptions_JSType) =>
+      ({
+        name: 'id',
+        type: FieldDescriptorProto_Type.TYPE_INT64,
+        options: jstype === undefined ? undefined : { jstype },
+      } as any);
+    const options = { ...defaultOptions(), forceLong: LongOption.LONG };
+
+    it('uses forceLong without a jstype', () => {
+      expect(longOption(field(), options)).toEqual(LongOption.LONG);
+    });
+
+    it('uses forceLong for JS_NORMAL', () => {
+      expect(longOption(field(FieldOptions_JSType.JS_NORMAL), options)).toEqual(LongOption.LONG);
+    });
+
+    it('uses strings for JS_STRING', () => {
+      expect(longOption(field(FieldOptions_JSType.JS_STRING), options)).toEqual(LongOption.STRING);
+    });
+
+    it('uses numbers for JS_NUMBER', () => {
+      expect(longOption(field(FieldOptions_JSType.JS_NUMBER), options)).toEqual(LongOption.NUMBER);
+    });
+
+    it('ignores jstype on non-64-bit fields', () => {
+      const int32 = { ...field(FieldOptions_JSType.JS_STRING), type: FieldDescriptorProto_Type.TYPE_INT32 };
+      expect(longOption(int32, options)).toEqual(LongOption.LONG);
+    });
+  });
+
+  describe('isSortKeyField', () => {
+    const field = (value: number) =>
+      ({ name: 'title', options: { _unknownFields: { [(50001 << 3) | 0]: [Uint8Array.of(value)] } } } as any);
+    const options = { ...defaultOptions(), sortKeyOption: 50001 };
+
+    it('reads the bool extension with the sortKeyOption number', () => {
+      expect(isSortKeyField(field(1), options)).toBe(true);
+      expect(isSortKeyField(field(0), options)).toBe(false);
+    });
+
+    it('ignores fields without the extension', () => {
+      expect(isSortKeyField({ name: 'title' } as any, options)).toBe(false);
+      expect(isSortKeyField(field(1), { ...options, sortKeyOption: 50002 })).toBe(false);
+    });
+
+    it('ignores the extension without sortKeyOption', () => {
+      expect(isSortKeyField(field(1), defaultOptions())).toBe(false);
+    });
+  });
 });
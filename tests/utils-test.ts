@@ -1,4 +1,5 @@
-import { maybeAddComment } from '../src/utils';
+import { maybeAddComment, protoFileModuleName } from '../src/utils';
+import { defaultOptions } from '../src/options';
 import { Code, joinCode } from 'ts-poet';
 
 describe('utils', () => {
@@ -54,4 +55,18 @@ describe('utils', () => {
       `);
     });
   });
+
+  describe('protoFileModuleName', () => {
+    const file = { name: 'protos/v1/foo.proto', package: 'company.api' } as any;
+
+    it('mirrors the proto file path by default', () => {
+      expect(protoFileModuleName(file, defaultOptions())).toEqual('protos/v1/foo');
+    });
+
+    it('places files in their package directory with outputLayout=package', () => {
+      const options = { ...defaultOptions(), outputLayout: 'package' as const };
+      expect(protoFileModuleName(file, options)).toEqual('company/api/foo');
+      expect(protoFileModuleName({ ...file, package: '' }, options)).toEqual('protos/v1/foo');
+    });
+  });
 });
@@ -0,0 +1,66 @@
+import { joinCode } from 'ts-poet';
+import { FieldDescriptorProto_Label, FieldDescriptorProto_Type } from 'ts-proto-descriptors';
+import { defaultOptions } from '../src/options';
+import { generateWatchHelpers } from '../src/generate-services';
+import { Context } from '../src/context';
+import { Utils } from '../src/main';
+import { FormattedMethodDescriptor } from '../src/utils';
+
+describe('watch', () => {
+  // package jobs; message GetJobRequest { string name = 1; string watch_token = 2; }
+  // message Job { string state = 1; string next_watch_token = 2; }
+  const string = (name: string, number: number) => ({
+    name,
+    number,
+    type: FieldDescriptorProto_Type.TYPE_STRING,
+    label: FieldDescriptorProto_Label.LABEL_OPTIONAL,
+  });
+  const typeMap = new Map([
+    [
+      '.jobs.GetJobRequest',
+      [
+        'jobs',
+        'GetJobRequest',
+        { name: 'GetJobRequest', field: [string('name', 1), string('watch_token', 2)] },
+        'jobs',
+      ],
+    ],
+    ['.jobs.Job', ['jobs', 'Job', { name: 'Job', field: [string('state', 1), string('next_watch_token', 2)] }, 'jobs']],
+  ]);
+  const ctx: Context = {
+    options: { ...defaultOptions(), outputWatch: true },
+    typeMap: typeMap as any,
+    utils: {} as any as Utils,
+    currentModule: 'jobs',
+  };
+  const serviceDesc = (name: string) =>
+    ({
+      name,
+      method: [
+        new FormattedMethodDescriptor(
+          {
+            name: 'GetJob',
+            inputType: '.jobs.GetJobRequest',
+            outputType: '.jobs.Job',
+            clientStreaming: false,
+            serverStreaming: false,
+          } as any,
+          ctx.options
+        ),
+      ],
+    } as any);
+
+  it('prefixes the helpers with the service name', async () => {
+    const generated = await joinCode(generateWatchHelpers(ctx, serviceDesc('Jobs'))).toStringWithImports();
+    expect(generated).toMatch(/export async function\* watchJobsGetJob\(\s*client: Jobs,/);
+  });
+
+  it('outputs distinct helpers for services of the same file with the same watch method', async () => {
+    const helpers = [
+      ...generateWatchHelpers(ctx, serviceDesc('Jobs')),
+      ...generateWatchHelpers(ctx, serviceDesc('Builds')),
+    ];
+    const generated = await joinCode(helpers, { on: '\n' }).toStringWithImports();
+    expect(generated.match(/function\* \w+/g)).toEqual(['function* watchJobsGetJob', 'function* watchBuildsGetJob']);
+  });
+});